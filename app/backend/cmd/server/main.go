@@ -1,7 +1,10 @@
 package main
 
 import (
+	"context"
 	"log"
+	"office-reservations/internal/auth"
+	"office-reservations/internal/config"
 	"office-reservations/internal/database"
 	"office-reservations/internal/handlers"
 	"office-reservations/internal/infrastructure/di"
@@ -14,6 +17,17 @@ import (
 )
 
 func main() {
+	// "server migrate ..." manages the database schema instead of serving requests
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	// Load configuration
+	cfg := config.Load()
+
 	// Initialize database
 	db, err := database.Initialize()
 	if err != nil {
@@ -21,14 +35,31 @@ func main() {
 	}
 
 	// Run migrations
-	if err := database.RunMigrations(db); err != nil {
-		log.Fatal("Failed to run migrations:", err)
+	if cfg.AutoMigrate {
+		if err := database.RunMigrations(db); err != nil {
+			log.Fatal("Failed to run migrations:", err)
+		}
 	}
 
 	// Initialize dependency injection container (Clean Architecture)
-	container := di.NewContainer(db)
+	container, err := di.NewContainer(db, cfg)
+	if err != nil {
+		log.Fatal("Failed to initialize container:", err)
+	}
+
+	// Start background workers
+	go container.SeriesService.RunMaterializer(context.Background(), cfg.SeriesMaterializeInterval)
+	go container.CheckInService.RunNoShowSweeper(context.Background(), cfg.CheckIn.SweepInterval)
+	go container.WebhookService.RunDispatcher(context.Background(), cfg.Webhooks.DispatchInterval)
+	go container.RealtimeService.Run(context.Background(), container.EventListener)
+	go container.IdempotencyService.RunCleaner(context.Background(), cfg.Idempotency.CleanupInterval)
+	if container.NotificationService.Enabled() {
+		go container.NotificationService.RunScheduler(context.Background(), cfg.Notifications.Interval)
+	} else {
+		log.Println("SMTP_HOST is not set, email notifications are disabled")
+	}
 
-	// Initialize legacy handlers (for Maps and Spaces - to be refactored later)
+	// Handlers not yet in the container; only the health check is left
 	legacyHandlers := handlers.New(db)
 
 	// Setup Gin router
@@ -56,7 +87,8 @@ func main() {
 	config := cors.DefaultConfig()
 	config.AllowOrigins = origins
 	config.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
-	config.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization"}
+	config.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization", "Last-Event-ID", "X-Audit-Reason", "If-Match", middleware.IdempotencyKeyHeader}
+	config.ExposeHeaders = []string{"ETag", middleware.IdempotentReplayedHeader}
 	config.AllowCredentials = true
 	r.Use(cors.New(config))
 
@@ -70,37 +102,157 @@ func main() {
 		// Health check
 		api.GET("/health", legacyHandlers.HealthCheck)
 
-		// Maps (using legacy handlers - to be refactored)
+		// Development tokens (never enable AUTH_DEV_ISSUER in production)
+		if cfg.Auth.DevIssuer {
+			api.POST("/auth/dev-token", container.AuthHandler.IssueDevToken)
+		}
+
+		// Calendar feeds are read by calendar clients, which cannot send bearer
+		// tokens; they are authenticated with a feed token in the query instead
+		feeds := api.Group("/calendar")
+		{
+			feeds.GET("/users/:user_id", container.CalendarHandler.GetUserFeed)
+			feeds.GET("/spaces/:id", container.CalendarHandler.GetSpaceFeed)
+			feeds.GET("/maps/:id", container.CalendarHandler.GetMapFeed)
+		}
+
+		// Real-time map events. EventSource and browser WebSockets cannot send
+		// headers, so the bearer token may also be passed as access_token.
+		events := api.Group("/maps/:id/events", middleware.TokenFromQuery("access_token"), middleware.Authenticate(container.Verifier))
+		{
+			events.GET("", container.MapEventsHandler.StreamEvents)
+			events.GET("/ws", container.MapEventsHandler.StreamEventsWebSocket)
+		}
+
+		// Every route below requires a bearer token
+		api.Use(middleware.Authenticate(container.Verifier))
+		api.Use(middleware.RememberEmail(container.NotificationService))
+		// POST requests sent with an Idempotency-Key are answered once
+		api.Use(middleware.Idempotency(container.IdempotencyService))
+
+		api.GET("/auth/me", container.AuthHandler.Me)
+
+		// Email notification preferences of the caller
+		api.GET("/notifications/preferences", container.NotificationHandler.GetPreferences)
+		api.PUT("/notifications/preferences", container.NotificationHandler.UpdatePreferences)
+
+		// Calendar feed tokens of the caller
+		api.POST("/calendar/token", container.CalendarHandler.IssueFeedToken)
+		api.DELETE("/calendar/token", container.CalendarHandler.RevokeFeedToken)
+
+		// Administrative routes are checked against the role policy, scoped to
+		// the office map they change
+		guard := container.Guard
+		spaceMap := middleware.MapOf("id", container.SpaceService.GetSpaceMapID)
+		groupMap := middleware.MapOf("id", container.SpaceGroupService.GetGroupMapID)
+		policyMap := middleware.MapOf("id", container.BookingPolicyService.GetPolicyMapID)
+		closureMap := middleware.MapOf("id", container.ClosureService.GetClosureMapID)
+
+		// Changes of reservations, spaces and maps name the version they are
+		// based on with If-Match, when required
+		ifMatch := middleware.RequireIfMatch(cfg.RequireIfMatch)
+
+		// Maps
 		maps := api.Group("/maps")
 		{
-			maps.GET("", legacyHandlers.GetMaps)
-			maps.GET("/:id", legacyHandlers.GetMap)
-			maps.POST("", legacyHandlers.CreateMap)
-			maps.PUT("/:id", legacyHandlers.UpdateMap)
-			maps.DELETE("/:id", legacyHandlers.DeleteMap)
+			maps.GET("", container.MapHandler.GetMaps)
+			maps.GET("/:id", container.MapHandler.GetMap)
+			maps.GET("/:id/export", container.MapFileHandler.ExportMap)
+			maps.GET("/:id/availability", container.MapHandler.GetMapAvailability)
+			maps.POST("", middleware.Authorize(guard, auth.PermManageMaps), container.MapHandler.CreateMap)
+			maps.POST("/import", middleware.Authorize(guard, auth.PermManageMaps, middleware.MapField("map_id")), container.MapFileHandler.ImportMap)
+			maps.PUT("/:id", middleware.Authorize(guard, auth.PermManageMaps, middleware.MapParam("id")), ifMatch, container.MapHandler.UpdateMap)
+			maps.DELETE("/:id", middleware.Authorize(guard, auth.PermDeleteMaps, middleware.MapParam("id")), ifMatch, container.MapHandler.DeleteMap)
 		}
 
-		// Spaces (using legacy handlers - to be refactored)
+		// Spaces
 		spaces := api.Group("/spaces")
 		{
-			spaces.GET("", legacyHandlers.GetSpaces)
-			spaces.GET("/:id", legacyHandlers.GetSpace)
-			spaces.POST("", legacyHandlers.CreateSpace)
-			spaces.PUT("/:id", legacyHandlers.UpdateSpace)
-			spaces.DELETE("/:id", legacyHandlers.DeleteSpace)
-			spaces.GET("/:id/availability", legacyHandlers.GetSpaceAvailability)
+			spaces.GET("", container.SpaceHandler.GetSpaces)
+			spaces.GET("/:id", container.SpaceHandler.GetSpace)
+			spaces.POST("", middleware.Authorize(guard, auth.PermManageSpaces, middleware.MapField("map_id")), container.SpaceHandler.CreateSpace)
+			spaces.PUT("/:id", middleware.Authorize(guard, auth.PermManageSpaces, spaceMap, middleware.MapField("map_id")), ifMatch, container.SpaceHandler.UpdateSpace)
+			spaces.DELETE("/:id", middleware.Authorize(guard, auth.PermManageSpaces, spaceMap), ifMatch, container.SpaceHandler.DeleteSpace)
+			spaces.GET("/:id/availability", container.SpaceHandler.GetSpaceAvailability)
+		}
+
+		// Search for free spaces
+		search := api.Group("/search")
+		{
+			search.POST("/meeting-rooms", container.SearchHandler.SearchMeetingRooms)
+		}
+
+		// Space groups (meeting rooms made of several spaces)
+		spaceGroups := api.Group("/space-groups")
+		{
+			spaceGroups.GET("", container.SpaceGroupHandler.GetGroups)
+			spaceGroups.GET("/:id", container.SpaceGroupHandler.GetGroup)
+			spaceGroups.POST("", middleware.Authorize(guard, auth.PermManageSpaces, middleware.MapField("map_id")), container.SpaceGroupHandler.CreateGroup)
+			spaceGroups.PUT("/:id", middleware.Authorize(guard, auth.PermManageSpaces, groupMap, middleware.MapField("map_id")), container.SpaceGroupHandler.UpdateGroup)
+			spaceGroups.DELETE("/:id", middleware.Authorize(guard, auth.PermManageSpaces, groupMap), container.SpaceGroupHandler.DeleteGroup)
+		}
+
+		// Booking policies. A policy for every map needs the permission on all maps.
+		policies := api.Group("/booking-policies")
+		{
+			policies.GET("", container.BookingPolicyHandler.GetPolicies)
+			policies.GET("/:id", container.BookingPolicyHandler.GetPolicy)
+			policies.POST("", middleware.Authorize(guard, auth.PermManagePolicies, middleware.MapField("map_id")), container.BookingPolicyHandler.CreatePolicy)
+			policies.PUT("/:id", middleware.Authorize(guard, auth.PermManagePolicies, policyMap, middleware.MapField("map_id")), container.BookingPolicyHandler.UpdatePolicy)
+			policies.DELETE("/:id", middleware.Authorize(guard, auth.PermManagePolicies, policyMap), container.BookingPolicyHandler.DeletePolicy)
 		}
 
-		// Reservations (using new Clean Architecture handlers)
+		// Closures of maps, space groups and spaces
+		closures := api.Group("/closures")
+		{
+			closures.GET("", container.ClosureHandler.GetClosures)
+			closures.GET("/:id", container.ClosureHandler.GetClosure)
+			closures.POST("", middleware.Authorize(guard, auth.PermManageClosures, middleware.MapField("map_id")), container.ClosureHandler.CreateClosure)
+			closures.POST("/import", middleware.Authorize(guard, auth.PermManageClosures, middleware.MapField("map_id")), container.ClosureHandler.ImportClosures)
+			closures.PUT("/:id", middleware.Authorize(guard, auth.PermManageClosures, closureMap, middleware.MapField("map_id")), container.ClosureHandler.UpdateClosure)
+			closures.DELETE("/:id", middleware.Authorize(guard, auth.PermManageClosures, closureMap), container.ClosureHandler.DeleteClosure)
+		}
+
+		// Audit log of reservation, space and map changes. Admins limited to
+		// some maps must filter by one of them.
+		api.GET("/audit", middleware.Authorize(guard, auth.PermReadAudit, middleware.MapQuery("map_id")), container.AuditHandler.GetAuditLog)
+
+		// Webhook subscriptions and their delivery logs
+		webhooks := api.Group("/webhooks")
+		{
+			webhooks.GET("", middleware.Authorize(guard, auth.PermManageWebhooks), container.WebhookHandler.GetWebhooks)
+			webhooks.GET("/:id", middleware.Authorize(guard, auth.PermManageWebhooks), container.WebhookHandler.GetWebhook)
+			webhooks.POST("", middleware.Authorize(guard, auth.PermManageWebhooks), container.WebhookHandler.CreateWebhook)
+			webhooks.PUT("/:id", middleware.Authorize(guard, auth.PermManageWebhooks), container.WebhookHandler.UpdateWebhook)
+			webhooks.DELETE("/:id", middleware.Authorize(guard, auth.PermManageWebhooks), container.WebhookHandler.DeleteWebhook)
+			webhooks.GET("/:id/deliveries", middleware.Authorize(guard, auth.PermManageWebhooks), container.WebhookHandler.GetDeliveries)
+			webhooks.POST("/:id/deliveries/:delivery_id/redeliver", middleware.Authorize(guard, auth.PermManageWebhooks), container.WebhookHandler.Redeliver)
+		}
+
+		// Reservations
 		reservations := api.Group("/reservations")
 		{
 			reservations.GET("", container.ReservationHandler.GetReservations)
 			reservations.GET("/:id", container.ReservationHandler.GetReservation)
 			reservations.POST("", container.ReservationHandler.CreateReservation)
-			reservations.PUT("/:id", container.ReservationHandler.UpdateReservation)
-			reservations.DELETE("/:id", container.ReservationHandler.DeleteReservation)
-			// Legacy endpoint - keeping for backward compatibility
-			reservations.POST("/cleanup/meeting-room/:space_id", legacyHandlers.CleanupMeetingRoomReservations)
+			reservations.POST("/assign-desk", container.DeskAssignmentHandler.AssignDesk)
+			reservations.PUT("/:id", ifMatch, container.ReservationHandler.UpdateReservation)
+			reservations.DELETE("/:id", ifMatch, container.ReservationHandler.DeleteReservation)
+			reservations.POST("/:id/check-in", container.ReservationHandler.CheckIn)
+			reservations.GET("/:id/history", container.AuditHandler.GetReservationHistory)
+			// Bulk bookings and cancellations; cancelling by filter is checked
+			// against the map of the filter by the handler
+			reservations.POST("/batch", container.ReservationHandler.CreateReservations)
+			reservations.POST("/batch-cancel", container.ReservationHandler.CancelReservations)
+		}
+
+		// Recurring reservations
+		series := api.Group("/reservation-series")
+		{
+			series.GET("/:id", container.SeriesHandler.GetSeries)
+			series.POST("", container.SeriesHandler.CreateSeries)
+			series.PUT("/:id", container.SeriesHandler.UpdateSeries)
+			series.DELETE("/:id", container.SeriesHandler.CancelSeries)
 		}
 	}
 
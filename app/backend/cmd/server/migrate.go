@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"office-reservations/internal/database"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+const migrateUsage = `usage: server migrate <command>
+
+commands:
+  status      list migrations and whether they are applied
+  up          apply every pending migration
+  down [N]    roll back the last N migrations (default 1)
+  to VERSION  migrate up or down to VERSION (0 rolls back everything)`
+
+// runMigrate handles the "migrate" subcommand
+func runMigrate(args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	db, err := database.Initialize()
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	migrator, err := database.NewSchemaMigrator(db)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	switch args[0] {
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tSTATUS")
+		for _, s := range statuses {
+			fmt.Fprintf(w, "%04d\t%s\t%s\n", s.Version, s.Name, migrationState(s))
+		}
+		return w.Flush()
+
+	case "up":
+		count, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Applied %d migrations\n", count)
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return fmt.Errorf("invalid number of migrations: %s", args[1])
+			}
+		}
+		count, err := migrator.Down(ctx, steps)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Rolled back %d migrations\n", count)
+
+	case "to":
+		if len(args) < 2 {
+			return errors.New(migrateUsage)
+		}
+		version, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil || version < 0 {
+			return fmt.Errorf("invalid migration version: %s", args[1])
+		}
+		count, err := migrator.To(ctx, version)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Applied or rolled back %d migrations, schema is at version %d\n", count, version)
+
+	default:
+		return errors.New(migrateUsage)
+	}
+
+	return nil
+}
+
+// migrationState describes a migration for the status listing
+func migrationState(s database.MigrationStatus) string {
+	switch {
+	case s.Missing:
+		return "applied " + s.AppliedAt.Format(time.RFC3339) + " (not in this build)"
+	case s.Modified:
+		return "applied " + s.AppliedAt.Format(time.RFC3339) + " (modified since)"
+	case s.Applied:
+		return "applied " + s.AppliedAt.Format(time.RFC3339)
+	default:
+		return "pending"
+	}
+}
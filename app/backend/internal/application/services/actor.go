@@ -0,0 +1,37 @@
+package services
+
+import "errors"
+
+var (
+	ErrNotReservationOwner = errors.New("only the owner or an admin can change this reservation")
+	ErrForceRequiresAdmin  = errors.New("only admins can override existing reservations")
+	ErrBookForOthers       = errors.New("only admins can book for other users")
+)
+
+// Actor is the authenticated user performing an operation. A nil *Actor denotes
+// an internal caller, such as a background worker, and is never restricted.
+type Actor struct {
+	UserID   string
+	UserName string
+	Admin    bool
+	// Roles select the booking policies that apply to the actor's bookings
+	Roles []string
+}
+
+// CanManage returns true if the actor may change a reservation owned by userID
+func (a *Actor) CanManage(userID string) bool {
+	return a == nil || a.Admin || a.UserID == userID
+}
+
+// IsPrivileged returns true if the actor may override other users' reservations
+func (a *Actor) IsPrivileged() bool {
+	return a == nil || a.Admin
+}
+
+// roleNames returns the actor's roles; internal callers have none
+func (a *Actor) roleNames() []string {
+	if a == nil {
+		return nil
+	}
+	return a.Roles
+}
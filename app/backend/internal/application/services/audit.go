@@ -0,0 +1,147 @@
+package services
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"office-reservations/internal/domain/entities"
+	"office-reservations/internal/domain/repositories"
+)
+
+// Every change of a reservation, space or map is recorded in the audit log by
+// the transaction that makes it, with snapshots of the resource before and
+// after the change in the same format as the webhook events.
+
+// AuditService reads the audit log
+type AuditService struct {
+	auditRepo       repositories.AuditRepository
+	reservationRepo repositories.ReservationRepository
+}
+
+// NewAuditService creates a new audit service
+func NewAuditService(auditRepo repositories.AuditRepository, reservationRepo repositories.ReservationRepository) *AuditService {
+	return &AuditService{
+		auditRepo:       auditRepo,
+		reservationRepo: reservationRepo,
+	}
+}
+
+// GetReservationHistory returns the changes of a reservation, oldest first.
+// Only the holder or an admin can read it; the history of a reservation that
+// no longer exists is only available to admins.
+func (s *AuditService) GetReservationHistory(id uuid.UUID, actor *Actor) ([]*entities.AuditEntry, error) {
+	entityType := entities.AuditEntityReservation
+	entries, err := s.auditRepo.FindAll(repositories.AuditFilters{EntityType: &entityType, EntityID: &id})
+	if err != nil {
+		return nil, err
+	}
+
+	reservation, err := s.reservationRepo.FindByID(id)
+	if err != nil {
+		if len(entries) == 0 {
+			return nil, ErrReservationNotFound
+		}
+		if !actor.IsPrivileged() {
+			return nil, ErrNotReservationOwner
+		}
+	} else if !actor.CanManage(reservation.UserID) {
+		return nil, ErrNotReservationOwner
+	}
+
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	return entries, nil
+}
+
+// GetAuditLog returns the entries matching the filters, newest first
+func (s *AuditService) GetAuditLog(filters repositories.AuditFilters) ([]*entities.AuditEntry, error) {
+	return s.auditRepo.FindAll(filters)
+}
+
+// auditReservation records a change of a reservation. before is its snapshot
+// taken before the change, nil if it was created, and after is nil if it was
+// deleted.
+func auditReservation(repos repositories.TxRepositories, actor *Actor, action entities.AuditAction, reason string, before *reservationSnapshot, after *entities.Reservation) error {
+	var beforeData, afterData interface{}
+	var entityID, spaceID uuid.UUID
+	if before != nil {
+		beforeData = before
+		entityID, spaceID = before.ID, before.SpaceID
+	}
+	if after != nil {
+		afterData = snapshotReservation(after)
+		entityID, spaceID = after.ID, after.SpaceID
+	}
+
+	var mapID *uuid.UUID
+	if space, err := repos.Spaces.FindByID(spaceID); err == nil {
+		mapID = &space.MapID
+	}
+	return appendAudit(repos.Audit, actor, action, reason, entities.AuditEntityReservation, entityID, mapID, beforeData, afterData)
+}
+
+// auditSpace records a change of a space. before is its snapshot taken before
+// the change, nil if it was created, and after is nil if it was deleted.
+func auditSpace(audit repositories.AuditRepository, actor *Actor, action entities.AuditAction, reason string, before *spaceSnapshot, after *entities.Space) error {
+	var beforeData, afterData interface{}
+	var entityID, mapID uuid.UUID
+	if before != nil {
+		beforeData = before
+		entityID, mapID = before.ID, before.MapID
+	}
+	if after != nil {
+		afterData = snapshotSpace(after)
+		entityID, mapID = after.ID, after.MapID
+	}
+	return appendAudit(audit, actor, action, reason, entities.AuditEntitySpace, entityID, &mapID, beforeData, afterData)
+}
+
+// auditMap records a change of an office map. before is its snapshot taken
+// before the change, nil if it was created, and after is nil if it was deleted.
+func auditMap(audit repositories.AuditRepository, actor *Actor, action entities.AuditAction, reason string, before *mapSnapshot, after *entities.OfficeMap) error {
+	var beforeData, afterData interface{}
+	var mapID uuid.UUID
+	if before != nil {
+		beforeData = before
+		mapID = before.ID
+	}
+	if after != nil {
+		afterData = snapshotMap(after)
+		mapID = after.ID
+	}
+	return appendAudit(audit, actor, action, reason, entities.AuditEntityMap, mapID, &mapID, beforeData, afterData)
+}
+
+// appendAudit encodes the snapshots of a change and appends it to the audit log.
+// Called with the audit repository of a transaction, the entry is only kept if
+// the change commits.
+func appendAudit(audit repositories.AuditRepository, actor *Actor, action entities.AuditAction, reason string, entityType entities.AuditEntityType, entityID uuid.UUID, mapID *uuid.UUID, before, after interface{}) error {
+	entry := &entities.AuditEntry{
+		ID:         uuid.New(),
+		EntityType: entityType,
+		EntityID:   entityID,
+		MapID:      mapID,
+		Action:     action,
+		Reason:     reason,
+		CreatedAt:  time.Now(),
+	}
+	if actor != nil {
+		entry.ActorID = actor.UserID
+		entry.ActorName = actor.UserName
+	}
+
+	var err error
+	if before != nil {
+		if entry.Before, err = json.Marshal(before); err != nil {
+			return err
+		}
+	}
+	if after != nil {
+		if entry.After, err = json.Marshal(after); err != nil {
+			return err
+		}
+	}
+	return audit.Append(entry)
+}
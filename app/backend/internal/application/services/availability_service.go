@@ -0,0 +1,327 @@
+package services
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"office-reservations/internal/domain/entities"
+	"office-reservations/internal/domain/repositories"
+)
+
+var (
+	ErrInvalidDateRange = errors.New("end date must not be before start date")
+	ErrDateRangeTooLong = errors.New("date range is too long")
+)
+
+// MaxAvailabilityDays bounds the number of days of a floor availability request
+const MaxAvailabilityDays = 31
+
+// AvailabilityService computes the availability of all spaces of a map
+type AvailabilityService struct {
+	mapRepo         repositories.OfficeMapRepository
+	spaceRepo       repositories.SpaceRepository
+	groupRepo       repositories.SpaceGroupRepository
+	reservationRepo repositories.ReservationRepository
+	closureRepo     repositories.ClosureRepository
+}
+
+// NewAvailabilityService creates a new availability service
+func NewAvailabilityService(
+	mapRepo repositories.OfficeMapRepository,
+	spaceRepo repositories.SpaceRepository,
+	groupRepo repositories.SpaceGroupRepository,
+	reservationRepo repositories.ReservationRepository,
+	closureRepo repositories.ClosureRepository,
+) *AvailabilityService {
+	return &AvailabilityService{
+		mapRepo:         mapRepo,
+		spaceRepo:       spaceRepo,
+		groupRepo:       groupRepo,
+		reservationRepo: reservationRepo,
+		closureRepo:     closureRepo,
+	}
+}
+
+// FloorAvailabilityRequest represents the input for a floor availability query.
+// Nil times default to the start and end of the day.
+type FloorAvailabilityRequest struct {
+	MapID     uuid.UUID
+	From      time.Time
+	To        time.Time
+	StartTime *string
+	EndTime   *string
+}
+
+// FloorAvailability is the availability of every bookable unit of a map
+type FloorAvailability struct {
+	MapID  uuid.UUID
+	From   time.Time
+	To     time.Time
+	Window entities.TimeRange
+	Units  []UnitAvailability
+}
+
+// UnitAvailability is the availability of a single space, or of a space group
+// whose members are booked together and are reported as one unit
+type UnitAvailability struct {
+	Space  *entities.Space
+	Group  *entities.SpaceGroup
+	Spaces []*entities.Space
+	// State summarizes all days of the range
+	State entities.OccupancyState
+	Days  []DayAvailability
+}
+
+// DayAvailability is the availability of a unit within the window on one date.
+// Closed parts of the window are reported apart from the booked ones and are
+// never free.
+type DayAvailability struct {
+	Date   time.Time
+	State  entities.OccupancyState
+	Busy   []BusyInterval
+	Closed []ClosedInterval
+	Free   []entities.TimeRange
+}
+
+// BusyInterval is a booked part of the window and the reservations covering it
+type BusyInterval struct {
+	entities.TimeRange
+	ReservationIDs []uuid.UUID
+}
+
+// ClosedInterval is a part of the window covered by a closure
+type ClosedInterval struct {
+	entities.TimeRange
+	ClosureID uuid.UUID
+	Reason    string
+}
+
+// GetFloorAvailability returns the free, busy and closed intervals of every
+// space of a map on each date of the range, loading all reservations in one query
+func (s *AvailabilityService) GetFloorAvailability(req FloorAvailabilityRequest) (*FloorAvailability, error) {
+	if _, err := s.mapRepo.FindByID(req.MapID); err != nil {
+		return nil, ErrMapNotFound
+	}
+	window, err := validateAvailabilityRange(req.From, req.To, req.StartTime, req.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	units, reservations, closures, err := s.loadUnits(&req.MapID, req.From, req.To)
+	if err != nil {
+		return nil, err
+	}
+
+	availability := &FloorAvailability{
+		MapID:  req.MapID,
+		From:   req.From,
+		To:     req.To,
+		Window: window,
+		Units:  make([]UnitAvailability, 0, len(units)),
+	}
+	for _, unit := range units {
+		for date := req.From; !date.After(req.To); date = date.AddDate(0, 0, 1) {
+			day := dayAvailability(unit, date, window, reservations.forUnit(unit, date), closures.forUnit(unit, date))
+			unit.Days = append(unit.Days, day)
+			unit.State = combineOccupancy(unit.State, day.State)
+		}
+		availability.Units = append(availability.Units, unit)
+	}
+	return availability, nil
+}
+
+// validateAvailabilityRange checks a date range and returns the time window
+// given by optional start and end times
+func validateAvailabilityRange(from, to time.Time, startTime, endTime *string) (entities.TimeRange, error) {
+	if to.Before(from) {
+		return entities.TimeRange{}, ErrInvalidDateRange
+	}
+	if to.Sub(from) >= MaxAvailabilityDays*24*time.Hour {
+		return entities.TimeRange{}, ErrDateRangeTooLong
+	}
+	window, err := entities.NewTimeRange(startTime, endTime)
+	if err != nil {
+		return entities.TimeRange{}, ErrInvalidTime
+	}
+	if !window.IsValid() {
+		return entities.TimeRange{}, ErrStartTimeAfterEndTime
+	}
+	return window, nil
+}
+
+// reservationIndex holds reservations by date and space
+type reservationIndex map[string]map[uuid.UUID][]*entities.Reservation
+
+// forUnit returns the reservations on any space of a unit on a date
+func (idx reservationIndex) forUnit(unit UnitAvailability, date time.Time) []*entities.Reservation {
+	bySpace := idx[date.Format("2006-01-02")]
+	var reservations []*entities.Reservation
+	for _, space := range unit.Spaces {
+		reservations = append(reservations, bySpace[space.ID]...)
+	}
+	return reservations
+}
+
+// closureList holds the closures of a date range
+type closureList []*entities.Closure
+
+// forUnit returns the closures covering any space of a unit on a date
+func (list closureList) forUnit(unit UnitAvailability, date time.Time) []*entities.Closure {
+	var closures []*entities.Closure
+	for _, c := range list {
+		if _, ok := c.RangeOn(date); !ok {
+			continue
+		}
+		for _, space := range unit.Spaces {
+			if c.Covers(space) {
+				closures = append(closures, c)
+				break
+			}
+		}
+	}
+	return closures
+}
+
+// loadUnits returns the bookable units of a map, or of all maps when mapID is
+// nil, the reservations holding their spaces in the date range, loaded in one
+// query, and the closures of the range
+func (s *AvailabilityService) loadUnits(mapID *uuid.UUID, from, to time.Time) ([]UnitAvailability, reservationIndex, closureList, error) {
+	var spaces []*entities.Space
+	var groups []*entities.SpaceGroup
+	var err error
+	if mapID != nil {
+		spaces, err = s.spaceRepo.FindByMapID(*mapID)
+		if err == nil {
+			groups, err = s.groupRepo.FindByMapID(*mapID)
+		}
+	} else {
+		spaces, err = s.spaceRepo.FindAll()
+		if err == nil {
+			groups, err = s.groupRepo.FindAll()
+		}
+	}
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	spaceIDs := make([]uuid.UUID, len(spaces))
+	for i, space := range spaces {
+		spaceIDs[i] = space.ID
+	}
+	reservations, err := s.reservationRepo.FindHoldingBySpaceIDsInRange(spaceIDs, from, to)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	closures, err := s.closureRepo.FindAll(repositories.ClosureFilters{MapID: mapID, From: &from, To: &to})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	index := make(reservationIndex)
+	for _, r := range reservations {
+		day := r.Date.Format("2006-01-02")
+		if index[day] == nil {
+			index[day] = make(map[uuid.UUID][]*entities.Reservation)
+		}
+		index[day][r.SpaceID] = append(index[day][r.SpaceID], r)
+	}
+	return floorUnits(spaces, groups), index, closures, nil
+}
+
+// floorUnits collapses the members of each space group into one unit, placed
+// where its first member appears
+func floorUnits(spaces []*entities.Space, groups []*entities.SpaceGroup) []UnitAvailability {
+	groupsByID := make(map[uuid.UUID]*entities.SpaceGroup, len(groups))
+	for _, group := range groups {
+		groupsByID[group.ID] = group
+	}
+
+	var units []UnitAvailability
+	groupUnit := make(map[uuid.UUID]int)
+	for _, space := range spaces {
+		if space.GroupID != nil {
+			if group, ok := groupsByID[*space.GroupID]; ok {
+				if i, seen := groupUnit[group.ID]; seen {
+					units[i].Spaces = append(units[i].Spaces, space)
+					continue
+				}
+				groupUnit[group.ID] = len(units)
+				units = append(units, UnitAvailability{Group: group, Spaces: []*entities.Space{space}})
+				continue
+			}
+		}
+		units = append(units, UnitAvailability{Space: space, Spaces: []*entities.Space{space}})
+	}
+	return units
+}
+
+// dayAvailability merges the reservations of a unit on one date into busy
+// intervals within the window and lists the closed ones. Invalid spaces are
+// never available.
+func dayAvailability(unit UnitAvailability, date time.Time, window entities.TimeRange, reservations []*entities.Reservation, closures []*entities.Closure) DayAvailability {
+	day := DayAvailability{Date: date, Busy: []BusyInterval{}, Closed: []ClosedInterval{}, Free: []entities.TimeRange{}}
+	if unit.Spaces[0].Type == entities.SpaceTypeInvalidSpace {
+		day.State = entities.OccupancyUnavailable
+		return day
+	}
+
+	type booked struct {
+		entities.TimeRange
+		id uuid.UUID
+	}
+	var ranges []booked
+	for _, r := range reservations {
+		timeRange, err := r.TimeRange()
+		if err != nil || !timeRange.Overlaps(window) {
+			continue
+		}
+		ranges = append(ranges, booked{TimeRange: timeRange, id: r.ID})
+	}
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].Start < ranges[j].Start })
+
+	var busyRanges []entities.TimeRange
+	for _, b := range ranges {
+		busyRanges = append(busyRanges, b.TimeRange)
+		clipped := entities.ClipRanges([]entities.TimeRange{b.TimeRange}, window)[0]
+		if n := len(day.Busy); n > 0 && clipped.Start <= day.Busy[n-1].End {
+			last := &day.Busy[n-1]
+			if clipped.End > last.End {
+				last.End = clipped.End
+			}
+			last.ReservationIDs = append(last.ReservationIDs, b.id)
+			continue
+		}
+		day.Busy = append(day.Busy, BusyInterval{TimeRange: clipped, ReservationIDs: []uuid.UUID{b.id}})
+	}
+
+	var closedRanges []entities.TimeRange
+	for _, c := range closures {
+		closed, ok := c.RangeOn(date)
+		if !ok || !closed.Overlaps(window) {
+			continue
+		}
+		closedRanges = append(closedRanges, closed)
+		clipped := entities.ClipRanges([]entities.TimeRange{closed}, window)[0]
+		day.Closed = append(day.Closed, ClosedInterval{TimeRange: clipped, ClosureID: c.ID, Reason: c.Reason})
+	}
+	sort.SliceStable(day.Closed, func(i, j int) bool { return day.Closed[i].Start < day.Closed[j].Start })
+
+	unavailable := append(busyRanges, closedRanges...)
+	day.Free = entities.FreeRanges(window, unavailable)
+	if entities.Occupancy(window, closedRanges) == entities.OccupancyBooked {
+		day.State = entities.OccupancyClosed
+	} else {
+		day.State = entities.Occupancy(window, unavailable)
+	}
+	return day
+}
+
+// combineOccupancy folds the state of one more day into the state of a range
+func combineOccupancy(current, next entities.OccupancyState) entities.OccupancyState {
+	if current == "" || current == next {
+		return next
+	}
+	return entities.OccupancyPartial
+}
@@ -0,0 +1,353 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"office-reservations/internal/domain/entities"
+	"office-reservations/internal/domain/repositories"
+)
+
+// ErrPolicyViolation is matched by every PolicyViolationError
+var ErrPolicyViolation = errors.New("reservation violates the booking policy")
+
+// maxAdvanceDays is how many days ahead reservations can be made when no
+// booking policy sets an advance window
+const maxAdvanceDays = 7
+
+// PolicyViolation describes a rule a booking breaks. PolicyID is nil for the
+// built-in rules: no past dates or times, the default advance window and the
+// opening hours of the map.
+type PolicyViolation struct {
+	Rule       entities.PolicyRule
+	PolicyID   *uuid.UUID
+	PolicyName string
+	Message    string
+}
+
+// PolicyViolationError is returned when a booking breaks one or more policy
+// rules. It matches ErrPolicyViolation with errors.Is, and ErrDateInPast or
+// ErrDateTooFarInFuture when it includes the corresponding rule.
+type PolicyViolationError struct {
+	Violations []PolicyViolation
+}
+
+func (e *PolicyViolationError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		messages[i] = v.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Is allows errors.Is to match the sentinel errors of the broken rules
+func (e *PolicyViolationError) Is(target error) bool {
+	switch target {
+	case ErrPolicyViolation:
+		return true
+	case ErrDateInPast:
+		return e.breaks(entities.RuleDateInPast)
+	case ErrDateTooFarInFuture:
+		return e.breaks(entities.RuleMaxAdvanceDays)
+	}
+	return false
+}
+
+func (e *PolicyViolationError) breaks(rule entities.PolicyRule) bool {
+	for _, v := range e.Violations {
+		if v.Rule == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// PolicyCheck is a booking to be checked against the booking policies
+type PolicyCheck struct {
+	Space *entities.Space
+	// Map is the office map of Space; dates and times are local to its time zone
+	Map       *entities.OfficeMap
+	UserID    string
+	Date      time.Time
+	StartTime *string
+	EndTime   *string
+	// Actor is the user making the request; role policies match the actor's roles
+	Actor *Actor
+	// Exclude holds the reservations of a booking being changed, which do not
+	// count towards the user's other bookings
+	Exclude map[uuid.UUID]bool
+	// Pending holds the bookings accepted earlier in the same batch, which are
+	// not stored yet but count towards the user's other bookings
+	Pending []*entities.Reservation
+}
+
+// effectiveRules holds the policy that decides each rule for one booking
+type effectiveRules struct {
+	maxAdvanceDays     *entities.BookingPolicy
+	minDuration        *entities.BookingPolicy
+	maxDuration        *entities.BookingPolicy
+	allowedHours       *entities.BookingPolicy
+	maxBookingsPerWeek *entities.BookingPolicy
+	maxConsecutiveDays *entities.BookingPolicy
+	// blackouts lists every applicable policy, as blackout dates add up
+	blackouts []*entities.BookingPolicy
+}
+
+// Check returns a PolicyViolationError listing every rule the booking breaks.
+// Each rule is decided by the most specific applicable policy that sets it;
+// among equally specific policies the strictest value wins. The booking must
+// have a valid time range.
+func (s *BookingPolicyService) Check(check PolicyCheck) error {
+	policies, err := s.policyRepo.FindForMap(check.Space.MapID)
+	if err != nil {
+		return err
+	}
+	roles := check.Actor.roleNames()
+	rules := resolveRules(policies, check.Space, roles)
+
+	timeRange, err := entities.NewTimeRange(check.StartTime, check.EndTime)
+	if err != nil {
+		return ErrInvalidTime
+	}
+
+	var violations []PolicyViolation
+	add := func(rule entities.PolicyRule, policy *entities.BookingPolicy, format string, args ...interface{}) {
+		violations = append(violations, newViolation(rule, policy, format, args...))
+	}
+
+	today, now := entities.LocalDay(time.Now(), check.Map.Location())
+	if check.Date.Before(today) {
+		add(entities.RuleDateInPast, nil, "Cannot reserve dates in the past")
+	} else if check.Date.Equal(today) && timeRange.End <= now {
+		add(entities.RuleDateInPast, nil, "Cannot reserve a time that has already passed")
+	}
+	advanceDays := rules.advanceDays()
+	if check.Date.After(today.AddDate(0, 0, advanceDays)) {
+		add(entities.RuleMaxAdvanceDays, rules.maxAdvanceDays, "Cannot reserve more than %s in advance", count(advanceDays, "day"))
+	}
+
+	// All-day bookings only need the office to open that day
+	if hours, open := check.Map.OpeningHours.On(check.Date); !open {
+		add(entities.RuleOpeningHours, nil, "The office is closed on %ss", check.Date.Weekday())
+	} else if !timeRange.IsAllDay() && (timeRange.Start < hours.Start || timeRange.End > hours.End) {
+		add(entities.RuleOpeningHours, nil, "The office is open from %s to %s on %ss",
+			entities.FormatClock(hours.Start), entities.FormatClock(hours.End), check.Date.Weekday())
+	}
+
+	for _, p := range rules.blackouts {
+		if p.IsBlackout(check.Date) {
+			add(entities.RuleBlackoutDate, p, "%s is closed for booking", check.Date.Format("2006-01-02"))
+		}
+	}
+
+	if p := rules.minDuration; p != nil && timeRange.Duration() < *p.MinDurationMinutes {
+		add(entities.RuleMinDuration, p, "Bookings must last at least %d minutes", *p.MinDurationMinutes)
+	}
+	if p := rules.maxDuration; p != nil && timeRange.Duration() > *p.MaxDurationMinutes {
+		add(entities.RuleMaxDuration, p, "Bookings must last at most %d minutes", *p.MaxDurationMinutes)
+	}
+	// All-day bookings are not limited by the allowed hours
+	if p := rules.allowedHours; p != nil && !timeRange.IsAllDay() {
+		if hours, ok := p.AllowedHours(); ok && (timeRange.Start < hours.Start || timeRange.End > hours.End) {
+			add(entities.RuleAllowedHours, p, "Bookings must be between %s and %s",
+				entities.FormatClock(hours.Start), entities.FormatClock(hours.End))
+		}
+	}
+
+	if rules.maxBookingsPerWeek != nil || rules.maxConsecutiveDays != nil {
+		userViolations, err := s.checkUserBookings(check, rules, roles)
+		if err != nil {
+			return err
+		}
+		violations = append(violations, userViolations...)
+	}
+
+	if len(violations) > 0 {
+		return &PolicyViolationError{Violations: violations}
+	}
+	return nil
+}
+
+// newViolation describes a broken rule of a policy, or of the built-in rules if policy is nil
+func newViolation(rule entities.PolicyRule, policy *entities.BookingPolicy, format string, args ...interface{}) PolicyViolation {
+	v := PolicyViolation{Rule: rule, Message: fmt.Sprintf(format, args...)}
+	if policy != nil {
+		v.PolicyID = &policy.ID
+		v.PolicyName = policy.Name
+	}
+	return v
+}
+
+// checkUserBookings enforces the weekly and consecutive day limits. Only the
+// user's bookings that the deciding policy also covers are counted, and a
+// space group booking counts once.
+func (s *BookingPolicyService) checkUserBookings(check PolicyCheck, rules effectiveRules, roles []string) ([]PolicyViolation, error) {
+	weekStart := check.Date.AddDate(0, 0, -((int(check.Date.Weekday()) + 6) % 7))
+	weekEnd := weekStart.AddDate(0, 0, 6)
+	from, to := weekStart, weekEnd
+	if p := rules.maxConsecutiveDays; p != nil {
+		if earliest := check.Date.AddDate(0, 0, -*p.MaxConsecutiveDays); earliest.Before(from) {
+			from = earliest
+		}
+		if latest := check.Date.AddDate(0, 0, *p.MaxConsecutiveDays); latest.After(to) {
+			to = latest
+		}
+	}
+
+	reservations, err := s.reservationRepo.FindAll(repositories.ReservationFilters{From: &from, To: &to, UserID: &check.UserID})
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range check.Pending {
+		if r.UserID == check.UserID && !r.Date.Before(from) && !r.Date.After(to) {
+			reservations = append(reservations, r)
+		}
+	}
+	var spaceIDs []uuid.UUID
+	for _, r := range reservations {
+		spaceIDs = append(spaceIDs, r.SpaceID)
+	}
+	spaces := make(map[uuid.UUID]*entities.Space)
+	if len(spaceIDs) > 0 {
+		found, err := s.spaceRepo.FindByIDs(uniqueIDs(spaceIDs))
+		if err != nil {
+			return nil, err
+		}
+		for _, space := range found {
+			spaces[space.ID] = space
+		}
+	}
+
+	// covered returns the user's other bookings that a policy applies to
+	covered := func(p *entities.BookingPolicy) []*entities.Reservation {
+		var result []*entities.Reservation
+		for _, r := range reservations {
+			space := spaces[r.SpaceID]
+			if !r.HoldsSpace() || check.Exclude[r.ID] || space == nil || !p.Applies(space.MapID, space.Type, roles) {
+				continue
+			}
+			result = append(result, r)
+		}
+		return result
+	}
+
+	var violations []PolicyViolation
+	if p := rules.maxBookingsPerWeek; p != nil {
+		bookings := map[uuid.UUID]bool{}
+		for _, r := range covered(p) {
+			if r.Date.Before(weekStart) || r.Date.After(weekEnd) {
+				continue
+			}
+			key := r.ID
+			if r.GroupBookingID != nil {
+				key = *r.GroupBookingID
+			}
+			bookings[key] = true
+		}
+		if len(bookings)+1 > *p.MaxBookingsPerWeek {
+			violations = append(violations, newViolation(entities.RuleMaxBookingsPerWeek, p,
+				"You can hold at most %s per week", count(*p.MaxBookingsPerWeek, "booking")))
+		}
+	}
+
+	if p := rules.maxConsecutiveDays; p != nil {
+		booked := map[string]bool{}
+		for _, r := range covered(p) {
+			booked[r.Date.Format("2006-01-02")] = true
+		}
+		run := 1
+		for d := check.Date.AddDate(0, 0, -1); booked[d.Format("2006-01-02")]; d = d.AddDate(0, 0, -1) {
+			run++
+		}
+		for d := check.Date.AddDate(0, 0, 1); booked[d.Format("2006-01-02")]; d = d.AddDate(0, 0, 1) {
+			run++
+		}
+		if run > *p.MaxConsecutiveDays {
+			violations = append(violations, newViolation(entities.RuleMaxConsecutiveDays, p,
+				"You can book at most %s in a row", count(*p.MaxConsecutiveDays, "day")))
+		}
+	}
+	return violations, nil
+}
+
+// AdvanceDays returns how many days ahead an actor can book a space under the
+// booking policies
+func (s *BookingPolicyService) AdvanceDays(space *entities.Space, actor *Actor) (int, error) {
+	policies, err := s.policyRepo.FindForMap(space.MapID)
+	if err != nil {
+		return 0, err
+	}
+	return resolveRules(policies, space, actor.roleNames()).advanceDays(), nil
+}
+
+// advanceDays returns how many days ahead bookings can be made, which is
+// maxAdvanceDays unless a policy sets it
+func (r effectiveRules) advanceDays() int {
+	if r.maxAdvanceDays == nil {
+		return maxAdvanceDays
+	}
+	return *r.maxAdvanceDays.MaxAdvanceDays
+}
+
+// resolveRules picks the deciding policy of each rule among the policies that
+// apply to a booking of space by a user with the given roles
+func resolveRules(policies []*entities.BookingPolicy, space *entities.Space, roles []string) effectiveRules {
+	var rules effectiveRules
+	for _, p := range policies {
+		if !p.Applies(space.MapID, space.Type, roles) {
+			continue
+		}
+		rules.maxAdvanceDays = decideInt(rules.maxAdvanceDays, p, func(p *entities.BookingPolicy) *int { return p.MaxAdvanceDays }, true)
+		rules.minDuration = decideInt(rules.minDuration, p, func(p *entities.BookingPolicy) *int { return p.MinDurationMinutes }, false)
+		rules.maxDuration = decideInt(rules.maxDuration, p, func(p *entities.BookingPolicy) *int { return p.MaxDurationMinutes }, true)
+		rules.maxBookingsPerWeek = decideInt(rules.maxBookingsPerWeek, p, func(p *entities.BookingPolicy) *int { return p.MaxBookingsPerWeek }, true)
+		rules.maxConsecutiveDays = decideInt(rules.maxConsecutiveDays, p, func(p *entities.BookingPolicy) *int { return p.MaxConsecutiveDays }, true)
+		if hours, ok := p.AllowedHours(); ok {
+			current := rules.allowedHours
+			if current == nil || p.Specificity() > current.Specificity() {
+				rules.allowedHours = p
+			} else if currentHours, _ := current.AllowedHours(); p.Specificity() == current.Specificity() && hours.Duration() < currentHours.Duration() {
+				rules.allowedHours = p
+			}
+		}
+		if len(p.BlackoutDates) > 0 {
+			rules.blackouts = append(rules.blackouts, p)
+		}
+	}
+	return rules
+}
+
+// decideInt returns the policy that decides an integer rule once candidate is
+// considered: a more specific policy wins, and among equally specific ones the
+// stricter value, which is the lower one if lowerIsStricter
+func decideInt(current, candidate *entities.BookingPolicy, value func(*entities.BookingPolicy) *int, lowerIsStricter bool) *entities.BookingPolicy {
+	v := value(candidate)
+	if v == nil {
+		return current
+	}
+	if current == nil {
+		return candidate
+	}
+	if a, b := candidate.Specificity(), current.Specificity(); a != b {
+		if a > b {
+			return candidate
+		}
+		return current
+	}
+	c := *value(current)
+	if (lowerIsStricter && *v < c) || (!lowerIsStricter && *v > c) {
+		return candidate
+	}
+	return current
+}
+
+// count formats n followed by noun, pluralized with an "s" unless n is 1
+func count(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"office-reservations/internal/domain/entities"
+)
+
+func intPtr(v int) *int { return &v }
+
+func strPtr(v string) *string { return &v }
+
+func TestResolveRules(t *testing.T) {
+	mapID := uuid.New()
+	otherMapID := uuid.New()
+	meetingRoom := entities.SpaceTypeMeetingRoom
+	workstation := entities.SpaceTypeWorkstation
+	space := &entities.Space{ID: uuid.New(), MapID: mapID, Type: entities.SpaceTypeWorkstation}
+
+	// decided names the policy deciding each rule, or "" if none does
+	type decided struct {
+		maxAdvanceDays     string
+		minDuration        string
+		maxDuration        string
+		allowedHours       string
+		maxBookingsPerWeek string
+		maxConsecutiveDays string
+		blackouts          []string
+	}
+
+	tests := []struct {
+		name     string
+		policies []*entities.BookingPolicy
+		roles    []string
+		want     decided
+	}{
+		{
+			name: "no policies",
+			want: decided{},
+		},
+		{
+			name: "global policy",
+			policies: []*entities.BookingPolicy{
+				{Name: "global", MaxAdvanceDays: intPtr(14), MinDurationMinutes: intPtr(30)},
+			},
+			want: decided{maxAdvanceDays: "global", minDuration: "global"},
+		},
+		{
+			name: "policies of other maps, space types and roles do not apply",
+			policies: []*entities.BookingPolicy{
+				{Name: "other map", MapID: &otherMapID, MaxAdvanceDays: intPtr(1)},
+				{Name: "meeting rooms", SpaceType: &meetingRoom, MaxAdvanceDays: intPtr(2)},
+				{Name: "managers", Role: strPtr("manager"), MaxAdvanceDays: intPtr(3)},
+			},
+			roles: []string{"employee"},
+			want:  decided{},
+		},
+		{
+			name: "map overrides space type",
+			policies: []*entities.BookingPolicy{
+				{Name: "map", MapID: &mapID, MaxAdvanceDays: intPtr(30)},
+				{Name: "workstations", SpaceType: &workstation, MaxAdvanceDays: intPtr(5)},
+			},
+			want: decided{maxAdvanceDays: "map"},
+		},
+		{
+			name: "role overrides map and space type",
+			policies: []*entities.BookingPolicy{
+				{Name: "map and type", MapID: &mapID, SpaceType: &workstation, MaxAdvanceDays: intPtr(7)},
+				{Name: "managers", Role: strPtr("manager"), MaxAdvanceDays: intPtr(60)},
+			},
+			roles: []string{"employee", "manager"},
+			want:  decided{maxAdvanceDays: "managers"},
+		},
+		{
+			name: "strictest value wins among equally specific policies",
+			policies: []*entities.BookingPolicy{
+				{Name: "loose", MapID: &mapID, MaxAdvanceDays: intPtr(30), MinDurationMinutes: intPtr(15), MaxBookingsPerWeek: intPtr(5)},
+				{Name: "strict", MapID: &mapID, MaxAdvanceDays: intPtr(10), MinDurationMinutes: intPtr(60), MaxBookingsPerWeek: intPtr(3)},
+			},
+			want: decided{maxAdvanceDays: "strict", minDuration: "strict", maxBookingsPerWeek: "strict"},
+		},
+		{
+			name: "rules are decided independently",
+			policies: []*entities.BookingPolicy{
+				{Name: "global", MaxDurationMinutes: intPtr(480), MaxConsecutiveDays: intPtr(3)},
+				{Name: "map", MapID: &mapID, MaxDurationMinutes: intPtr(600)},
+			},
+			want: decided{maxDuration: "map", maxConsecutiveDays: "global"},
+		},
+		{
+			name: "narrowest allowed hours win among equally specific policies",
+			policies: []*entities.BookingPolicy{
+				{Name: "day", AllowedStart: strPtr("08:00"), AllowedEnd: strPtr("20:00")},
+				{Name: "core", AllowedStart: strPtr("09:00"), AllowedEnd: strPtr("17:00")},
+			},
+			want: decided{allowedHours: "core"},
+		},
+		{
+			name: "more specific allowed hours win",
+			policies: []*entities.BookingPolicy{
+				{Name: "global", AllowedStart: strPtr("09:00"), AllowedEnd: strPtr("17:00")},
+				{Name: "map", MapID: &mapID, AllowedStart: strPtr("07:00"), AllowedEnd: strPtr("22:00")},
+			},
+			want: decided{allowedHours: "map"},
+		},
+		{
+			name: "blackout dates add up",
+			policies: []*entities.BookingPolicy{
+				{Name: "holidays", BlackoutDates: []time.Time{time.Date(2024, 12, 25, 0, 0, 0, 0, time.UTC)}},
+				{Name: "maintenance", MapID: &mapID, BlackoutDates: []time.Time{time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC)}},
+				{Name: "other map", MapID: &otherMapID, BlackoutDates: []time.Time{time.Date(2024, 8, 2, 0, 0, 0, 0, time.UTC)}},
+			},
+			want: decided{blackouts: []string{"holidays", "maintenance"}},
+		},
+	}
+
+	name := func(p *entities.BookingPolicy) string {
+		if p == nil {
+			return ""
+		}
+		return p.Name
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := resolveRules(tt.policies, space, tt.roles)
+
+			got := decided{
+				maxAdvanceDays:     name(rules.maxAdvanceDays),
+				minDuration:        name(rules.minDuration),
+				maxDuration:        name(rules.maxDuration),
+				allowedHours:       name(rules.allowedHours),
+				maxBookingsPerWeek: name(rules.maxBookingsPerWeek),
+				maxConsecutiveDays: name(rules.maxConsecutiveDays),
+			}
+			for _, p := range rules.blackouts {
+				got.blackouts = append(got.blackouts, p.Name)
+			}
+
+			if got.maxAdvanceDays != tt.want.maxAdvanceDays ||
+				got.minDuration != tt.want.minDuration ||
+				got.maxDuration != tt.want.maxDuration ||
+				got.allowedHours != tt.want.allowedHours ||
+				got.maxBookingsPerWeek != tt.want.maxBookingsPerWeek ||
+				got.maxConsecutiveDays != tt.want.maxConsecutiveDays {
+				t.Errorf("resolveRules() = %+v, want %+v", got, tt.want)
+			}
+			if len(got.blackouts) != len(tt.want.blackouts) {
+				t.Fatalf("resolveRules() blackouts = %v, want %v", got.blackouts, tt.want.blackouts)
+			}
+			for i := range got.blackouts {
+				if got.blackouts[i] != tt.want.blackouts[i] {
+					t.Errorf("resolveRules() blackouts = %v, want %v", got.blackouts, tt.want.blackouts)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"office-reservations/internal/domain/entities"
+	"office-reservations/internal/domain/repositories"
+)
+
+var (
+	ErrBookingPolicyNotFound = errors.New("booking policy not found")
+	// ErrInvalidBookingPolicy is matched by the ValidationError of a rejected policy
+	ErrInvalidBookingPolicy = errors.New("invalid booking policy")
+)
+
+// BookingPolicyService manages the booking policies and checks bookings against them
+type BookingPolicyService struct {
+	policyRepo      repositories.BookingPolicyRepository
+	reservationRepo repositories.ReservationRepository
+	spaceRepo       repositories.SpaceRepository
+	mapRepo         repositories.OfficeMapRepository
+}
+
+// NewBookingPolicyService creates a new booking policy service
+func NewBookingPolicyService(
+	policyRepo repositories.BookingPolicyRepository,
+	reservationRepo repositories.ReservationRepository,
+	spaceRepo repositories.SpaceRepository,
+	mapRepo repositories.OfficeMapRepository,
+) *BookingPolicyService {
+	return &BookingPolicyService{
+		policyRepo:      policyRepo,
+		reservationRepo: reservationRepo,
+		spaceRepo:       spaceRepo,
+		mapRepo:         mapRepo,
+	}
+}
+
+// BookingPolicyRequest represents the input for creating or replacing a policy.
+// Nil scope fields match everything and nil rules are not enforced.
+type BookingPolicyRequest struct {
+	Name               string
+	MapID              *uuid.UUID
+	SpaceType          *entities.SpaceType
+	Role               *string
+	MaxAdvanceDays     *int
+	MinDurationMinutes *int
+	MaxDurationMinutes *int
+	AllowedStart       *string
+	AllowedEnd         *string
+	MaxBookingsPerWeek *int
+	MaxConsecutiveDays *int
+	BlackoutDates      []string // Format: YYYY-MM-DD
+}
+
+// GetPolicies retrieves all policies, or only those that apply to a map
+func (s *BookingPolicyService) GetPolicies(mapID *uuid.UUID) ([]*entities.BookingPolicy, error) {
+	if mapID != nil {
+		return s.policyRepo.FindForMap(*mapID)
+	}
+	return s.policyRepo.FindAll()
+}
+
+// GetPolicy retrieves a policy by ID
+func (s *BookingPolicyService) GetPolicy(id uuid.UUID) (*entities.BookingPolicy, error) {
+	policy, err := s.policyRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrBookingPolicyNotFound
+	}
+	return policy, nil
+}
+
+// GetPolicyMapID returns the ID of the office map a policy is scoped to, or
+// uuid.Nil for a policy that applies to every map
+func (s *BookingPolicyService) GetPolicyMapID(id uuid.UUID) (uuid.UUID, error) {
+	policy, err := s.GetPolicy(id)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if policy.MapID == nil {
+		return uuid.Nil, nil
+	}
+	return *policy.MapID, nil
+}
+
+// CreatePolicy validates and stores a new policy
+func (s *BookingPolicyService) CreatePolicy(req BookingPolicyRequest) (*entities.BookingPolicy, error) {
+	policy := &entities.BookingPolicy{
+		ID:        uuid.New(),
+		CreatedAt: time.Now(),
+	}
+	if err := s.applyPolicyRequest(policy, req); err != nil {
+		return nil, err
+	}
+	if err := s.policyRepo.Create(policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// UpdatePolicy replaces the scope and rules of a policy
+func (s *BookingPolicyService) UpdatePolicy(id uuid.UUID, req BookingPolicyRequest) (*entities.BookingPolicy, error) {
+	policy, err := s.GetPolicy(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyPolicyRequest(policy, req); err != nil {
+		return nil, err
+	}
+	if err := s.policyRepo.Update(policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// DeletePolicy deletes a policy
+func (s *BookingPolicyService) DeletePolicy(id uuid.UUID) error {
+	if _, err := s.GetPolicy(id); err != nil {
+		return err
+	}
+	return s.policyRepo.Delete(id)
+}
+
+// applyPolicyRequest validates a request and copies it onto the policy
+func (s *BookingPolicyService) applyPolicyRequest(policy *entities.BookingPolicy, req BookingPolicyRequest) error {
+	var errs fieldErrors
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		errs.add("name", "name is required")
+	}
+	if req.MapID != nil {
+		if _, err := s.mapRepo.FindByID(*req.MapID); err != nil {
+			errs.add("map_id", "map not found")
+		}
+	}
+	if req.SpaceType != nil && !req.SpaceType.IsValid() {
+		errs.add("space_type", "invalid space type %q (use workstation, meeting_room, cubicle or invalid_space)", *req.SpaceType)
+	}
+	if req.Role != nil && strings.TrimSpace(*req.Role) == "" {
+		errs.add("role", "role must not be empty")
+	}
+
+	if req.MaxAdvanceDays != nil && *req.MaxAdvanceDays < 0 {
+		errs.add("max_advance_days", "max_advance_days must not be negative")
+	}
+	minOK := validDuration(&errs, "min_duration_minutes", req.MinDurationMinutes)
+	maxOK := validDuration(&errs, "max_duration_minutes", req.MaxDurationMinutes)
+	if minOK && maxOK && req.MinDurationMinutes != nil && req.MaxDurationMinutes != nil &&
+		*req.MinDurationMinutes > *req.MaxDurationMinutes {
+		errs.add("min_duration_minutes", "min_duration_minutes must not exceed max_duration_minutes")
+	}
+
+	startOK := validClock(&errs, "allowed_start", req.AllowedStart)
+	endOK := validClock(&errs, "allowed_end", req.AllowedEnd)
+	if startOK && endOK {
+		if hours, err := entities.NewTimeRange(req.AllowedStart, req.AllowedEnd); err == nil && !hours.IsValid() {
+			errs.add("allowed_start", "allowed_start must be before allowed_end")
+		}
+	}
+
+	if req.MaxBookingsPerWeek != nil && *req.MaxBookingsPerWeek < 1 {
+		errs.add("max_bookings_per_week", "max_bookings_per_week must be at least 1")
+	}
+	if req.MaxConsecutiveDays != nil && *req.MaxConsecutiveDays < 1 {
+		errs.add("max_consecutive_days", "max_consecutive_days must be at least 1")
+	}
+
+	blackoutDates := make([]time.Time, 0, len(req.BlackoutDates))
+	for i, value := range req.BlackoutDates {
+		date, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			errs.add(fmt.Sprintf("blackout_dates[%d]", i), "invalid date %q (use YYYY-MM-DD)", value)
+			continue
+		}
+		blackoutDates = append(blackoutDates, date)
+	}
+
+	if req.MaxAdvanceDays == nil && req.MinDurationMinutes == nil && req.MaxDurationMinutes == nil &&
+		req.AllowedStart == nil && req.AllowedEnd == nil && req.MaxBookingsPerWeek == nil &&
+		req.MaxConsecutiveDays == nil && len(req.BlackoutDates) == 0 {
+		errs.add("rules", "a policy must set at least one rule")
+	}
+
+	if err := errs.errOf(ErrInvalidBookingPolicy); err != nil {
+		return err
+	}
+
+	var role *string
+	if req.Role != nil {
+		trimmed := strings.TrimSpace(*req.Role)
+		role = &trimmed
+	}
+	policy.Name = name
+	policy.MapID = req.MapID
+	policy.SpaceType = req.SpaceType
+	policy.Role = role
+	policy.MaxAdvanceDays = req.MaxAdvanceDays
+	policy.MinDurationMinutes = req.MinDurationMinutes
+	policy.MaxDurationMinutes = req.MaxDurationMinutes
+	policy.AllowedStart = req.AllowedStart
+	policy.AllowedEnd = req.AllowedEnd
+	policy.MaxBookingsPerWeek = req.MaxBookingsPerWeek
+	policy.MaxConsecutiveDays = req.MaxConsecutiveDays
+	policy.BlackoutDates = blackoutDates
+	policy.UpdatedAt = time.Now()
+	return nil
+}
+
+// validDuration checks an optional duration in minutes, which must fit in a day
+func validDuration(errs *fieldErrors, field string, minutes *int) bool {
+	if minutes != nil && (*minutes < 1 || *minutes > entities.MinutesPerDay) {
+		errs.add(field, "%s must be between 1 and %d", field, entities.MinutesPerDay)
+		return false
+	}
+	return true
+}
+
+// validClock checks an optional time of day
+func validClock(errs *fieldErrors, field string, value *string) bool {
+	if value == nil {
+		return true
+	}
+	if _, err := entities.ParseClock(*value); err != nil {
+		errs.add(field, "%s must be a time of day (use HH:MM)", field)
+		return false
+	}
+	return true
+}
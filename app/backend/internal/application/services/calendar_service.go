@@ -0,0 +1,217 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"office-reservations/internal/domain/entities"
+	"office-reservations/internal/domain/repositories"
+)
+
+var (
+	ErrInvalidFeedToken = errors.New("invalid calendar feed token")
+)
+
+// feedHistoryDays is how far back calendar feeds include past reservations
+const feedHistoryDays = 90
+
+// CalendarService builds the calendar feeds of users, spaces and maps and
+// manages the secret tokens calendar clients use to read them
+type CalendarService struct {
+	tokenRepo       repositories.CalendarFeedTokenRepository
+	reservationRepo repositories.ReservationRepository
+	spaceRepo       repositories.SpaceRepository
+	groupRepo       repositories.SpaceGroupRepository
+	mapRepo         repositories.OfficeMapRepository
+}
+
+// NewCalendarService creates a new calendar service
+func NewCalendarService(
+	tokenRepo repositories.CalendarFeedTokenRepository,
+	reservationRepo repositories.ReservationRepository,
+	spaceRepo repositories.SpaceRepository,
+	groupRepo repositories.SpaceGroupRepository,
+	mapRepo repositories.OfficeMapRepository,
+) *CalendarService {
+	return &CalendarService{
+		tokenRepo:       tokenRepo,
+		reservationRepo: reservationRepo,
+		spaceRepo:       spaceRepo,
+		groupRepo:       groupRepo,
+		mapRepo:         mapRepo,
+	}
+}
+
+// CalendarFeed is the content of a calendar feed
+type CalendarFeed struct {
+	Name    string
+	Entries []CalendarEntry
+}
+
+// CalendarEntry is one booking in a calendar feed. A group booking appears once,
+// under the name of its space group.
+type CalendarEntry struct {
+	Reservation *entities.Reservation
+	// Title is the name of the booked space or space group
+	Title string
+	Map   *entities.OfficeMap
+}
+
+// IssueFeedToken creates a new feed token for a user, replacing the previous
+// one. The secret is returned once; only its hash is stored.
+func (s *CalendarService) IssueFeedToken(userID string) (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(secret)
+
+	err := s.tokenRepo.Save(&entities.CalendarFeedToken{
+		UserID:    userID,
+		TokenHash: hashFeedToken(token),
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// RevokeFeedToken deletes the feed token of a user
+func (s *CalendarService) RevokeFeedToken(userID string) error {
+	return s.tokenRepo.DeleteByUserID(userID)
+}
+
+// FeedTokenUser returns the ID of the user a feed token belongs to
+func (s *CalendarService) FeedTokenUser(token string) (string, error) {
+	if token == "" {
+		return "", ErrInvalidFeedToken
+	}
+	feedToken, err := s.tokenRepo.FindByHash(hashFeedToken(token))
+	if err != nil {
+		return "", ErrInvalidFeedToken
+	}
+	return feedToken.UserID, nil
+}
+
+// UserFeed returns the reservations of a user
+func (s *CalendarService) UserFeed(userID string) (*CalendarFeed, error) {
+	entries, err := s.feedEntries(repositories.ReservationFilters{UserID: &userID})
+	if err != nil {
+		return nil, err
+	}
+	return &CalendarFeed{Name: "Office reservations", Entries: entries}, nil
+}
+
+// SpaceFeed returns the reservations of a space
+func (s *CalendarService) SpaceFeed(spaceID uuid.UUID) (*CalendarFeed, error) {
+	space, err := s.spaceRepo.FindByID(spaceID)
+	if err != nil {
+		return nil, ErrSpaceNotFound
+	}
+	entries, err := s.feedEntries(repositories.ReservationFilters{SpaceID: &spaceID})
+	if err != nil {
+		return nil, err
+	}
+	return &CalendarFeed{Name: space.Name, Entries: entries}, nil
+}
+
+// MapFeed returns the reservations of all spaces of a map
+func (s *CalendarService) MapFeed(mapID uuid.UUID) (*CalendarFeed, error) {
+	officeMap, err := s.mapRepo.FindByID(mapID)
+	if err != nil {
+		return nil, ErrMapNotFound
+	}
+	entries, err := s.feedEntries(repositories.ReservationFilters{MapID: &mapID})
+	if err != nil {
+		return nil, err
+	}
+	return &CalendarFeed{Name: officeMap.Name, Entries: entries}, nil
+}
+
+// feedEntries loads the reservations matching the filters from feedHistoryDays
+// ago onwards, with the names of their spaces and their maps
+func (s *CalendarService) feedEntries(filters repositories.ReservationFilters) ([]CalendarEntry, error) {
+	from := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -feedHistoryDays)
+	filters.From = &from
+	reservations, err := s.reservationRepo.FindAll(filters)
+	if err != nil {
+		return nil, err
+	}
+	return calendarEntries(s.spaceRepo, s.groupRepo, s.mapRepo, reservations)
+}
+
+// calendarEntries pairs reservations with the names of their spaces and their
+// maps. A group booking is kept once, under the name of its space group.
+func calendarEntries(
+	spaceRepo repositories.SpaceRepository,
+	groupRepo repositories.SpaceGroupRepository,
+	mapRepo repositories.OfficeMapRepository,
+	reservations []*entities.Reservation,
+) ([]CalendarEntry, error) {
+	seenGroups := make(map[uuid.UUID]bool)
+	var spaceIDs []uuid.UUID
+	for _, r := range reservations {
+		spaceIDs = append(spaceIDs, r.SpaceID)
+	}
+	spaces, err := spaceRepo.FindByIDs(spaceIDs)
+	if err != nil {
+		return nil, err
+	}
+	spacesByID := make(map[uuid.UUID]*entities.Space, len(spaces))
+	for _, space := range spaces {
+		spacesByID[space.ID] = space
+	}
+
+	maps := make(map[uuid.UUID]*entities.OfficeMap)
+	groupNames := make(map[uuid.UUID]string)
+	entries := make([]CalendarEntry, 0, len(reservations))
+	for _, r := range reservations {
+		if r.GroupBookingID != nil {
+			if seenGroups[*r.GroupBookingID] {
+				continue
+			}
+			seenGroups[*r.GroupBookingID] = true
+		}
+		space, ok := spacesByID[r.SpaceID]
+		if !ok {
+			continue
+		}
+
+		officeMap, ok := maps[space.MapID]
+		if !ok {
+			if officeMap, err = mapRepo.FindByID(space.MapID); err != nil {
+				return nil, err
+			}
+			maps[space.MapID] = officeMap
+		}
+
+		title := space.Name
+		if r.GroupBookingID != nil && space.GroupID != nil {
+			name, ok := groupNames[*space.GroupID]
+			if !ok {
+				if group, err := groupRepo.FindByID(*space.GroupID); err == nil {
+					name = group.Name
+				}
+				groupNames[*space.GroupID] = name
+			}
+			if name != "" {
+				title = name
+			}
+		}
+
+		entries = append(entries, CalendarEntry{Reservation: r, Title: title, Map: officeMap})
+	}
+	return entries, nil
+}
+
+// hashFeedToken returns the hex SHA-256 hash a feed token is stored as
+func hashFeedToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
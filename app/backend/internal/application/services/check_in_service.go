@@ -0,0 +1,256 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"office-reservations/internal/config"
+	"office-reservations/internal/domain/entities"
+	"office-reservations/internal/domain/repositories"
+)
+
+var (
+	ErrCheckInNotAllowed = errors.New("only active reservations can be checked in")
+	ErrCheckInTooEarly   = errors.New("check-in window has not opened yet")
+	ErrCheckInClosed     = errors.New("check-in window has closed")
+)
+
+// CheckInService handles desk check-in and the release of unclaimed reservations
+type CheckInService struct {
+	reservationRepo repositories.ReservationRepository
+	spaceRepo       repositories.SpaceRepository
+	mapRepo         repositories.OfficeMapRepository
+	txManager       repositories.TransactionManager
+	config          config.CheckInConfig
+}
+
+// NewCheckInService creates a new check-in service
+func NewCheckInService(
+	reservationRepo repositories.ReservationRepository,
+	spaceRepo repositories.SpaceRepository,
+	mapRepo repositories.OfficeMapRepository,
+	txManager repositories.TransactionManager,
+	cfg config.CheckInConfig,
+) *CheckInService {
+	return &CheckInService{
+		reservationRepo: reservationRepo,
+		spaceRepo:       spaceRepo,
+		mapRepo:         mapRepo,
+		txManager:       txManager,
+		config:          cfg,
+	}
+}
+
+// CheckIn claims a reservation for its holder. Check-in is accepted from
+// WindowBefore the start time until WindowAfter it. Only the owner or an admin
+// can check in.
+func (s *CheckInService) CheckIn(id uuid.UUID, actor *Actor) (*entities.Reservation, error) {
+	reservation, err := s.reservationRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrReservationNotFound
+	}
+
+	if !actor.CanManage(reservation.UserID) {
+		return nil, ErrNotReservationOwner
+	}
+
+	if !reservation.IsActive() {
+		return nil, ErrCheckInNotAllowed
+	}
+
+	opens, closes, err := s.checkInWindow(reservation, make(map[uuid.UUID]*entities.OfficeMap))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if now.Before(opens) {
+		return nil, ErrCheckInTooEarly
+	}
+	if now.After(closes) {
+		return nil, ErrCheckInClosed
+	}
+
+	// Checking in to one room of a group booking claims the whole group
+	booking := []*entities.Reservation{reservation}
+	if reservation.GroupBookingID != nil {
+		siblings, err := s.reservationRepo.FindAll(repositories.ReservationFilters{GroupBookingID: reservation.GroupBookingID})
+		if err != nil {
+			return nil, err
+		}
+		for _, sibling := range siblings {
+			if sibling.ID != reservation.ID && sibling.IsActive() {
+				booking = append(booking, sibling)
+			}
+		}
+	}
+
+	err = s.txManager.WithinTransaction(func(repos repositories.TxRepositories) error {
+		for _, r := range booking {
+			before := snapshotReservation(r)
+			r.CheckIn(now)
+			if err := repos.Reservations.Update(r); err != nil {
+				return err
+			}
+			if err := appendReservationEvent(repos, entities.WebhookReservationUpdated, r); err != nil {
+				return err
+			}
+			if err := auditReservation(repos, actor, entities.AuditActionCheckIn, "", &before, r); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, translateVersionConflict(err)
+	}
+
+	return reservation, nil
+}
+
+// SweepNoShows releases active reservations whose check-in window has closed by
+// marking them as no-shows, and completes checked-in reservations that have ended.
+// It returns the number of released and completed reservations.
+func (s *CheckInService) SweepNoShows() (released int, completed int, err error) {
+	// Offices ahead of UTC may already be a day later and offices behind it
+	// still a day earlier; each reservation is then checked in the time zone of
+	// its map. Older reservations were swept when their day ended.
+	now := time.Now()
+	today, _ := entities.LocalDay(now, time.UTC)
+	earliest, latest := today.AddDate(0, 0, -1), today.AddDate(0, 0, 1)
+
+	activeStatus := entities.ReservationStatusActive
+	active, err := s.reservationRepo.FindAll(repositories.ReservationFilters{From: &earliest, To: &latest, Status: &activeStatus})
+	if err != nil {
+		return 0, 0, err
+	}
+	maps := make(map[uuid.UUID]*entities.OfficeMap)
+	for _, r := range active {
+		_, closes, err := s.checkInWindow(r, maps)
+		if err != nil || !now.After(closes) {
+			continue
+		}
+		ok, err := s.transition(r, entities.ReservationStatusNoShow, "Not checked in before the check-in window closed")
+		if err != nil {
+			return released, completed, err
+		}
+		if ok {
+			released++
+		}
+	}
+
+	checkedInStatus := entities.ReservationStatusCheckedIn
+	checkedIn, err := s.reservationRepo.FindAll(repositories.ReservationFilters{From: &earliest, To: &latest, Status: &checkedInStatus})
+	if err != nil {
+		return released, completed, err
+	}
+	for _, r := range checkedIn {
+		_, end, err := r.Interval()
+		if err != nil || !now.After(end) {
+			continue
+		}
+		ok, err := s.transition(r, entities.ReservationStatusCompleted, "Ended after check-in")
+		if err != nil {
+			return released, completed, err
+		}
+		if ok {
+			completed++
+		}
+	}
+
+	return released, completed, nil
+}
+
+// transition moves a reservation from its current status to another one,
+// announces it as a reservation.updated event and records it in the audit log.
+// It returns false if the reservation was changed concurrently.
+func (s *CheckInService) transition(r *entities.Reservation, to entities.ReservationStatus, reason string) (bool, error) {
+	var ok bool
+	err := s.txManager.WithinTransaction(func(repos repositories.TxRepositories) error {
+		var err error
+		ok, err = repos.Reservations.TransitionStatus(r.ID, r.Status, to)
+		if err != nil || !ok {
+			return err
+		}
+		before := snapshotReservation(r)
+		r.Status = to
+		r.Version++
+		r.UpdatedAt = time.Now()
+		if err := appendReservationEvent(repos, entities.WebhookReservationUpdated, r); err != nil {
+			return err
+		}
+		return auditReservation(repos, nil, entities.AuditActionUpdate, reason, &before, r)
+	})
+	return ok, err
+}
+
+// RunNoShowSweeper releases unclaimed reservations every interval until ctx is done
+func (s *CheckInService) RunNoShowSweeper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		released, completed, err := s.SweepNoShows()
+		if err != nil {
+			log.Printf("No-show sweeper error: %v", err)
+		} else if released > 0 || completed > 0 {
+			log.Printf("No-show sweeper released %d and completed %d reservations", released, completed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkInWindow returns when check-in opens and closes for a reservation, in the
+// time zone of its map. All-day reservations are anchored at the map's opening
+// time on their weekday, or the configured office opening if it has none. maps
+// caches the office map of each space across calls.
+func (s *CheckInService) checkInWindow(r *entities.Reservation, maps map[uuid.UUID]*entities.OfficeMap) (time.Time, time.Time, error) {
+	var start int
+	if r.StartTime != nil {
+		minutes, err := entities.ParseClock(*r.StartTime)
+		if err != nil {
+			return time.Time{}, time.Time{}, ErrInvalidTime
+		}
+		start = minutes
+	} else if opening, ok := s.mapOpening(r, maps); ok {
+		start = opening
+	} else {
+		minutes, err := entities.ParseClock(s.config.OfficeOpening)
+		if err != nil {
+			return time.Time{}, time.Time{}, ErrInvalidTime
+		}
+		start = minutes
+	}
+
+	startAt := entities.AtClock(r.Date, start, entities.LoadLocation(r.Timezone))
+	return startAt.Add(-s.config.WindowBefore), startAt.Add(s.config.WindowAfter), nil
+}
+
+// mapOpening returns the minute the map of a reservation's space opens on the
+// reservation's weekday, or false if the map sets no opening hours or is closed
+// that day
+func (s *CheckInService) mapOpening(r *entities.Reservation, maps map[uuid.UUID]*entities.OfficeMap) (int, bool) {
+	officeMap, cached := maps[r.SpaceID]
+	if !cached {
+		if _, found, err := spaceWithMap(s.spaceRepo, s.mapRepo, r.SpaceID); err == nil {
+			officeMap = found
+		}
+		maps[r.SpaceID] = officeMap
+	}
+	if officeMap == nil || officeMap.OpeningHours == nil {
+		return 0, false
+	}
+	hours, open := officeMap.OpeningHours.On(r.Date)
+	if !open {
+		return 0, false
+	}
+	return hours.Start, true
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"office-reservations/internal/config"
+	"office-reservations/internal/domain/entities"
+)
+
+func newTestCheckInService(store *fakeStore, window time.Duration) *CheckInService {
+	repos := store.fakeRepos()
+	return NewCheckInService(repos.Reservations, repos.Spaces, repos.Maps, &fakeTxManager{store: store}, config.CheckInConfig{
+		WindowBefore:  window,
+		WindowAfter:   window,
+		OfficeOpening: "09:00",
+	})
+}
+
+func TestCheckIn(t *testing.T) {
+	store := newFakeStore()
+	officeMap := store.addMap("UTC")
+	desk := store.addSpace(officeMap.ID, "Desk 1")
+	today, _ := entities.LocalDay(time.Now(), time.UTC)
+	reservation := store.addReservation(entities.Reservation{SpaceID: desk.ID, UserID: "alice", Date: today})
+
+	// A window of two days keeps the test independent of the time it runs at
+	service := newTestCheckInService(store, 48*time.Hour)
+	if _, err := service.CheckIn(reservation.ID, &Actor{UserID: "bob"}); err != ErrNotReservationOwner {
+		t.Fatalf("CheckIn() by another user error = %v, want %v", err, ErrNotReservationOwner)
+	}
+	if _, err := service.CheckIn(reservation.ID, &Actor{UserID: "alice"}); err != nil {
+		t.Fatalf("CheckIn() error = %v", err)
+	}
+
+	got := store.reservation(reservation.ID)
+	if got.Status != entities.ReservationStatusCheckedIn || got.CheckedInAt == nil {
+		t.Errorf("CheckIn() status = %s, checked in at %v, want checked_in with a time", got.Status, got.CheckedInAt)
+	}
+	if events := store.events(reservation.ID); len(events) != 1 || events[0] != entities.WebhookReservationUpdated {
+		t.Errorf("CheckIn() events = %v, want [%s]", events, entities.WebhookReservationUpdated)
+	}
+	if entries := store.auditEntries(reservation.ID); len(entries) != 1 || entries[0].Action != entities.AuditActionCheckIn {
+		t.Errorf("CheckIn() audit entries = %+v, want one check_in entry", entries)
+	}
+}
+
+func TestSweepNoShows(t *testing.T) {
+	store := newFakeStore()
+	officeMap := store.addMap("UTC")
+	desk := store.addSpace(officeMap.ID, "Desk 1")
+	today, _ := entities.LocalDay(time.Now(), time.UTC)
+	clock := func(value string) *string { return &value }
+
+	tests := []struct {
+		name       string
+		date       time.Time
+		status     entities.ReservationStatus
+		wantStatus entities.ReservationStatus
+	}{
+		{name: "unclaimed yesterday", date: today.AddDate(0, 0, -1), status: entities.ReservationStatusActive, wantStatus: entities.ReservationStatusNoShow},
+		{name: "checked in yesterday", date: today.AddDate(0, 0, -1), status: entities.ReservationStatusCheckedIn, wantStatus: entities.ReservationStatusCompleted},
+		{name: "unclaimed tomorrow", date: today.AddDate(0, 0, 1), status: entities.ReservationStatusActive, wantStatus: entities.ReservationStatusActive},
+		{name: "unclaimed before the sweep window", date: today.AddDate(0, 0, -10), status: entities.ReservationStatusActive, wantStatus: entities.ReservationStatusActive},
+		{name: "checked in before the sweep window", date: today.AddDate(0, 0, -10), status: entities.ReservationStatusCheckedIn, wantStatus: entities.ReservationStatusCheckedIn},
+	}
+
+	ids := make([]*entities.Reservation, len(tests))
+	for i, tt := range tests {
+		ids[i] = store.addReservation(entities.Reservation{
+			SpaceID:   desk.ID,
+			UserID:    "user-" + tt.name,
+			Date:      tt.date,
+			StartTime: clock("09:00"),
+			EndTime:   clock("10:00"),
+			Status:    tt.status,
+		})
+	}
+
+	released, completed, err := newTestCheckInService(store, 30*time.Minute).SweepNoShows()
+	if err != nil {
+		t.Fatalf("SweepNoShows() error = %v", err)
+	}
+	if released != 1 || completed != 1 {
+		t.Errorf("SweepNoShows() = %d released, %d completed, want 1 and 1", released, completed)
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := store.reservation(ids[i].ID)
+			if got.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", got.Status, tt.wantStatus)
+			}
+			changed := tt.status != tt.wantStatus
+			if entries := store.auditEntries(got.ID); (len(entries) > 0) != changed {
+				t.Errorf("audit entries = %d, want them only for swept reservations", len(entries))
+			}
+			events := store.events(got.ID)
+			if changed && (len(events) != 1 || events[0] != entities.WebhookReservationUpdated) {
+				t.Errorf("events = %v, want [%s]", events, entities.WebhookReservationUpdated)
+			}
+			if !changed && len(events) > 0 {
+				t.Errorf("events = %v, want none", events)
+			}
+		})
+	}
+}
@@ -0,0 +1,554 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"office-reservations/internal/domain/entities"
+	"office-reservations/internal/domain/repositories"
+)
+
+var (
+	ErrClosureNotFound = errors.New("closure not found")
+	// ErrInvalidClosure is matched by the ValidationError of a rejected closure
+	ErrInvalidClosure = errors.New("invalid closure")
+	ErrSpaceClosed    = errors.New("space is closed at this time")
+)
+
+// defaultClosureReason is used for imported calendar events without a summary
+const defaultClosureReason = "Closed"
+
+// ClosureConflictError is returned when a booking falls into one or more
+// closures. It matches ErrSpaceClosed with errors.Is.
+type ClosureConflictError struct {
+	Closures []*entities.Closure
+}
+
+func (e *ClosureConflictError) Error() string {
+	reasons := make([]string, len(e.Closures))
+	for i, c := range e.Closures {
+		reasons[i] = c.Reason
+	}
+	return ErrSpaceClosed.Error() + ": " + strings.Join(reasons, "; ")
+}
+
+// Is allows errors.Is(err, ErrSpaceClosed) to match a ClosureConflictError
+func (e *ClosureConflictError) Is(target error) bool {
+	return target == ErrSpaceClosed
+}
+
+// CancellationNotifier is told about reservations cancelled by the system
+// rather than by their holders, so that they can be informed
+type CancellationNotifier interface {
+	ReservationsCancelled(reservations []*entities.Reservation, reason string)
+}
+
+// ClosureService manages closures and cancels the reservations they displace
+type ClosureService struct {
+	closureRepo repositories.ClosureRepository
+	mapRepo     repositories.OfficeMapRepository
+	spaceRepo   repositories.SpaceRepository
+	groupRepo   repositories.SpaceGroupRepository
+	txManager   repositories.TransactionManager
+	notifier    CancellationNotifier
+}
+
+// NewClosureService creates a new closure service
+func NewClosureService(
+	closureRepo repositories.ClosureRepository,
+	mapRepo repositories.OfficeMapRepository,
+	spaceRepo repositories.SpaceRepository,
+	groupRepo repositories.SpaceGroupRepository,
+	txManager repositories.TransactionManager,
+	notifier CancellationNotifier,
+) *ClosureService {
+	return &ClosureService{
+		closureRepo: closureRepo,
+		mapRepo:     mapRepo,
+		spaceRepo:   spaceRepo,
+		groupRepo:   groupRepo,
+		txManager:   txManager,
+		notifier:    notifier,
+	}
+}
+
+// ClosureRequest represents the input for creating or replacing a closure. The
+// closure applies to the whole map unless GroupID or SpaceID narrow it.
+type ClosureRequest struct {
+	MapID     uuid.UUID
+	GroupID   *uuid.UUID
+	SpaceID   *uuid.UUID
+	StartDate string // Format: YYYY-MM-DD
+	EndDate   string // Format: YYYY-MM-DD, defaults to StartDate
+	StartTime *string
+	EndTime   *string
+	Reason    string
+	// Actor is the user making the request, recorded in the audit log of the
+	// reservations the closure cancels
+	Actor *Actor
+}
+
+// GetClosures retrieves the closures matching the filters
+func (s *ClosureService) GetClosures(filters repositories.ClosureFilters) ([]*entities.Closure, error) {
+	return s.closureRepo.FindAll(filters)
+}
+
+// GetClosure retrieves a closure by ID
+func (s *ClosureService) GetClosure(id uuid.UUID) (*entities.Closure, error) {
+	closure, err := s.closureRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrClosureNotFound
+	}
+	return closure, nil
+}
+
+// GetClosureMapID returns the ID of the office map a closure belongs to
+func (s *ClosureService) GetClosureMapID(id uuid.UUID) (uuid.UUID, error) {
+	closure, err := s.GetClosure(id)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return closure.MapID, nil
+}
+
+// CreateClosure stores a new closure and cancels the upcoming reservations that
+// fall into it. It returns the cancelled reservations.
+func (s *ClosureService) CreateClosure(req ClosureRequest) (*entities.Closure, []*entities.Reservation, error) {
+	closure := &entities.Closure{
+		ID:        uuid.New(),
+		CreatedAt: time.Now(),
+	}
+	if err := s.applyClosureRequest(closure, req); err != nil {
+		return nil, nil, err
+	}
+
+	var cancelled []*entities.Reservation
+	err := s.txManager.WithinTransaction(func(repos repositories.TxRepositories) error {
+		if err := repos.Closures.Create(closure); err != nil {
+			return err
+		}
+		var err error
+		cancelled, err = s.cancelClosed(repos, closure, nil, req.Actor)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.notifyCancelled(cancelled, closure.Reason)
+	return closure, cancelled, nil
+}
+
+// UpdateClosure replaces the target, period and reason of a closure and cancels
+// the upcoming reservations that fall into its new period. Reservations
+// cancelled earlier are not restored.
+func (s *ClosureService) UpdateClosure(id uuid.UUID, req ClosureRequest) (*entities.Closure, []*entities.Reservation, error) {
+	closure, err := s.GetClosure(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.applyClosureRequest(closure, req); err != nil {
+		return nil, nil, err
+	}
+
+	var cancelled []*entities.Reservation
+	err = s.txManager.WithinTransaction(func(repos repositories.TxRepositories) error {
+		if err := repos.Closures.Update(closure); err != nil {
+			return err
+		}
+		var err error
+		cancelled, err = s.cancelClosed(repos, closure, nil, req.Actor)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.notifyCancelled(cancelled, closure.Reason)
+	return closure, cancelled, nil
+}
+
+// DeleteClosure deletes a closure. Reservations it cancelled stay cancelled.
+func (s *ClosureService) DeleteClosure(id uuid.UUID) error {
+	if _, err := s.GetClosure(id); err != nil {
+		return err
+	}
+	return s.closureRepo.Delete(id)
+}
+
+// CalendarEvent is an event read from an iCalendar file
+type CalendarEvent struct {
+	UID     string
+	Summary string
+	// AllDay events cover the dates from Start up to but excluding End
+	AllDay bool
+	Start  time.Time
+	End    time.Time
+	// Floating times have no time zone and are read in the map's time zone
+	Floating bool
+}
+
+// ClosureImportRequest represents calendar events to import as closures of a
+// map, or of one space group or space of it
+type ClosureImportRequest struct {
+	MapID   uuid.UUID
+	GroupID *uuid.UUID
+	SpaceID *uuid.UUID
+	Events  []CalendarEvent
+	// Actor is the user making the request, recorded in the audit log of the
+	// reservations the closures cancel
+	Actor *Actor
+}
+
+// ClosureImportResult reports the outcome of a calendar import
+type ClosureImportResult struct {
+	Created   []*entities.Closure
+	Updated   []*entities.Closure
+	Cancelled []*entities.Reservation
+}
+
+// ImportClosures creates a closure for each calendar event, or updates the one
+// imported earlier from the same event for the same target, and cancels the
+// upcoming reservations that fall into them. The import is atomic.
+func (s *ClosureService) ImportClosures(req ClosureImportRequest) (*ClosureImportResult, error) {
+	officeMap, err := s.mapRepo.FindByID(req.MapID)
+	if err != nil {
+		return nil, ErrMapNotFound
+	}
+	loc := officeMap.Location()
+
+	var errs fieldErrors
+	closures := make([]*entities.Closure, 0, len(req.Events))
+	for i, event := range req.Events {
+		field := fmt.Sprintf("events[%d]", i)
+		if event.UID == "" {
+			errs.add(field, "event has no UID")
+			continue
+		}
+		startDate, endDate, startTime, endTime, ok := eventPeriod(event, loc)
+		if !ok {
+			errs.add(field, "event %s ends before it starts", event.UID)
+			continue
+		}
+		reason := strings.TrimSpace(event.Summary)
+		if reason == "" {
+			reason = defaultClosureReason
+		}
+		uid := event.UID
+		closures = append(closures, &entities.Closure{
+			MapID:      req.MapID,
+			GroupID:    req.GroupID,
+			SpaceID:    req.SpaceID,
+			StartDate:  startDate,
+			EndDate:    endDate,
+			StartTime:  startTime,
+			EndTime:    endTime,
+			Reason:     reason,
+			ExternalID: &uid,
+		})
+	}
+	s.validateTarget(&errs, req.MapID, req.GroupID, req.SpaceID)
+	if err := errs.errOf(ErrInvalidClosure); err != nil {
+		return nil, err
+	}
+
+	result := &ClosureImportResult{}
+	cancelledBy := make([][]*entities.Reservation, len(closures))
+	err = s.txManager.WithinTransaction(func(repos repositories.TxRepositories) error {
+		seen := make(map[uuid.UUID]bool)
+		for i, closure := range closures {
+			existing, err := repos.Closures.FindByExternalID(closure.MapID, *closure.ExternalID)
+			if err != nil {
+				return err
+			}
+			var previous *entities.Closure
+			for _, e := range existing {
+				if sameUUID(e.GroupID, closure.GroupID) && sameUUID(e.SpaceID, closure.SpaceID) {
+					previous = e
+					break
+				}
+			}
+
+			closure.UpdatedAt = time.Now()
+			if previous != nil {
+				closure.ID, closure.CreatedAt = previous.ID, previous.CreatedAt
+				if err := repos.Closures.Update(closure); err != nil {
+					return err
+				}
+				result.Updated = append(result.Updated, closure)
+			} else {
+				closure.ID, closure.CreatedAt = uuid.New(), time.Now()
+				if err := repos.Closures.Create(closure); err != nil {
+					return err
+				}
+				result.Created = append(result.Created, closure)
+			}
+
+			cancelledBy[i], err = s.cancelClosed(repos, closure, seen, req.Actor)
+			if err != nil {
+				return err
+			}
+			result.Cancelled = append(result.Cancelled, cancelledBy[i]...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, closure := range closures {
+		s.notifyCancelled(cancelledBy[i], closure.Reason)
+	}
+	return result, nil
+}
+
+// eventPeriod converts a calendar event to the dates and times of a closure in
+// the map's time zone
+func eventPeriod(event CalendarEvent, loc *time.Location) (startDate, endDate time.Time, startTime, endTime *string, ok bool) {
+	if event.AllDay {
+		startDate, _ = entities.LocalDay(event.Start, time.UTC)
+		endDate, _ = entities.LocalDay(event.End, time.UTC)
+		endDate = endDate.AddDate(0, 0, -1)
+		if event.End.IsZero() || endDate.Before(startDate) {
+			endDate = startDate
+		}
+		return startDate, endDate, nil, nil, true
+	}
+
+	start, end := event.Start, event.End
+	if event.Floating {
+		start = wallClockIn(start, loc)
+		end = wallClockIn(end, loc)
+	}
+	if end.IsZero() {
+		end = start
+	}
+	if end.Before(start) {
+		return time.Time{}, time.Time{}, nil, nil, false
+	}
+
+	startDate, startClock := entities.LocalDay(start, loc)
+	endDate, endClock := entities.LocalDay(end, loc)
+	if startClock > 0 {
+		formatted := entities.FormatClock(startClock)
+		startTime = &formatted
+	}
+	switch {
+	case end.Equal(start):
+		// Events without an end cover the rest of their day
+	case endClock == 0:
+		// Ending at midnight closes the whole previous day
+		endDate = endDate.AddDate(0, 0, -1)
+	default:
+		formatted := entities.FormatClock(endClock)
+		endTime = &formatted
+	}
+	return startDate, endDate, startTime, endTime, true
+}
+
+// wallClockIn returns the instant at the wall clock time of t in loc
+func wallClockIn(t time.Time, loc *time.Location) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, loc)
+}
+
+// sameUUID returns true if both IDs are nil or equal
+func sameUUID(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// applyClosureRequest validates a request and copies it onto the closure
+func (s *ClosureService) applyClosureRequest(closure *entities.Closure, req ClosureRequest) error {
+	var errs fieldErrors
+
+	s.validateTarget(&errs, req.MapID, req.GroupID, req.SpaceID)
+
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		errs.add("start_date", "invalid date %q (use YYYY-MM-DD)", req.StartDate)
+	}
+	endDate := startDate
+	if req.EndDate != "" {
+		if endDate, err = time.Parse("2006-01-02", req.EndDate); err != nil {
+			errs.add("end_date", "invalid date %q (use YYYY-MM-DD)", req.EndDate)
+		} else if endDate.Before(startDate) {
+			errs.add("end_date", "end_date must not be before start_date")
+		}
+	}
+
+	startOK := validClock(&errs, "start_time", req.StartTime)
+	endOK := validClock(&errs, "end_time", req.EndTime)
+	if startOK && endOK && req.StartTime != nil && req.EndTime != nil && startDate.Equal(endDate) {
+		if hours, err := entities.NewTimeRange(req.StartTime, req.EndTime); err == nil && !hours.IsValid() {
+			errs.add("start_time", "start_time must be before end_time")
+		}
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		errs.add("reason", "reason is required")
+	}
+
+	if err := errs.errOf(ErrInvalidClosure); err != nil {
+		return err
+	}
+
+	closure.MapID = req.MapID
+	closure.GroupID = req.GroupID
+	closure.SpaceID = req.SpaceID
+	closure.StartDate = startDate
+	closure.EndDate = endDate
+	closure.StartTime = req.StartTime
+	closure.EndTime = req.EndTime
+	closure.Reason = reason
+	closure.UpdatedAt = time.Now()
+	return nil
+}
+
+// validateTarget checks that the map exists and that an optional space group or
+// space belongs to it
+func (s *ClosureService) validateTarget(errs *fieldErrors, mapID uuid.UUID, groupID, spaceID *uuid.UUID) {
+	if _, err := s.mapRepo.FindByID(mapID); err != nil {
+		errs.add("map_id", "map not found")
+		return
+	}
+	if groupID != nil && spaceID != nil {
+		errs.add("space_id", "set either group_id or space_id, not both")
+		return
+	}
+	if groupID != nil {
+		group, err := s.groupRepo.FindByID(*groupID)
+		if err != nil || group.MapID != mapID {
+			errs.add("group_id", "space group not found on this map")
+		}
+	}
+	if spaceID != nil {
+		space, err := s.spaceRepo.FindByID(*spaceID)
+		if err != nil || space.MapID != mapID {
+			errs.add("space_id", "space not found on this map")
+		}
+	}
+}
+
+// cancelClosed cancels the bookings that hold a space covered by the closure
+// during a closed period that has not ended yet. A group booking is cancelled as
+// a whole. Reservations in seen are skipped, and the cancelled ones are added to
+// it. It returns the cancelled reservations.
+func (s *ClosureService) cancelClosed(repos repositories.TxRepositories, closure *entities.Closure, seen map[uuid.UUID]bool, actor *Actor) ([]*entities.Reservation, error) {
+	if seen == nil {
+		seen = make(map[uuid.UUID]bool)
+	}
+	officeMap, err := repos.Maps.FindByID(closure.MapID)
+	if err != nil {
+		return nil, err
+	}
+	loc := officeMap.Location()
+
+	spaces, err := closedSpaces(repos.Spaces, closure)
+	if err != nil {
+		return nil, err
+	}
+	spaceIDs := make([]uuid.UUID, len(spaces))
+	for i, space := range spaces {
+		spaceIDs[i] = space.ID
+	}
+	if err := repos.Spaces.LockByIDs(spaceIDs); err != nil {
+		return nil, err
+	}
+
+	reservations, err := repos.Reservations.FindHoldingBySpaceIDsInRange(spaceIDs, closure.StartDate, closure.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var cancelled []*entities.Reservation
+	for _, r := range reservations {
+		timeRange, err := r.TimeRange()
+		if err != nil || seen[r.ID] || !closure.Blocks(r.Date, timeRange) {
+			continue
+		}
+		if _, end := timeRange.In(r.Date, loc); !end.After(now) {
+			continue
+		}
+
+		booking, err := bookingReservations(repos.Reservations, r)
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range booking {
+			if seen[b.ID] || !b.HoldsSpace() {
+				continue
+			}
+			seen[b.ID] = true
+			b.Timezone = officeMap.Timezone
+			before := snapshotReservation(b)
+			b.Cancel()
+			if err := repos.Reservations.Update(b); err != nil {
+				return nil, err
+			}
+			if err := appendReservationEvent(repos, entities.WebhookReservationCancelled, b); err != nil {
+				return nil, err
+			}
+			if err := auditReservation(repos, actor, entities.AuditActionCancel, "Closed: "+closure.Reason, &before, b); err != nil {
+				return nil, err
+			}
+			cancelled = append(cancelled, b)
+		}
+	}
+	return cancelled, nil
+}
+
+// closedSpaces returns the spaces a closure applies to
+func closedSpaces(spaceRepo repositories.SpaceRepository, closure *entities.Closure) ([]*entities.Space, error) {
+	switch {
+	case closure.SpaceID != nil:
+		space, err := spaceRepo.FindByID(*closure.SpaceID)
+		if err != nil {
+			return nil, err
+		}
+		return []*entities.Space{space}, nil
+	case closure.GroupID != nil:
+		return spaceRepo.FindByGroupID(*closure.GroupID)
+	default:
+		return spaceRepo.FindByMapID(closure.MapID)
+	}
+}
+
+// notifyCancelled passes the reservations cancelled by a closure to the notifier
+func (s *ClosureService) notifyCancelled(cancelled []*entities.Reservation, reason string) {
+	if s.notifier == nil || len(cancelled) == 0 {
+		return
+	}
+	s.notifier.ReservationsCancelled(cancelled, reason)
+}
+
+// closuresBlocking returns the closures that cover space, or another space of
+// its booking scope, at any time of timeRange on date
+func closuresBlocking(closureRepo repositories.ClosureRepository, space *entities.Space, spaceIDs []uuid.UUID, date time.Time, timeRange entities.TimeRange) ([]*entities.Closure, error) {
+	closures, err := closureRepo.FindAll(repositories.ClosureFilters{MapID: &space.MapID, From: &date, To: &date})
+	if err != nil {
+		return nil, err
+	}
+	inScope := make(map[uuid.UUID]bool, len(spaceIDs))
+	for _, id := range spaceIDs {
+		inScope[id] = true
+	}
+
+	var blocking []*entities.Closure
+	for _, c := range closures {
+		covers := c.Covers(space) || (c.SpaceID != nil && inScope[*c.SpaceID])
+		if covers && c.Blocks(date, timeRange) {
+			blocking = append(blocking, c)
+		}
+	}
+	return blocking, nil
+}
@@ -0,0 +1,291 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"office-reservations/internal/domain/entities"
+	"office-reservations/internal/domain/repositories"
+)
+
+var (
+	ErrNoDeskAvailable   = errors.New("no desk is available for this time slot")
+	ErrDeskAlreadyBooked = errors.New("user already has a desk booked for this time slot")
+	ErrInvalidDeskType   = errors.New("desk type must be workstation or cubicle")
+)
+
+const (
+	// maxAssignmentAttempts bounds how many candidates are tried when other
+	// bookings take them first
+	maxAssignmentAttempts = 5
+	// historyDays is how far back previous bookings count as usage history
+	historyDays = 90
+
+	favoriteScore       = 100.0
+	favoriteRankPenalty = 10.0
+	teammateScore       = 40.0
+	teammateDistanceCut = 4.0 // Points lost per grid cell away from the nearest teammate
+	historyScore        = 5.0 // Points per previous booking of the desk
+	maxHistoryScore     = 30.0
+)
+
+// deskTypes are the space types that can be assigned as desks
+var deskTypes = []entities.SpaceType{entities.SpaceTypeWorkstation, entities.SpaceTypeCubicle}
+
+// DeskAssignmentService picks and books a free desk for a user
+type DeskAssignmentService struct {
+	reservationService *ReservationService
+	reservationRepo    repositories.ReservationRepository
+	spaceRepo          repositories.SpaceRepository
+	mapRepo            repositories.OfficeMapRepository
+	closureRepo        repositories.ClosureRepository
+}
+
+// NewDeskAssignmentService creates a new desk assignment service
+func NewDeskAssignmentService(
+	reservationService *ReservationService,
+	reservationRepo repositories.ReservationRepository,
+	spaceRepo repositories.SpaceRepository,
+	mapRepo repositories.OfficeMapRepository,
+	closureRepo repositories.ClosureRepository,
+) *DeskAssignmentService {
+	return &DeskAssignmentService{
+		reservationService: reservationService,
+		reservationRepo:    reservationRepo,
+		spaceRepo:          spaceRepo,
+		mapRepo:            mapRepo,
+		closureRepo:        closureRepo,
+	}
+}
+
+// AssignDeskRequest represents the input for booking any desk of a map
+type AssignDeskRequest struct {
+	MapID     uuid.UUID
+	UserID    string
+	UserName  string
+	Date      time.Time
+	StartTime *string
+	EndTime   *string
+	Notes     string
+	// FavoriteSpaceIDs are preferred in the given order
+	FavoriteSpaceIDs []uuid.UUID
+	// Teammates are user IDs whose desks booked that day should be close by
+	Teammates []string
+	// SpaceTypes restricts the desk types; workstations and cubicles when empty
+	SpaceTypes []entities.SpaceType
+	Actor      *Actor
+}
+
+// DeskAssignment is the desk booked for a user and why it was chosen
+type DeskAssignment struct {
+	Reservation *entities.Reservation
+	Space       *entities.Space
+	Reasons     []string
+}
+
+// deskCandidate is a free desk with its preference score
+type deskCandidate struct {
+	space   *entities.Space
+	score   float64
+	reasons []string
+}
+
+// AssignDesk books the free desk of a map that best matches the preferences.
+// Each attempt books through CreateReservation, which locks the desk and checks
+// for overlaps in one transaction; when a concurrent booking takes the chosen
+// desk first, the next best candidate is tried.
+func (s *DeskAssignmentService) AssignDesk(req AssignDeskRequest) (*DeskAssignment, error) {
+	if _, err := s.mapRepo.FindByID(req.MapID); err != nil {
+		return nil, ErrMapNotFound
+	}
+	types := req.SpaceTypes
+	if len(types) == 0 {
+		types = deskTypes
+	}
+	for _, t := range types {
+		if t != entities.SpaceTypeWorkstation && t != entities.SpaceTypeCubicle {
+			return nil, ErrInvalidDeskType
+		}
+	}
+	if err := validateTimeRange(req.StartTime, req.EndTime); err != nil {
+		return nil, err
+	}
+	window, _ := entities.NewTimeRange(req.StartTime, req.EndTime)
+
+	spaces, err := s.spaceRepo.FindByMapID(req.MapID)
+	if err != nil {
+		return nil, err
+	}
+	spaceIDs := make([]uuid.UUID, len(spaces))
+	spacesByID := make(map[uuid.UUID]*entities.Space, len(spaces))
+	for i, space := range spaces {
+		spaceIDs[i] = space.ID
+		spacesByID[space.ID] = space
+	}
+	reservations, err := s.reservationRepo.FindHoldingBySpaceIDsInRange(spaceIDs, req.Date, req.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	busy := make(map[uuid.UUID]bool)
+	var teammateDesks []*entities.Space
+	teammates := make(map[string]bool, len(req.Teammates))
+	for _, userID := range req.Teammates {
+		teammates[userID] = true
+	}
+	for _, r := range reservations {
+		if teammates[r.UserID] {
+			teammateDesks = append(teammateDesks, spacesByID[r.SpaceID])
+		}
+		timeRange, err := r.TimeRange()
+		if err != nil || !timeRange.Overlaps(window) {
+			continue
+		}
+		busy[r.SpaceID] = true
+		if r.UserID == req.UserID && isDesk(spacesByID[r.SpaceID], types) {
+			return &DeskAssignment{Reservation: r, Space: spacesByID[r.SpaceID]}, ErrDeskAlreadyBooked
+		}
+	}
+
+	// Desks closed at any time of the window cannot be assigned
+	closures, err := s.closureRepo.FindAll(repositories.ClosureFilters{MapID: &req.MapID, From: &req.Date, To: &req.Date})
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range closures {
+		if !c.Blocks(req.Date, window) {
+			continue
+		}
+		for _, space := range spaces {
+			if c.Covers(space) {
+				busy[space.ID] = true
+			}
+		}
+	}
+
+	history, err := s.usageHistory(req.UserID, req.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []deskCandidate
+	for _, space := range spaces {
+		if !isDesk(space, types) || busy[space.ID] {
+			continue
+		}
+		candidates = append(candidates, scoreDesk(space, req.FavoriteSpaceIDs, teammateDesks, history[space.ID]))
+	}
+	if len(candidates) == 0 {
+		return nil, ErrNoDeskAvailable
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.score != b.score {
+			return a.score > b.score
+		}
+		if a.space.Y != b.space.Y {
+			return a.space.Y < b.space.Y
+		}
+		return a.space.X < b.space.X
+	})
+
+	for i, candidate := range candidates {
+		if i == maxAssignmentAttempts {
+			break
+		}
+		reservation, err := s.reservationService.CreateReservation(CreateReservationRequest{
+			SpaceID:   candidate.space.ID,
+			UserID:    req.UserID,
+			UserName:  req.UserName,
+			Date:      req.Date,
+			StartTime: req.StartTime,
+			EndTime:   req.EndTime,
+			Notes:     req.Notes,
+			Actor:     req.Actor,
+			Reason:    "Assigned automatically",
+		})
+		if errors.Is(err, ErrReservationAlreadyExists) || errors.Is(err, ErrSpaceClosed) {
+			// Taken by a concurrent booking or closed since the candidates were loaded
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &DeskAssignment{Reservation: reservation, Space: candidate.space, Reasons: candidate.reasons}, nil
+	}
+	return nil, ErrNoDeskAvailable
+}
+
+// usageHistory counts the user's recent bookings per space, leaving out
+// cancelled bookings and no-shows
+func (s *DeskAssignmentService) usageHistory(userID string, date time.Time) (map[uuid.UUID]int, error) {
+	from := date.AddDate(0, 0, -historyDays)
+	to := date.AddDate(0, 0, -1)
+	past, err := s.reservationRepo.FindAll(repositories.ReservationFilters{From: &from, To: &to, UserID: &userID})
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uuid.UUID]int)
+	for _, r := range past {
+		if r.Status != entities.ReservationStatusCancelled && r.Status != entities.ReservationStatusNoShow {
+			counts[r.SpaceID]++
+		}
+	}
+	return counts, nil
+}
+
+// isDesk returns true if the space is an ungrouped space of one of the desk types
+func isDesk(space *entities.Space, types []entities.SpaceType) bool {
+	if space == nil || space.IsGrouped() {
+		return false
+	}
+	for _, t := range types {
+		if space.Type == t {
+			return true
+		}
+	}
+	return false
+}
+
+// scoreDesk rates a free desk by the user's favorites, the distance to the
+// nearest teammate and how often the user booked it before
+func scoreDesk(space *entities.Space, favorites []uuid.UUID, teammateDesks []*entities.Space, timesUsed int) deskCandidate {
+	candidate := deskCandidate{space: space, reasons: []string{}}
+
+	for rank, id := range favorites {
+		if id == space.ID {
+			candidate.score += math.Max(favoriteScore-favoriteRankPenalty*float64(rank), favoriteScore/2)
+			candidate.reasons = append(candidate.reasons, fmt.Sprintf("favorite #%d", rank+1))
+			break
+		}
+	}
+
+	nearest := math.Inf(1)
+	for _, desk := range teammateDesks {
+		if desk != nil && desk.MapID == space.MapID {
+			nearest = math.Min(nearest, gridDistance(space, desk))
+		}
+	}
+	if points := teammateScore - teammateDistanceCut*nearest; points > 0 {
+		candidate.score += points
+		candidate.reasons = append(candidate.reasons, fmt.Sprintf("%.1f cells from a teammate", nearest))
+	}
+
+	if timesUsed > 0 {
+		candidate.score += math.Min(historyScore*float64(timesUsed), maxHistoryScore)
+		candidate.reasons = append(candidate.reasons, fmt.Sprintf("booked %d times in the last %d days", timesUsed, historyDays))
+	}
+	return candidate
+}
+
+// gridDistance returns the distance between the centers of two spaces in grid cells
+func gridDistance(a, b *entities.Space) float64 {
+	dx := (float64(a.X) + float64(a.Width)/2) - (float64(b.X) + float64(b.Width)/2)
+	dy := (float64(a.Y) + float64(a.Height)/2) - (float64(b.Y) + float64(b.Height)/2)
+	return math.Hypot(dx, dy)
+}
@@ -0,0 +1,558 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"office-reservations/internal/domain/entities"
+	"office-reservations/internal/domain/repositories"
+)
+
+// fakeStore is an in-memory database shared by the fake repositories. Entities
+// are stored by value, so callers never alias stored state, and a transaction
+// is rolled back by restoring a copy of the store.
+type fakeStore struct {
+	reservations map[uuid.UUID]entities.Reservation
+	spaces       map[uuid.UUID]entities.Space
+	deleted      map[uuid.UUID]entities.Space
+	maps         map[uuid.UUID]entities.OfficeMap
+	series       map[uuid.UUID]entities.ReservationSeries
+	policies     map[uuid.UUID]entities.BookingPolicy
+	outbox       []entities.OutboxEvent
+	audit        []entities.AuditEntry
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{
+		reservations: make(map[uuid.UUID]entities.Reservation),
+		spaces:       make(map[uuid.UUID]entities.Space),
+		deleted:      make(map[uuid.UUID]entities.Space),
+		maps:         make(map[uuid.UUID]entities.OfficeMap),
+		series:       make(map[uuid.UUID]entities.ReservationSeries),
+		policies:     make(map[uuid.UUID]entities.BookingPolicy),
+	}
+}
+
+// clone returns a copy of the store that does not share its maps
+func (s *fakeStore) clone() *fakeStore {
+	c := newFakeStore()
+	for k, v := range s.reservations {
+		c.reservations[k] = v
+	}
+	for k, v := range s.spaces {
+		c.spaces[k] = v
+	}
+	for k, v := range s.deleted {
+		c.deleted[k] = v
+	}
+	for k, v := range s.maps {
+		c.maps[k] = v
+	}
+	for k, v := range s.series {
+		c.series[k] = v
+	}
+	for k, v := range s.policies {
+		c.policies[k] = v
+	}
+	c.outbox = append(c.outbox, s.outbox...)
+	c.audit = append(c.audit, s.audit...)
+	return c
+}
+
+// addMap stores an office map in the given time zone
+func (s *fakeStore) addMap(timezone string) *entities.OfficeMap {
+	m := entities.OfficeMap{ID: uuid.New(), Name: "Office", Timezone: timezone, Version: 1}
+	s.maps[m.ID] = m
+	return &m
+}
+
+// addSpace stores a workstation on a map
+func (s *fakeStore) addSpace(mapID uuid.UUID, name string) *entities.Space {
+	space := entities.Space{ID: uuid.New(), MapID: mapID, Name: name, Type: entities.SpaceTypeWorkstation, Version: 1}
+	s.spaces[space.ID] = space
+	return &space
+}
+
+// addReservation stores a reservation, filling in its ID, version and time zone
+func (s *fakeStore) addReservation(r entities.Reservation) *entities.Reservation {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	if r.Status == "" {
+		r.Status = entities.ReservationStatusActive
+	}
+	if r.Timezone == "" {
+		if space, ok := s.spaces[r.SpaceID]; ok {
+			r.Timezone = s.maps[space.MapID].Timezone
+		}
+	}
+	r.Version = 1
+	s.reservations[r.ID] = r
+	return &r
+}
+
+// reservation returns the stored state of a reservation
+func (s *fakeStore) reservation(id uuid.UUID) entities.Reservation {
+	return s.reservations[id]
+}
+
+// events returns the types of the outbox events appended for a reservation
+func (s *fakeStore) events(id uuid.UUID) []entities.WebhookEventType {
+	var types []entities.WebhookEventType
+	for _, e := range s.outbox {
+		var data reservationEventData
+		if json.Unmarshal(e.Data, &data) == nil && data.Reservation.ID == id {
+			types = append(types, e.Type)
+		}
+	}
+	return types
+}
+
+// auditEntries returns the audit entries recorded for an entity
+func (s *fakeStore) auditEntries(id uuid.UUID) []entities.AuditEntry {
+	var entries []entities.AuditEntry
+	for _, e := range s.audit {
+		if e.EntityID == id {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// fakeRepos returns repositories over the store
+func (s *fakeStore) fakeRepos() repositories.TxRepositories {
+	return repositories.TxRepositories{
+		Reservations: &fakeReservationRepo{store: s},
+		Series:       &fakeSeriesRepo{store: s},
+		Spaces:       &fakeSpaceRepo{store: s},
+		Maps:         &fakeMapRepo{store: s},
+		Closures:     &fakeClosureRepo{},
+		Outbox:       &fakeOutboxRepo{store: s},
+		Audit:        &fakeAuditRepo{store: s},
+	}
+}
+
+// newTestReservationService returns a reservation service over the store, with
+// its booking policies and without notifications
+func newTestReservationService(store *fakeStore) *ReservationService {
+	repos := store.fakeRepos()
+	policies := NewBookingPolicyService(&fakePolicyRepo{store: store}, repos.Reservations, repos.Spaces, repos.Maps)
+	return NewReservationService(repos.Reservations, repos.Spaces, repos.Maps, &fakeTxManager{store: store}, policies, nil)
+}
+
+// fakeTxManager runs transactions against the store, restoring it when the
+// transaction fails
+type fakeTxManager struct {
+	store *fakeStore
+}
+
+func (m *fakeTxManager) WithinTransaction(fn func(repos repositories.TxRepositories) error) error {
+	saved := m.store.clone()
+	if err := fn(m.store.fakeRepos()); err != nil {
+		*m.store = *saved
+		return err
+	}
+	return nil
+}
+
+// The fake repositories embed their interface so that methods a test does not
+// exercise panic instead of having to be stubbed.
+
+type fakeReservationRepo struct {
+	repositories.ReservationRepository
+	store *fakeStore
+}
+
+func (r *fakeReservationRepo) FindByID(id uuid.UUID) (*entities.Reservation, error) {
+	found, ok := r.store.reservations[id]
+	if !ok {
+		return nil, errors.New("reservation not found")
+	}
+	return &found, nil
+}
+
+func (r *fakeReservationRepo) FindAll(filters repositories.ReservationFilters) ([]*entities.Reservation, error) {
+	var result []*entities.Reservation
+	for _, found := range r.store.reservations {
+		found := found
+		if r.matches(&found, filters) {
+			result = append(result, &found)
+		}
+	}
+	sortReservations(result)
+	return result, nil
+}
+
+func (r *fakeReservationRepo) matches(res *entities.Reservation, f repositories.ReservationFilters) bool {
+	day := res.Date.Format("2006-01-02")
+	switch {
+	case f.From != nil && day < f.From.Format("2006-01-02"):
+		return false
+	case f.To != nil && day > f.To.Format("2006-01-02"):
+		return false
+	case f.UserID != nil && res.UserID != *f.UserID:
+		return false
+	case f.SpaceID != nil && res.SpaceID != *f.SpaceID:
+		return false
+	case f.MapID != nil && r.store.mapOf(res.SpaceID) != *f.MapID:
+		return false
+	case f.Status != nil && res.Status != *f.Status:
+		return false
+	case f.SeriesID != nil && (res.SeriesID == nil || *res.SeriesID != *f.SeriesID):
+		return false
+	case f.GroupBookingID != nil && (res.GroupBookingID == nil || *res.GroupBookingID != *f.GroupBookingID):
+		return false
+	}
+	if len(f.Statuses) > 0 {
+		for _, status := range f.Statuses {
+			if res.Status == status {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func (r *fakeReservationRepo) Create(res *entities.Reservation) error {
+	if overlapping, _ := r.FindOverlapping([]uuid.UUID{res.SpaceID}, res.Date, res.StartTime, res.EndTime); len(overlapping) > 0 && res.HoldsSpace() {
+		return repositories.ErrReservationOverlap
+	}
+	res.Version = 1
+	r.store.reservations[res.ID] = *res
+	return nil
+}
+
+func (r *fakeReservationRepo) Update(res *entities.Reservation) error {
+	stored, ok := r.store.reservations[res.ID]
+	if !ok || stored.Version != res.Version {
+		return repositories.ErrVersionConflict
+	}
+	res.Version++
+	r.store.reservations[res.ID] = *res
+	return nil
+}
+
+func (r *fakeReservationRepo) TransitionStatus(id uuid.UUID, from, to entities.ReservationStatus) (bool, error) {
+	stored, ok := r.store.reservations[id]
+	if !ok || stored.Status != from {
+		return false, nil
+	}
+	stored.Status = to
+	stored.Version++
+	r.store.reservations[id] = stored
+	return true, nil
+}
+
+func (r *fakeReservationRepo) FindOverlapping(spaceIDs []uuid.UUID, date time.Time, startTime, endTime *string) ([]*entities.Reservation, error) {
+	probe := &entities.Reservation{Date: date, StartTime: startTime, EndTime: endTime}
+	var result []*entities.Reservation
+	for _, found := range r.store.reservations {
+		found := found
+		if containsID(spaceIDs, found.SpaceID) && found.HoldsSpace() && found.Overlaps(probe) {
+			result = append(result, &found)
+		}
+	}
+	sortReservations(result)
+	return result, nil
+}
+
+func (r *fakeReservationRepo) FindUpcomingBySpaceIDs(spaceIDs []uuid.UUID, from time.Time) ([]*entities.Reservation, error) {
+	var result []*entities.Reservation
+	for _, found := range r.store.reservations {
+		found := found
+		if containsID(spaceIDs, found.SpaceID) && found.HoldsSpace() && !found.Date.Before(from) {
+			result = append(result, &found)
+		}
+	}
+	sortReservations(result)
+	return result, nil
+}
+
+type fakeSpaceRepo struct {
+	repositories.SpaceRepository
+	store *fakeStore
+}
+
+func (r *fakeSpaceRepo) FindByID(id uuid.UUID) (*entities.Space, error) {
+	found, ok := r.store.spaces[id]
+	if !ok {
+		return nil, errors.New("space not found")
+	}
+	return &found, nil
+}
+
+func (r *fakeSpaceRepo) FindByMapID(mapID uuid.UUID) ([]*entities.Space, error) {
+	var result []*entities.Space
+	for _, found := range r.store.spaces {
+		found := found
+		if found.MapID == mapID {
+			result = append(result, &found)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+func (r *fakeSpaceRepo) FindByGroupID(groupID uuid.UUID) ([]*entities.Space, error) {
+	var result []*entities.Space
+	for _, found := range r.store.spaces {
+		found := found
+		if found.GroupID != nil && *found.GroupID == groupID {
+			result = append(result, &found)
+		}
+	}
+	return result, nil
+}
+
+func (r *fakeSpaceRepo) FindByIDs(ids []uuid.UUID) ([]*entities.Space, error) {
+	var result []*entities.Space
+	for _, id := range ids {
+		if found, ok := r.store.spaces[id]; ok {
+			result = append(result, &found)
+		}
+	}
+	return result, nil
+}
+
+func (r *fakeSpaceRepo) LockByIDs(ids []uuid.UUID) error {
+	return nil
+}
+
+func (r *fakeSpaceRepo) Create(space *entities.Space) error {
+	space.Version = 1
+	r.store.spaces[space.ID] = *space
+	return nil
+}
+
+func (r *fakeSpaceRepo) Update(space *entities.Space) error {
+	stored, ok := r.store.spaces[space.ID]
+	if !ok || stored.Version != space.Version {
+		return repositories.ErrVersionConflict
+	}
+	space.Version++
+	r.store.spaces[space.ID] = *space
+	return nil
+}
+
+func (r *fakeSpaceRepo) IDTaken(id uuid.UUID) (bool, error) {
+	_, live := r.store.spaces[id]
+	_, deleted := r.store.deleted[id]
+	return live || deleted, nil
+}
+
+func (r *fakeSpaceRepo) SoftDeleteByIDs(ids []uuid.UUID) error {
+	for _, id := range ids {
+		if space, ok := r.store.spaces[id]; ok {
+			space.GroupID = nil
+			r.store.deleted[id] = space
+			delete(r.store.spaces, id)
+		}
+	}
+	return nil
+}
+
+type fakeMapRepo struct {
+	repositories.OfficeMapRepository
+	store *fakeStore
+}
+
+func (r *fakeMapRepo) FindByID(id uuid.UUID) (*entities.OfficeMap, error) {
+	found, ok := r.store.maps[id]
+	if !ok {
+		return nil, errors.New("map not found")
+	}
+	spaces, _ := (&fakeSpaceRepo{store: r.store}).FindByMapID(id)
+	found.Spaces = spaces
+	return &found, nil
+}
+
+func (r *fakeMapRepo) Update(m *entities.OfficeMap) error {
+	stored, ok := r.store.maps[m.ID]
+	if !ok || stored.Version != m.Version {
+		return repositories.ErrVersionConflict
+	}
+	m.Version++
+	saved := *m
+	saved.Spaces = nil
+	r.store.maps[m.ID] = saved
+	return nil
+}
+
+func (r *fakeMapRepo) Delete(id uuid.UUID) error {
+	delete(r.store.maps, id)
+	return nil
+}
+
+type fakeSeriesRepo struct {
+	repositories.ReservationSeriesRepository
+	store *fakeStore
+}
+
+func (r *fakeSeriesRepo) FindByID(id uuid.UUID) (*entities.ReservationSeries, error) {
+	found, ok := r.store.series[id]
+	if !ok {
+		return nil, errors.New("series not found")
+	}
+	return &found, nil
+}
+
+func (r *fakeSeriesRepo) Create(series *entities.ReservationSeries) error {
+	r.store.series[series.ID] = *series
+	return nil
+}
+
+func (r *fakeSeriesRepo) Update(series *entities.ReservationSeries) error {
+	r.store.series[series.ID] = *series
+	return nil
+}
+
+func (r *fakeSeriesRepo) SaveException(exception *entities.SeriesException) error {
+	series := r.store.series[exception.SeriesID]
+	var exceptions []*entities.SeriesException
+	for _, e := range series.Exceptions {
+		if !e.OccurrenceDate.Equal(exception.OccurrenceDate) {
+			exceptions = append(exceptions, e)
+		}
+	}
+	series.Exceptions = append(exceptions, exception)
+	r.store.series[series.ID] = series
+	return nil
+}
+
+func (r *fakeSeriesRepo) CancelBySpaceIDs(spaceIDs []uuid.UUID) error {
+	for id, series := range r.store.series {
+		if containsID(spaceIDs, series.SpaceID) && series.IsActive() {
+			series.Status = entities.SeriesStatusCancelled
+			r.store.series[id] = series
+		}
+	}
+	return nil
+}
+
+type fakePolicyRepo struct {
+	repositories.BookingPolicyRepository
+	store *fakeStore
+}
+
+func (r *fakePolicyRepo) FindForMap(mapID uuid.UUID) ([]*entities.BookingPolicy, error) {
+	var result []*entities.BookingPolicy
+	for _, found := range r.store.policies {
+		found := found
+		if found.MapID == nil || *found.MapID == mapID {
+			result = append(result, &found)
+		}
+	}
+	return result, nil
+}
+
+type fakeClosureRepo struct {
+	repositories.ClosureRepository
+}
+
+func (r *fakeClosureRepo) FindAll(filters repositories.ClosureFilters) ([]*entities.Closure, error) {
+	return nil, nil
+}
+
+type fakeOutboxRepo struct {
+	repositories.OutboxRepository
+	store *fakeStore
+}
+
+func (r *fakeOutboxRepo) Append(event *entities.OutboxEvent) error {
+	event.Seq = int64(len(r.store.outbox) + 1)
+	r.store.outbox = append(r.store.outbox, *event)
+	return nil
+}
+
+func (r *fakeOutboxRepo) FindBySeq(seq int64) (*entities.OutboxEvent, error) {
+	for _, e := range r.store.outbox {
+		if e.Seq == seq {
+			return &e, nil
+		}
+	}
+	return nil, errors.New("event not found")
+}
+
+func (r *fakeOutboxRepo) FindByMapAfter(mapID uuid.UUID, afterSeq int64, limit int) ([]*entities.OutboxEvent, error) {
+	var result []*entities.OutboxEvent
+	for _, e := range r.store.outbox {
+		e := e
+		if e.MapID != nil && *e.MapID == mapID && e.Seq > afterSeq && len(result) < limit {
+			result = append(result, &e)
+		}
+	}
+	return result, nil
+}
+
+func (r *fakeOutboxRepo) LastSeqByMap(mapID uuid.UUID) (int64, error) {
+	var seq int64
+	for _, e := range r.store.outbox {
+		if e.MapID != nil && *e.MapID == mapID {
+			seq = e.Seq
+		}
+	}
+	return seq, nil
+}
+
+type fakeAuditRepo struct {
+	repositories.AuditRepository
+	store *fakeStore
+}
+
+func (r *fakeAuditRepo) Append(entry *entities.AuditEntry) error {
+	r.store.audit = append(r.store.audit, *entry)
+	return nil
+}
+
+// mapOf returns the map of a space, including deleted spaces
+func (s *fakeStore) mapOf(spaceID uuid.UUID) uuid.UUID {
+	if space, ok := s.spaces[spaceID]; ok {
+		return space.MapID
+	}
+	return s.deleted[spaceID].MapID
+}
+
+func containsID(ids []uuid.UUID, id uuid.UUID) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
+
+// sortReservations orders reservations by date and start time like the
+// repository, with all-day reservations last as Postgres sorts NULLs
+func sortReservations(reservations []*entities.Reservation) {
+	start := func(r *entities.Reservation) string {
+		if r.StartTime == nil {
+			return "~"
+		}
+		return *r.StartTime
+	}
+	sort.Slice(reservations, func(i, j int) bool {
+		a, b := reservations[i], reservations[j]
+		if !a.Date.Equal(b.Date) {
+			return a.Date.Before(b.Date)
+		}
+		if start(a) != start(b) {
+			return start(a) < start(b)
+		}
+		return a.ID.String() < b.ID.String()
+	})
+}
+
+// recordingNotifier records the reservations it is told were cancelled
+type recordingNotifier struct {
+	cancelled []*entities.Reservation
+	reasons   []string
+}
+
+func (n *recordingNotifier) ReservationsCancelled(reservations []*entities.Reservation, reason string) {
+	n.cancelled = append(n.cancelled, reservations...)
+	n.reasons = append(n.reasons, reason)
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"office-reservations/internal/config"
+	"office-reservations/internal/domain/entities"
+	"office-reservations/internal/domain/repositories"
+)
+
+var ErrIdempotencyKeyUnavailable = errors.New("idempotency key could not be claimed")
+
+// IdempotencyService remembers the requests made with an Idempotency-Key header
+// and their responses, so that a retried request is answered with the original
+// response instead of being applied twice
+type IdempotencyService struct {
+	repo   repositories.IdempotencyRepository
+	config config.IdempotencyConfig
+}
+
+// NewIdempotencyService creates a new idempotency service
+func NewIdempotencyService(repo repositories.IdempotencyRepository, cfg config.IdempotencyConfig) *IdempotencyService {
+	return &IdempotencyService{
+		repo:   repo,
+		config: cfg,
+	}
+}
+
+// Begin claims a key for a request. It returns nil if the caller should run the
+// request and then Complete or Release the key, or the record of the request
+// that already used the key. The claim lasts for the lease until the request
+// completes, so that a key left behind by a request that never finished can be
+// claimed again after a short while, as can keys whose response expired.
+func (s *IdempotencyService) Begin(scope, key, requestHash string) (*entities.IdempotencyRecord, error) {
+	// A second attempt is made when the existing record expired or disappeared
+	// in between
+	for attempt := 0; attempt < 2; attempt++ {
+		now := time.Now()
+		created, err := s.repo.Create(&entities.IdempotencyRecord{
+			Scope:       scope,
+			Key:         key,
+			RequestHash: requestHash,
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(s.config.Lease),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if created {
+			return nil, nil
+		}
+
+		existing, err := s.repo.Find(scope, key)
+		if err != nil {
+			continue
+		}
+		if !existing.Expired(now) {
+			return existing, nil
+		}
+		if err := s.repo.Delete(scope, key); err != nil {
+			return nil, err
+		}
+	}
+	return nil, ErrIdempotencyKeyUnavailable
+}
+
+// Complete stores the response of a request, which is replayed to retries until
+// the key expires after the TTL
+func (s *IdempotencyService) Complete(record *entities.IdempotencyRecord) error {
+	record.ExpiresAt = time.Now().Add(s.config.TTL)
+	return s.repo.Complete(record)
+}
+
+// Release gives up a key claimed by a request that failed, so that it can be retried
+func (s *IdempotencyService) Release(scope, key string) error {
+	return s.repo.Delete(scope, key)
+}
+
+// PurgeExpired deletes the expired keys and returns how many were deleted
+func (s *IdempotencyService) PurgeExpired() (int64, error) {
+	return s.repo.DeleteExpired(time.Now())
+}
+
+// RunCleaner deletes expired keys every interval until ctx is done
+func (s *IdempotencyService) RunCleaner(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if purged, err := s.PurgeExpired(); err != nil {
+			log.Printf("Idempotency key cleaner error: %v", err)
+		} else if purged > 0 {
+			log.Printf("Idempotency key cleaner deleted %d expired keys", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"office-reservations/internal/config"
+	"office-reservations/internal/domain/entities"
+	"office-reservations/internal/domain/repositories"
+)
+
+// fakeIdempotencyRepo keeps idempotency records in memory
+type fakeIdempotencyRepo struct {
+	repositories.IdempotencyRepository
+	records map[string]entities.IdempotencyRecord
+}
+
+func (r *fakeIdempotencyRepo) Create(record *entities.IdempotencyRecord) (bool, error) {
+	if _, taken := r.records[record.Scope+"\x00"+record.Key]; taken {
+		return false, nil
+	}
+	r.records[record.Scope+"\x00"+record.Key] = *record
+	return true, nil
+}
+
+func (r *fakeIdempotencyRepo) Find(scope, key string) (*entities.IdempotencyRecord, error) {
+	record, ok := r.records[scope+"\x00"+key]
+	if !ok {
+		return nil, errors.New("record not found")
+	}
+	return &record, nil
+}
+
+func (r *fakeIdempotencyRepo) Complete(record *entities.IdempotencyRecord) error {
+	stored := r.records[record.Scope+"\x00"+record.Key]
+	stored.StatusCode, stored.Headers, stored.Body, stored.ExpiresAt = record.StatusCode, record.Headers, record.Body, record.ExpiresAt
+	r.records[record.Scope+"\x00"+record.Key] = stored
+	return nil
+}
+
+func (r *fakeIdempotencyRepo) Delete(scope, key string) error {
+	delete(r.records, scope+"\x00"+key)
+	return nil
+}
+
+func TestIdempotencyBegin(t *testing.T) {
+	tests := []struct {
+		name string
+		// age is how long ago the first request claimed the key
+		age       time.Duration
+		completed bool
+		wantRetry bool
+	}{
+		{name: "in progress", age: 10 * time.Second},
+		{name: "lease lapsed", age: 2 * time.Minute, wantRetry: true},
+		{name: "completed", age: 2 * time.Minute, completed: true},
+		{name: "completed response expired", age: 25 * time.Hour, completed: true, wantRetry: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeIdempotencyRepo{records: make(map[string]entities.IdempotencyRecord)}
+			service := NewIdempotencyService(repo, config.IdempotencyConfig{TTL: 24 * time.Hour, Lease: time.Minute})
+			if existing, err := service.Begin("alice", "k1", "hash"); existing != nil || err != nil {
+				t.Fatalf("Begin() = %v, %v, want the key claimed", existing, err)
+			}
+			if tt.completed {
+				if err := service.Complete(&entities.IdempotencyRecord{Scope: "alice", Key: "k1", RequestHash: "hash", StatusCode: 201}); err != nil {
+					t.Fatalf("Complete() error = %v", err)
+				}
+			}
+			// Moves the first request back in time
+			record := repo.records["alice\x00k1"]
+			record.CreatedAt, record.ExpiresAt = record.CreatedAt.Add(-tt.age), record.ExpiresAt.Add(-tt.age)
+			repo.records["alice\x00k1"] = record
+
+			existing, err := service.Begin("alice", "k1", "hash")
+			if err != nil {
+				t.Fatalf("Begin() error = %v", err)
+			}
+			if retried := existing == nil; retried != tt.wantRetry {
+				t.Fatalf("Begin() = %+v, want the key claimed again: %v", existing, tt.wantRetry)
+			}
+			if !tt.wantRetry && existing.Completed() != tt.completed {
+				t.Errorf("Begin() returned a record completed: %v, want %v", existing.Completed(), tt.completed)
+			}
+		})
+	}
+}
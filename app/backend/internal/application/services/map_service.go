@@ -0,0 +1,555 @@
+package services
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"office-reservations/internal/domain/entities"
+	"office-reservations/internal/domain/repositories"
+)
+
+var (
+	ErrMapNotFound = errors.New("map not found")
+	// ErrRemovedSpacesReserved is returned when a map update would remove spaces
+	// that still have upcoming reservations and the caller did not ask to cancel them
+	ErrRemovedSpacesReserved = errors.New("removed spaces have upcoming reservations")
+)
+
+// MapService handles office map business logic
+type MapService struct {
+	mapRepo   repositories.OfficeMapRepository
+	txManager repositories.TransactionManager
+	notifier  CancellationNotifier
+}
+
+// NewMapService creates a new map service
+func NewMapService(mapRepo repositories.OfficeMapRepository, txManager repositories.TransactionManager, notifier CancellationNotifier) *MapService {
+	return &MapService{
+		mapRepo:   mapRepo,
+		txManager: txManager,
+		notifier:  notifier,
+	}
+}
+
+// CreateMapRequest represents the input for creating a map
+type CreateMapRequest struct {
+	Name        string
+	Description string
+	JSONData    map[string]interface{}
+	// Timezone is an IANA zone name; it defaults to UTC
+	Timezone string
+	// OpeningHours are keyed by weekday name; days not listed are closed.
+	// Nil means the office is always open.
+	OpeningHours map[string]DayHours
+	// Actor is the user making the request and Reason explains the change in
+	// the audit log
+	Actor  *Actor
+	Reason string
+}
+
+// UpdateMapRequest represents the input for updating a map. Empty fields and nil
+// JSONData or OpeningHours leave the map unchanged; empty, non-nil OpeningHours
+// make the office always open.
+type UpdateMapRequest struct {
+	ID           uuid.UUID
+	Name         string
+	Description  string
+	JSONData     map[string]interface{}
+	Timezone     string
+	OpeningHours map[string]DayHours
+	// CancelReservations allows removing spaces that still have upcoming reservations
+	CancelReservations bool
+	// Version is the version of the map the change is based on, from the
+	// If-Match header; nil skips the check
+	Version *int64
+	// Actor is the user making the request and Reason explains the change in
+	// the audit log
+	Actor  *Actor
+	Reason string
+}
+
+// DayHours are the opening hours of one weekday
+type DayHours struct {
+	Open  string // Format: HH:MM
+	Close string // Format: HH:MM
+}
+
+// SpaceSyncSummary reports the changes applied when syncing a map's spaces from its JSON data
+type SpaceSyncSummary struct {
+	Created              int
+	Updated              int
+	Unchanged            int
+	Removed              int
+	AffectedReservations []AffectedReservation
+
+	// cancelled are the reservations cancelled with the removed spaces
+	cancelled []*entities.Reservation
+}
+
+// AffectedReservation is an upcoming reservation on a space removed from a map
+type AffectedReservation struct {
+	Reservation *entities.Reservation
+	SpaceName   string
+}
+
+// GetMaps retrieves one page of the maps matching the filters, without their
+// spaces
+func (s *MapService) GetMaps(filters repositories.MapFilters, page repositories.PageRequest) ([]*entities.OfficeMap, repositories.PageInfo, error) {
+	return s.mapRepo.FindPage(filters, page)
+}
+
+// GetMap retrieves a map with its spaces
+func (s *MapService) GetMap(id uuid.UUID) (*entities.OfficeMap, error) {
+	officeMap, err := s.mapRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrMapNotFound
+	}
+	return officeMap, nil
+}
+
+// CreateMap validates and stores a new map and creates the spaces of its JSON data
+func (s *MapService) CreateMap(req CreateMapRequest) (*entities.OfficeMap, *SpaceSyncSummary, error) {
+	var errs fieldErrors
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		errs.add("name", "name is required")
+	}
+	if req.JSONData == nil {
+		errs.add("json_data", "json_data is required")
+	}
+	timezone := entities.DefaultTimezone
+	if req.Timezone != "" {
+		timezone = validTimezone(&errs, req.Timezone)
+	}
+	openingHours := parseOpeningHours(&errs, req.OpeningHours)
+	if err := errs.err(); err != nil {
+		return nil, nil, err
+	}
+	layout, err := parseMapLayout(req.JSONData)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	officeMap := &entities.OfficeMap{
+		ID:           uuid.New(),
+		Name:         name,
+		Description:  req.Description,
+		JSONData:     req.JSONData,
+		Timezone:     timezone,
+		OpeningHours: openingHours,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	var summary *SpaceSyncSummary
+	err = s.txManager.WithinTransaction(func(repos repositories.TxRepositories) error {
+		if err := repos.Maps.Create(officeMap); err != nil {
+			return err
+		}
+		var err error
+		summary, err = syncSpaces(repos, officeMap, layout, false, req.Actor, req.Reason)
+		if err != nil {
+			return err
+		}
+		if err := repos.Maps.Update(officeMap); err != nil {
+			return err
+		}
+		return auditMap(repos.Audit, req.Actor, entities.AuditActionCreate, req.Reason, nil, officeMap)
+	})
+	if err != nil {
+		return nil, summary, err
+	}
+
+	created, err := s.GetMap(officeMap.ID)
+	return created, summary, err
+}
+
+// UpdateMap changes a map and, when JSON data is given, syncs its spaces. The map
+// and its spaces are saved atomically, so a refused sync leaves both untouched.
+func (s *MapService) UpdateMap(req UpdateMapRequest) (*entities.OfficeMap, *SpaceSyncSummary, error) {
+	officeMap, err := s.GetMap(req.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := checkVersion(req.Version, officeMap.Version); err != nil {
+		return nil, nil, err
+	}
+	before := snapshotMap(officeMap)
+
+	if name := strings.TrimSpace(req.Name); name != "" {
+		officeMap.Name = name
+	}
+	if req.Description != "" {
+		officeMap.Description = req.Description
+	}
+	var errs fieldErrors
+	if req.Timezone != "" {
+		officeMap.Timezone = validTimezone(&errs, req.Timezone)
+	}
+	if req.OpeningHours != nil {
+		officeMap.OpeningHours = parseOpeningHours(&errs, req.OpeningHours)
+	}
+	if err := errs.err(); err != nil {
+		return nil, nil, err
+	}
+	var layout *entities.MapLayout
+	if req.JSONData != nil {
+		layout, err = parseMapLayout(req.JSONData)
+		if err != nil {
+			return nil, nil, err
+		}
+		officeMap.JSONData = req.JSONData
+	}
+	officeMap.UpdatedAt = time.Now()
+
+	var summary *SpaceSyncSummary
+	err = s.txManager.WithinTransaction(func(repos repositories.TxRepositories) error {
+		if layout != nil {
+			var err error
+			summary, err = syncSpaces(repos, officeMap, layout, req.CancelReservations, req.Actor, req.Reason)
+			if err != nil {
+				return err
+			}
+		}
+		if err := repos.Maps.Update(officeMap); err != nil {
+			return err
+		}
+		if err := auditMap(repos.Audit, req.Actor, entities.AuditActionUpdate, req.Reason, &before, officeMap); err != nil {
+			return err
+		}
+		return appendMapUpdated(repos.Outbox, officeMap, summary)
+	})
+	if err != nil {
+		return nil, summary, translateVersionConflict(err)
+	}
+	if summary != nil && s.notifier != nil && len(summary.cancelled) > 0 {
+		s.notifier.ReservationsCancelled(summary.cancelled, spaceRemovedReason(req.Reason))
+	}
+
+	updated, err := s.GetMap(officeMap.ID)
+	return updated, summary, err
+}
+
+// DeleteMap deletes a map. Its spaces are removed as by an update that leaves
+// them all out of the layout: their upcoming reservations and the series booking
+// them are cancelled, and the holders are notified. The map and its spaces are
+// kept, marked as deleted, so that their past reservations stay in the history.
+// Every change is recorded in the audit log as made by actor. A non-nil version
+// must be the current version of the map.
+func (s *MapService) DeleteMap(id uuid.UUID, version *int64, actor *Actor, reason string) error {
+	if _, err := s.GetMap(id); err != nil {
+		return err
+	}
+	summary := &SpaceSyncSummary{}
+	err := s.txManager.WithinTransaction(func(repos repositories.TxRepositories) error {
+		officeMap, err := repos.Maps.FindByID(id)
+		if err != nil {
+			return ErrMapNotFound
+		}
+		if err := checkVersion(version, officeMap.Version); err != nil {
+			return err
+		}
+		spaces, err := repos.Spaces.FindByMapID(id)
+		if err != nil {
+			return err
+		}
+		if len(spaces) > 0 {
+			spaceIDs := make([]uuid.UUID, len(spaces))
+			for i, space := range spaces {
+				spaceIDs[i] = space.ID
+			}
+			upcoming, err := repos.Reservations.FindUpcomingBySpaceIDs(spaceIDs, time.Now())
+			if err != nil {
+				return err
+			}
+			if err := removeSpaces(repos, officeMap, spaces, upcoming, summary, actor, reason, mapDeletedReason(reason)); err != nil {
+				return err
+			}
+		}
+		before := snapshotMap(officeMap)
+		if err := auditMap(repos.Audit, actor, entities.AuditActionDelete, reason, &before, nil); err != nil {
+			return err
+		}
+		return repos.Maps.Delete(id)
+	})
+	if err != nil {
+		return err
+	}
+	if s.notifier != nil && len(summary.cancelled) > 0 {
+		s.notifier.ReservationsCancelled(summary.cancelled, mapDeletedReason(reason))
+	}
+	return nil
+}
+
+// syncSpaces reconciles the stored spaces of a map with its layout. Spaces are
+// matched by their JSON id so that existing spaces keep their ID and reservations;
+// spaces saved before IDs were preserved are matched by grid position instead.
+// Every space id in the map's JSON data is then set to the ID of its stored space.
+//
+// Removed spaces are soft-deleted, keeping their past reservations. If any of
+// them has upcoming reservations the sync is refused with ErrRemovedSpacesReserved
+// unless cancelReservations is set, in which case they are cancelled; either way
+// the affected reservations are reported.
+// Every change is recorded in the audit log as made by actor.
+func syncSpaces(repos repositories.TxRepositories, officeMap *entities.OfficeMap, layout *entities.MapLayout, cancelReservations bool, actor *Actor, reason string) (*SpaceSyncSummary, error) {
+	existing, err := repos.Spaces.FindByMapID(officeMap.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uuid.UUID]*entities.Space, len(existing))
+	byPosition := make(map[[2]int]*entities.Space, len(existing))
+	for _, space := range existing {
+		byID[space.ID] = space
+		byPosition[[2]int{space.X, space.Y}] = space
+	}
+
+	// Match by ID first so a position match never takes a space claimed by its ID
+	matches := make([]*entities.Space, len(layout.Spaces))
+	matched := make(map[uuid.UUID]bool, len(existing))
+	for i, incoming := range layout.Spaces {
+		id, err := uuid.Parse(incoming.ID)
+		if err != nil {
+			continue
+		}
+		if space, ok := byID[id]; ok && !matched[id] {
+			matches[i] = space
+			matched[id] = true
+		}
+	}
+	for i, incoming := range layout.Spaces {
+		if matches[i] != nil {
+			continue
+		}
+		if space, ok := byPosition[[2]int{incoming.X, incoming.Y}]; ok && !matched[space.ID] {
+			matches[i] = space
+			matched[space.ID] = true
+		}
+	}
+
+	summary := &SpaceSyncSummary{AffectedReservations: []AffectedReservation{}}
+
+	var removed []*entities.Space
+	var removedIDs []uuid.UUID
+	spaceNames := make(map[uuid.UUID]string)
+	for _, space := range existing {
+		if !matched[space.ID] {
+			removed = append(removed, space)
+			removedIDs = append(removedIDs, space.ID)
+			spaceNames[space.ID] = space.Name
+		}
+	}
+
+	if len(removedIDs) > 0 {
+		upcoming, err := repos.Reservations.FindUpcomingBySpaceIDs(removedIDs, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range upcoming {
+			summary.AffectedReservations = append(summary.AffectedReservations, AffectedReservation{
+				Reservation: r,
+				SpaceName:   spaceNames[r.SpaceID],
+			})
+		}
+		summary.Removed = len(removedIDs)
+		if len(upcoming) > 0 && !cancelReservations {
+			return summary, ErrRemovedSpacesReserved
+		}
+		if err := removeSpaces(repos, officeMap, removed, upcoming, summary, actor, reason, spaceRemovedReason(reason)); err != nil {
+			return nil, err
+		}
+	}
+
+	assignedIDs := make([]uuid.UUID, len(layout.Spaces))
+	for i, incoming := range layout.Spaces {
+		if space := matches[i]; space != nil {
+			assignedIDs[i] = space.ID
+			if incoming.Matches(space) {
+				summary.Unchanged++
+				continue
+			}
+			before := snapshotSpace(space)
+			space.Name = incoming.Name
+			space.Type = incoming.Type
+			space.X, space.Y = incoming.X, incoming.Y
+			space.Width, space.Height = incoming.Width, incoming.Height
+			space.UpdatedAt = time.Now()
+			if err := repos.Spaces.Update(space); err != nil {
+				return nil, err
+			}
+			if err := auditSpace(repos.Audit, actor, entities.AuditActionUpdate, reason, &before, space); err != nil {
+				return nil, err
+			}
+			summary.Updated++
+			continue
+		}
+
+		space := &entities.Space{
+			ID:        uuid.New(),
+			MapID:     officeMap.ID,
+			Name:      incoming.Name,
+			Type:      incoming.Type,
+			X:         incoming.X,
+			Y:         incoming.Y,
+			Width:     incoming.Width,
+			Height:    incoming.Height,
+			Capacity:  1, // Default capacity
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+		// Keep the client's id when it is free so the JSON and the database agree
+		if id, err := uuid.Parse(incoming.ID); err == nil {
+			taken, err := repos.Spaces.IDTaken(id)
+			if err != nil {
+				return nil, err
+			}
+			if !taken {
+				space.ID = id
+			}
+		}
+		if err := repos.Spaces.Create(space); err != nil {
+			return nil, err
+		}
+		if err := auditSpace(repos.Audit, actor, entities.AuditActionCreate, reason, nil, space); err != nil {
+			return nil, err
+		}
+		assignedIDs[i] = space.ID
+		summary.Created++
+	}
+
+	setSpaceIDs(officeMap.JSONData, assignedIDs)
+	return summary, nil
+}
+
+// spaceRemovedReason explains the cancellation of reservations on spaces removed
+// from a map, adding the reason given for the change
+func spaceRemovedReason(reason string) string {
+	if reason == "" {
+		return "Space removed from the map"
+	}
+	return "Space removed from the map: " + reason
+}
+
+// mapDeletedReason explains the cancellation of reservations on the spaces of a
+// deleted map, adding the reason given for the deletion
+func mapDeletedReason(reason string) string {
+	if reason == "" {
+		return "Map deleted"
+	}
+	return "Map deleted: " + reason
+}
+
+// removeSpaces cancels the upcoming reservations on spaces removed from officeMap,
+// together with the rest of their group bookings, and the series booking them,
+// giving cancelReason. The spaces are then soft-deleted so that their past
+// reservations are kept. The cancelled reservations are added to the summary for
+// notification.
+func removeSpaces(repos repositories.TxRepositories, officeMap *entities.OfficeMap, removed []*entities.Space, upcoming []*entities.Reservation, summary *SpaceSyncSummary, actor *Actor, reason, cancelReason string) error {
+	// Cancelled before the spaces are deleted, while their events can still
+	// find the map of each space
+	seen := make(map[uuid.UUID]bool)
+	for _, r := range upcoming {
+		if seen[r.ID] {
+			continue
+		}
+		r.Timezone = officeMap.Timezone
+		booking, err := cancelBooking(repos, r, actor, cancelReason)
+		if err != nil {
+			return err
+		}
+		for _, b := range booking {
+			seen[b.ID] = true
+		}
+		if err := appendReservationEvent(repos, entities.WebhookReservationCancelled, booking...); err != nil {
+			return err
+		}
+		summary.cancelled = append(summary.cancelled, booking...)
+	}
+
+	removedIDs := make([]uuid.UUID, len(removed))
+	for i, space := range removed {
+		removedIDs[i] = space.ID
+		before := snapshotSpace(space)
+		if err := auditSpace(repos.Audit, actor, entities.AuditActionDelete, reason, &before, nil); err != nil {
+			return err
+		}
+		if err := appendSpaceEvent(repos.Outbox, entities.WebhookSpaceDeleted, space); err != nil {
+			return err
+		}
+	}
+	if err := repos.Series.CancelBySpaceIDs(removedIDs); err != nil {
+		return err
+	}
+	return repos.Spaces.SoftDeleteByIDs(removedIDs)
+}
+
+// setSpaceIDs rewrites the id of each space in the JSON data, leaving every other
+// field untouched
+func setSpaceIDs(jsonData map[string]interface{}, ids []uuid.UUID) {
+	spaces, ok := jsonData["spaces"].([]interface{})
+	if !ok {
+		return
+	}
+	for i, item := range spaces {
+		if space, ok := item.(map[string]interface{}); ok && i < len(ids) {
+			space["id"] = ids[i].String()
+		}
+	}
+}
+
+// validTimezone checks an IANA zone name and returns it
+func validTimezone(errs *fieldErrors, name string) string {
+	if _, err := time.LoadLocation(name); err != nil || name == "Local" {
+		errs.add("timezone", "unknown time zone %q (use an IANA name such as Europe/Madrid)", name)
+	}
+	return name
+}
+
+// parseOpeningHours validates opening hours keyed by weekday name. Nil hours
+// mean the office is always open, and days that are not listed are closed.
+func parseOpeningHours(errs *fieldErrors, days map[string]DayHours) *entities.OpeningHours {
+	if len(days) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(days))
+	for name := range days {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	hours := &entities.OpeningHours{}
+	for _, name := range names {
+		day := days[name]
+		field := "opening_hours." + name
+		weekday, ok := entities.ParseWeekday(name)
+		if !ok {
+			errs.add(field, "unknown weekday %q (use monday to sunday)", name)
+			continue
+		}
+		openOK := validClock(errs, field+".open", &day.Open)
+		closeOK := validClock(errs, field+".close", &day.Close)
+		if !openOK || !closeOK {
+			continue
+		}
+		r, _ := entities.NewTimeRange(&day.Open, &day.Close)
+		if !r.IsValid() {
+			errs.add(field, "the office must open before it closes")
+			continue
+		}
+		hours[weekday] = &r
+	}
+	return hours
+}
+
+// mapGrid returns the grid of a map, or nil if its JSON data has no valid grid
+func mapGrid(officeMap *entities.OfficeMap) *entities.MapGrid {
+	layout, err := parseMapLayout(map[string]interface{}{"grid": officeMap.JSONData["grid"]})
+	if err != nil {
+		return nil
+	}
+	return &layout.Grid
+}
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"office-reservations/internal/domain/entities"
+)
+
+// removalFixture is a map whose first desk has a past reservation, an upcoming
+// one booked together with the second desk, and a recurring series
+type removalFixture struct {
+	store     *fakeStore
+	notifier  *recordingNotifier
+	officeMap *entities.OfficeMap
+	desk      *entities.Space
+	otherDesk *entities.Space
+	past      *entities.Reservation
+	upcoming  *entities.Reservation
+	sibling   *entities.Reservation
+	series    entities.ReservationSeries
+}
+
+func newRemovalFixture() *removalFixture {
+	store := newFakeStore()
+	officeMap := store.addMap("UTC")
+	desk := store.addSpace(officeMap.ID, "Desk 1")
+	otherDesk := store.addSpace(officeMap.ID, "Desk 2")
+	otherDesk.X, otherDesk.Width, otherDesk.Height = 1, 1, 1
+	store.spaces[otherDesk.ID] = *otherDesk
+	today, _ := entities.LocalDay(time.Now(), time.UTC)
+	group := uuid.New()
+
+	series := entities.ReservationSeries{ID: uuid.New(), SpaceID: desk.ID, UserID: "carol", Status: entities.SeriesStatusActive}
+	store.series[series.ID] = series
+
+	return &removalFixture{
+		store:     store,
+		notifier:  &recordingNotifier{},
+		officeMap: officeMap,
+		desk:      desk,
+		otherDesk: otherDesk,
+		past:      store.addReservation(entities.Reservation{SpaceID: desk.ID, UserID: "alice", Date: today.AddDate(0, 0, -1)}),
+		upcoming:  store.addReservation(entities.Reservation{SpaceID: desk.ID, UserID: "bob", Date: today.AddDate(0, 0, 1), GroupBookingID: &group}),
+		sibling:   store.addReservation(entities.Reservation{SpaceID: otherDesk.ID, UserID: "bob", Date: today.AddDate(0, 0, 1), GroupBookingID: &group}),
+		series:    series,
+	}
+}
+
+func (f *removalFixture) mapService() *MapService {
+	return NewMapService(f.store.fakeRepos().Maps, &fakeTxManager{store: f.store}, f.notifier)
+}
+
+// assertRemoved checks that the first desk was soft-deleted with its past
+// reservation kept, its upcoming booking cancelled with events, audit entries
+// and a notification, and its series cancelled
+func (f *removalFixture) assertRemoved(t *testing.T, wantReason string) {
+	t.Helper()
+	if _, live := f.store.spaces[f.desk.ID]; live {
+		t.Errorf("desk is still listed")
+	}
+	if _, kept := f.store.deleted[f.desk.ID]; !kept {
+		t.Errorf("desk was not kept as deleted")
+	}
+	if entries := f.store.auditEntries(f.desk.ID); len(entries) != 1 || entries[0].Action != entities.AuditActionDelete {
+		t.Errorf("desk audit entries = %+v, want one delete entry", entries)
+	}
+
+	if got := f.store.reservation(f.past.ID); got.Status != entities.ReservationStatusActive {
+		t.Errorf("past reservation status = %s, want it kept active", got.Status)
+	}
+	for name, r := range map[string]*entities.Reservation{"upcoming": f.upcoming, "group sibling": f.sibling} {
+		if got := f.store.reservation(r.ID); got.Status != entities.ReservationStatusCancelled {
+			t.Errorf("%s reservation status = %s, want cancelled", name, got.Status)
+		}
+		if events := f.store.events(r.ID); len(events) != 1 || events[0] != entities.WebhookReservationCancelled {
+			t.Errorf("%s reservation events = %v, want [%s]", name, events, entities.WebhookReservationCancelled)
+		}
+		if entries := f.store.auditEntries(r.ID); len(entries) != 1 || entries[0].Action != entities.AuditActionCancel {
+			t.Errorf("%s reservation audit entries = %+v, want one cancel entry", name, entries)
+		}
+	}
+	if len(f.notifier.cancelled) != 2 || len(f.notifier.reasons) != 1 || f.notifier.reasons[0] != wantReason {
+		t.Errorf("notified %d cancellations for %q, want 2 for %q", len(f.notifier.cancelled), f.notifier.reasons, wantReason)
+	}
+
+	if got := f.store.series[f.series.ID]; got.Status != entities.SeriesStatusCancelled {
+		t.Errorf("series status = %s, want cancelled", got.Status)
+	}
+}
+
+func TestUpdateMapRemovesSpaces(t *testing.T) {
+	layout := func(f *removalFixture) map[string]interface{} {
+		return map[string]interface{}{
+			"grid": map[string]interface{}{"width": 10.0, "height": 10.0},
+			"spaces": []interface{}{
+				map[string]interface{}{"id": f.otherDesk.ID.String(), "name": "Desk 2", "type": "workstation", "x": 1.0, "y": 0.0},
+			},
+		}
+	}
+
+	t.Run("refused with upcoming reservations", func(t *testing.T) {
+		f := newRemovalFixture()
+		_, summary, err := f.mapService().UpdateMap(UpdateMapRequest{ID: f.officeMap.ID, JSONData: layout(f)})
+		if err != ErrRemovedSpacesReserved {
+			t.Fatalf("UpdateMap() error = %v, want %v", err, ErrRemovedSpacesReserved)
+		}
+		if summary == nil || len(summary.AffectedReservations) != 1 || summary.AffectedReservations[0].Reservation.ID != f.upcoming.ID {
+			t.Errorf("UpdateMap() summary = %+v, want the upcoming reservation affected", summary)
+		}
+		if _, live := f.store.spaces[f.desk.ID]; !live {
+			t.Errorf("desk was removed although the update was refused")
+		}
+		if got := f.store.reservation(f.upcoming.ID); got.Status != entities.ReservationStatusActive {
+			t.Errorf("upcoming reservation status = %s, want it unchanged", got.Status)
+		}
+	})
+
+	t.Run("cancelling the reservations", func(t *testing.T) {
+		f := newRemovalFixture()
+		_, summary, err := f.mapService().UpdateMap(UpdateMapRequest{ID: f.officeMap.ID, JSONData: layout(f), CancelReservations: true})
+		if err != nil {
+			t.Fatalf("UpdateMap() error = %v", err)
+		}
+		if summary.Removed != 1 || summary.Unchanged != 1 {
+			t.Errorf("UpdateMap() summary = %+v, want 1 removed and 1 unchanged", summary)
+		}
+		f.assertRemoved(t, spaceRemovedReason(""))
+	})
+}
+
+func TestDeleteMap(t *testing.T) {
+	f := newRemovalFixture()
+	if err := f.mapService().DeleteMap(f.officeMap.ID, nil, &Actor{UserID: "admin", Admin: true}, "Office closed"); err != nil {
+		t.Fatalf("DeleteMap() error = %v", err)
+	}
+
+	f.assertRemoved(t, mapDeletedReason("Office closed"))
+	if _, kept := f.store.deleted[f.otherDesk.ID]; !kept {
+		t.Errorf("second desk was not kept as deleted")
+	}
+	if _, live := f.store.maps[f.officeMap.ID]; live {
+		t.Errorf("map is still listed")
+	}
+	if entries := f.store.auditEntries(f.officeMap.ID); len(entries) != 1 || entries[0].Action != entities.AuditActionDelete || entries[0].ActorID != "admin" {
+		t.Errorf("map audit entries = %+v, want one delete entry by admin", entries)
+	}
+}
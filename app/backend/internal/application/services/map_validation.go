@@ -0,0 +1,235 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"office-reservations/internal/domain/entities"
+)
+
+// ErrInvalidMap is matched by every ValidationError
+var ErrInvalidMap = errors.New("invalid office map")
+
+// maxGridSize bounds the grid dimensions of a map
+const maxGridSize = 500
+
+// FieldError describes one invalid field. Field is a path into the request, such
+// as "json_data.spaces[2].x".
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// ValidationError is returned when a map, space, booking policy or closure is
+// rejected. It matches ErrInvalidMap with errors.Is, or ErrInvalidBookingPolicy
+// and ErrInvalidClosure for policies and closures.
+type ValidationError struct {
+	Errors []FieldError
+	// kind is the sentinel the error matches; nil means ErrInvalidMap
+	kind error
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		messages[i] = fe.Field + ": " + fe.Message
+	}
+	return e.sentinel().Error() + ": " + strings.Join(messages, "; ")
+}
+
+// Is allows errors.Is(err, ErrInvalidMap) to match a ValidationError
+func (e *ValidationError) Is(target error) bool {
+	return target == e.sentinel()
+}
+
+func (e *ValidationError) sentinel() error {
+	if e.kind != nil {
+		return e.kind
+	}
+	return ErrInvalidMap
+}
+
+// fieldErrors collects field errors while validating
+type fieldErrors []FieldError
+
+func (f *fieldErrors) add(field, format string, args ...interface{}) {
+	*f = append(*f, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (f fieldErrors) err() error {
+	return f.errOf(nil)
+}
+
+// errOf returns a ValidationError matching kind, or nil if there are no errors
+func (f fieldErrors) errOf(kind error) error {
+	if len(f) == 0 {
+		return nil
+	}
+	return &ValidationError{Errors: f, kind: kind}
+}
+
+// parseMapLayout validates the JSON data of a map: a grid with positive
+// dimensions and a list of spaces of known types that lie inside the grid, with
+// unique ids and positions. Width and height of a space default to 1.
+func parseMapLayout(jsonData map[string]interface{}) (*entities.MapLayout, error) {
+	var errs fieldErrors
+	layout := &entities.MapLayout{}
+
+	grid, ok := jsonData["grid"].(map[string]interface{})
+	if !ok {
+		errs.add("json_data.grid", "grid is required and must be an object")
+	} else {
+		width, widthOK := intField(grid, "width", "json_data.grid", true, 0, &errs)
+		height, heightOK := intField(grid, "height", "json_data.grid", true, 0, &errs)
+		cellSize, cellSizeOK := intField(grid, "cellSize", "json_data.grid", false, 40, &errs)
+		layout.Grid = entities.MapGrid{Width: width, Height: height, CellSize: cellSize}
+		if widthOK && (width < 1 || width > maxGridSize) {
+			errs.add("json_data.grid.width", "width must be between 1 and %d", maxGridSize)
+		}
+		if heightOK && (height < 1 || height > maxGridSize) {
+			errs.add("json_data.grid.height", "height must be between 1 and %d", maxGridSize)
+		}
+		if cellSizeOK && cellSize < 1 {
+			errs.add("json_data.grid.cellSize", "cellSize must be positive")
+		}
+	}
+	gridValid := len(errs) == 0
+
+	rawSpaces, present := jsonData["spaces"]
+	items, ok := rawSpaces.([]interface{})
+	if present && rawSpaces != nil && !ok {
+		errs.add("json_data.spaces", "spaces must be a list")
+	}
+
+	seenIDs := make(map[string]int)
+	seenPositions := make(map[[2]int]int)
+	for i, item := range items {
+		path := fmt.Sprintf("json_data.spaces[%d]", i)
+		raw, ok := item.(map[string]interface{})
+		if !ok {
+			errs.add(path, "space must be an object")
+			continue
+		}
+
+		space := entities.LayoutSpace{}
+		space.ID, _ = raw["id"].(string)
+		space.Name, _ = raw["name"].(string)
+		space.Name = strings.TrimSpace(space.Name)
+		typeName, _ := raw["type"].(string)
+		space.Type = entities.SpaceType(typeName)
+
+		if space.Name == "" {
+			errs.add(path+".name", "name is required")
+		}
+		if !space.Type.IsValid() {
+			errs.add(path+".type", "invalid space type %q (use workstation, meeting_room, cubicle or invalid_space)", typeName)
+		}
+		x, xOK := intField(raw, "x", path, true, 0, &errs)
+		y, yOK := intField(raw, "y", path, true, 0, &errs)
+		width, widthOK := intField(raw, "width", path, false, 1, &errs)
+		height, heightOK := intField(raw, "height", path, false, 1, &errs)
+		space.X, space.Y, space.Width, space.Height = x, y, width, height
+		sizeOK := widthOK && heightOK
+
+		if xOK && space.X < 0 {
+			errs.add(path+".x", "x must not be negative")
+			xOK = false
+		}
+		if yOK && space.Y < 0 {
+			errs.add(path+".y", "y must not be negative")
+			yOK = false
+		}
+		if sizeOK && (space.Width < 1 || space.Height < 1) {
+			errs.add(path, "width and height must be at least 1")
+			sizeOK = false
+		}
+		if gridValid && xOK && yOK && sizeOK && !layout.Grid.Contains(space.X, space.Y, space.Width, space.Height) {
+			errs.add(path, "space at (%d, %d) of size %dx%d lies outside the %dx%d grid",
+				space.X, space.Y, space.Width, space.Height, layout.Grid.Width, layout.Grid.Height)
+		}
+
+		if space.ID != "" {
+			if first, ok := seenIDs[space.ID]; ok {
+				errs.add(path+".id", "duplicate id %q (also used by spaces[%d])", space.ID, first)
+			} else {
+				seenIDs[space.ID] = i
+			}
+		}
+		if xOK && yOK {
+			position := [2]int{space.X, space.Y}
+			if first, ok := seenPositions[position]; ok {
+				errs.add(path, "position (%d, %d) is already used by spaces[%d]", space.X, space.Y, first)
+			} else {
+				seenPositions[position] = i
+			}
+		}
+
+		layout.Spaces = append(layout.Spaces, space)
+	}
+
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
+	return layout, nil
+}
+
+// validateSpace checks a single space against the known types and, when the map
+// has a valid grid, its bounds
+func validateSpace(space *entities.Space, grid *entities.MapGrid) error {
+	var errs fieldErrors
+	if strings.TrimSpace(space.Name) == "" {
+		errs.add("name", "name is required")
+	}
+	if !space.Type.IsValid() {
+		errs.add("type", "invalid space type %q (use workstation, meeting_room, cubicle or invalid_space)", space.Type)
+	}
+	if space.X < 0 {
+		errs.add("x", "x must not be negative")
+	}
+	if space.Y < 0 {
+		errs.add("y", "y must not be negative")
+	}
+	if space.Width < 1 {
+		errs.add("width", "width must be at least 1")
+	}
+	if space.Height < 1 {
+		errs.add("height", "height must be at least 1")
+	}
+	if space.Capacity < 1 {
+		errs.add("capacity", "capacity must be at least 1")
+	}
+	if len(errs) == 0 && grid != nil && !grid.Contains(space.X, space.Y, space.Width, space.Height) {
+		errs.add("x", "space lies outside the %dx%d grid", grid.Width, grid.Height)
+	}
+	return errs.err()
+}
+
+// intField reads an integer from a decoded JSON object. Missing optional fields
+// get the default value. It returns false if the field is missing or not an integer.
+func intField(obj map[string]interface{}, key, path string, required bool, def int, errs *fieldErrors) (int, bool) {
+	value, ok := obj[key]
+	if !ok || value == nil {
+		if required {
+			errs.add(path+"."+key, "%s is required", key)
+			return 0, false
+		}
+		return def, true
+	}
+	var number float64
+	switch v := value.(type) {
+	case float64:
+		number = v
+	case int:
+		number = float64(v)
+	default:
+		errs.add(path+"."+key, "%s must be an integer", key)
+		return 0, false
+	}
+	if number != math.Trunc(number) || math.Abs(number) > math.MaxInt32 {
+		errs.add(path+"."+key, "%s must be an integer", key)
+		return 0, false
+	}
+	return int(number), true
+}
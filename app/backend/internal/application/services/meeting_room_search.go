@@ -0,0 +1,171 @@
+package services
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"office-reservations/internal/domain/entities"
+)
+
+var (
+	ErrInvalidDuration  = errors.New("duration must be positive")
+	ErrInvalidAttendees = errors.New("attendees must be at least 1")
+)
+
+const (
+	// slotStepMinutes aligns the start of suggested slots
+	slotStepMinutes = 15
+	// DefaultSlotLimit and MaxSlotLimit bound the number of suggested slots
+	DefaultSlotLimit = 20
+	MaxSlotLimit     = 100
+)
+
+// MeetingRoomSearchRequest represents the input for finding free meeting room
+// slots. Nil times default to the start and end of the day and a nil map
+// searches all maps.
+type MeetingRoomSearchRequest struct {
+	MapID     *uuid.UUID
+	From      time.Time
+	To        time.Time
+	Earliest  *string
+	Latest    *string
+	Duration  int // Minutes
+	Attendees int
+	Limit     int
+}
+
+// MeetingRoomSlot is a free slot in a meeting room: a single meeting room space
+// or a space group booked as one room
+type MeetingRoomSlot struct {
+	Room     UnitAvailability
+	MapID    uuid.UUID
+	Name     string
+	Capacity int
+	Date     time.Time
+	Range    entities.TimeRange
+}
+
+// FindMeetingRoomSlots suggests the earliest slot of each free gap in every
+// meeting room that seats the attendees. Slots are ranked by the smallest room
+// that fits, then by the earliest start.
+func (s *AvailabilityService) FindMeetingRoomSlots(req MeetingRoomSearchRequest) ([]MeetingRoomSlot, error) {
+	if req.Duration <= 0 {
+		return nil, ErrInvalidDuration
+	}
+	if req.Attendees < 1 {
+		return nil, ErrInvalidAttendees
+	}
+	maps, err := s.searchedMaps(req.MapID)
+	if err != nil {
+		return nil, err
+	}
+	window, err := validateAvailabilityRange(req.From, req.To, req.Earliest, req.Latest)
+	if err != nil {
+		return nil, err
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = DefaultSlotLimit
+	}
+	if limit > MaxSlotLimit {
+		limit = MaxSlotLimit
+	}
+
+	units, reservations, closures, err := s.loadUnits(req.MapID, req.From, req.To)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	slots := []MeetingRoomSlot{}
+	for _, unit := range units {
+		slot := MeetingRoomSlot{Room: unit}
+		if unit.Group != nil {
+			slot.MapID, slot.Name, slot.Capacity = unit.Group.MapID, unit.Group.Name, unit.Group.Capacity
+		} else {
+			slot.MapID, slot.Name, slot.Capacity = unit.Space.MapID, unit.Space.Name, unit.Space.Capacity
+		}
+		officeMap := maps[slot.MapID]
+		if unit.Spaces[0].Type != entities.SpaceTypeMeetingRoom || slot.Capacity < req.Attendees || officeMap == nil {
+			continue
+		}
+		today, clock := entities.LocalDay(now, officeMap.Location())
+
+		for date := req.From; !date.After(req.To); date = date.AddDate(0, 0, 1) {
+			// Slots in the past or outside the opening hours cannot be booked
+			hours, open := officeMap.OpeningHours.On(date)
+			if date.Before(today) || !open {
+				continue
+			}
+			earliest, latest := window.Start, window.End
+			if hours.Start > earliest {
+				earliest = hours.Start
+			}
+			if hours.End < latest {
+				latest = hours.End
+			}
+			if date.Equal(today) && clock > earliest {
+				earliest = clock
+			}
+
+			// Closed parts of the day are never free
+			day := dayAvailability(unit, date, window, reservations.forUnit(unit, date), closures.forUnit(unit, date))
+			for _, free := range day.Free {
+				start := free.Start
+				if start < earliest {
+					start = earliest
+				}
+				start = (start + slotStepMinutes - 1) / slotStepMinutes * slotStepMinutes
+				if start+req.Duration > free.End || start+req.Duration > latest {
+					continue
+				}
+				candidate := slot
+				candidate.Date = date
+				candidate.Range = entities.TimeRange{Start: start, End: start + req.Duration}
+				slots = append(slots, candidate)
+			}
+		}
+	}
+
+	sort.SliceStable(slots, func(i, j int) bool {
+		a, b := slots[i], slots[j]
+		if a.Capacity != b.Capacity {
+			return a.Capacity < b.Capacity
+		}
+		if !a.Date.Equal(b.Date) {
+			return a.Date.Before(b.Date)
+		}
+		return a.Range.Start < b.Range.Start
+	})
+	if len(slots) > limit {
+		slots = slots[:limit]
+	}
+	return slots, nil
+}
+
+// searchedMaps returns the maps a search covers, keyed by ID: the given map, or
+// every map when mapID is nil
+func (s *AvailabilityService) searchedMaps(mapID *uuid.UUID) (map[uuid.UUID]*entities.OfficeMap, error) {
+	var maps []*entities.OfficeMap
+	if mapID != nil {
+		officeMap, err := s.mapRepo.FindByID(*mapID)
+		if err != nil {
+			return nil, ErrMapNotFound
+		}
+		maps = append(maps, officeMap)
+	} else {
+		var err error
+		if maps, err = s.mapRepo.FindAll(); err != nil {
+			return nil, err
+		}
+	}
+
+	byID := make(map[uuid.UUID]*entities.OfficeMap, len(maps))
+	for _, m := range maps {
+		byID[m.ID] = m
+	}
+	return byID, nil
+}
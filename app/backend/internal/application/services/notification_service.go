@@ -0,0 +1,421 @@
+package services
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"office-reservations/internal/config"
+	"office-reservations/internal/domain/entities"
+	"office-reservations/internal/domain/repositories"
+)
+
+const (
+	// notificationBatchSize bounds the notifications sent per run
+	notificationBatchSize = 50
+	// maxNotificationErrorLength bounds the error stored for a failed attempt
+	maxNotificationErrorLength = 1000
+)
+
+// NotificationMailer writes and sends the email of a notification
+type NotificationMailer interface {
+	Send(message *NotificationMessage) error
+}
+
+// NotificationMessage is a notification with everything needed to write its email
+type NotificationMessage struct {
+	Kind entities.NotificationKind
+	// To and ToName are the address and name of the holder of the booking
+	To     string
+	ToName string
+	// Reason explains a cancellation the holder did not make themselves
+	Reason string
+	// Booking is the reservation with the name of its space or space group and its map
+	Booking CalendarEntry
+	// Start is when the booking starts; all-day bookings start at the opening
+	// time of their office
+	Start time.Time
+}
+
+// NotificationService queues email notifications about bookings for their
+// holders, queues reminders ahead of upcoming bookings and sends them. Without
+// a mailer nothing is queued, but preferences can still be managed.
+type NotificationService struct {
+	notificationRepo repositories.NotificationRepository
+	preferencesRepo  repositories.NotificationPreferencesRepository
+	reservationRepo  repositories.ReservationRepository
+	spaceRepo        repositories.SpaceRepository
+	groupRepo        repositories.SpaceGroupRepository
+	mapRepo          repositories.OfficeMapRepository
+	mailer           NotificationMailer
+	config           config.NotificationConfig
+
+	// emails caches the address last stored for each user
+	emails sync.Map
+}
+
+// NewNotificationService creates a new notification service. mailer is nil
+// when no mail server is configured.
+func NewNotificationService(
+	notificationRepo repositories.NotificationRepository,
+	preferencesRepo repositories.NotificationPreferencesRepository,
+	reservationRepo repositories.ReservationRepository,
+	spaceRepo repositories.SpaceRepository,
+	groupRepo repositories.SpaceGroupRepository,
+	mapRepo repositories.OfficeMapRepository,
+	mailer NotificationMailer,
+	cfg config.NotificationConfig,
+) *NotificationService {
+	return &NotificationService{
+		notificationRepo: notificationRepo,
+		preferencesRepo:  preferencesRepo,
+		reservationRepo:  reservationRepo,
+		spaceRepo:        spaceRepo,
+		groupRepo:        groupRepo,
+		mapRepo:          mapRepo,
+		mailer:           mailer,
+		config:           cfg,
+	}
+}
+
+// Enabled returns true if notifications are sent
+func (s *NotificationService) Enabled() bool {
+	return s.mailer != nil
+}
+
+// NotificationPreferencesRequest represents the input for changing the
+// preferences of a user. Nil fields keep their current value.
+type NotificationPreferencesRequest struct {
+	Confirmations *bool
+	Cancellations *bool
+	DayReminders  *bool
+	HourReminders *bool
+}
+
+// GetPreferences retrieves the preferences of a user, or the defaults if they
+// have none
+func (s *NotificationService) GetPreferences(userID string) (*entities.NotificationPreferences, error) {
+	preferences, err := s.preferencesRepo.FindByUserID(userID)
+	if err != nil {
+		return entities.DefaultNotificationPreferences(userID), nil
+	}
+	return preferences, nil
+}
+
+// UpdatePreferences changes which notifications a user receives
+func (s *NotificationService) UpdatePreferences(userID string, req NotificationPreferencesRequest) (*entities.NotificationPreferences, error) {
+	preferences, err := s.GetPreferences(userID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Confirmations != nil {
+		preferences.Confirmations = *req.Confirmations
+	}
+	if req.Cancellations != nil {
+		preferences.Cancellations = *req.Cancellations
+	}
+	if req.DayReminders != nil {
+		preferences.DayReminders = *req.DayReminders
+	}
+	if req.HourReminders != nil {
+		preferences.HourReminders = *req.HourReminders
+	}
+	preferences.UpdatedAt = time.Now()
+
+	if err := s.preferencesRepo.Save(preferences); err != nil {
+		return nil, err
+	}
+	s.emails.Store(userID, preferences.Email)
+	return preferences, nil
+}
+
+// RememberEmail stores the email address of a user, as found in their bearer
+// token, unless it is already known
+func (s *NotificationService) RememberEmail(userID, email string) error {
+	if userID == "" || email == "" {
+		return nil
+	}
+	if known, ok := s.emails.Load(userID); ok && known == email {
+		return nil
+	}
+	if err := s.preferencesRepo.SaveEmail(userID, email); err != nil {
+		return err
+	}
+	s.emails.Store(userID, email)
+	return nil
+}
+
+// ReservationsCreated queues a confirmation for each new booking
+func (s *NotificationService) ReservationsCreated(reservations []*entities.Reservation) {
+	s.queue(entities.NotificationConfirmation, reservations, "")
+}
+
+// ReservationsUpdated queues an update notice for each changed booking
+func (s *NotificationService) ReservationsUpdated(reservations []*entities.Reservation) {
+	s.queue(entities.NotificationUpdate, reservations, "")
+}
+
+// ReservationsCancelled queues a cancellation notice for each cancelled
+// booking. reason is empty when the holders cancelled themselves.
+func (s *NotificationService) ReservationsCancelled(reservations []*entities.Reservation, reason string) {
+	s.queue(entities.NotificationCancellation, reservations, reason)
+}
+
+// queue queues a notification of the given kind for each booking. Failures are
+// logged: the change the notification is about has already been committed.
+func (s *NotificationService) queue(kind entities.NotificationKind, reservations []*entities.Reservation, reason string) {
+	if s.mailer == nil {
+		return
+	}
+	now := time.Now()
+	for _, r := range bookingLeads(reservations) {
+		_, err := s.notificationRepo.Create(&entities.Notification{
+			ID:            uuid.New(),
+			UserID:        r.UserID,
+			ReservationID: r.ID,
+			Kind:          kind,
+			Reason:        reason,
+			Status:        entities.NotificationPending,
+			NextAttemptAt: &now,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		})
+		if err != nil {
+			log.Printf("Failed to queue %s notification for reservation %s: %v", kind, r.ID, err)
+		}
+	}
+}
+
+// QueueReminders queues the day and hour reminders that are due at now. A
+// reminder is not sent for a booking made after it would have been due, and
+// the day reminder is dropped once the hour reminder is due. It returns the
+// number of reminders queued.
+func (s *NotificationService) QueueReminders(now time.Time) (int, error) {
+	if s.mailer == nil {
+		return 0, nil
+	}
+
+	// Dates are local to each map; a day on either side covers every time zone
+	from := now.UTC().Truncate(24*time.Hour).AddDate(0, 0, -1)
+	to := now.Add(s.config.DayReminderLead).UTC().Truncate(24*time.Hour).AddDate(0, 0, 1)
+	status := entities.ReservationStatusActive
+	reservations, err := s.reservationRepo.FindAll(repositories.ReservationFilters{From: &from, To: &to, Status: &status})
+	if err != nil {
+		return 0, err
+	}
+	entries, err := s.bookingEntries(bookingLeads(reservations))
+	if err != nil {
+		return 0, err
+	}
+
+	queued := 0
+	for _, entry := range entries {
+		start, err := s.bookingStart(entry)
+		if err != nil {
+			continue
+		}
+		kind, due := s.reminderDue(entry.Reservation, start, now)
+		if !due {
+			continue
+		}
+		created, err := s.notificationRepo.Create(&entities.Notification{
+			ID:            uuid.New(),
+			UserID:        entry.Reservation.UserID,
+			ReservationID: entry.Reservation.ID,
+			Kind:          kind,
+			StartsAt:      &start,
+			Status:        entities.NotificationPending,
+			NextAttemptAt: &now,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		})
+		if err != nil {
+			return queued, err
+		}
+		if created {
+			queued++
+		}
+	}
+	return queued, nil
+}
+
+// reminderDue returns the reminder due at now for a booking starting at start
+func (s *NotificationService) reminderDue(r *entities.Reservation, start, now time.Time) (entities.NotificationKind, bool) {
+	if !now.Before(start) {
+		return "", false
+	}
+	if hourFrom := start.Add(-s.config.HourReminderLead); !now.Before(hourFrom) {
+		return entities.NotificationHourReminder, r.CreatedAt.Before(hourFrom)
+	}
+	if dayFrom := start.Add(-s.config.DayReminderLead); !now.Before(dayFrom) {
+		return entities.NotificationDayReminder, r.CreatedAt.Before(dayFrom)
+	}
+	return "", false
+}
+
+// bookingStart returns when a booking starts. All-day bookings start when
+// their office opens that day, or at the configured opening time.
+func (s *NotificationService) bookingStart(entry CalendarEntry) (time.Time, error) {
+	r := entry.Reservation
+	timeRange, err := r.TimeRange()
+	if err != nil {
+		return time.Time{}, err
+	}
+	loc := entry.Map.Location()
+	if !timeRange.IsAllDay() {
+		start, _ := timeRange.In(r.Date, loc)
+		return start, nil
+	}
+	if entry.Map.OpeningHours != nil {
+		if hours, open := entry.Map.OpeningHours.On(r.Date); open {
+			return entities.AtClock(r.Date, hours.Start, loc), nil
+		}
+	}
+	minutes, err := entities.ParseClock(s.config.OfficeOpening)
+	if err != nil {
+		return time.Time{}, ErrInvalidTime
+	}
+	return entities.AtClock(r.Date, minutes, loc), nil
+}
+
+// SendDue sends the notifications whose next attempt is due. Failed attempts
+// are retried with exponential backoff until MaxAttempts. It returns the
+// number of notifications sent and of failed attempts.
+func (s *NotificationService) SendDue(now time.Time) (sent int, failed int, err error) {
+	if s.mailer == nil {
+		return 0, 0, nil
+	}
+	// Claimed notifications are postponed past the SMTP timeout, so that another
+	// instance only picks them up again if this one dies while sending
+	notifications, err := s.notificationRepo.ClaimDue(now, now.Add(s.config.SMTP.Timeout+time.Minute), notificationBatchSize)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, n := range notifications {
+		message, skip := s.message(n)
+		at := time.Now()
+		switch {
+		case skip != "":
+			n.Skip(skip, at)
+		default:
+			if err := s.mailer.Send(message); err != nil {
+				n.RecordFailure(truncate(err.Error(), maxNotificationErrorLength), at, s.config.MaxAttempts)
+				failed++
+			} else {
+				n.RecordSent(at)
+				sent++
+			}
+		}
+		if err := s.notificationRepo.Update(n); err != nil {
+			log.Printf("Failed to record notification %s: %v", n.ID, err)
+		}
+	}
+	return sent, failed, nil
+}
+
+// message builds the email of a notification, or returns why it is not sent
+func (s *NotificationService) message(n *entities.Notification) (*NotificationMessage, string) {
+	r, err := s.reservationRepo.FindByID(n.ReservationID)
+	if err != nil {
+		return nil, "reservation not found"
+	}
+	preferences, err := s.GetPreferences(n.UserID)
+	if err != nil {
+		return nil, "preferences not found"
+	}
+	if preferences.Email == "" {
+		return nil, "no email address known"
+	}
+	if !preferences.Allows(n.Kind) {
+		return nil, "opted out"
+	}
+
+	entries, err := s.bookingEntries([]*entities.Reservation{r})
+	if err != nil || len(entries) == 0 {
+		return nil, "space not found"
+	}
+	entry := entries[0]
+	start, err := s.bookingStart(entry)
+	if err != nil {
+		return nil, "invalid booking time"
+	}
+
+	switch {
+	case n.Kind.IsReminder() && (!r.IsActive() || n.StartsAt == nil || !start.Equal(*n.StartsAt)):
+		return nil, "booking changed since the reminder was queued"
+	case (n.Kind == entities.NotificationConfirmation || n.Kind == entities.NotificationUpdate) && r.IsCancelled():
+		return nil, "booking cancelled since"
+	}
+
+	return &NotificationMessage{
+		Kind:    n.Kind,
+		To:      preferences.Email,
+		ToName:  r.UserName,
+		Reason:  n.Reason,
+		Booking: entry,
+		Start:   start,
+	}, ""
+}
+
+// bookingEntries loads the space or space group name and the map of each
+// reservation
+func (s *NotificationService) bookingEntries(reservations []*entities.Reservation) ([]CalendarEntry, error) {
+	return calendarEntries(s.spaceRepo, s.groupRepo, s.mapRepo, reservations)
+}
+
+// RunScheduler queues due reminders and sends due notifications every
+// interval until ctx is done
+func (s *NotificationService) RunScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if queued, err := s.QueueReminders(time.Now()); err != nil {
+			log.Printf("Reminder scheduler error: %v", err)
+		} else if queued > 0 {
+			log.Printf("Reminder scheduler queued %d reminders", queued)
+		}
+
+		if sent, failed, err := s.SendDue(time.Now()); err != nil {
+			log.Printf("Notification sender error: %v", err)
+		} else if sent > 0 || failed > 0 {
+			log.Printf("Notifications: %d sent, %d failed", sent, failed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// bookingLeads returns one reservation per booking: the reservation itself, or
+// for group bookings the member with the lowest ID, so that the same booking
+// always gets the same lead
+func bookingLeads(reservations []*entities.Reservation) []*entities.Reservation {
+	leads := make(map[uuid.UUID]*entities.Reservation)
+	var order []uuid.UUID
+	for _, r := range reservations {
+		key := r.ID
+		if r.GroupBookingID != nil {
+			key = *r.GroupBookingID
+		}
+		lead, ok := leads[key]
+		if !ok {
+			order = append(order, key)
+		}
+		if !ok || r.ID.String() < lead.ID.String() {
+			leads[key] = r
+		}
+	}
+
+	result := make([]*entities.Reservation, len(order))
+	for i, key := range order {
+		result[i] = leads[key]
+	}
+	return result
+}
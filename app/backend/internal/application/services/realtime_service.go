@@ -0,0 +1,294 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"office-reservations/internal/config"
+	"office-reservations/internal/domain/entities"
+	"office-reservations/internal/domain/repositories"
+)
+
+// ErrSubscriptionDropped is returned once a subscription was closed because its
+// client fell behind or events may have been missed; the client should resume
+// from the last event it received
+var ErrSubscriptionDropped = errors.New("subscription dropped")
+
+// RealtimeEvent is a committed lifecycle event of an office map, as streamed to
+// clients. Seq is the event ID clients resume from.
+type RealtimeEvent struct {
+	Seq       int64
+	ID        uuid.UUID
+	Type      entities.WebhookEventType
+	MapID     uuid.UUID
+	Data      json.RawMessage
+	CreatedAt time.Time
+}
+
+// EventListener receives the announcements of committed outbox events, made by
+// this instance or any other one sharing the database
+type EventListener interface {
+	// Listen calls listening once announcements are being received, then notify
+	// with the payload of each one, until ctx is done or the connection fails
+	Listen(ctx context.Context, listening func(), notify func(payload string)) error
+}
+
+// RealtimeService fans out the lifecycle events of office maps to the clients
+// subscribed to them. Events reach it through the database rather than from
+// the services that append them, so every instance streams the changes made
+// through any other one.
+type RealtimeService struct {
+	outboxRepo repositories.OutboxRepository
+	mapRepo    repositories.OfficeMapRepository
+	config     config.RealtimeConfig
+
+	mu          sync.Mutex
+	subscribers map[uuid.UUID]map[*RealtimeSubscription]struct{}
+}
+
+// NewRealtimeService creates a new real-time service
+func NewRealtimeService(outboxRepo repositories.OutboxRepository, mapRepo repositories.OfficeMapRepository, cfg config.RealtimeConfig) *RealtimeService {
+	return &RealtimeService{
+		outboxRepo:  outboxRepo,
+		mapRepo:     mapRepo,
+		config:      cfg,
+		subscribers: make(map[uuid.UUID]map[*RealtimeSubscription]struct{}),
+	}
+}
+
+// Heartbeat returns how often idle streams are sent a keepalive
+func (s *RealtimeService) Heartbeat() time.Duration {
+	return s.config.Heartbeat
+}
+
+// RealtimeSubscription receives the events of one map for one client
+type RealtimeSubscription struct {
+	// Replay holds the events appended after the last event ID the client
+	// resumed from, oldest first
+	Replay []*RealtimeEvent
+	// Reset is true if the client missed more events than are replayed; it
+	// should reload the map instead
+	Reset bool
+	// Cursor is the ID of the latest event of the map when the subscription
+	// started. Clients that resume from it miss nothing, even if no event was
+	// sent to them yet.
+	Cursor int64
+
+	mapID   uuid.UUID
+	events  chan *RealtimeEvent
+	dropped chan struct{}
+	// replayed holds the sequence numbers of replayed events that may also
+	// arrive live, so that they are not sent twice
+	replayed map[int64]struct{}
+	service  *RealtimeService
+	once     sync.Once
+}
+
+// Subscribe starts streaming the events of a map. With lastEventID, the events
+// appended after it are replayed first; as the events of a map commit in
+// sequence order, none committed later can be numbered before it. The
+// subscription must be closed.
+func (s *RealtimeService) Subscribe(mapID uuid.UUID, lastEventID *int64) (*RealtimeSubscription, error) {
+	if _, err := s.mapRepo.FindByID(mapID); err != nil {
+		return nil, ErrMapNotFound
+	}
+
+	sub := &RealtimeSubscription{
+		mapID:    mapID,
+		events:   make(chan *RealtimeEvent, s.config.Buffer),
+		dropped:  make(chan struct{}),
+		replayed: make(map[int64]struct{}),
+		service:  s,
+	}
+	// Registered before the replay is read, so that no event committed in
+	// between is lost
+	s.mu.Lock()
+	if s.subscribers[mapID] == nil {
+		s.subscribers[mapID] = make(map[*RealtimeSubscription]struct{})
+	}
+	s.subscribers[mapID][sub] = struct{}{}
+	s.mu.Unlock()
+
+	if lastEventID != nil {
+		events, err := s.outboxRepo.FindByMapAfter(mapID, *lastEventID, s.config.ReplayLimit+1)
+		if err != nil {
+			sub.Close()
+			return nil, err
+		}
+		if len(events) > s.config.ReplayLimit {
+			sub.Reset = true
+		} else {
+			for _, event := range events {
+				sub.Replay = append(sub.Replay, toRealtimeEvent(event))
+				sub.replayed[event.Seq] = struct{}{}
+			}
+		}
+	}
+
+	cursor, err := s.outboxRepo.LastSeqByMap(mapID)
+	if err != nil {
+		sub.Close()
+		return nil, err
+	}
+	sub.Cursor = cursor
+	// An ID past the latest event of the map was not issued for it
+	if lastEventID != nil && *lastEventID > cursor {
+		sub.Reset = true
+		sub.Replay = nil
+	}
+	return sub, nil
+}
+
+// MapID returns the map the subscription follows
+func (sub *RealtimeSubscription) MapID() uuid.UUID {
+	return sub.mapID
+}
+
+// Next waits up to wait for the next live event. It returns nil without error
+// if none arrived in time, and ErrSubscriptionDropped once the subscription
+// was dropped.
+func (sub *RealtimeSubscription) Next(ctx context.Context, wait time.Duration) (*RealtimeEvent, error) {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	for {
+		select {
+		case event := <-sub.events:
+			if _, ok := sub.replayed[event.Seq]; ok {
+				delete(sub.replayed, event.Seq)
+				continue
+			}
+			return event, nil
+		case <-sub.dropped:
+			return nil, ErrSubscriptionDropped
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer.C:
+			return nil, nil
+		}
+	}
+}
+
+// Close stops the subscription
+func (sub *RealtimeSubscription) Close() {
+	sub.service.unsubscribe(sub)
+}
+
+// unsubscribe removes a subscription and wakes up its reader
+func (s *RealtimeService) unsubscribe(sub *RealtimeSubscription) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.remove(sub)
+}
+
+// remove removes a subscription. It must be called with mu held.
+func (s *RealtimeService) remove(sub *RealtimeSubscription) {
+	if subs, ok := s.subscribers[sub.mapID]; ok {
+		delete(subs, sub)
+		if len(subs) == 0 {
+			delete(s.subscribers, sub.mapID)
+		}
+	}
+	sub.once.Do(func() { close(sub.dropped) })
+}
+
+// Run listens for committed events and fans them out until ctx is done. When
+// the connection to the database is lost every subscription is dropped, as
+// events may be missed until it is back, and clients resume from their last
+// event once it is.
+func (s *RealtimeService) Run(ctx context.Context, listener EventListener) {
+	for {
+		err := listener.Listen(ctx, s.dropAll, s.announce)
+		s.dropAll()
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("Real-time event listener error: %v", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(s.config.RetryInterval):
+		}
+	}
+}
+
+// dropAll drops every subscription
+func (s *RealtimeService) dropAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, subs := range s.subscribers {
+		for sub := range subs {
+			s.remove(sub)
+		}
+	}
+}
+
+// announce loads an announced event and sends it to the subscribers of its
+// map. The payload is "<seq> <map_id>"; events of maps without subscribers on
+// this instance are not loaded.
+func (s *RealtimeService) announce(payload string) {
+	seqText, mapText, _ := strings.Cut(payload, " ")
+	seq, err := strconv.ParseInt(seqText, 10, 64)
+	if err != nil {
+		log.Printf("Ignoring malformed event announcement %q", payload)
+		return
+	}
+	mapID, err := uuid.Parse(mapText)
+	if err != nil {
+		return
+	}
+	if !s.hasSubscribers(mapID) {
+		return
+	}
+
+	event, err := s.outboxRepo.FindBySeq(seq)
+	if err != nil {
+		log.Printf("Failed to load event %d: %v", seq, err)
+		return
+	}
+	s.publish(toRealtimeEvent(event))
+}
+
+// hasSubscribers returns true if anyone on this instance follows the map
+func (s *RealtimeService) hasSubscribers(mapID uuid.UUID) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.subscribers[mapID]) > 0
+}
+
+// publish sends an event to the subscribers of its map. Subscribers whose
+// buffer is full are dropped rather than slowing down everyone else.
+func (s *RealtimeService) publish(event *RealtimeEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for sub := range s.subscribers[event.MapID] {
+		select {
+		case sub.events <- event:
+		default:
+			s.remove(sub)
+		}
+	}
+}
+
+// toRealtimeEvent converts an outbox event of a map
+func toRealtimeEvent(event *entities.OutboxEvent) *RealtimeEvent {
+	result := &RealtimeEvent{
+		Seq:       event.Seq,
+		ID:        event.ID,
+		Type:      event.Type,
+		Data:      event.Data,
+		CreatedAt: event.CreatedAt,
+	}
+	if event.MapID != nil {
+		result.MapID = *event.MapID
+	}
+	return result
+}
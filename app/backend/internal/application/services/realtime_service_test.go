@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"office-reservations/internal/config"
+	"office-reservations/internal/domain/entities"
+)
+
+// realtimeFixture is a map whose outbox holds a check-in and a no-show, and
+// another map with an event of its own
+type realtimeFixture struct {
+	store     *fakeStore
+	officeMap *entities.OfficeMap
+	desk      *entities.Space
+	checkedIn *entities.Reservation
+	noShow    *entities.Reservation
+}
+
+func newRealtimeFixture(t *testing.T) *realtimeFixture {
+	t.Helper()
+	store := newFakeStore()
+	officeMap := store.addMap("UTC")
+	desk := store.addSpace(officeMap.ID, "Desk 1")
+	other := store.addSpace(store.addMap("UTC").ID, "Desk 2")
+	today, _ := entities.LocalDay(time.Now(), time.UTC)
+	nine, ten := "09:00", "10:00"
+
+	checkedIn := store.addReservation(entities.Reservation{SpaceID: desk.ID, UserID: "alice", Date: today})
+	noShow := store.addReservation(entities.Reservation{SpaceID: desk.ID, UserID: "bob", Date: today.AddDate(0, 0, -1), StartTime: &nine, EndTime: &ten})
+	elsewhere := store.addReservation(entities.Reservation{SpaceID: other.ID, UserID: "carol", Date: today})
+
+	if _, err := newTestCheckInService(store, 48*time.Hour).CheckIn(checkedIn.ID, nil); err != nil {
+		t.Fatalf("CheckIn() error = %v", err)
+	}
+	if _, err := newTestCheckInService(store, 48*time.Hour).CheckIn(elsewhere.ID, nil); err != nil {
+		t.Fatalf("CheckIn() error = %v", err)
+	}
+	if _, _, err := newTestCheckInService(store, 30*time.Minute).SweepNoShows(); err != nil {
+		t.Fatalf("SweepNoShows() error = %v", err)
+	}
+	return &realtimeFixture{store: store, officeMap: officeMap, desk: desk, checkedIn: checkedIn, noShow: noShow}
+}
+
+func (f *realtimeFixture) service(replayLimit int) *RealtimeService {
+	repos := f.store.fakeRepos()
+	return NewRealtimeService(repos.Outbox, repos.Maps, config.RealtimeConfig{ReplayLimit: replayLimit, Buffer: 8})
+}
+
+func TestSubscribeResume(t *testing.T) {
+	f := newRealtimeFixture(t)
+	// The events of the map are numbered 1 and 3; the other map's event is 2
+	seq := func(n int64) *int64 { return &n }
+
+	tests := []struct {
+		name        string
+		lastEventID *int64
+		replayLimit int
+		wantReplay  []int64
+		wantReset   bool
+	}{
+		{name: "new subscription", replayLimit: 10},
+		{name: "resume from the start", lastEventID: seq(0), replayLimit: 10, wantReplay: []int64{1, 3}},
+		{name: "resume after the check-in", lastEventID: seq(1), replayLimit: 10, wantReplay: []int64{3}},
+		{name: "resume after another map's event", lastEventID: seq(2), replayLimit: 10, wantReplay: []int64{3}},
+		{name: "up to date", lastEventID: seq(3), replayLimit: 10},
+		{name: "too far behind", lastEventID: seq(0), replayLimit: 1, wantReset: true},
+		{name: "past the latest event", lastEventID: seq(42), replayLimit: 10, wantReset: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub, err := f.service(tt.replayLimit).Subscribe(f.officeMap.ID, tt.lastEventID)
+			if err != nil {
+				t.Fatalf("Subscribe() error = %v", err)
+			}
+			defer sub.Close()
+
+			if sub.Cursor != 3 {
+				t.Errorf("Cursor = %d, want 3", sub.Cursor)
+			}
+			if sub.Reset != tt.wantReset {
+				t.Errorf("Reset = %v, want %v", sub.Reset, tt.wantReset)
+			}
+			var replay []int64
+			for _, event := range sub.Replay {
+				replay = append(replay, event.Seq)
+				if event.MapID != f.officeMap.ID || event.Type != entities.WebhookReservationUpdated {
+					t.Errorf("replayed %s of map %s, want reservation.updated of map %s", event.Type, event.MapID, f.officeMap.ID)
+				}
+			}
+			if fmt.Sprint(replay) != fmt.Sprint(tt.wantReplay) {
+				t.Errorf("Replay = %v, want %v", replay, tt.wantReplay)
+			}
+		})
+	}
+}
+
+func TestSubscribeSkipsReplayedLiveEvents(t *testing.T) {
+	f := newRealtimeFixture(t)
+	service := f.service(10)
+	lastEventID := int64(1)
+	sub, err := service.Subscribe(f.officeMap.ID, &lastEventID)
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	defer sub.Close()
+
+	// The no-show was replayed; its announcement arriving afterwards is not
+	// sent again, while a later event is
+	service.announce(fmt.Sprintf("3 %s", f.officeMap.ID))
+	if err := appendEvent(f.store.fakeRepos().Outbox, entities.WebhookSpaceUpdated, f.officeMap.ID, spaceEventData{Space: snapshotSpace(f.desk)}); err != nil {
+		t.Fatalf("appendEvent() error = %v", err)
+	}
+	service.announce(fmt.Sprintf("4 %s", f.officeMap.ID))
+
+	event, err := sub.Next(context.Background(), time.Second)
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if event == nil || event.Seq != 4 {
+		t.Fatalf("Next() = %+v, want event 4", event)
+	}
+	if event, _ := sub.Next(context.Background(), 10*time.Millisecond); event != nil {
+		t.Errorf("Next() = event %d, want none", event.Seq)
+	}
+}
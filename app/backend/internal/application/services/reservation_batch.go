@@ -0,0 +1,305 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"office-reservations/internal/domain/entities"
+	"office-reservations/internal/domain/repositories"
+)
+
+// MaxReservationBatchSize is how many items a single batch may hold
+const MaxReservationBatchSize = 100
+
+var (
+	ErrBatchEmpty           = errors.New("batch has no items")
+	ErrBatchTooLarge        = fmt.Errorf("batch has more than %d items", MaxReservationBatchSize)
+	ErrInvalidBatchMode     = errors.New("invalid batch mode")
+	ErrBatchAborted         = errors.New("not applied because another item of the batch failed")
+	ErrCancelFilterRequired = errors.New("a space or map is required to cancel by filter")
+)
+
+// BatchMode selects how a batch treats items that fail
+type BatchMode string
+
+const (
+	// BatchAllOrNothing applies the items in a single transaction, and none of
+	// them if any fails
+	BatchAllOrNothing BatchMode = "all_or_nothing"
+	// BatchBestEffort applies every item on its own, regardless of the others
+	BatchBestEffort BatchMode = "best_effort"
+)
+
+// IsValid returns true if the batch mode is known
+func (m BatchMode) IsValid() bool {
+	return m == BatchAllOrNothing || m == BatchBestEffort
+}
+
+// BatchResult is the outcome of one item of a batch, in the order of the items.
+// Reservation is the reservation created or cancelled, or nil if Err is set.
+type BatchResult struct {
+	Reservation *entities.Reservation
+	Err         error
+}
+
+// CancelReservationItem names a reservation to cancel and, optionally, the
+// version the cancellation is based on
+type CancelReservationItem struct {
+	ID      uuid.UUID
+	Version *int64
+}
+
+// CancelFilter selects the reservations cancelled together: the active ones on
+// a space, or any space of a map, between two dates, inclusive
+type CancelFilter struct {
+	SpaceID *uuid.UUID
+	MapID   *uuid.UUID
+	From    *time.Time
+	To      *time.Time
+}
+
+// ValidateBatch validates the mode and size of a batch
+func ValidateBatch(mode BatchMode, size int) error {
+	if !mode.IsValid() {
+		return ErrInvalidBatchMode
+	}
+	if size == 0 {
+		return ErrBatchEmpty
+	}
+	if size > MaxReservationBatchSize {
+		return ErrBatchTooLarge
+	}
+	return nil
+}
+
+// abortBatch reports the failure of one item of an all-or-nothing batch: the
+// failed item gets its error and every other item ErrBatchAborted
+func abortBatch(size int, failed int, err error) []BatchResult {
+	results := make([]BatchResult, size)
+	for i := range results {
+		results[i].Err = ErrBatchAborted
+	}
+	results[failed].Err = err
+	return results
+}
+
+// CreateReservations books several reservations, such as desks for a whole team,
+// each validated like CreateReservation. In all-or-nothing mode they are booked
+// in a single transaction, and nothing is booked if any item fails, including
+// items that overlap each other.
+func (s *ReservationService) CreateReservations(reqs []CreateReservationRequest, mode BatchMode) ([]BatchResult, error) {
+	if err := ValidateBatch(mode, len(reqs)); err != nil {
+		return nil, err
+	}
+
+	if mode == BatchBestEffort {
+		results := make([]BatchResult, len(reqs))
+		for i, req := range reqs {
+			results[i].Reservation, results[i].Err = s.CreateReservation(req)
+		}
+		return results, nil
+	}
+
+	// Each item is checked against the booking policies as if the items
+	// before it were already booked, as they will be in the transaction
+	bookings := make([]*newBooking, len(reqs))
+	var pending []*entities.Reservation
+	for i, req := range reqs {
+		booking, err := s.prepareBooking(req, pending)
+		if err != nil {
+			return abortBatch(len(reqs), i, err), nil
+		}
+		bookings[i] = booking
+		pending = append(pending, booking.pending())
+	}
+
+	var current int
+	err := s.txManager.WithinTransaction(func(repos repositories.TxRepositories) error {
+		for i, booking := range bookings {
+			current = i
+			if err := booking.create(repos); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, repositories.ErrReservationOverlap) {
+			err = ErrReservationAlreadyExists
+		}
+		return abortBatch(len(reqs), current, err), nil
+	}
+
+	results := make([]BatchResult, len(reqs))
+	for i, booking := range bookings {
+		s.notifyBooked(booking)
+		results[i].Reservation = booking.reservation
+	}
+	return results, nil
+}
+
+// CancelReservations cancels several reservations, each with the same checks as
+// DeleteReservation. In all-or-nothing mode they are cancelled in a single
+// transaction, and none is cancelled if any item fails.
+func (s *ReservationService) CancelReservations(items []CancelReservationItem, mode BatchMode, actor *Actor, reason string) ([]BatchResult, error) {
+	if err := ValidateBatch(mode, len(items)); err != nil {
+		return nil, err
+	}
+
+	if mode == BatchBestEffort {
+		results := make([]BatchResult, len(items))
+		for i, item := range items {
+			if err := s.DeleteReservation(item.ID, item.Version, actor, reason); err != nil {
+				results[i].Err = err
+				continue
+			}
+			results[i].Reservation, results[i].Err = s.reservationRepo.FindByID(item.ID)
+		}
+		return results, nil
+	}
+
+	reservations := make([]*entities.Reservation, len(items))
+	for i, item := range items {
+		reservation, err := s.reservationRepo.FindByID(item.ID)
+		if err != nil {
+			return abortBatch(len(items), i, ErrReservationNotFound), nil
+		}
+		if !actor.CanManage(reservation.UserID) {
+			return abortBatch(len(items), i, ErrNotReservationOwner), nil
+		}
+		if err := checkVersion(item.Version, reservation.Version); err != nil {
+			return abortBatch(len(items), i, err), nil
+		}
+		reservations[i] = reservation
+	}
+
+	var current int
+	var cancelled []*entities.Reservation
+	err := s.txManager.WithinTransaction(func(repos repositories.TxRepositories) error {
+		for i, reservation := range reservations {
+			current = i
+			// Reload it, as cancelling an earlier item may have cancelled it
+			// with its group booking
+			fresh, err := repos.Reservations.FindByID(reservation.ID)
+			if err != nil {
+				return err
+			}
+			done, err := cancelReservation(repos, fresh, items[i].Version, actor, reason)
+			if err != nil {
+				return err
+			}
+			cancelled = append(cancelled, done...)
+			reservations[i] = fresh
+		}
+		return nil
+	})
+	if err != nil {
+		return abortBatch(len(items), current, translateVersionConflict(err)), nil
+	}
+
+	s.notifyCancelled(cancelled, actor)
+	results := make([]BatchResult, len(items))
+	for i, reservation := range reservations {
+		results[i].Reservation = reservation
+	}
+	return results, nil
+}
+
+// CancelReservationsMatching cancels, in a single transaction, every active
+// reservation matching the filter from today on, in the time zone of the map,
+// together with the rest of its group booking, and returns the cancelled
+// reservations. Past reservations and checked-in ones are left alone. The
+// caller must be allowed to manage the reservations of the map.
+func (s *ReservationService) CancelReservationsMatching(filter CancelFilter, actor *Actor, reason string) ([]*entities.Reservation, error) {
+	if filter.SpaceID == nil && filter.MapID == nil {
+		return nil, ErrCancelFilterRequired
+	}
+	mapID := filter.MapID
+	if filter.SpaceID != nil {
+		space, err := s.spaceRepo.FindByID(*filter.SpaceID)
+		if err != nil {
+			return nil, ErrSpaceNotFound
+		}
+		if filter.MapID != nil && *filter.MapID != space.MapID {
+			return nil, nil
+		}
+		mapID = &space.MapID
+	}
+	officeMap, err := s.mapRepo.FindByID(*mapID)
+	if err != nil {
+		return nil, ErrMapNotFound
+	}
+
+	from, _ := entities.LocalDay(time.Now(), officeMap.Location())
+	if filter.From != nil && filter.From.After(from) {
+		from = *filter.From
+	}
+	if filter.To != nil && filter.To.Before(from) {
+		return nil, nil
+	}
+
+	var cancelled []*entities.Reservation
+	err = s.txManager.WithinTransaction(func(repos repositories.TxRepositories) error {
+		active := entities.ReservationStatusActive
+		matching, err := repos.Reservations.FindAll(repositories.ReservationFilters{
+			SpaceID: filter.SpaceID,
+			MapID:   filter.MapID,
+			From:    &from,
+			To:      filter.To,
+			Status:  &active,
+		})
+		if err != nil {
+			return err
+		}
+
+		done := make(map[uuid.UUID]bool)
+		for _, r := range matching {
+			// Cancelled earlier with its group booking
+			if done[r.ID] || !r.IsActive() {
+				continue
+			}
+			booking, err := cancelBooking(repos, r, actor, reason)
+			if err != nil {
+				return err
+			}
+			if err := appendReservationEvent(repos, entities.WebhookReservationCancelled, booking...); err != nil {
+				return err
+			}
+			for _, b := range booking {
+				done[b.ID] = true
+			}
+			cancelled = append(cancelled, booking...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.notifyCancelled(cancelled, actor)
+	return cancelled, nil
+}
+
+// notifyCancelled tells the holders of committed cancellations about them.
+// Holders who cancelled their own reservations are not given a reason.
+func (s *ReservationService) notifyCancelled(cancelled []*entities.Reservation, actor *Actor) {
+	if s.notifier == nil {
+		return
+	}
+	var own, others []*entities.Reservation
+	for _, r := range notifiable(cancelled, actor) {
+		if cancellationReason(actor, r) == "" {
+			own = append(own, r)
+		} else {
+			others = append(others, r)
+		}
+	}
+	if len(own) > 0 {
+		s.notifier.ReservationsCancelled(own, "")
+	}
+	if len(others) > 0 {
+		s.notifier.ReservationsCancelled(others, cancellationReason(actor, others[0]))
+	}
+}
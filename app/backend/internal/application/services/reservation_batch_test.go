@@ -0,0 +1,187 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"office-reservations/internal/domain/entities"
+)
+
+func TestCancelReservationsMatching(t *testing.T) {
+	store := newFakeStore()
+	// A zone far ahead of UTC, so that its today is often not the UTC one
+	officeMap := store.addMap("Pacific/Kiritimati")
+	desk := store.addSpace(officeMap.ID, "Desk 1")
+	otherDesk := store.addSpace(store.addMap("UTC").ID, "Desk 2")
+	today, _ := entities.LocalDay(time.Now(), officeMap.Location())
+
+	reservations := map[string]*entities.Reservation{
+		"past":          store.addReservation(entities.Reservation{SpaceID: desk.ID, UserID: "alice", Date: today.AddDate(0, 0, -1)}),
+		"today":         store.addReservation(entities.Reservation{SpaceID: desk.ID, UserID: "alice", Date: today}),
+		"checked in":    store.addReservation(entities.Reservation{SpaceID: desk.ID, UserID: "bob", Date: today, Status: entities.ReservationStatusCheckedIn, StartTime: strPtr("08:00"), EndTime: strPtr("09:00")}),
+		"upcoming":      store.addReservation(entities.Reservation{SpaceID: desk.ID, UserID: "carol", Date: today.AddDate(0, 0, 3)}),
+		"other map":     store.addReservation(entities.Reservation{SpaceID: otherDesk.ID, UserID: "carol", Date: today.AddDate(0, 0, 3)}),
+		"already gone":  store.addReservation(entities.Reservation{SpaceID: desk.ID, UserID: "dave", Date: today.AddDate(0, 0, 4), Status: entities.ReservationStatusCancelled}),
+		"after the end": store.addReservation(entities.Reservation{SpaceID: desk.ID, UserID: "erin", Date: today.AddDate(0, 0, 10)}),
+	}
+
+	tests := []struct {
+		name   string
+		filter CancelFilter
+		want   []string
+	}{
+		{
+			name:   "to before today",
+			filter: CancelFilter{MapID: &officeMap.ID, From: timePtr(today.AddDate(0, 0, -30)), To: timePtr(today.AddDate(0, 0, -1))},
+		},
+		{
+			name:   "from in the past",
+			filter: CancelFilter{MapID: &officeMap.ID, From: timePtr(today.AddDate(0, 0, -30)), To: timePtr(today.AddDate(0, 0, 5))},
+			want:   []string{"today", "upcoming"},
+		},
+		{
+			name:   "open ended space",
+			filter: CancelFilter{SpaceID: &desk.ID},
+			want:   []string{"after the end"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cancelled, err := newTestReservationService(store).CancelReservationsMatching(tt.filter, &Actor{UserID: "admin", Admin: true}, "")
+			if err != nil {
+				t.Fatalf("CancelReservationsMatching() error = %v", err)
+			}
+			want := make(map[uuid.UUID]string)
+			for _, name := range tt.want {
+				want[reservations[name].ID] = name
+			}
+			if len(cancelled) != len(want) {
+				t.Errorf("CancelReservationsMatching() cancelled %d reservations, want %v", len(cancelled), tt.want)
+			}
+			for _, r := range cancelled {
+				if _, ok := want[r.ID]; !ok {
+					t.Errorf("CancelReservationsMatching() cancelled %s on %s, want only %v", r.UserID, r.Date.Format("2006-01-02"), tt.want)
+				}
+			}
+		})
+	}
+
+	for name, r := range reservations {
+		switch got := store.reservation(r.ID); name {
+		case "today", "upcoming", "after the end", "already gone":
+			if got.Status != entities.ReservationStatusCancelled {
+				t.Errorf("%s status = %s, want cancelled", name, got.Status)
+			}
+		default:
+			if got.Status != r.Status {
+				t.Errorf("%s status = %s, want it unchanged at %s", name, got.Status, r.Status)
+			}
+		}
+	}
+}
+
+func timePtr(t time.Time) *time.Time { return &t }
+
+func TestCreateReservationsCountsEarlierItems(t *testing.T) {
+	today, _ := entities.LocalDay(time.Now(), time.UTC)
+	untilMonday := (8 - int(today.Weekday())) % 7
+	if untilMonday == 0 {
+		untilMonday = 7
+	}
+	monday := today.AddDate(0, 0, untilMonday)
+
+	type item struct {
+		user string
+		day  int
+	}
+	tests := []struct {
+		name       string
+		policy     entities.BookingPolicy
+		mode       BatchMode
+		items      []item
+		wantFailed map[int]entities.PolicyRule
+		wantBooked int
+	}{
+		{
+			name:       "weekly limit across the batch",
+			policy:     entities.BookingPolicy{MaxBookingsPerWeek: intPtr(2)},
+			mode:       BatchAllOrNothing,
+			items:      []item{{"alice", 0}, {"alice", 2}, {"alice", 4}},
+			wantFailed: map[int]entities.PolicyRule{2: entities.RuleMaxBookingsPerWeek},
+		},
+		{
+			name:       "consecutive days across the batch",
+			policy:     entities.BookingPolicy{MaxConsecutiveDays: intPtr(2)},
+			mode:       BatchAllOrNothing,
+			items:      []item{{"alice", 1}, {"alice", 2}, {"alice", 0}},
+			wantFailed: map[int]entities.PolicyRule{2: entities.RuleMaxConsecutiveDays},
+		},
+		{
+			name:       "items of other users",
+			policy:     entities.BookingPolicy{MaxBookingsPerWeek: intPtr(1)},
+			mode:       BatchAllOrNothing,
+			items:      []item{{"alice", 0}, {"bob", 0}, {"carol", 0}},
+			wantBooked: 3,
+		},
+		{
+			name:       "another week",
+			policy:     entities.BookingPolicy{MaxBookingsPerWeek: intPtr(1)},
+			mode:       BatchAllOrNothing,
+			items:      []item{{"alice", 0}, {"alice", 7}},
+			wantBooked: 2,
+		},
+		{
+			name:       "best effort",
+			policy:     entities.BookingPolicy{MaxBookingsPerWeek: intPtr(2)},
+			mode:       BatchBestEffort,
+			items:      []item{{"alice", 0}, {"alice", 2}, {"alice", 4}},
+			wantFailed: map[int]entities.PolicyRule{2: entities.RuleMaxBookingsPerWeek},
+			wantBooked: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newFakeStore()
+			officeMap := store.addMap("UTC")
+			policy := tt.policy
+			policy.ID, policy.Name, policy.MaxAdvanceDays = uuid.New(), "Limits", intPtr(30)
+			store.policies[policy.ID] = policy
+
+			reqs := make([]CreateReservationRequest, len(tt.items))
+			for i, it := range tt.items {
+				desk := store.addSpace(officeMap.ID, it.user)
+				reqs[i] = CreateReservationRequest{
+					SpaceID:  desk.ID,
+					UserID:   it.user,
+					UserName: it.user,
+					Date:     monday.AddDate(0, 0, it.day),
+					Actor:    &Actor{UserID: it.user, UserName: it.user},
+				}
+			}
+
+			results, err := newTestReservationService(store).CreateReservations(reqs, tt.mode)
+			if err != nil {
+				t.Fatalf("CreateReservations() error = %v", err)
+			}
+			for i, result := range results {
+				rule, wantFailure := tt.wantFailed[i]
+				var violation *PolicyViolationError
+				switch {
+				case wantFailure && !(errors.As(result.Err, &violation) && violation.breaks(rule)):
+					t.Errorf("item %d error = %v, want a %s violation", i, result.Err, rule)
+				case !wantFailure && len(tt.wantFailed) > 0 && tt.mode == BatchAllOrNothing && result.Err != ErrBatchAborted:
+					t.Errorf("item %d error = %v, want %v", i, result.Err, ErrBatchAborted)
+				case !wantFailure && (len(tt.wantFailed) == 0 || tt.mode == BatchBestEffort) && result.Err != nil:
+					t.Errorf("item %d error = %v, want none", i, result.Err)
+				}
+			}
+			if len(store.reservations) != tt.wantBooked {
+				t.Errorf("CreateReservations() stored %d reservations, want %d", len(store.reservations), tt.wantBooked)
+			}
+		})
+	}
+}
@@ -0,0 +1,751 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"office-reservations/internal/domain/entities"
+	"office-reservations/internal/domain/repositories"
+)
+
+var (
+	ErrSeriesNotFound        = errors.New("reservation series not found")
+	ErrSeriesCancelled       = errors.New("reservation series is cancelled")
+	ErrInvalidSeriesScope    = errors.New("invalid scope (use occurrence, following or series)")
+	ErrOccurrenceNotFound    = errors.New("date is not an occurrence of the series")
+	ErrOccurrenceDateMissing = errors.New("occurrence date is required for this scope")
+)
+
+// SeriesScope selects which occurrences an edit or cancellation applies to
+type SeriesScope string
+
+const (
+	SeriesScopeOccurrence SeriesScope = "occurrence"
+	SeriesScopeFollowing  SeriesScope = "following"
+	SeriesScopeSeries     SeriesScope = "series"
+)
+
+// Reasons recorded in the audit log for the occurrences the series changes
+const (
+	seriesOccurrenceReason = "Occurrence of a recurring series"
+	seriesUpdateReason     = "Changed with its recurring series"
+	seriesCancelReason     = "Cancelled with its recurring series"
+)
+
+// OccurrenceStatus describes what happened to a single occurrence
+type OccurrenceStatus string
+
+const (
+	OccurrenceCreated  OccurrenceStatus = "created"
+	OccurrenceUpdated  OccurrenceStatus = "updated"
+	OccurrenceExisting OccurrenceStatus = "existing"
+	OccurrenceSkipped  OccurrenceStatus = "skipped"
+	OccurrenceConflict OccurrenceStatus = "conflict"
+	OccurrenceClosed   OccurrenceStatus = "closed"
+	OccurrenceFailed   OccurrenceStatus = "failed"
+)
+
+// OccurrenceResult reports the outcome of materializing or updating one occurrence
+type OccurrenceResult struct {
+	Date        time.Time
+	Status      OccurrenceStatus
+	Reservation *entities.Reservation
+	Conflicts   []*entities.Reservation
+	Err         error
+}
+
+// ReservationSeriesService handles recurring reservation business logic.
+// Occurrences are materialized as regular reservations once they enter the
+// booking window, so every occurrence goes through ReservationService validation.
+type ReservationSeriesService struct {
+	seriesRepo         repositories.ReservationSeriesRepository
+	reservationRepo    repositories.ReservationRepository
+	spaceRepo          repositories.SpaceRepository
+	mapRepo            repositories.OfficeMapRepository
+	reservationService *ReservationService
+}
+
+// NewReservationSeriesService creates a new reservation series service
+func NewReservationSeriesService(
+	seriesRepo repositories.ReservationSeriesRepository,
+	reservationRepo repositories.ReservationRepository,
+	spaceRepo repositories.SpaceRepository,
+	mapRepo repositories.OfficeMapRepository,
+	reservationService *ReservationService,
+) *ReservationSeriesService {
+	return &ReservationSeriesService{
+		seriesRepo:         seriesRepo,
+		reservationRepo:    reservationRepo,
+		spaceRepo:          spaceRepo,
+		mapRepo:            mapRepo,
+		reservationService: reservationService,
+	}
+}
+
+// CreateSeriesRequest represents the input for creating a reservation series
+type CreateSeriesRequest struct {
+	SpaceID   uuid.UUID
+	UserID    string
+	UserName  string
+	StartDate time.Time
+	StartTime *string
+	EndTime   *string
+	RRule     string
+	Notes     string
+	// Actor is the user making the request; every occurrence is booked on
+	// their behalf, under the booking policies of their roles
+	Actor *Actor
+}
+
+// CreateSeries creates a new series and materializes the occurrences that fall
+// within the booking window. The series is discarded if they cannot be
+// materialized.
+func (s *ReservationSeriesService) CreateSeries(req CreateSeriesRequest) (*entities.ReservationSeries, []OccurrenceResult, error) {
+	rule, err := entities.ParseRecurrenceRule(req.RRule)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	today := s.today(req.SpaceID)
+	if req.StartDate.Before(today) {
+		return nil, nil, ErrDateInPast
+	}
+
+	if _, err := s.spaceRepo.FindByID(req.SpaceID); err != nil {
+		return nil, nil, ErrSpaceNotFound
+	}
+
+	if err := validateTimeRange(req.StartTime, req.EndTime); err != nil {
+		return nil, nil, err
+	}
+
+	series := &entities.ReservationSeries{
+		ID:        uuid.New(),
+		SpaceID:   req.SpaceID,
+		UserID:    req.UserID,
+		UserName:  req.UserName,
+		StartDate: req.StartDate,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+		Rule:      rule,
+		Notes:     req.Notes,
+		Status:    entities.SeriesStatusActive,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if req.Actor != nil {
+		series.BookedBy = &entities.SeriesBooker{
+			UserID:   req.Actor.UserID,
+			UserName: req.Actor.UserName,
+			Admin:    req.Actor.Admin,
+			Roles:    req.Actor.Roles,
+		}
+	}
+
+	if err := s.seriesRepo.Create(series); err != nil {
+		return nil, nil, err
+	}
+
+	results, err := s.materialize(series)
+	if err != nil {
+		// Nothing was booked; leaving the series would let the materializer
+		// book it later although the request failed
+		if err := s.seriesRepo.Delete(series.ID); err != nil {
+			log.Printf("Failed to discard series %s: %v", series.ID, err)
+		}
+		return nil, nil, err
+	}
+
+	return series, results, nil
+}
+
+// GetSeries retrieves a series by ID
+func (s *ReservationSeriesService) GetSeries(id uuid.UUID) (*entities.ReservationSeries, error) {
+	series, err := s.seriesRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrSeriesNotFound
+	}
+	return series, nil
+}
+
+// GetSeriesReservations retrieves the materialized occurrences of a series
+func (s *ReservationSeriesService) GetSeriesReservations(id uuid.UUID) ([]*entities.Reservation, error) {
+	return s.reservationRepo.FindAll(repositories.ReservationFilters{SeriesID: &id})
+}
+
+// UpdateSeriesRequest represents the input for editing a series
+type UpdateSeriesRequest struct {
+	SeriesID       uuid.UUID
+	Scope          SeriesScope
+	OccurrenceDate *time.Time
+	StartTime      *string
+	EndTime        *string
+	Notes          *string
+	// Actor is the user making the request, or nil for internal callers
+	Actor *Actor
+}
+
+// UpdateSeries edits a single occurrence, an occurrence and all following ones,
+// or the whole series. Editing "following" splits the series in two and returns
+// the new series.
+func (s *ReservationSeriesService) UpdateSeries(req UpdateSeriesRequest) (*entities.ReservationSeries, []OccurrenceResult, error) {
+	series, err := s.activeSeries(req.SeriesID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !req.Actor.CanManage(series.UserID) {
+		return nil, nil, ErrNotReservationOwner
+	}
+
+	switch req.Scope {
+	case SeriesScopeOccurrence:
+		date, err := s.occurrenceDate(series, req.OccurrenceDate)
+		if err != nil {
+			return nil, nil, err
+		}
+		result, err := s.updateOccurrence(series, date, req)
+		if err != nil {
+			return nil, nil, err
+		}
+		return series, []OccurrenceResult{result}, nil
+
+	case SeriesScopeFollowing:
+		date, err := s.occurrenceDate(series, req.OccurrenceDate)
+		if err != nil {
+			return nil, nil, err
+		}
+		if date.After(series.StartDate) {
+			return s.splitSeries(series, date, req)
+		}
+		// Editing from the first occurrence onwards is the same as editing the series
+		return s.updateWholeSeries(series, req)
+
+	case SeriesScopeSeries:
+		return s.updateWholeSeries(series, req)
+
+	default:
+		return nil, nil, ErrInvalidSeriesScope
+	}
+}
+
+// CancelSeriesRequest represents the input for cancelling occurrences of a series
+type CancelSeriesRequest struct {
+	SeriesID       uuid.UUID
+	Scope          SeriesScope
+	OccurrenceDate *time.Time
+	// Actor is the user making the request, or nil for internal callers
+	Actor *Actor
+}
+
+// CancelSeries cancels a single occurrence, an occurrence and all following ones,
+// or the whole series. Past occurrences are never modified.
+func (s *ReservationSeriesService) CancelSeries(req CancelSeriesRequest) error {
+	series, err := s.activeSeries(req.SeriesID)
+	if err != nil {
+		return err
+	}
+
+	if !req.Actor.CanManage(series.UserID) {
+		return ErrNotReservationOwner
+	}
+
+	switch req.Scope {
+	case SeriesScopeOccurrence:
+		date, err := s.occurrenceDate(series, req.OccurrenceDate)
+		if err != nil {
+			return err
+		}
+		exception := series.ExceptionFor(date)
+		if exception == nil {
+			exception = &entities.SeriesException{SeriesID: series.ID, OccurrenceDate: date, CreatedAt: time.Now()}
+		}
+		exception.Cancelled = true
+		exception.UpdatedAt = time.Now()
+		if err := s.seriesRepo.SaveException(exception); err != nil {
+			return err
+		}
+		return s.cancelOccurrences(series.ID, date, date)
+
+	case SeriesScopeFollowing:
+		date, err := s.occurrenceDate(series, req.OccurrenceDate)
+		if err != nil {
+			return err
+		}
+		if date.After(series.StartDate) {
+			s.truncateSeries(series, date)
+			series.UpdatedAt = time.Now()
+			if err := s.seriesRepo.Update(series); err != nil {
+				return err
+			}
+			return s.cancelOccurrences(series.ID, date, time.Time{})
+		}
+		return s.cancelWholeSeries(series)
+
+	case SeriesScopeSeries:
+		return s.cancelWholeSeries(series)
+
+	default:
+		return ErrInvalidSeriesScope
+	}
+}
+
+// MaterializeAll materializes the occurrences of every active series that have
+// entered the booking window. It is safe to run repeatedly.
+func (s *ReservationSeriesService) MaterializeAll() error {
+	seriesList, err := s.seriesRepo.FindActive()
+	if err != nil {
+		return err
+	}
+
+	for _, series := range seriesList {
+		if series.Rule == nil {
+			log.Printf("Skipping series %s: invalid recurrence rule", series.ID)
+			continue
+		}
+		results, err := s.materialize(series)
+		if err != nil {
+			log.Printf("Failed to materialize series %s: %v", series.ID, err)
+			continue
+		}
+		for _, r := range results {
+			if r.Status == OccurrenceConflict || r.Status == OccurrenceClosed || r.Status == OccurrenceFailed {
+				log.Printf("Series %s occurrence %s not booked: %s", series.ID, r.Date.Format("2006-01-02"), r.Status)
+			}
+		}
+	}
+
+	return nil
+}
+
+// RunMaterializer materializes series occurrences every interval until ctx is done,
+// rolling the booking window forward day by day
+func (s *ReservationSeriesService) RunMaterializer(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.MaterializeAll(); err != nil {
+			log.Printf("Series materializer error: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// materialize books every occurrence in the booking window that has no
+// reservation yet, as the user who created the series. Cancelled reservations of
+// the series count as existing so an occurrence cancelled directly is not booked
+// again.
+func (s *ReservationSeriesService) materialize(series *entities.ReservationSeries) ([]OccurrenceResult, error) {
+	from, to, err := s.bookingWindow(series)
+	if err != nil {
+		return nil, err
+	}
+	dates := series.Occurrences(from, to)
+	if len(dates) == 0 {
+		return []OccurrenceResult{}, nil
+	}
+
+	existing, err := s.occurrenceReservations(series.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	actor := seriesActor(series)
+	results := make([]OccurrenceResult, 0, len(dates))
+	for _, date := range dates {
+		key := date.Format("2006-01-02")
+		if r, ok := existing[key]; ok {
+			results = append(results, OccurrenceResult{Date: date, Status: OccurrenceExisting, Reservation: r})
+			continue
+		}
+
+		exception := series.ExceptionFor(date)
+		if exception != nil && exception.Cancelled {
+			results = append(results, OccurrenceResult{Date: date, Status: OccurrenceSkipped})
+			continue
+		}
+
+		startTime, endTime, notes := occurrenceValues(series, exception)
+		occurrenceDate := date
+		reservation, err := s.reservationService.CreateReservation(CreateReservationRequest{
+			SpaceID:        series.SpaceID,
+			UserID:         series.UserID,
+			UserName:       series.UserName,
+			Date:           date,
+			StartTime:      startTime,
+			EndTime:        endTime,
+			Notes:          notes,
+			SeriesID:       &series.ID,
+			OccurrenceDate: &occurrenceDate,
+			Actor:          actor,
+			Reason:         seriesOccurrenceReason,
+		})
+		results = append(results, occurrenceResult(date, OccurrenceCreated, reservation, err))
+	}
+
+	return results, nil
+}
+
+// updateOccurrence records an override for one occurrence and applies it to the
+// materialized reservation if there is one
+func (s *ReservationSeriesService) updateOccurrence(series *entities.ReservationSeries, date time.Time, req UpdateSeriesRequest) (OccurrenceResult, error) {
+	exception := series.ExceptionFor(date)
+	if exception == nil {
+		exception = &entities.SeriesException{SeriesID: series.ID, OccurrenceDate: date, CreatedAt: time.Now()}
+	}
+	if req.StartTime != nil {
+		exception.StartTime = req.StartTime
+	}
+	if req.EndTime != nil {
+		exception.EndTime = req.EndTime
+	}
+	if req.Notes != nil {
+		exception.Notes = req.Notes
+	}
+	exception.UpdatedAt = time.Now()
+
+	startTime, endTime, _ := occurrenceValues(series, exception)
+	if err := validateTimeRange(startTime, endTime); err != nil {
+		return OccurrenceResult{}, err
+	}
+
+	existing, err := s.occurrenceReservations(series.ID)
+	if err != nil {
+		return OccurrenceResult{}, err
+	}
+
+	result := OccurrenceResult{Date: date, Status: OccurrenceUpdated}
+	if r, ok := existing[date.Format("2006-01-02")]; ok && r.IsActive() {
+		updated, err := s.reservationService.UpdateReservation(UpdateReservationRequest{
+			ID:        r.ID,
+			StartTime: req.StartTime,
+			EndTime:   req.EndTime,
+			Notes:     req.Notes,
+			Actor:     req.Actor,
+			Reason:    seriesUpdateReason,
+		})
+		result = occurrenceResult(date, OccurrenceUpdated, updated, err)
+		if result.Status != OccurrenceUpdated {
+			return result, nil
+		}
+	}
+
+	if err := s.seriesRepo.SaveException(exception); err != nil {
+		return OccurrenceResult{}, err
+	}
+	return result, nil
+}
+
+// updateWholeSeries changes the series defaults and re-applies them to every
+// upcoming materialized occurrence. Per-occurrence overrides are kept.
+func (s *ReservationSeriesService) updateWholeSeries(series *entities.ReservationSeries, req UpdateSeriesRequest) (*entities.ReservationSeries, []OccurrenceResult, error) {
+	if req.StartTime != nil {
+		series.StartTime = req.StartTime
+	}
+	if req.EndTime != nil {
+		series.EndTime = req.EndTime
+	}
+	if req.Notes != nil {
+		series.Notes = *req.Notes
+	}
+	if err := validateTimeRange(series.StartTime, series.EndTime); err != nil {
+		return nil, nil, err
+	}
+
+	series.UpdatedAt = time.Now()
+	if err := s.seriesRepo.Update(series); err != nil {
+		return nil, nil, err
+	}
+
+	reservations, err := s.GetSeriesReservations(series.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	today := s.today(series.SpaceID)
+	var results []OccurrenceResult
+	for _, r := range reservations {
+		if !r.IsActive() || r.Date.Before(today) || r.OccurrenceDate == nil {
+			continue
+		}
+		startTime, endTime, notes := occurrenceValues(series, series.ExceptionFor(*r.OccurrenceDate))
+		updated, err := s.reservationService.UpdateReservation(UpdateReservationRequest{
+			ID:        r.ID,
+			StartTime: startTime,
+			EndTime:   endTime,
+			Notes:     &notes,
+			Actor:     req.Actor,
+			Reason:    seriesUpdateReason,
+		})
+		results = append(results, occurrenceResult(*r.OccurrenceDate, OccurrenceUpdated, updated, err))
+	}
+
+	materialized, err := s.materialize(series)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, m := range materialized {
+		if m.Status != OccurrenceExisting {
+			results = append(results, m)
+		}
+	}
+
+	return series, results, nil
+}
+
+// splitSeries ends the series before date and continues it as a new series
+// carrying the requested changes
+func (s *ReservationSeriesService) splitSeries(series *entities.ReservationSeries, date time.Time, req UpdateSeriesRequest) (*entities.ReservationSeries, []OccurrenceResult, error) {
+	continuation := &entities.ReservationSeries{
+		ID:        uuid.New(),
+		SpaceID:   series.SpaceID,
+		UserID:    series.UserID,
+		UserName:  series.UserName,
+		StartDate: date,
+		StartTime: series.StartTime,
+		EndTime:   series.EndTime,
+		Notes:     series.Notes,
+		Status:    entities.SeriesStatusActive,
+		BookedBy:  series.BookedBy,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if req.StartTime != nil {
+		continuation.StartTime = req.StartTime
+	}
+	if req.EndTime != nil {
+		continuation.EndTime = req.EndTime
+	}
+	if req.Notes != nil {
+		continuation.Notes = *req.Notes
+	}
+	if err := validateTimeRange(continuation.StartTime, continuation.EndTime); err != nil {
+		return nil, nil, err
+	}
+
+	rule := *series.Rule
+	if rule.Count != nil {
+		remaining := *rule.Count - s.truncateSeries(series, date)
+		rule.Count = &remaining
+	} else {
+		s.truncateSeries(series, date)
+	}
+	continuation.Rule = &rule
+
+	series.UpdatedAt = time.Now()
+	if err := s.seriesRepo.Update(series); err != nil {
+		return nil, nil, err
+	}
+	if err := s.cancelOccurrences(series.ID, date, time.Time{}); err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.seriesRepo.Create(continuation); err != nil {
+		return nil, nil, err
+	}
+	// Carry over exceptions that belong to the continued part of the series
+	for _, e := range series.Exceptions {
+		if e.OccurrenceDate.Before(date) {
+			continue
+		}
+		moved := *e
+		moved.SeriesID = continuation.ID
+		if err := s.seriesRepo.SaveException(&moved); err != nil {
+			return nil, nil, err
+		}
+		continuation.Exceptions = append(continuation.Exceptions, &moved)
+	}
+
+	results, err := s.materialize(continuation)
+	if err != nil {
+		return nil, nil, err
+	}
+	return continuation, results, nil
+}
+
+// truncateSeries makes the series end on the day before date and returns the
+// number of occurrences it keeps
+func (s *ReservationSeriesService) truncateSeries(series *entities.ReservationSeries, date time.Time) int {
+	lastDay := date.AddDate(0, 0, -1)
+	kept := len(series.Rule.Occurrences(series.StartDate, series.StartDate, lastDay))
+
+	rule := *series.Rule
+	if rule.Count != nil {
+		rule.Count = &kept
+	} else {
+		rule.Until = &lastDay
+	}
+	series.Rule = &rule
+	return kept
+}
+
+// cancelWholeSeries cancels the series and all of its upcoming occurrences
+func (s *ReservationSeriesService) cancelWholeSeries(series *entities.ReservationSeries) error {
+	series.Status = entities.SeriesStatusCancelled
+	series.UpdatedAt = time.Now()
+	if err := s.seriesRepo.Update(series); err != nil {
+		return err
+	}
+	today := s.today(series.SpaceID)
+	return s.cancelOccurrences(series.ID, today, time.Time{})
+}
+
+// cancelOccurrences cancels the active materialized occurrences of a series whose
+// occurrence date lies in [from, to]. A zero to means no upper bound.
+func (s *ReservationSeriesService) cancelOccurrences(seriesID uuid.UUID, from, to time.Time) error {
+	reservations, err := s.GetSeriesReservations(seriesID)
+	if err != nil {
+		return err
+	}
+	for _, r := range reservations {
+		if !r.IsActive() || r.OccurrenceDate == nil {
+			continue
+		}
+		if r.OccurrenceDate.Before(from) || (!to.IsZero() && r.OccurrenceDate.After(to)) {
+			continue
+		}
+		// Cancelled through the reservation service so that webhooks are told
+		if err := s.reservationService.DeleteReservation(r.ID, nil, nil, seriesCancelReason); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// activeSeries loads a series and ensures it can still be modified
+func (s *ReservationSeriesService) activeSeries(id uuid.UUID) (*entities.ReservationSeries, error) {
+	series, err := s.GetSeries(id)
+	if err != nil {
+		return nil, err
+	}
+	if !series.IsActive() {
+		return nil, ErrSeriesCancelled
+	}
+	if series.Rule == nil {
+		return nil, entities.ErrInvalidRecurrenceRule
+	}
+	return series, nil
+}
+
+// occurrenceDate validates that date is a scheduled, non-past occurrence of the series
+func (s *ReservationSeriesService) occurrenceDate(series *entities.ReservationSeries, date *time.Time) (time.Time, error) {
+	if date == nil {
+		return time.Time{}, ErrOccurrenceDateMissing
+	}
+	today := s.today(series.SpaceID)
+	if date.Before(today) {
+		return time.Time{}, ErrDateInPast
+	}
+	if len(series.Occurrences(*date, *date)) == 0 {
+		return time.Time{}, ErrOccurrenceNotFound
+	}
+	return *date, nil
+}
+
+// occurrenceReservations returns the series reservations keyed by occurrence date
+func (s *ReservationSeriesService) occurrenceReservations(seriesID uuid.UUID) (map[string]*entities.Reservation, error) {
+	reservations, err := s.GetSeriesReservations(seriesID)
+	if err != nil {
+		return nil, err
+	}
+	byDate := make(map[string]*entities.Reservation, len(reservations))
+	for _, r := range reservations {
+		if r.OccurrenceDate == nil {
+			continue
+		}
+		key := r.OccurrenceDate.Format("2006-01-02")
+		// Prefer the active reservation when an occurrence was re-booked
+		if current, ok := byDate[key]; ok && current.IsActive() {
+			continue
+		}
+		byDate[key] = r
+	}
+	return byDate, nil
+}
+
+// occurrenceValues returns the times and notes of an occurrence after applying
+// its exception, if any
+func occurrenceValues(series *entities.ReservationSeries, exception *entities.SeriesException) (*string, *string, string) {
+	startTime, endTime, notes := series.StartTime, series.EndTime, series.Notes
+	if exception != nil {
+		if exception.StartTime != nil {
+			startTime = exception.StartTime
+		}
+		if exception.EndTime != nil {
+			endTime = exception.EndTime
+		}
+		if exception.Notes != nil {
+			notes = *exception.Notes
+		}
+	}
+	return startTime, endTime, notes
+}
+
+// occurrenceResult converts the outcome of a reservation create or update into
+// an occurrence result
+func occurrenceResult(date time.Time, success OccurrenceStatus, reservation *entities.Reservation, err error) OccurrenceResult {
+	if err == nil {
+		return OccurrenceResult{Date: date, Status: success, Reservation: reservation}
+	}
+	var conflictErr *ConflictError
+	if errors.As(err, &conflictErr) {
+		return OccurrenceResult{Date: date, Status: OccurrenceConflict, Conflicts: conflictErr.Conflicts, Err: err}
+	}
+	if errors.Is(err, ErrReservationAlreadyExists) {
+		return OccurrenceResult{Date: date, Status: OccurrenceConflict, Err: err}
+	}
+	if errors.Is(err, ErrSpaceClosed) {
+		return OccurrenceResult{Date: date, Status: OccurrenceClosed, Err: err}
+	}
+	return OccurrenceResult{Date: date, Status: OccurrenceFailed, Err: err}
+}
+
+// seriesActor returns the actor the occurrences of a series are booked as: the
+// user who created it or, for series created before that was recorded, its
+// holder without any role
+func seriesActor(series *entities.ReservationSeries) *Actor {
+	if series.BookedBy == nil {
+		return &Actor{UserID: series.UserID, UserName: series.UserName}
+	}
+	return &Actor{
+		UserID:   series.BookedBy.UserID,
+		UserName: series.BookedBy.UserName,
+		Admin:    series.BookedBy.Admin,
+		Roles:    series.BookedBy.Roles,
+	}
+}
+
+// today returns the current date in the time zone of the office map of a space
+func (s *ReservationSeriesService) today(spaceID uuid.UUID) time.Time {
+	loc := time.UTC
+	if _, officeMap, err := spaceWithMap(s.spaceRepo, s.mapRepo, spaceID); err == nil {
+		loc = officeMap.Location()
+	}
+	today, _ := entities.LocalDay(time.Now(), loc)
+	return today
+}
+
+// bookingWindow returns the first and last dates on which the occurrences of a
+// series can currently be booked: from today in the time zone of its office map
+// as far ahead as the booking policies allow the user who created it
+func (s *ReservationSeriesService) bookingWindow(series *entities.ReservationSeries) (time.Time, time.Time, error) {
+	space, officeMap, err := spaceWithMap(s.spaceRepo, s.mapRepo, series.SpaceID)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	advanceDays, err := s.reservationService.policyService.AdvanceDays(space, seriesActor(series))
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	today, _ := entities.LocalDay(time.Now(), officeMap.Location())
+	return today, today.AddDate(0, 0, advanceDays), nil
+}
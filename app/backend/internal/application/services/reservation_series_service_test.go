@@ -0,0 +1,149 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"office-reservations/internal/domain/entities"
+)
+
+func newTestSeriesService(store *fakeStore) *ReservationSeriesService {
+	repos := store.fakeRepos()
+	return NewReservationSeriesService(repos.Series, repos.Reservations, repos.Spaces, repos.Maps, newTestReservationService(store))
+}
+
+func TestUpdateSeries(t *testing.T) {
+	tests := []struct {
+		name        string
+		scope       SeriesScope
+		wantUpdated int
+	}{
+		{name: "occurrence", scope: SeriesScopeOccurrence, wantUpdated: 1},
+		{name: "whole series", scope: SeriesScopeSeries, wantUpdated: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newFakeStore()
+			officeMap := store.addMap("UTC")
+			desk := store.addSpace(officeMap.ID, "Desk 1")
+			today, _ := entities.LocalDay(time.Now(), time.UTC)
+			alice := &Actor{UserID: "alice", UserName: "Alice"}
+
+			service := newTestSeriesService(store)
+			series, results, err := service.CreateSeries(CreateSeriesRequest{
+				SpaceID:   desk.ID,
+				UserID:    "alice",
+				UserName:  "Alice",
+				StartDate: today.AddDate(0, 0, 1),
+				RRule:     "FREQ=DAILY;COUNT=3",
+				Actor:     alice,
+			})
+			if err != nil {
+				t.Fatalf("CreateSeries() error = %v", err)
+			}
+			if len(results) != 3 {
+				t.Fatalf("CreateSeries() materialized %d occurrences, want 3", len(results))
+			}
+
+			// Changed by an admin, who is recorded as the actor of the changes
+			notes := "Bring a laptop"
+			occurrence := today.AddDate(0, 0, 2)
+			_, _, err = service.UpdateSeries(UpdateSeriesRequest{
+				SeriesID:       series.ID,
+				Scope:          tt.scope,
+				OccurrenceDate: &occurrence,
+				Notes:          &notes,
+				Actor:          &Actor{UserID: "admin", UserName: "Admin", Admin: true},
+			})
+			if err != nil {
+				t.Fatalf("UpdateSeries() error = %v", err)
+			}
+
+			updated := 0
+			for _, result := range results {
+				events := store.events(result.Reservation.ID)
+				if len(events) == 0 || events[0] != entities.WebhookReservationCreated {
+					t.Errorf("occurrence %s events = %v, want reservation.created first", result.Date.Format("2006-01-02"), events)
+				}
+				for _, e := range events[1:] {
+					if e == entities.WebhookReservationUpdated {
+						updated++
+					}
+				}
+				if got := store.reservation(result.Reservation.ID); len(events) > 1 && got.Notes != notes {
+					t.Errorf("occurrence %s notes = %q, want %q", result.Date.Format("2006-01-02"), got.Notes, notes)
+				}
+				for _, entry := range store.auditEntries(result.Reservation.ID) {
+					if entry.Action == entities.AuditActionUpdate && entry.ActorID != "admin" {
+						t.Errorf("occurrence %s updated by %q, want admin", result.Date.Format("2006-01-02"), entry.ActorID)
+					}
+				}
+			}
+			if updated != tt.wantUpdated {
+				t.Errorf("UpdateSeries() appended %d reservation.updated events, want %d", updated, tt.wantUpdated)
+			}
+		})
+	}
+}
+
+func TestSeriesBookingWindow(t *testing.T) {
+	manager := "manager"
+
+	tests := []struct {
+		name     string
+		policies []entities.BookingPolicy
+		roles    []string
+		// wantBooked is how many daily occurrences from tomorrow on are booked
+		wantBooked int
+	}{
+		{name: "default window", wantBooked: maxAdvanceDays},
+		{name: "longer window", policies: []entities.BookingPolicy{{MaxAdvanceDays: intPtr(14)}}, wantBooked: 14},
+		{name: "shorter window", policies: []entities.BookingPolicy{{MaxAdvanceDays: intPtr(3)}}, wantBooked: 3},
+		{
+			name:       "window of the creator's role",
+			policies:   []entities.BookingPolicy{{MaxAdvanceDays: intPtr(3)}, {Role: &manager, MaxAdvanceDays: intPtr(10)}},
+			roles:      []string{manager},
+			wantBooked: 10,
+		},
+		{
+			name:       "window of another role",
+			policies:   []entities.BookingPolicy{{MaxAdvanceDays: intPtr(3)}, {Role: &manager, MaxAdvanceDays: intPtr(10)}},
+			wantBooked: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newFakeStore()
+			officeMap := store.addMap("UTC")
+			desk := store.addSpace(officeMap.ID, "Desk 1")
+			for _, policy := range tt.policies {
+				policy.ID, policy.Name = uuid.New(), "Advance window"
+				store.policies[policy.ID] = policy
+			}
+			today, _ := entities.LocalDay(time.Now(), time.UTC)
+
+			_, results, err := newTestSeriesService(store).CreateSeries(CreateSeriesRequest{
+				SpaceID:   desk.ID,
+				UserID:    "alice",
+				UserName:  "Alice",
+				StartDate: today.AddDate(0, 0, 1),
+				RRule:     "FREQ=DAILY;COUNT=30",
+				Actor:     &Actor{UserID: "alice", UserName: "Alice", Roles: tt.roles},
+			})
+			if err != nil {
+				t.Fatalf("CreateSeries() error = %v", err)
+			}
+			if len(results) != tt.wantBooked {
+				t.Errorf("CreateSeries() materialized %d occurrences, want %d", len(results), tt.wantBooked)
+			}
+			for _, result := range results {
+				if result.Status != OccurrenceCreated {
+					t.Errorf("occurrence %s status = %s, want created", result.Date.Format("2006-01-02"), result.Status)
+				}
+			}
+		})
+	}
+}
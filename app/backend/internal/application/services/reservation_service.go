@@ -2,6 +2,7 @@ package services
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,29 +16,65 @@ var (
 	ErrInvalidDate              = errors.New("invalid date")
 	ErrInvalidTime              = errors.New("invalid time")
 	ErrDateInPast               = errors.New("cannot reserve dates in the past")
-	ErrDateTooFarInFuture       = errors.New("cannot reserve more than 1 week in advance")
+	ErrDateTooFarInFuture       = errors.New("cannot reserve that far in advance")
 	ErrStartTimeAfterEndTime    = errors.New("start time must be before end time")
 	ErrReservationAlreadyExists = errors.New("space is already reserved for this time slot")
 	ErrCannotUpdateCancelled    = errors.New("cannot update cancelled reservation")
+	ErrInvalidStatusChange      = errors.New("reservation cannot be moved to that status")
 )
 
 // ReservationService handles reservation business logic
 type ReservationService struct {
 	reservationRepo repositories.ReservationRepository
 	spaceRepo       repositories.SpaceRepository
+	mapRepo         repositories.OfficeMapRepository
+	txManager       repositories.TransactionManager
+	policyService   *BookingPolicyService
+	notifier        ReservationNotifier
+}
+
+// ReservationNotifier is told about reservations that were created, changed or
+// cancelled once the change is committed, so that their holders can be informed
+type ReservationNotifier interface {
+	CancellationNotifier
+	ReservationsCreated(reservations []*entities.Reservation)
+	ReservationsUpdated(reservations []*entities.Reservation)
 }
 
 // NewReservationService creates a new reservation service
 func NewReservationService(
 	reservationRepo repositories.ReservationRepository,
 	spaceRepo repositories.SpaceRepository,
+	mapRepo repositories.OfficeMapRepository,
+	txManager repositories.TransactionManager,
+	policyService *BookingPolicyService,
+	notifier ReservationNotifier,
 ) *ReservationService {
 	return &ReservationService{
 		reservationRepo: reservationRepo,
 		spaceRepo:       spaceRepo,
+		mapRepo:         mapRepo,
+		txManager:       txManager,
+		policyService:   policyService,
+		notifier:        notifier,
 	}
 }
 
+// ConflictError is returned when a reservation overlaps existing active reservations.
+// It matches ErrReservationAlreadyExists with errors.Is.
+type ConflictError struct {
+	Conflicts []*entities.Reservation
+}
+
+func (e *ConflictError) Error() string {
+	return ErrReservationAlreadyExists.Error()
+}
+
+// Is allows errors.Is(err, ErrReservationAlreadyExists) to match a ConflictError
+func (e *ConflictError) Is(target error) bool {
+	return target == ErrReservationAlreadyExists
+}
+
 // CreateReservationRequest represents the input for creating a reservation
 type CreateReservationRequest struct {
 	SpaceID   uuid.UUID
@@ -47,93 +84,318 @@ type CreateReservationRequest struct {
 	StartTime *string
 	EndTime   *string
 	Notes     string
+	// Force cancels overlapping reservations instead of rejecting the request.
+	// It is reserved for privileged callers.
+	Force bool
+	// SeriesID and OccurrenceDate are set when materializing a series occurrence
+	SeriesID       *uuid.UUID
+	OccurrenceDate *time.Time
+	// Actor is the user making the request, or nil for internal callers
+	Actor *Actor
+	// Reason explains the change in the audit log
+	Reason string
 }
 
 // CreateReservation creates a new reservation with business logic validation
 func (s *ReservationService) CreateReservation(req CreateReservationRequest) (*entities.Reservation, error) {
-	// Validate date
-	now := time.Now()
-	maxDate := now.AddDate(0, 0, 7)
-	if req.Date.After(maxDate) {
-		return nil, ErrDateTooFarInFuture
-	}
-	if req.Date.Before(now.Truncate(24 * time.Hour)) {
-		return nil, ErrDateInPast
-	}
-
-	// Verify space exists
-	space, err := s.spaceRepo.FindByID(req.SpaceID)
+	booking, err := s.prepareBooking(req, nil)
 	if err != nil {
-		return nil, ErrSpaceNotFound
+		return nil, err
 	}
 
-	// Validate time format and range
-	if req.StartTime != nil {
-		if _, err := time.Parse("15:04", *req.StartTime); err != nil {
-			return nil, ErrInvalidTime
+	err = s.txManager.WithinTransaction(func(repos repositories.TxRepositories) error {
+		return booking.create(repos)
+	})
+	if err != nil {
+		if errors.Is(err, repositories.ErrReservationOverlap) {
+			return nil, ErrReservationAlreadyExists
 		}
+		return nil, err
 	}
-	if req.EndTime != nil {
-		if _, err := time.Parse("15:04", *req.EndTime); err != nil {
-			return nil, ErrInvalidTime
-		}
+
+	s.notifyBooked(booking)
+	return booking.reservation, nil
+}
+
+// newBooking is a validated request for a reservation, and once created the
+// reservations it made and those it overrode
+type newBooking struct {
+	req       CreateReservationRequest
+	space     *entities.Space
+	officeMap *entities.OfficeMap
+
+	reservation *entities.Reservation
+	created     []*entities.Reservation
+	overridden  []*entities.Reservation
+}
+
+// prepareBooking validates a request for a reservation against everything that
+// does not depend on the other reservations of the slot: the space, the time
+// range and the booking policies. pending holds the bookings accepted earlier
+// in the same batch, which the policies count as made.
+func (s *ReservationService) prepareBooking(req CreateReservationRequest, pending []*entities.Reservation) (*newBooking, error) {
+	if req.Force && !req.Actor.IsPrivileged() {
+		return nil, ErrForceRequiresAdmin
 	}
-	if req.StartTime != nil && req.EndTime != nil {
-		start, _ := time.Parse("15:04", *req.StartTime)
-		end, _ := time.Parse("15:04", *req.EndTime)
-		if !start.Before(end) {
-			return nil, ErrStartTimeAfterEndTime
-		}
+
+	// Verify space exists
+	space, officeMap, err := spaceWithMap(s.spaceRepo, s.mapRepo, req.SpaceID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Delete existing reservations for this space/date/time (overwrite behavior)
-	if err := s.deleteExistingReservations(space, req.Date, req.StartTime); err != nil {
+	// Validate time format and range
+	if err := validateTimeRange(req.StartTime, req.EndTime); err != nil {
 		return nil, err
 	}
 
-	// Create new reservation
-	reservation := &entities.Reservation{
-		ID:        uuid.New(),
-		SpaceID:   req.SpaceID,
+	// Enforce the booking policies, including the date window and opening hours
+	err = s.policyService.Check(PolicyCheck{
+		Space:     space,
+		Map:       officeMap,
 		UserID:    req.UserID,
-		UserName:  req.UserName,
 		Date:      req.Date,
 		StartTime: req.StartTime,
 		EndTime:   req.EndTime,
+		Actor:     req.Actor,
+		Pending:   pending,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &newBooking{req: req, space: space, officeMap: officeMap}, nil
+}
+
+// pending returns the reservation the booking will make on its space, for the
+// policy checks of the bookings that follow it in a batch
+func (b *newBooking) pending() *entities.Reservation {
+	return &entities.Reservation{
+		ID:        uuid.New(),
+		SpaceID:   b.space.ID,
+		UserID:    b.req.UserID,
+		Date:      b.req.Date,
+		StartTime: b.req.StartTime,
+		EndTime:   b.req.EndTime,
 		Status:    entities.ReservationStatusActive,
-		Notes:     req.Notes,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
 	}
+}
 
-	if err := s.reservationRepo.Create(reservation); err != nil {
-		return nil, err
+// create books the space, or every space of its group, within a transaction.
+// It fails if the slot is closed or taken, unless the request forces it.
+func (b *newBooking) create(repos repositories.TxRepositories) error {
+	req, space, officeMap := b.req, b.space, b.officeMap
+	timeRange, _ := entities.NewTimeRange(req.StartTime, req.EndTime)
+
+	spaceIDs, err := bookingScope(repos.Spaces, space)
+	if err != nil {
+		return err
+	}
+	if err := repos.Spaces.LockByIDs(spaceIDs); err != nil {
+		return err
 	}
 
-	return reservation, nil
-}
+	// Closures cannot be overridden, not even by privileged callers
+	closed, err := closuresBlocking(repos.Closures, space, spaceIDs, req.Date, timeRange)
+	if err != nil {
+		return err
+	}
+	if len(closed) > 0 {
+		return &ClosureConflictError{Closures: closed}
+	}
 
-// deleteExistingReservations deletes existing reservations for overwrite behavior
-func (s *ReservationService) deleteExistingReservations(space *entities.Space, date time.Time, startTime *string) error {
-	if space.IsMeetingRoom() {
-		// For meeting rooms, find all spaces in the group
-		baseName := space.GetBaseName()
-		groupSpaces, err := s.spaceRepo.FindMeetingRoomsByBaseName(baseName, space.MapID)
-		if err != nil {
+	conflicts, err := repos.Reservations.FindOverlapping(spaceIDs, req.Date, req.StartTime, req.EndTime)
+	if err != nil {
+		return err
+	}
+	if len(conflicts) > 0 {
+		if !req.Force {
+			return &ConflictError{Conflicts: conflicts}
+		}
+		// Privileged override: cancel whoever holds the slot
+		reason := fmt.Sprintf("Replaced by a booking of %s", req.UserName)
+		for _, c := range conflicts {
+			cancelled, err := cancelBooking(repos, c, req.Actor, reason)
+			if err != nil {
+				return err
+			}
+			if err := appendReservationEvent(repos, entities.WebhookReservationCancelled, cancelled...); err != nil {
+				return err
+			}
+			b.overridden = append(b.overridden, cancelled...)
+		}
+	}
+
+	// A space group is booked as one logical reservation: one row per member
+	// space, all sharing the same group booking ID
+	var groupBookingID *uuid.UUID
+	if len(spaceIDs) > 1 {
+		id := uuid.New()
+		groupBookingID = &id
+	}
+
+	for _, spaceID := range spaceIDs {
+		r := &entities.Reservation{
+			ID:             uuid.New(),
+			SpaceID:        spaceID,
+			UserID:         req.UserID,
+			UserName:       req.UserName,
+			Date:           req.Date,
+			StartTime:      req.StartTime,
+			EndTime:        req.EndTime,
+			Status:         entities.ReservationStatusActive,
+			Notes:          req.Notes,
+			SeriesID:       req.SeriesID,
+			OccurrenceDate: req.OccurrenceDate,
+			GroupBookingID: groupBookingID,
+			Timezone:       officeMap.Timezone,
+			CreatedAt:      time.Now(),
+			UpdatedAt:      time.Now(),
+		}
+		if err := repos.Reservations.Create(r); err != nil {
 			return err
 		}
+		if err := auditReservation(repos, req.Actor, entities.AuditActionCreate, req.Reason, nil, r); err != nil {
+			return err
+		}
+		if err := appendReservationEvent(repos, entities.WebhookReservationCreated, r); err != nil {
+			return err
+		}
+		b.created = append(b.created, r)
+		if spaceID == space.ID {
+			b.reservation = r
+		}
+	}
+	return nil
+}
+
+// notifyBooked tells the holders of a committed booking, and of the
+// reservations it overrode, about it
+func (s *ReservationService) notifyBooked(b *newBooking) {
+	if s.notifier == nil {
+		return
+	}
+	if len(b.overridden) > 0 {
+		s.notifier.ReservationsCancelled(b.overridden, fmt.Sprintf("Replaced by a booking of %s", b.req.UserName))
+	}
+	// Occurrences materialized by their series are not announced one by one
+	if b.req.SeriesID != nil {
+		return
+	}
+	if created := notifiable(b.created, b.req.Actor); len(created) > 0 {
+		s.notifier.ReservationsCreated(created)
+	}
+}
 
-		if len(groupSpaces) > 0 {
-			spaceIDs := make([]uuid.UUID, len(groupSpaces))
-			for i, s := range groupSpaces {
-				spaceIDs[i] = s.ID
+// notifiable returns the reservations whose holders are told about a change.
+// Occurrences changed by their series are left out, so that changing or
+// cancelling a series does not send an email per occurrence.
+func notifiable(reservations []*entities.Reservation, actor *Actor) []*entities.Reservation {
+	if actor != nil {
+		return reservations
+	}
+	var result []*entities.Reservation
+	for _, r := range reservations {
+		if r.SeriesID == nil {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+// cancellationReason explains a cancellation to the holder of r, unless they
+// cancelled it themselves
+func cancellationReason(actor *Actor, r *entities.Reservation) string {
+	if actor == nil || actor.UserID == r.UserID {
+		return ""
+	}
+	return fmt.Sprintf("Cancelled by %s", actor.UserName)
+}
+
+// spaceWithMap returns a space together with its office map
+func spaceWithMap(spaceRepo repositories.SpaceRepository, mapRepo repositories.OfficeMapRepository, spaceID uuid.UUID) (*entities.Space, *entities.OfficeMap, error) {
+	space, err := spaceRepo.FindByID(spaceID)
+	if err != nil {
+		return nil, nil, ErrSpaceNotFound
+	}
+	officeMap, err := mapRepo.FindByID(space.MapID)
+	if err != nil {
+		return nil, nil, ErrSpaceNotFound
+	}
+	return space, officeMap, nil
+}
+
+// bookingScope returns the spaces booked together with the given space: every
+// member of its space group, or just the space itself when it is not grouped
+func bookingScope(spaceRepo repositories.SpaceRepository, space *entities.Space) ([]uuid.UUID, error) {
+	if space.IsGrouped() {
+		members, err := spaceRepo.FindByGroupID(*space.GroupID)
+		if err != nil {
+			return nil, err
+		}
+		if len(members) > 0 {
+			spaceIDs := make([]uuid.UUID, len(members))
+			for i, m := range members {
+				spaceIDs[i] = m.ID
 			}
-			return s.reservationRepo.DeleteBySpaceIDsAndTime(spaceIDs, date, startTime)
+			return spaceIDs, nil
 		}
 	}
+	return []uuid.UUID{space.ID}, nil
+}
 
-	// For non-meeting rooms, delete for single space
-	return s.reservationRepo.DeleteBySpaceAndTime(space.ID, date, startTime)
+// bookingReservations returns all reservations that belong to the same logical
+// booking as r, including r itself
+func bookingReservations(reservationRepo repositories.ReservationRepository, r *entities.Reservation) ([]*entities.Reservation, error) {
+	if r.GroupBookingID == nil {
+		return []*entities.Reservation{r}, nil
+	}
+	siblings, err := reservationRepo.FindAll(repositories.ReservationFilters{GroupBookingID: r.GroupBookingID})
+	if err != nil {
+		return nil, err
+	}
+	if len(siblings) == 0 {
+		return []*entities.Reservation{r}, nil
+	}
+	return siblings, nil
+}
+
+// cancelBooking cancels a reservation together with the rest of its group
+// booking and returns the cancelled reservations
+func cancelBooking(repos repositories.TxRepositories, r *entities.Reservation, actor *Actor, reason string) ([]*entities.Reservation, error) {
+	booking, err := bookingReservations(repos.Reservations, r)
+	if err != nil {
+		return nil, err
+	}
+	var cancelled []*entities.Reservation
+	for _, b := range booking {
+		if !b.HoldsSpace() {
+			continue
+		}
+		before := snapshotReservation(b)
+		b.Cancel()
+		if err := repos.Reservations.Update(b); err != nil {
+			return nil, err
+		}
+		if err := auditReservation(repos, actor, entities.AuditActionCancel, reason, &before, b); err != nil {
+			return nil, err
+		}
+		cancelled = append(cancelled, b)
+	}
+	return cancelled, nil
+}
+
+// validateTimeRange validates the format of optional start and end times and
+// that the start comes before the end
+func validateTimeRange(startTime, endTime *string) error {
+	timeRange, err := entities.NewTimeRange(startTime, endTime)
+	if err != nil {
+		return ErrInvalidTime
+	}
+	if !timeRange.IsValid() {
+		return ErrStartTimeAfterEndTime
+	}
+	return nil
 }
 
 // UpdateReservationRequest represents the input for updating a reservation
@@ -145,130 +407,275 @@ type UpdateReservationRequest struct {
 	EndTime   *string
 	Status    *entities.ReservationStatus
 	Notes     *string
+	// Version is the version of the reservation the change is based on, from the
+	// If-Match header; nil skips the check
+	Version *int64
+	// Actor is the user making the request, or nil for internal callers
+	Actor *Actor
+	// Reason explains the change in the audit log
+	Reason string
 }
 
-// UpdateReservation updates an existing reservation
+// UpdateReservation updates an existing reservation. Changes to a group booking
+// are applied to every reservation of the booking.
 func (s *ReservationService) UpdateReservation(req UpdateReservationRequest) (*entities.Reservation, error) {
 	reservation, err := s.reservationRepo.FindByID(req.ID)
 	if err != nil {
 		return nil, ErrReservationNotFound
 	}
 
-	if reservation.IsCancelled() {
-		return nil, ErrCannotUpdateCancelled
+	if !req.Actor.CanManage(reservation.UserID) {
+		return nil, ErrNotReservationOwner
 	}
 
-	// Update fields if provided
-	if req.UserName != nil {
-		reservation.UserName = *req.UserName
+	if err := checkVersion(req.Version, reservation.Version); err != nil {
+		return nil, err
 	}
-	if req.Date != nil {
-		reservation.Date = *req.Date
+
+	if reservation.IsCancelled() {
+		return nil, ErrCannotUpdateCancelled
 	}
+
+	// Validate time format
 	if req.StartTime != nil {
-		if _, err := time.Parse("15:04", *req.StartTime); err != nil {
+		if _, err := entities.ParseClock(*req.StartTime); err != nil {
 			return nil, ErrInvalidTime
 		}
-		reservation.StartTime = req.StartTime
 	}
 	if req.EndTime != nil {
-		if _, err := time.Parse("15:04", *req.EndTime); err != nil {
+		if _, err := entities.ParseClock(*req.EndTime); err != nil {
 			return nil, ErrInvalidTime
 		}
-		reservation.EndTime = req.EndTime
 	}
-	if req.Status != nil {
-		reservation.Status = *req.Status
-	}
-	if req.Notes != nil {
-		reservation.Notes = *req.Notes
-	}
-
-	reservation.UpdatedAt = time.Now()
 
-	if err := s.reservationRepo.Update(reservation); err != nil {
+	booking, err := bookingReservations(s.reservationRepo, reservation)
+	if err != nil {
 		return nil, err
 	}
 
-	return reservation, nil
-}
-
-// DeleteReservation deletes (cancels) a reservation
-func (s *ReservationService) DeleteReservation(id uuid.UUID) error {
-	reservation, err := s.reservationRepo.FindByID(id)
-	if err != nil {
-		return ErrReservationNotFound
+	var result *entities.Reservation
+	var spaceIDs []uuid.UUID
+	bookingIDs := make(map[uuid.UUID]bool, len(booking))
+	before := make(map[uuid.UUID]reservationSnapshot, len(booking))
+	for _, r := range booking {
+		if req.Status != nil && !r.CanChangeStatusTo(*req.Status) {
+			return nil, ErrInvalidStatusChange
+		}
+		before[r.ID] = snapshotReservation(r)
+		applyReservationUpdate(r, req)
+		if err := validateTimeRange(r.StartTime, r.EndTime); err != nil {
+			return nil, err
+		}
+		spaceIDs = append(spaceIDs, r.SpaceID)
+		bookingIDs[r.ID] = true
+		if r.ID == reservation.ID {
+			result = r
+		}
 	}
-
-	// Get the space to check if it's a meeting room
-	space, err := s.spaceRepo.FindByID(reservation.SpaceID)
-	if err != nil {
-		return ErrSpaceNotFound
+	if result == nil {
+		result = reservation
+	}
+	// Siblings are loaded again; the reservation must not have changed since
+	if err := checkVersion(req.Version, result.Version); err != nil {
+		return nil, err
 	}
 
-	// If it's a meeting room, delete all related group reservations
-	if space.IsMeetingRoom() {
-		baseName := space.GetBaseName()
-		groupSpaces, err := s.spaceRepo.FindMeetingRoomsByBaseName(baseName, space.MapID)
+	// A new date or time must follow the same booking policies and closures as a
+	// new booking
+	rescheduled := req.Date != nil || req.StartTime != nil || req.EndTime != nil
+	if result.HoldsSpace() && rescheduled {
+		space, officeMap, err := spaceWithMap(s.spaceRepo, s.mapRepo, result.SpaceID)
 		if err != nil {
-			return err
+			return nil, err
 		}
+		err = s.policyService.Check(PolicyCheck{
+			Space:     space,
+			Map:       officeMap,
+			UserID:    result.UserID,
+			Date:      result.Date,
+			StartTime: result.StartTime,
+			EndTime:   result.EndTime,
+			Actor:     req.Actor,
+			Exclude:   bookingIDs,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = s.txManager.WithinTransaction(func(repos repositories.TxRepositories) error {
+		if result.HoldsSpace() {
+			if err := repos.Spaces.LockByIDs(spaceIDs); err != nil {
+				return err
+			}
 
-		if len(groupSpaces) > 0 {
-			spaceIDs := make([]uuid.UUID, len(groupSpaces))
-			for i, s := range groupSpaces {
-				spaceIDs[i] = s.ID
+			if rescheduled {
+				space, err := repos.Spaces.FindByID(result.SpaceID)
+				if err != nil {
+					return ErrSpaceNotFound
+				}
+				timeRange, _ := result.TimeRange()
+				closed, err := closuresBlocking(repos.Closures, space, spaceIDs, result.Date, timeRange)
+				if err != nil {
+					return err
+				}
+				if len(closed) > 0 {
+					return &ClosureConflictError{Closures: closed}
+				}
 			}
 
-			// Delete all active reservations for these spaces with same user, date, and time
-			reservations, err := s.reservationRepo.FindBySpaceIDsAndDate(spaceIDs, reservation.Date)
+			overlapping, err := repos.Reservations.FindOverlapping(spaceIDs, result.Date, result.StartTime, result.EndTime)
 			if err != nil {
 				return err
 			}
-
-			for _, r := range reservations {
-				if r.UserName == reservation.UserName &&
-					r.Status == entities.ReservationStatusActive &&
-					timeMatches(r.StartTime, reservation.StartTime) &&
-					timeMatches(r.EndTime, reservation.EndTime) {
-					if err := s.reservationRepo.Delete(r.ID); err != nil {
-						return err
-					}
+			var conflicts []*entities.Reservation
+			for _, o := range overlapping {
+				if !bookingIDs[o.ID] {
+					conflicts = append(conflicts, o)
 				}
 			}
-			return nil
+			if len(conflicts) > 0 {
+				return &ConflictError{Conflicts: conflicts}
+			}
+		}
+
+		for _, r := range booking {
+			if err := repos.Reservations.Update(r); err != nil {
+				return err
+			}
+			eventType, action := entities.WebhookReservationUpdated, entities.AuditActionUpdate
+			if r.IsCancelled() {
+				eventType, action = entities.WebhookReservationCancelled, entities.AuditActionCancel
+			}
+			if err := appendReservationEvent(repos, eventType, r); err != nil {
+				return err
+			}
+			snapshot := before[r.ID]
+			if err := auditReservation(repos, req.Actor, action, req.Reason, &snapshot, r); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, repositories.ErrReservationOverlap) {
+			return nil, ErrReservationAlreadyExists
+		}
+		return nil, translateVersionConflict(err)
+	}
+
+	if s.notifier != nil {
+		if booking := notifiable(booking, req.Actor); len(booking) > 0 {
+			if result.IsCancelled() {
+				s.notifier.ReservationsCancelled(booking, cancellationReason(req.Actor, result))
+			} else {
+				s.notifier.ReservationsUpdated(booking)
+			}
 		}
 	}
 
-	// For non-meeting rooms, delete single reservation
-	return s.reservationRepo.Delete(id)
+	return result, nil
 }
 
-// timeMatches checks if two time strings match (handles HH:MM and HH:MM:SS formats)
-func timeMatches(t1, t2 *string) bool {
-	if t1 == nil && t2 == nil {
-		return true
+// applyReservationUpdate copies the provided fields of an update request onto a reservation
+func applyReservationUpdate(r *entities.Reservation, req UpdateReservationRequest) {
+	if req.UserName != nil {
+		r.UserName = *req.UserName
+	}
+	if req.Date != nil {
+		r.Date = *req.Date
 	}
-	if t1 == nil || t2 == nil {
-		return false
+	if req.StartTime != nil {
+		r.StartTime = req.StartTime
+	}
+	if req.EndTime != nil {
+		r.EndTime = req.EndTime
 	}
-	// Normalize to HH:MM for comparison
-	normalize := func(t string) string {
-		if len(t) > 5 {
-			return t[:5]
+	if req.Status != nil {
+		r.Status = *req.Status
+	}
+	if req.Notes != nil {
+		r.Notes = *req.Notes
+	}
+	r.UpdatedAt = time.Now()
+}
+
+// DeleteReservation deletes (cancels) a reservation. Cancelling any reservation
+// of a group booking cancels the whole booking atomically. Only the owner or an
+// admin can cancel a reservation; reason explains it in the audit log. A non-nil
+// version must be the current version of the reservation.
+func (s *ReservationService) DeleteReservation(id uuid.UUID, version *int64, actor *Actor, reason string) error {
+	reservation, err := s.reservationRepo.FindByID(id)
+	if err != nil {
+		return ErrReservationNotFound
+	}
+
+	if !actor.CanManage(reservation.UserID) {
+		return ErrNotReservationOwner
+	}
+
+	if err := checkVersion(version, reservation.Version); err != nil {
+		return err
+	}
+
+	var cancelled []*entities.Reservation
+	err = s.txManager.WithinTransaction(func(repos repositories.TxRepositories) error {
+		var err error
+		cancelled, err = cancelReservation(repos, reservation, version, actor, reason)
+		return err
+	})
+	if err != nil {
+		return translateVersionConflict(err)
+	}
+
+	if s.notifier != nil {
+		if cancelled := notifiable(cancelled, actor); len(cancelled) > 0 {
+			s.notifier.ReservationsCancelled(cancelled, cancellationReason(actor, reservation))
 		}
-		return t
 	}
-	return normalize(*t1) == normalize(*t2)
+	return nil
 }
 
-// GetReservations retrieves reservations with optional filters
-func (s *ReservationService) GetReservations(filters repositories.ReservationFilters) ([]*entities.Reservation, error) {
-	return s.reservationRepo.FindAll(filters)
+// cancelReservation cancels a reservation together with the rest of its group
+// booking within a transaction and returns the reservations it cancelled. A
+// non-nil version must be the current version of the reservation.
+func cancelReservation(repos repositories.TxRepositories, reservation *entities.Reservation, version *int64, actor *Actor, reason string) ([]*entities.Reservation, error) {
+	booking, err := bookingReservations(repos.Reservations, reservation)
+	if err != nil {
+		return nil, err
+	}
+	var cancelled []*entities.Reservation
+	for _, r := range booking {
+		if r.ID == reservation.ID {
+			if err := checkVersion(version, r.Version); err != nil {
+				return nil, err
+			}
+		}
+		if r.IsCancelled() {
+			continue
+		}
+		before := snapshotReservation(r)
+		r.Cancel()
+		if err := repos.Reservations.Update(r); err != nil {
+			return nil, err
+		}
+		if err := appendReservationEvent(repos, entities.WebhookReservationCancelled, r); err != nil {
+			return nil, err
+		}
+		if err := auditReservation(repos, actor, entities.AuditActionCancel, reason, &before, r); err != nil {
+			return nil, err
+		}
+		cancelled = append(cancelled, r)
+	}
+	return cancelled, nil
+}
+
+// GetReservations retrieves one page of the reservations matching the filters
+func (s *ReservationService) GetReservations(filters repositories.ReservationFilters, page repositories.PageRequest) ([]*entities.Reservation, repositories.PageInfo, error) {
+	return s.reservationRepo.FindPage(filters, page)
 }
 
 // GetReservation retrieves a single reservation by ID
 func (s *ReservationService) GetReservation(id uuid.UUID) (*entities.Reservation, error) {
 	return s.reservationRepo.FindByID(id)
 }
-
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"office-reservations/internal/domain/entities"
+)
+
+func TestCreateReservationRejectsOverlaps(t *testing.T) {
+	tests := []struct {
+		name          string
+		start, end    *string
+		wantConflicts []string
+	}{
+		{name: "partial overlap", start: strPtr("09:30"), end: strPtr("10:30"), wantConflicts: []string{"active"}},
+		{name: "touching ranges", start: strPtr("10:00"), end: strPtr("11:00")},
+		{name: "slot of a cancelled reservation", start: strPtr("11:00"), end: strPtr("12:00")},
+		{name: "slot of a no-show", start: strPtr("13:00"), end: strPtr("14:00")},
+		{name: "checked in", start: strPtr("15:30"), end: strPtr("16:30"), wantConflicts: []string{"checked in"}},
+		{name: "all day", wantConflicts: []string{"active", "checked in"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newFakeStore()
+			desk := store.addSpace(store.addMap("UTC").ID, "Desk 1")
+			today, _ := entities.LocalDay(time.Now(), time.UTC)
+			tomorrow := today.AddDate(0, 0, 1)
+			existing := map[string]*entities.Reservation{
+				"active":     store.addReservation(entities.Reservation{SpaceID: desk.ID, UserID: "alice", Date: tomorrow, StartTime: strPtr("09:00"), EndTime: strPtr("10:00")}),
+				"cancelled":  store.addReservation(entities.Reservation{SpaceID: desk.ID, UserID: "alice", Date: tomorrow, StartTime: strPtr("11:00"), EndTime: strPtr("12:00"), Status: entities.ReservationStatusCancelled}),
+				"no-show":    store.addReservation(entities.Reservation{SpaceID: desk.ID, UserID: "alice", Date: tomorrow, StartTime: strPtr("13:00"), EndTime: strPtr("14:00"), Status: entities.ReservationStatusNoShow}),
+				"checked in": store.addReservation(entities.Reservation{SpaceID: desk.ID, UserID: "alice", Date: tomorrow, StartTime: strPtr("15:00"), EndTime: strPtr("16:00"), Status: entities.ReservationStatusCheckedIn}),
+			}
+
+			_, err := newTestReservationService(store).CreateReservation(CreateReservationRequest{
+				SpaceID:   desk.ID,
+				UserID:    "bob",
+				UserName:  "Bob",
+				Date:      tomorrow,
+				StartTime: tt.start,
+				EndTime:   tt.end,
+				Actor:     &Actor{UserID: "bob", UserName: "Bob"},
+			})
+			if len(tt.wantConflicts) == 0 {
+				if err != nil {
+					t.Fatalf("CreateReservation() error = %v", err)
+				}
+				return
+			}
+
+			var conflict *ConflictError
+			if !errors.As(err, &conflict) || !errors.Is(err, ErrReservationAlreadyExists) {
+				t.Fatalf("CreateReservation() error = %v, want a conflict", err)
+			}
+			if len(conflict.Conflicts) != len(tt.wantConflicts) {
+				t.Fatalf("CreateReservation() conflicts = %d, want %v", len(conflict.Conflicts), tt.wantConflicts)
+			}
+			for i, name := range tt.wantConflicts {
+				if conflict.Conflicts[i].ID != existing[name].ID {
+					t.Errorf("conflict %d = %s to %s, want the %s reservation", i, *conflict.Conflicts[i].StartTime, *conflict.Conflicts[i].EndTime, name)
+				}
+			}
+			if len(store.reservations) != len(existing) {
+				t.Errorf("CreateReservation() stored a reservation despite the conflict")
+			}
+		})
+	}
+}
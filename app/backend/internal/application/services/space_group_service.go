@@ -0,0 +1,174 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"office-reservations/internal/domain/entities"
+	"office-reservations/internal/domain/repositories"
+)
+
+var (
+	ErrSpaceGroupNotFound   = errors.New("space group not found")
+	ErrInvalidSpaceGroup    = errors.New("space group needs a name and at least one space")
+	ErrSpaceNotInMap        = errors.New("all spaces of a group must belong to the group's map")
+	ErrSpaceNotGroupable    = errors.New("only meeting room spaces can be grouped")
+	ErrSpaceAlreadyGrouped  = errors.New("space already belongs to another group")
+	ErrInvalidGroupCapacity = errors.New("capacity must be positive")
+)
+
+// SpaceGroupService handles space group business logic
+type SpaceGroupService struct {
+	groupRepo repositories.SpaceGroupRepository
+	spaceRepo repositories.SpaceRepository
+}
+
+// NewSpaceGroupService creates a new space group service
+func NewSpaceGroupService(groupRepo repositories.SpaceGroupRepository, spaceRepo repositories.SpaceRepository) *SpaceGroupService {
+	return &SpaceGroupService{
+		groupRepo: groupRepo,
+		spaceRepo: spaceRepo,
+	}
+}
+
+// SpaceGroupRequest represents the input for creating or updating a space group
+type SpaceGroupRequest struct {
+	MapID    uuid.UUID
+	Name     string
+	Capacity int
+	SpaceIDs []uuid.UUID
+}
+
+// GetGroups retrieves all groups, optionally restricted to a map
+func (s *SpaceGroupService) GetGroups(mapID *uuid.UUID) ([]*entities.SpaceGroup, error) {
+	if mapID != nil {
+		return s.groupRepo.FindByMapID(*mapID)
+	}
+	return s.groupRepo.FindAll()
+}
+
+// GetGroup retrieves a group by ID
+func (s *SpaceGroupService) GetGroup(id uuid.UUID) (*entities.SpaceGroup, error) {
+	group, err := s.groupRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrSpaceGroupNotFound
+	}
+	return group, nil
+}
+
+// GetGroupMapID returns the ID of the office map a group belongs to
+func (s *SpaceGroupService) GetGroupMapID(id uuid.UUID) (uuid.UUID, error) {
+	group, err := s.GetGroup(id)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return group.MapID, nil
+}
+
+// CreateGroup creates a new group from meeting room spaces of one map
+func (s *SpaceGroupService) CreateGroup(req SpaceGroupRequest) (*entities.SpaceGroup, error) {
+	group := &entities.SpaceGroup{
+		ID:        uuid.New(),
+		MapID:     req.MapID,
+		Name:      strings.TrimSpace(req.Name),
+		Capacity:  req.Capacity,
+		SpaceIDs:  uniqueIDs(req.SpaceIDs),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if group.Capacity == 0 {
+		group.Capacity = 1
+	}
+
+	if err := s.validateGroup(group); err != nil {
+		return nil, err
+	}
+
+	if err := s.groupRepo.Create(group); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+// UpdateGroup renames a group, changes its capacity or replaces its members.
+// Zero values leave the corresponding field unchanged.
+func (s *SpaceGroupService) UpdateGroup(id uuid.UUID, req SpaceGroupRequest) (*entities.SpaceGroup, error) {
+	group, err := s.GetGroup(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if name := strings.TrimSpace(req.Name); name != "" {
+		group.Name = name
+	}
+	if req.Capacity != 0 {
+		group.Capacity = req.Capacity
+	}
+	if req.SpaceIDs != nil {
+		group.SpaceIDs = uniqueIDs(req.SpaceIDs)
+	}
+	group.UpdatedAt = time.Now()
+
+	if err := s.validateGroup(group); err != nil {
+		return nil, err
+	}
+
+	if err := s.groupRepo.Update(group); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+// DeleteGroup deletes a group. Its spaces become individually bookable again.
+func (s *SpaceGroupService) DeleteGroup(id uuid.UUID) error {
+	if _, err := s.GetGroup(id); err != nil {
+		return err
+	}
+	return s.groupRepo.Delete(id)
+}
+
+// validateGroup checks the group fields and that every member is an ungrouped
+// (or already member) meeting room on the group's map
+func (s *SpaceGroupService) validateGroup(group *entities.SpaceGroup) error {
+	if group.Name == "" || len(group.SpaceIDs) == 0 {
+		return ErrInvalidSpaceGroup
+	}
+	if group.Capacity < 1 {
+		return ErrInvalidGroupCapacity
+	}
+
+	spaces, err := s.spaceRepo.FindByIDs(group.SpaceIDs)
+	if err != nil {
+		return err
+	}
+	if len(spaces) != len(group.SpaceIDs) {
+		return ErrSpaceNotFound
+	}
+	for _, space := range spaces {
+		if space.MapID != group.MapID {
+			return ErrSpaceNotInMap
+		}
+		if !space.IsMeetingRoom() {
+			return ErrSpaceNotGroupable
+		}
+		if space.GroupID != nil && *space.GroupID != group.ID {
+			return ErrSpaceAlreadyGrouped
+		}
+	}
+	return nil
+}
+
+// uniqueIDs removes duplicate IDs while preserving order
+func uniqueIDs(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	result := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	return result
+}
@@ -1,6 +1,9 @@
 package services
 
 import (
+	"strings"
+	"time"
+
 	"github.com/google/uuid"
 	"office-reservations/internal/domain/entities"
 	"office-reservations/internal/domain/repositories"
@@ -8,16 +11,64 @@ import (
 
 // SpaceService handles space business logic
 type SpaceService struct {
-	spaceRepo repositories.SpaceRepository
+	spaceRepo       repositories.SpaceRepository
+	mapRepo         repositories.OfficeMapRepository
+	reservationRepo repositories.ReservationRepository
+	closureRepo     repositories.ClosureRepository
+	txManager       repositories.TransactionManager
+	notifier        CancellationNotifier
 }
 
-// NewSpaceService creates a new space service
-func NewSpaceService(spaceRepo repositories.SpaceRepository) *SpaceService {
+// NewSpaceService creates a new space service. notifier is told about the
+// reservations cancelled with deleted spaces and may be nil.
+func NewSpaceService(spaceRepo repositories.SpaceRepository, mapRepo repositories.OfficeMapRepository, reservationRepo repositories.ReservationRepository, closureRepo repositories.ClosureRepository, txManager repositories.TransactionManager, notifier CancellationNotifier) *SpaceService {
 	return &SpaceService{
-		spaceRepo: spaceRepo,
+		spaceRepo:       spaceRepo,
+		mapRepo:         mapRepo,
+		reservationRepo: reservationRepo,
+		closureRepo:     closureRepo,
+		txManager:       txManager,
+		notifier:        notifier,
 	}
 }
 
+// CreateSpaceRequest represents the input for creating a space. Zero width, height
+// and capacity default to 1.
+type CreateSpaceRequest struct {
+	MapID    uuid.UUID
+	Name     string
+	Type     entities.SpaceType
+	X        int
+	Y        int
+	Width    int
+	Height   int
+	Capacity int
+	// Actor is the user making the request and Reason explains the change in
+	// the audit log
+	Actor  *Actor
+	Reason string
+}
+
+// UpdateSpaceRequest represents the input for updating a space. Empty and nil
+// fields leave the space unchanged.
+type UpdateSpaceRequest struct {
+	ID       uuid.UUID
+	Name     string
+	Type     entities.SpaceType
+	X        *int
+	Y        *int
+	Width    *int
+	Height   *int
+	Capacity *int
+	// Version is the version of the space the change is based on, from the
+	// If-Match header; nil skips the check
+	Version *int64
+	// Actor is the user making the request and Reason explains the change in
+	// the audit log
+	Actor  *Actor
+	Reason string
+}
+
 // GetSpace retrieves a space by ID
 func (s *SpaceService) GetSpace(id uuid.UUID) (*entities.Space, error) {
 	space, err := s.spaceRepo.FindByID(id)
@@ -27,13 +78,209 @@ func (s *SpaceService) GetSpace(id uuid.UUID) (*entities.Space, error) {
 	return space, nil
 }
 
+// GetSpaceMapID returns the ID of the office map a space belongs to
+func (s *SpaceService) GetSpaceMapID(id uuid.UUID) (uuid.UUID, error) {
+	space, err := s.GetSpace(id)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return space.MapID, nil
+}
+
+// GetSpaces retrieves one page of the spaces matching the filters
+func (s *SpaceService) GetSpaces(filters repositories.SpaceFilters, page repositories.PageRequest) ([]*entities.Space, repositories.PageInfo, error) {
+	return s.spaceRepo.FindPage(filters, page)
+}
+
 // GetSpacesByMapID retrieves all spaces for a map
 func (s *SpaceService) GetSpacesByMapID(mapID uuid.UUID) ([]*entities.Space, error) {
 	return s.spaceRepo.FindByMapID(mapID)
 }
 
-// GetMeetingRoomsByBaseName finds meeting room spaces with the same base name
-func (s *SpaceService) GetMeetingRoomsByBaseName(baseName string, mapID uuid.UUID) ([]*entities.Space, error) {
-	return s.spaceRepo.FindMeetingRoomsByBaseName(baseName, mapID)
+// GetSpacesByGroupID retrieves the member spaces of a space group
+func (s *SpaceService) GetSpacesByGroupID(groupID uuid.UUID) ([]*entities.Space, error) {
+	return s.spaceRepo.FindByGroupID(groupID)
+}
+
+// CreateSpace validates and stores a new space inside the grid of its map
+func (s *SpaceService) CreateSpace(req CreateSpaceRequest) (*entities.Space, error) {
+	officeMap, err := s.mapRepo.FindByID(req.MapID)
+	if err != nil {
+		return nil, ErrMapNotFound
+	}
+
+	now := time.Now()
+	space := &entities.Space{
+		ID:        uuid.New(),
+		MapID:     req.MapID,
+		Name:      strings.TrimSpace(req.Name),
+		Type:      req.Type,
+		X:         req.X,
+		Y:         req.Y,
+		Width:     req.Width,
+		Height:    req.Height,
+		Capacity:  req.Capacity,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	// Set defaults
+	if space.Width == 0 {
+		space.Width = 1
+	}
+	if space.Height == 0 {
+		space.Height = 1
+	}
+	if space.Capacity == 0 {
+		space.Capacity = 1
+	}
+
+	if err := validateSpace(space, mapGrid(officeMap)); err != nil {
+		return nil, err
+	}
+	err = s.txManager.WithinTransaction(func(repos repositories.TxRepositories) error {
+		if err := repos.Spaces.Create(space); err != nil {
+			return err
+		}
+		if err := auditSpace(repos.Audit, req.Actor, entities.AuditActionCreate, req.Reason, nil, space); err != nil {
+			return err
+		}
+		return appendSpaceEvent(repos.Outbox, entities.WebhookSpaceCreated, space)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return space, nil
+}
+
+// UpdateSpace changes the given fields of a space and validates the result
+// against the grid of its map
+func (s *SpaceService) UpdateSpace(req UpdateSpaceRequest) (*entities.Space, error) {
+	space, err := s.GetSpace(req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkVersion(req.Version, space.Version); err != nil {
+		return nil, err
+	}
+	officeMap, err := s.mapRepo.FindByID(space.MapID)
+	if err != nil {
+		return nil, ErrMapNotFound
+	}
+	before := snapshotSpace(space)
+
+	// Update fields if provided
+	if name := strings.TrimSpace(req.Name); name != "" {
+		space.Name = name
+	}
+	if req.Type != "" {
+		space.Type = req.Type
+	}
+	if req.X != nil {
+		space.X = *req.X
+	}
+	if req.Y != nil {
+		space.Y = *req.Y
+	}
+	if req.Width != nil {
+		space.Width = *req.Width
+	}
+	if req.Height != nil {
+		space.Height = *req.Height
+	}
+	if req.Capacity != nil {
+		space.Capacity = *req.Capacity
+	}
+	space.UpdatedAt = time.Now()
+
+	if err := validateSpace(space, mapGrid(officeMap)); err != nil {
+		return nil, err
+	}
+	err = s.txManager.WithinTransaction(func(repos repositories.TxRepositories) error {
+		if err := repos.Spaces.Update(space); err != nil {
+			return err
+		}
+		if err := auditSpace(repos.Audit, req.Actor, entities.AuditActionUpdate, req.Reason, &before, space); err != nil {
+			return err
+		}
+		return appendSpaceEvent(repos.Outbox, entities.WebhookSpaceUpdated, space)
+	})
+	if err != nil {
+		return nil, translateVersionConflict(err)
+	}
+	return space, nil
+}
+
+// DeleteSpace removes a space from its map as an update of the map that leaves
+// it out of the layout does: its upcoming reservations and the series booking it
+// are cancelled, and the holders are notified. The space is kept, marked as
+// deleted, so that its past reservations stay in the history. Every change is
+// recorded in the audit log as made by actor. A non-nil version must be the
+// current version of the space.
+func (s *SpaceService) DeleteSpace(id uuid.UUID, version *int64, actor *Actor, reason string) error {
+	if _, err := s.GetSpace(id); err != nil {
+		return err
+	}
+	summary := &SpaceSyncSummary{}
+	err := s.txManager.WithinTransaction(func(repos repositories.TxRepositories) error {
+		if err := repos.Spaces.LockByIDs([]uuid.UUID{id}); err != nil {
+			return err
+		}
+		space, err := repos.Spaces.FindByID(id)
+		if err != nil {
+			return ErrSpaceNotFound
+		}
+		if err := checkVersion(version, space.Version); err != nil {
+			return err
+		}
+		officeMap, err := repos.Maps.FindByID(space.MapID)
+		if err != nil {
+			return ErrMapNotFound
+		}
+		upcoming, err := repos.Reservations.FindUpcomingBySpaceIDs([]uuid.UUID{id}, time.Now())
+		if err != nil {
+			return err
+		}
+		return removeSpaces(repos, officeMap, []*entities.Space{space}, upcoming, summary, actor, reason, spaceRemovedReason(reason))
+	})
+	if err != nil {
+		return err
+	}
+	if s.notifier != nil && len(summary.cancelled) > 0 {
+		s.notifier.ReservationsCancelled(summary.cancelled, spaceRemovedReason(reason))
+	}
+	return nil
 }
 
+// GetAvailability returns the reservations that keep a space occupied on a date
+// and the closures covering it
+func (s *SpaceService) GetAvailability(id uuid.UUID, date time.Time) ([]*entities.Reservation, []*entities.Closure, error) {
+	space, err := s.GetSpace(id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reservations, err := s.reservationRepo.FindBySpaceAndDate(id, date)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	holding := make([]*entities.Reservation, 0, len(reservations))
+	for _, r := range reservations {
+		if r.HoldsSpace() {
+			holding = append(holding, r)
+		}
+	}
+
+	closures, err := s.closureRepo.FindAll(repositories.ClosureFilters{MapID: &space.MapID, From: &date, To: &date})
+	if err != nil {
+		return nil, nil, err
+	}
+	covering := make([]*entities.Closure, 0, len(closures))
+	for _, c := range closures {
+		if c.Covers(space) {
+			covering = append(covering, c)
+		}
+	}
+	return holding, covering, nil
+}
@@ -0,0 +1,24 @@
+package services
+
+import (
+	"testing"
+)
+
+func TestDeleteSpace(t *testing.T) {
+	f := newRemovalFixture()
+	repos := f.store.fakeRepos()
+	service := NewSpaceService(repos.Spaces, repos.Maps, repos.Reservations, repos.Closures, &fakeTxManager{store: f.store}, f.notifier)
+
+	stale := f.desk.Version - 1
+	if err := service.DeleteSpace(f.desk.ID, &stale, nil, ""); err == nil {
+		t.Fatalf("DeleteSpace() at a stale version succeeded")
+	}
+	if err := service.DeleteSpace(f.desk.ID, &f.desk.Version, nil, ""); err != nil {
+		t.Fatalf("DeleteSpace() error = %v", err)
+	}
+
+	f.assertRemoved(t, spaceRemovedReason(""))
+	if _, live := f.store.spaces[f.otherDesk.ID]; !live {
+		t.Errorf("second desk was removed too")
+	}
+}
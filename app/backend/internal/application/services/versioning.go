@@ -0,0 +1,29 @@
+package services
+
+import (
+	"errors"
+
+	"office-reservations/internal/domain/repositories"
+)
+
+// ErrVersionMismatch is returned when a change is based on another version of a
+// reservation, space or map than its current one
+var ErrVersionMismatch = errors.New("resource has been modified since it was read")
+
+// checkVersion returns ErrVersionMismatch unless the caller expects the current
+// version. A nil expected version matches any.
+func checkVersion(expected *int64, current int64) error {
+	if expected != nil && *expected != current {
+		return ErrVersionMismatch
+	}
+	return nil
+}
+
+// translateVersionConflict reports an update rejected because the resource was
+// changed concurrently as ErrVersionMismatch
+func translateVersionConflict(err error) error {
+	if errors.Is(err, repositories.ErrVersionConflict) {
+		return ErrVersionMismatch
+	}
+	return err
+}
@@ -0,0 +1,207 @@
+package services
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"office-reservations/internal/domain/entities"
+	"office-reservations/internal/domain/repositories"
+)
+
+// The data of each event type is a snapshot of its subject taken in the
+// transaction that changed it, so deliveries describe the change even when
+// the subject has changed again or been deleted since.
+
+// reservationEventData is the data of the reservation.* events
+type reservationEventData struct {
+	Reservation reservationSnapshot `json:"reservation"`
+}
+
+// reservationSnapshot is the state of a reservation sent to webhooks and kept
+// in the audit log
+type reservationSnapshot struct {
+	ID             uuid.UUID  `json:"id"`
+	SpaceID        uuid.UUID  `json:"space_id"`
+	UserID         string     `json:"user_id"`
+	UserName       string     `json:"user_name"`
+	Date           string     `json:"date"`
+	StartTime      *string    `json:"start_time"`
+	EndTime        *string    `json:"end_time"`
+	Timezone       string     `json:"timezone,omitempty"`
+	Status         string     `json:"status"`
+	Notes          string     `json:"notes,omitempty"`
+	SeriesID       *uuid.UUID `json:"series_id,omitempty"`
+	GroupBookingID *uuid.UUID `json:"group_booking_id,omitempty"`
+	CheckedInAt    *string    `json:"checked_in_at,omitempty"`
+	CreatedAt      string     `json:"created_at"`
+	UpdatedAt      string     `json:"updated_at"`
+}
+
+// mapEventData is the data of the map.updated event. Spaces summarizes the
+// sync of the map's spaces, when its layout was changed.
+type mapEventData struct {
+	Map    mapSnapshot        `json:"map"`
+	Spaces *spaceSyncSnapshot `json:"spaces,omitempty"`
+}
+
+// mapSnapshot is the state of an office map sent to webhooks and kept in the
+// audit log
+type mapSnapshot struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Timezone    string    `json:"timezone"`
+	UpdatedAt   string    `json:"updated_at"`
+}
+
+// spaceSyncSnapshot counts the spaces changed by a map update
+type spaceSyncSnapshot struct {
+	Created   int `json:"created"`
+	Updated   int `json:"updated"`
+	Unchanged int `json:"unchanged"`
+	Removed   int `json:"removed"`
+}
+
+// spaceEventData is the data of the space.* events
+type spaceEventData struct {
+	Space spaceSnapshot `json:"space"`
+}
+
+// spaceSnapshot is the state of a space sent to webhooks and kept in the audit
+// log
+type spaceSnapshot struct {
+	ID       uuid.UUID  `json:"id"`
+	MapID    uuid.UUID  `json:"map_id"`
+	GroupID  *uuid.UUID `json:"group_id,omitempty"`
+	Name     string     `json:"name"`
+	Type     string     `json:"type"`
+	X        int        `json:"x"`
+	Y        int        `json:"y"`
+	Width    int        `json:"width"`
+	Height   int        `json:"height"`
+	Capacity int        `json:"capacity"`
+}
+
+// appendEvent records a lifecycle event of a map in the outbox. Called with the
+// outbox of a transaction, the event is only dispatched and streamed if the
+// transaction commits.
+func appendEvent(outbox repositories.OutboxRepository, eventType entities.WebhookEventType, mapID uuid.UUID, data interface{}) error {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return outbox.Append(&entities.OutboxEvent{
+		ID:        uuid.New(),
+		Type:      eventType,
+		MapID:     &mapID,
+		Data:      encoded,
+		CreatedAt: time.Now(),
+	})
+}
+
+// appendReservationEvent records a reservation.* event for each reservation,
+// under the map of its space
+func appendReservationEvent(repos repositories.TxRepositories, eventType entities.WebhookEventType, reservations ...*entities.Reservation) error {
+	mapIDs := make(map[uuid.UUID]uuid.UUID)
+	for _, r := range reservations {
+		mapID, ok := mapIDs[r.SpaceID]
+		if !ok {
+			space, err := repos.Spaces.FindByID(r.SpaceID)
+			if err != nil {
+				return err
+			}
+			mapID = space.MapID
+			mapIDs[r.SpaceID] = mapID
+		}
+		data := reservationEventData{Reservation: snapshotReservation(r)}
+		if err := appendEvent(repos.Outbox, eventType, mapID, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendMapUpdated records a map.updated event
+func appendMapUpdated(outbox repositories.OutboxRepository, officeMap *entities.OfficeMap, summary *SpaceSyncSummary) error {
+	data := mapEventData{Map: snapshotMap(officeMap)}
+	if summary != nil {
+		data.Spaces = &spaceSyncSnapshot{
+			Created:   summary.Created,
+			Updated:   summary.Updated,
+			Unchanged: summary.Unchanged,
+			Removed:   summary.Removed,
+		}
+	}
+	return appendEvent(outbox, entities.WebhookMapUpdated, officeMap.ID, data)
+}
+
+// appendSpaceEvent records a space.* event
+func appendSpaceEvent(outbox repositories.OutboxRepository, eventType entities.WebhookEventType, space *entities.Space) error {
+	return appendEvent(outbox, eventType, space.MapID, spaceEventData{Space: snapshotSpace(space)})
+}
+
+// snapshotReservation returns the state of a reservation
+func snapshotReservation(r *entities.Reservation) reservationSnapshot {
+	snapshot := reservationSnapshot{
+		ID:             r.ID,
+		SpaceID:        r.SpaceID,
+		UserID:         r.UserID,
+		UserName:       r.UserName,
+		Date:           r.Date.Format("2006-01-02"),
+		StartTime:      snapshotClock(r.StartTime),
+		EndTime:        snapshotClock(r.EndTime),
+		Timezone:       r.Timezone,
+		Status:         string(r.Status),
+		Notes:          r.Notes,
+		SeriesID:       r.SeriesID,
+		GroupBookingID: r.GroupBookingID,
+		CreatedAt:      r.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      r.UpdatedAt.Format(time.RFC3339),
+	}
+	if r.CheckedInAt != nil {
+		checkedInAt := r.CheckedInAt.Format(time.RFC3339)
+		snapshot.CheckedInAt = &checkedInAt
+	}
+	return snapshot
+}
+
+// snapshotSpace returns the state of a space
+func snapshotSpace(space *entities.Space) spaceSnapshot {
+	return spaceSnapshot{
+		ID:       space.ID,
+		MapID:    space.MapID,
+		GroupID:  space.GroupID,
+		Name:     space.Name,
+		Type:     string(space.Type),
+		X:        space.X,
+		Y:        space.Y,
+		Width:    space.Width,
+		Height:   space.Height,
+		Capacity: space.Capacity,
+	}
+}
+
+// snapshotMap returns the state of an office map, without its layout
+func snapshotMap(officeMap *entities.OfficeMap) mapSnapshot {
+	return mapSnapshot{
+		ID:          officeMap.ID,
+		Name:        officeMap.Name,
+		Description: officeMap.Description,
+		Timezone:    officeMap.Timezone,
+		UpdatedAt:   officeMap.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// snapshotClock normalizes a stored time of day to HH:MM
+func snapshotClock(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	minutes, err := entities.ParseClock(*value)
+	if err != nil {
+		return value
+	}
+	formatted := entities.FormatClock(minutes)
+	return &formatted
+}
@@ -0,0 +1,404 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"office-reservations/internal/config"
+	"office-reservations/internal/domain/entities"
+	"office-reservations/internal/domain/repositories"
+)
+
+var (
+	ErrWebhookNotFound         = errors.New("webhook not found")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+	// ErrInvalidWebhook is matched by the ValidationError of a rejected webhook
+	ErrInvalidWebhook = errors.New("invalid webhook")
+)
+
+const (
+	// webhookBatchSize bounds the events dispatched and deliveries sent per run
+	webhookBatchSize = 100
+	// minWebhookSecretLength is the shortest secret accepted for signing
+	minWebhookSecretLength = 16
+	// maxWebhookErrorLength bounds the error stored for a failed attempt
+	maxWebhookErrorLength = 1000
+)
+
+// WebhookSender sends a delivery to its webhook. It returns the HTTP status of
+// the response, or an error if no response was received.
+type WebhookSender interface {
+	Send(webhook *entities.Webhook, delivery *entities.WebhookDelivery) (int, error)
+}
+
+// WebhookService manages webhook subscriptions and delivers the lifecycle
+// events recorded in the outbox to them
+type WebhookService struct {
+	webhookRepo  repositories.WebhookRepository
+	deliveryRepo repositories.WebhookDeliveryRepository
+	txManager    repositories.TransactionManager
+	sender       WebhookSender
+	config       config.WebhookConfig
+}
+
+// NewWebhookService creates a new webhook service
+func NewWebhookService(
+	webhookRepo repositories.WebhookRepository,
+	deliveryRepo repositories.WebhookDeliveryRepository,
+	txManager repositories.TransactionManager,
+	sender WebhookSender,
+	cfg config.WebhookConfig,
+) *WebhookService {
+	return &WebhookService{
+		webhookRepo:  webhookRepo,
+		deliveryRepo: deliveryRepo,
+		txManager:    txManager,
+		sender:       sender,
+		config:       cfg,
+	}
+}
+
+// WebhookRequest represents the input for creating or replacing a webhook
+type WebhookRequest struct {
+	URL         string
+	Description string
+	// Events are event type names; empty subscribes to every event
+	Events []string
+	// Secret signs the deliveries. Nil generates one for a new webhook and keeps
+	// the current one of an existing webhook.
+	Secret *string
+	// Active pauses or resumes deliveries. Nil makes a new webhook active and
+	// keeps the state of an existing one.
+	Active *bool
+}
+
+// GetWebhooks retrieves all webhooks
+func (s *WebhookService) GetWebhooks() ([]*entities.Webhook, error) {
+	return s.webhookRepo.FindAll()
+}
+
+// GetWebhook retrieves a webhook by ID
+func (s *WebhookService) GetWebhook(id uuid.UUID) (*entities.Webhook, error) {
+	webhook, err := s.webhookRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrWebhookNotFound
+	}
+	return webhook, nil
+}
+
+// CreateWebhook validates and stores a new webhook
+func (s *WebhookService) CreateWebhook(req WebhookRequest) (*entities.Webhook, error) {
+	webhook := &entities.Webhook{
+		ID:        uuid.New(),
+		Active:    true,
+		CreatedAt: time.Now(),
+	}
+	if err := applyWebhookRequest(webhook, req); err != nil {
+		return nil, err
+	}
+	if webhook.Secret == "" {
+		secret, err := newWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		webhook.Secret = secret
+	}
+
+	if err := s.webhookRepo.Create(webhook); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// UpdateWebhook replaces the settings of a webhook. Deliveries already created
+// are sent with the new URL and secret.
+func (s *WebhookService) UpdateWebhook(id uuid.UUID, req WebhookRequest) (*entities.Webhook, error) {
+	webhook, err := s.GetWebhook(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyWebhookRequest(webhook, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.webhookRepo.Update(webhook); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// DeleteWebhook deletes a webhook with its delivery log
+func (s *WebhookService) DeleteWebhook(id uuid.UUID) error {
+	if _, err := s.GetWebhook(id); err != nil {
+		return err
+	}
+	return s.webhookRepo.Delete(id)
+}
+
+// GetDeliveries retrieves the delivery log of a webhook, newest first
+func (s *WebhookService) GetDeliveries(webhookID uuid.UUID, filters repositories.WebhookDeliveryFilters) ([]*entities.WebhookDelivery, error) {
+	if _, err := s.GetWebhook(webhookID); err != nil {
+		return nil, err
+	}
+	return s.deliveryRepo.FindByWebhookID(webhookID, filters)
+}
+
+// Redeliver queues a delivery to be sent again with the same payload. The new
+// delivery is sent by the dispatcher and keeps a link to the original.
+func (s *WebhookService) Redeliver(webhookID, deliveryID uuid.UUID) (*entities.WebhookDelivery, error) {
+	if _, err := s.GetWebhook(webhookID); err != nil {
+		return nil, err
+	}
+	original, err := s.deliveryRepo.FindByID(deliveryID)
+	if err != nil || original.WebhookID != webhookID {
+		return nil, ErrWebhookDeliveryNotFound
+	}
+
+	now := time.Now()
+	delivery := &entities.WebhookDelivery{
+		ID:            uuid.New(),
+		WebhookID:     webhookID,
+		EventID:       original.EventID,
+		EventType:     original.EventType,
+		Payload:       original.Payload,
+		Status:        entities.WebhookDeliveryPending,
+		NextAttemptAt: &now,
+		RedeliveryOf:  &original.ID,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := s.deliveryRepo.Create(delivery); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// applyWebhookRequest validates a request and copies it onto the webhook
+func applyWebhookRequest(webhook *entities.Webhook, req WebhookRequest) error {
+	var errs fieldErrors
+
+	target := strings.TrimSpace(req.URL)
+	if parsed, err := url.Parse(target); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		errs.add("url", "url must be an absolute http or https URL")
+	}
+
+	events := make([]entities.WebhookEventType, 0, len(req.Events))
+	seen := make(map[entities.WebhookEventType]bool, len(req.Events))
+	for i, name := range req.Events {
+		eventType := entities.WebhookEventType(strings.TrimSpace(name))
+		if !entities.IsKnownWebhookEvent(eventType) {
+			errs.add(fmt.Sprintf("events[%d]", i), "unknown event type %q", name)
+			continue
+		}
+		if !seen[eventType] {
+			seen[eventType] = true
+			events = append(events, eventType)
+		}
+	}
+
+	if req.Secret != nil && len(*req.Secret) < minWebhookSecretLength {
+		errs.add("secret", "secret must be at least %d characters", minWebhookSecretLength)
+	}
+
+	if err := errs.errOf(ErrInvalidWebhook); err != nil {
+		return err
+	}
+
+	webhook.URL = target
+	webhook.Description = strings.TrimSpace(req.Description)
+	webhook.Events = events
+	if req.Secret != nil {
+		webhook.Secret = *req.Secret
+	}
+	if req.Active != nil {
+		webhook.Active = *req.Active
+	}
+	webhook.UpdatedAt = time.Now()
+	return nil
+}
+
+// newWebhookSecret generates a random signing secret
+func newWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(secret), nil
+}
+
+// webhookEnvelope is the body of every delivery
+type webhookEnvelope struct {
+	ID        uuid.UUID       `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt string          `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// DispatchEvents turns committed outbox events into a pending delivery for each
+// active webhook subscribed to them. Events are claimed with row locks that
+// other dispatchers skip, so several instances can run at once. It returns the
+// number of events dispatched.
+func (s *WebhookService) DispatchEvents() (int, error) {
+	dispatched := 0
+	err := s.txManager.WithinTransaction(func(repos repositories.TxRepositories) error {
+		events, err := repos.Outbox.ClaimPending(webhookBatchSize)
+		if err != nil || len(events) == 0 {
+			return err
+		}
+		webhooks, err := repos.Webhooks.FindActive()
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		ids := make([]uuid.UUID, len(events))
+		for i, event := range events {
+			ids[i] = event.ID
+			payload, err := json.Marshal(webhookEnvelope{
+				ID:        event.ID,
+				Type:      string(event.Type),
+				CreatedAt: event.CreatedAt.UTC().Format(time.RFC3339),
+				Data:      event.Data,
+			})
+			if err != nil {
+				return err
+			}
+			for _, webhook := range webhooks {
+				if !webhook.Subscribes(event.Type) {
+					continue
+				}
+				err := repos.WebhookDeliveries.Create(&entities.WebhookDelivery{
+					ID:            uuid.New(),
+					WebhookID:     webhook.ID,
+					EventID:       event.ID,
+					EventType:     event.Type,
+					Payload:       payload,
+					Status:        entities.WebhookDeliveryPending,
+					NextAttemptAt: &now,
+					CreatedAt:     now,
+					UpdatedAt:     now,
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+		dispatched = len(events)
+		return repos.Outbox.MarkDispatched(ids, now)
+	})
+	return dispatched, err
+}
+
+// DeliverDue sends the pending deliveries whose next attempt is due, in
+// parallel. Failed attempts are retried with exponential backoff until
+// MaxAttempts. It returns the number of successful and failed attempts.
+func (s *WebhookService) DeliverDue() (succeeded int, failed int, err error) {
+	now := time.Now()
+	// Claimed deliveries are postponed past the request timeout, so that another
+	// instance only picks them up again if this one dies while sending
+	deliveries, err := s.deliveryRepo.ClaimDue(now, now.Add(s.config.Timeout+time.Minute), webhookBatchSize)
+	if err != nil || len(deliveries) == 0 {
+		return 0, 0, err
+	}
+
+	// A webhook deleted since the claim takes its deliveries with it
+	webhooks := make(map[uuid.UUID]*entities.Webhook)
+	for _, d := range deliveries {
+		if _, ok := webhooks[d.WebhookID]; !ok {
+			webhook, _ := s.webhookRepo.FindByID(d.WebhookID)
+			webhooks[d.WebhookID] = webhook
+		}
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, d := range deliveries {
+		if webhooks[d.WebhookID] == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(d *entities.WebhookDelivery) {
+			defer wg.Done()
+			ok := s.attempt(webhooks[d.WebhookID], d)
+			mu.Lock()
+			defer mu.Unlock()
+			if ok {
+				succeeded++
+			} else {
+				failed++
+			}
+		}(d)
+	}
+	wg.Wait()
+	return succeeded, failed, nil
+}
+
+// attempt sends a delivery once and records the outcome. It returns true if
+// the webhook accepted the delivery.
+func (s *WebhookService) attempt(webhook *entities.Webhook, delivery *entities.WebhookDelivery) bool {
+	status, err := s.sender.Send(webhook, delivery)
+	now := time.Now()
+	ok := err == nil && status >= 200 && status < 300
+	switch {
+	case ok:
+		delivery.RecordSuccess(status, now)
+	case err != nil:
+		delivery.RecordFailure(nil, truncate(err.Error(), maxWebhookErrorLength), now, s.config.MaxAttempts)
+	default:
+		delivery.RecordFailure(&status, fmt.Sprintf("unexpected response status %d", status), now, s.config.MaxAttempts)
+	}
+
+	if err := s.deliveryRepo.Update(delivery); err != nil {
+		log.Printf("Failed to record webhook delivery %s: %v", delivery.ID, err)
+	}
+	return ok
+}
+
+// RunDispatcher dispatches outbox events and sends due deliveries every
+// interval until ctx is done
+func (s *WebhookService) RunDispatcher(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if dispatched, err := s.DispatchEvents(); err != nil {
+			log.Printf("Webhook dispatcher error: %v", err)
+		} else if dispatched > 0 {
+			log.Printf("Webhook dispatcher queued deliveries for %d events", dispatched)
+		}
+
+		if succeeded, failed, err := s.DeliverDue(); err != nil {
+			log.Printf("Webhook delivery error: %v", err)
+		} else if succeeded > 0 || failed > 0 {
+			log.Printf("Webhook deliveries: %d succeeded, %d failed", succeeded, failed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// truncate shortens s to at most n bytes without splitting a UTF-8 character
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
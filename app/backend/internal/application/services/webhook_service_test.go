@@ -0,0 +1,36 @@
+package services
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		n     int
+		want  string
+	}{
+		{name: "short", value: "timeout", n: 10, want: "timeout"},
+		{name: "exact", value: "timeout", n: 7, want: "timeout"},
+		{name: "ascii", value: "connection refused", n: 10, want: "connection"},
+		{name: "cut inside a two-byte character", value: "señal", n: 3, want: "se"},
+		{name: "cut after a two-byte character", value: "señal", n: 4, want: "señ"},
+		{name: "cut inside a four-byte character", value: "ok 🎉 done", n: 5, want: "ok "},
+		{name: "cut inside the first character", value: "ñ", n: 1, want: ""},
+		{name: "zero", value: "timeout", n: 0, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncate(tt.value, tt.n)
+			if got != tt.want {
+				t.Errorf("truncate(%q, %d) = %q, want %q", tt.value, tt.n, got, tt.want)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("truncate(%q, %d) = %q, not valid UTF-8", tt.value, tt.n, got)
+			}
+		})
+	}
+}
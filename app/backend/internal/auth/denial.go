@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Denial is a request refused by the authorization policy
+type Denial struct {
+	UserID     string
+	Roles      []string
+	Method     string
+	Path       string
+	Permission Permission
+	MapID      string
+	Reason     string
+	CreatedAt  time.Time
+}
+
+// DenialRecorder stores authorization denials for later review
+type DenialRecorder interface {
+	RecordDenial(denial *Denial) error
+}
+
+// ForbiddenResponse is the body of every 403 response
+type ForbiddenResponse struct {
+	Error      string     `json:"error"`
+	Message    string     `json:"message"`
+	Permission Permission `json:"permission,omitempty"`
+	MapID      string     `json:"map_id,omitempty"`
+}
+
+// Guard answers refused requests with a consistent 403 body and records them
+type Guard struct {
+	recorder DenialRecorder
+}
+
+// NewGuard creates a guard that records denials with recorder
+func NewGuard(recorder DenialRecorder) *Guard {
+	return &Guard{recorder: recorder}
+}
+
+// Deny aborts the request with 403 Forbidden and records the denial. Recording
+// failures are logged and never change the response.
+func (g *Guard) Deny(c *gin.Context, permission Permission, mapID string, reason string) {
+	denial := &Denial{
+		Method:     c.Request.Method,
+		Path:       c.Request.URL.Path,
+		Permission: permission,
+		MapID:      mapID,
+		Reason:     reason,
+		CreatedAt:  time.Now(),
+	}
+	if identity, ok := IdentityFromContext(c); ok {
+		denial.UserID = identity.UserID
+		denial.Roles = identity.Roles
+	}
+
+	log.Printf("Access denied: user=%q roles=%s %s %s permission=%s map=%s: %s",
+		denial.UserID, strings.Join(denial.Roles, ","), denial.Method, denial.Path, permission, mapID, reason)
+	if g.recorder != nil {
+		if err := g.recorder.RecordDenial(denial); err != nil {
+			log.Printf("Failed to record access denial: %v", err)
+		}
+	}
+
+	c.AbortWithStatusJSON(http.StatusForbidden, ForbiddenResponse{
+		Error:      "Forbidden",
+		Message:    reason,
+		Permission: permission,
+		MapID:      mapID,
+	})
+}
@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// identityKey is the gin context key holding the authenticated identity
+const identityKey = "auth.identity"
+
+// Identity is the authenticated caller, taken from a verified bearer token
+type Identity struct {
+	UserID   string   `json:"user_id"`
+	UserName string   `json:"user_name"`
+	Email    string   `json:"email,omitempty"`
+	Roles    []string `json:"roles"`
+	MapIDs   []string `json:"map_ids,omitempty"` // Limits scoped roles to these office maps
+}
+
+// HasRole returns true if the identity holds the given role
+func (i *Identity) HasRole(role string) bool {
+	for _, r := range i.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// HasMap returns true if scoped roles of the identity cover the given map. An
+// identity without map IDs is not limited to particular maps.
+func (i *Identity) HasMap(mapID string) bool {
+	if len(i.MapIDs) == 0 {
+		return true
+	}
+	for _, id := range i.MapIDs {
+		if mapID != "" && strings.EqualFold(id, mapID) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetIdentity stores the authenticated identity in the request context
+func SetIdentity(c *gin.Context, identity *Identity) {
+	c.Set(identityKey, identity)
+}
+
+// IdentityFromContext returns the identity stored by the authentication middleware
+func IdentityFromContext(c *gin.Context) (*Identity, bool) {
+	value, ok := c.Get(identityKey)
+	if !ok {
+		return nil, false
+	}
+	identity, ok := value.(*Identity)
+	return identity, ok && identity != nil
+}
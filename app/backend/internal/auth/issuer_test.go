@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"office-reservations/internal/config"
+)
+
+func TestNewVerifierConfiguration(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config.AuthConfig
+		wantErr error
+	}{
+		{name: "secret", cfg: config.AuthConfig{JWTSecret: "secret"}},
+		{name: "JWKS", cfg: config.AuthConfig{JWKSURL: "https://idp.example.com/jwks.json"}},
+		{name: "development issuer with secret", cfg: config.AuthConfig{JWTSecret: "secret", DevIssuer: true}},
+		{name: "nothing configured", cfg: config.AuthConfig{}, wantErr: ErrAuthNotConfigured},
+		{name: "development issuer without secret", cfg: config.AuthConfig{DevIssuer: true}, wantErr: ErrAuthNotConfigured},
+		{name: "development issuer with JWKS only", cfg: config.AuthConfig{JWKSURL: "https://idp.example.com/jwks.json", DevIssuer: true}, wantErr: ErrDevIssuerSecret},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewVerifier(&tt.cfg)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewVerifier() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDevIssuerIssue(t *testing.T) {
+	tests := []struct {
+		name       string
+		superAdmin bool
+		roles      []string
+		wantErr    error
+	}{
+		{name: "employee", roles: []string{RoleEmployee}},
+		{name: "no roles"},
+		{name: "facilities admin", roles: []string{RoleFacilitiesAdmin}},
+		{name: "super admin refused", roles: []string{RoleEmployee, RoleSuperAdmin}, wantErr: ErrRoleNotIssuable},
+		{name: "super admin allowed", superAdmin: true, roles: []string{RoleSuperAdmin}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.AuthConfig{JWTSecret: "secret", DevIssuer: true, DevIssuerSuperAdmin: tt.superAdmin, DevTokenTTL: time.Hour}
+			verifier, err := NewVerifier(&cfg)
+			if err != nil {
+				t.Fatalf("NewVerifier() error = %v", err)
+			}
+
+			token, _, err := NewDevIssuer(&cfg).Issue(Identity{UserID: "john.doe", UserName: "John Doe", Roles: tt.roles})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Issue() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			identity, err := verifier.Verify(token)
+			if err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			}
+			if identity.UserID != "john.doe" || len(identity.Roles) != len(tt.roles) {
+				t.Errorf("Verify() = %+v, want john.doe with roles %v", identity, tt.roles)
+			}
+		})
+	}
+}
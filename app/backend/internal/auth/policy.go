@@ -0,0 +1,71 @@
+package auth
+
+// Roles carried in the token's roles claim. A caller without any of them is an employee.
+const (
+	RoleEmployee        = "employee"
+	RoleFacilitiesAdmin = "facilities_admin"
+	RoleSuperAdmin      = "super_admin"
+)
+
+// Permission names an administrative action checked by the policy
+type Permission string
+
+const (
+	PermManageMaps         Permission = "maps:manage"
+	PermDeleteMaps         Permission = "maps:delete"
+	PermManageSpaces       Permission = "spaces:manage"
+	PermManageReservations Permission = "reservations:manage"
+	PermManagePolicies     Permission = "policies:manage"
+	PermManageClosures     Permission = "closures:manage"
+	PermManageWebhooks     Permission = "webhooks:manage"
+	PermReadAudit          Permission = "audit:read"
+)
+
+// rolePermissions lists what each role may do. Employees can read maps and
+// manage their own reservations, which needs no permission.
+var rolePermissions = map[string][]Permission{
+	RoleEmployee:        {},
+	RoleFacilitiesAdmin: {PermManageMaps, PermManageSpaces, PermManageReservations, PermManagePolicies, PermManageClosures, PermReadAudit},
+	RoleSuperAdmin:      {PermManageMaps, PermDeleteMaps, PermManageSpaces, PermManageReservations, PermManagePolicies, PermManageClosures, PermManageWebhooks, PermReadAudit},
+}
+
+// scopedRoles are limited to the identity's map IDs when it has any
+var scopedRoles = map[string]bool{
+	RoleFacilitiesAdmin: true,
+}
+
+// Allowed returns true if the identity holds the permission for the given office
+// map. An empty mapID asks for the permission across all maps, which scoped
+// roles only have when the identity is not limited to particular maps.
+func Allowed(identity *Identity, permission Permission, mapID string) bool {
+	if identity == nil {
+		return false
+	}
+	for _, role := range identity.Roles {
+		if !grants(role, permission) {
+			continue
+		}
+		if !scopedRoles[role] {
+			return true
+		}
+		if identity.HasMap(mapID) {
+			return true
+		}
+	}
+	return false
+}
+
+// KnownRole returns true if the role is part of the policy
+func KnownRole(role string) bool {
+	_, ok := rolePermissions[role]
+	return ok
+}
+
+func grants(role string, permission Permission) bool {
+	for _, p := range rolePermissions[role] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
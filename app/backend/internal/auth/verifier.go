@@ -0,0 +1,128 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"office-reservations/internal/config"
+)
+
+var (
+	ErrInvalidToken      = errors.New("invalid or expired token")
+	ErrAuthNotConfigured = errors.New("authentication is not configured: set AUTH_JWT_SECRET or AUTH_JWKS_URL")
+	ErrDevIssuerSecret   = errors.New("the development issuer signs tokens with AUTH_JWT_SECRET, which is not set")
+)
+
+// tokenLeeway tolerates small clock differences between the issuer and this server
+const tokenLeeway = 30 * time.Second
+
+// Claims are the JWT claims read from bearer tokens
+type Claims struct {
+	jwt.RegisteredClaims
+	Name              string   `json:"name,omitempty"`
+	PreferredUsername string   `json:"preferred_username,omitempty"`
+	Email             string   `json:"email,omitempty"`
+	Roles             []string `json:"roles,omitempty"`
+	MapIDs            []string `json:"map_ids,omitempty"`
+}
+
+// Verifier validates bearer tokens signed with the shared HMAC secret (HS256) or
+// with a key published at the JWKS URL (RS256, ES256)
+type Verifier struct {
+	secret []byte
+	keys   *KeySet
+	parser *jwt.Parser
+}
+
+// NewVerifier creates a verifier for the configured keys. The development
+// issuer needs the shared secret to sign its tokens.
+func NewVerifier(cfg *config.AuthConfig) (*Verifier, error) {
+	if cfg.JWTSecret == "" && cfg.JWKSURL == "" {
+		return nil, ErrAuthNotConfigured
+	}
+	if cfg.JWTSecret == "" && cfg.DevIssuer {
+		return nil, ErrDevIssuerSecret
+	}
+
+	v := &Verifier{}
+	var methods []string
+	if cfg.JWTSecret != "" {
+		v.secret = []byte(cfg.JWTSecret)
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	if cfg.JWKSURL != "" {
+		v.keys = NewKeySet(cfg.JWKSURL, cfg.JWKSRefreshInterval)
+		methods = append(methods,
+			jwt.SigningMethodRS256.Alg(), jwt.SigningMethodRS384.Alg(), jwt.SigningMethodRS512.Alg(),
+			jwt.SigningMethodES256.Alg(), jwt.SigningMethodES384.Alg(), jwt.SigningMethodES512.Alg(),
+		)
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(methods),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(tokenLeeway),
+	}
+	if cfg.Issuer != "" {
+		options = append(options, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		options = append(options, jwt.WithAudience(cfg.Audience))
+	}
+	v.parser = jwt.NewParser(options...)
+
+	return v, nil
+}
+
+// Verify validates a token and returns the identity it carries
+func (v *Verifier) Verify(tokenString string) (*Identity, error) {
+	claims := &Claims{}
+	if _, err := v.parser.ParseWithClaims(tokenString, claims, v.key); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidToken)
+	}
+
+	identity := &Identity{
+		UserID: claims.Subject,
+		Email:  claims.Email,
+		Roles:  claims.Roles,
+		MapIDs: claims.MapIDs,
+	}
+	switch {
+	case claims.Name != "":
+		identity.UserName = claims.Name
+	case claims.PreferredUsername != "":
+		identity.UserName = claims.PreferredUsername
+	case claims.Email != "":
+		identity.UserName = claims.Email
+	default:
+		identity.UserName = claims.Subject
+	}
+	if identity.Roles == nil {
+		identity.Roles = []string{}
+	}
+	return identity, nil
+}
+
+// key selects the verification key for the token's signing method
+func (v *Verifier) key(token *jwt.Token) (interface{}, error) {
+	switch token.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		if v.secret == nil {
+			return nil, ErrUnknownKey
+		}
+		return v.secret, nil
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+		if v.keys == nil {
+			return nil, ErrUnknownKey
+		}
+		kid, _ := token.Header["kid"].(string)
+		return v.keys.Key(kid)
+	default:
+		return nil, ErrUnknownKey
+	}
+}
@@ -0,0 +1,251 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Config holds application settings loaded from the environment
+type Config struct {
+	// AutoMigrate applies pending schema migrations when the server starts
+	AutoMigrate bool
+
+	// SeriesMaterializeInterval is how often recurring reservation occurrences
+	// are materialized into the booking window
+	SeriesMaterializeInterval time.Duration
+
+	// RequireIfMatch refuses changes of reservations, spaces and maps that do
+	// not name the version they are based on with If-Match
+	RequireIfMatch bool
+
+	CheckIn       CheckInConfig
+	Auth          AuthConfig
+	Webhooks      WebhookConfig
+	Notifications NotificationConfig
+	Realtime      RealtimeConfig
+	Idempotency   IdempotencyConfig
+}
+
+// CheckInConfig holds the desk check-in settings
+type CheckInConfig struct {
+	// WindowBefore is how early before the start time check-in opens
+	WindowBefore time.Duration
+	// WindowAfter is how long after the start time check-in stays open before
+	// the reservation is released as a no-show
+	WindowAfter time.Duration
+	// OfficeOpening is the start time (HH:MM) of all-day reservations on maps
+	// without opening hours for their weekday
+	OfficeOpening string
+	// SweepInterval is how often unclaimed reservations are released
+	SweepInterval time.Duration
+}
+
+// WebhookConfig holds the outgoing webhook settings
+type WebhookConfig struct {
+	// DispatchInterval is how often committed events are handed to webhooks
+	// and due deliveries are sent
+	DispatchInterval time.Duration
+	// Timeout bounds each delivery request
+	Timeout time.Duration
+	// MaxAttempts is how many times a delivery is tried before it is marked as failed
+	MaxAttempts int
+}
+
+// NotificationConfig holds the email notification settings. Notifications are
+// disabled unless an SMTP host is set.
+type NotificationConfig struct {
+	SMTP SMTPConfig
+	// Interval is how often reminders are queued and due notifications are sent
+	Interval time.Duration
+	// DayReminderLead and HourReminderLead are how long before the start of a
+	// booking its reminders are sent
+	DayReminderLead  time.Duration
+	HourReminderLead time.Duration
+	// MaxAttempts is how many times a notification is tried before it is marked as failed
+	MaxAttempts int
+	// OfficeOpening is the start time (HH:MM) of all-day reservations on maps
+	// without opening hours, for their reminders
+	OfficeOpening string
+}
+
+// RealtimeConfig holds the settings of the real-time event streams of office maps
+type RealtimeConfig struct {
+	// Heartbeat is how often an idle stream is sent a keepalive, so that
+	// proxies do not close it
+	Heartbeat time.Duration
+	// ReplayLimit is how many missed events a resuming client is sent. Clients
+	// further behind are told to reload the map instead.
+	ReplayLimit int
+	// Buffer is how many events may wait for a slow client before its stream
+	// is closed
+	Buffer int
+	// RetryInterval is how long to wait before listening for events again
+	// after the database connection is lost
+	RetryInterval time.Duration
+}
+
+// IdempotencyConfig holds the settings of requests retried with an Idempotency-Key header
+type IdempotencyConfig struct {
+	// TTL is how long the response to a key is kept and replayed to retries
+	TTL time.Duration
+	// Lease is how long a request holds its key while in progress; a retry
+	// after the lease lapsed, e.g. because the server stopped mid-request, runs
+	// the request again
+	Lease time.Duration
+	// CleanupInterval is how often expired keys are deleted
+	CleanupInterval time.Duration
+}
+
+// SMTPConfig holds the mail server that notifications are sent through
+type SMTPConfig struct {
+	Host string
+	Port int
+	// Username and Password authenticate with PLAIN auth when set, which
+	// requires TLS unless the server is on localhost
+	Username string
+	Password string
+	// From is the sender address, optionally with a display name
+	From string
+	// ImplicitTLS connects with TLS right away, as on port 465. Otherwise
+	// STARTTLS is used when the server offers it.
+	ImplicitTLS bool
+	// Timeout bounds the conversation with the server for each email
+	Timeout time.Duration
+}
+
+// Enabled returns true if a mail server is configured
+func (c SMTPConfig) Enabled() bool {
+	return c.Host != ""
+}
+
+// AuthConfig holds the bearer token authentication settings. At least one of
+// JWTSecret and JWKSURL must be set unless DevIssuer is enabled.
+type AuthConfig struct {
+	// JWTSecret verifies HS256 tokens and signs development tokens
+	JWTSecret string
+	// JWKSURL serves the public keys that verify RS256 and ES256 tokens
+	JWKSURL string
+	// JWKSRefreshInterval is how long keys fetched from JWKSURL are cached
+	JWKSRefreshInterval time.Duration
+	// Issuer and Audience must match the token's iss and aud claims when set
+	Issuer   string
+	Audience string
+	// DevIssuer enables POST /api/auth/dev-token, which issues a token for any
+	// user. It is meant for local development only.
+	DevIssuer bool
+	// DevIssuerSuperAdmin allows development tokens with the super_admin role
+	DevIssuerSuperAdmin bool
+	// DevTokenTTL is how long development tokens are valid
+	DevTokenTTL time.Duration
+}
+
+// Load reads the configuration from environment variables, falling back to defaults
+func Load() *Config {
+	officeOpening := getEnv("OFFICE_OPENING_TIME", "09:00")
+
+	return &Config{
+		AutoMigrate:               getBool("DB_AUTO_MIGRATE", true),
+		SeriesMaterializeInterval: getDuration("SERIES_MATERIALIZE_INTERVAL", time.Hour),
+		RequireIfMatch:            getBool("REQUIRE_IF_MATCH", false),
+		CheckIn: CheckInConfig{
+			WindowBefore:  getDuration("CHECKIN_WINDOW_BEFORE", 15*time.Minute),
+			WindowAfter:   getDuration("CHECKIN_WINDOW_AFTER", 30*time.Minute),
+			OfficeOpening: officeOpening,
+			SweepInterval: getDuration("NO_SHOW_SWEEP_INTERVAL", time.Minute),
+		},
+		Auth: AuthConfig{
+			JWTSecret:           os.Getenv("AUTH_JWT_SECRET"),
+			JWKSURL:             os.Getenv("AUTH_JWKS_URL"),
+			JWKSRefreshInterval: getDuration("AUTH_JWKS_REFRESH_INTERVAL", time.Hour),
+			Issuer:              os.Getenv("AUTH_ISSUER"),
+			Audience:            os.Getenv("AUTH_AUDIENCE"),
+			DevIssuer:           getBool("AUTH_DEV_ISSUER", false),
+			DevIssuerSuperAdmin: getBool("AUTH_DEV_ISSUER_SUPER_ADMIN", false),
+			DevTokenTTL:         getDuration("AUTH_DEV_TOKEN_TTL", 12*time.Hour),
+		},
+		Webhooks: WebhookConfig{
+			DispatchInterval: getDuration("WEBHOOK_DISPATCH_INTERVAL", 5*time.Second),
+			Timeout:          getDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+			MaxAttempts:      getInt("WEBHOOK_MAX_ATTEMPTS", 10),
+		},
+		Notifications: NotificationConfig{
+			SMTP: SMTPConfig{
+				Host:        os.Getenv("SMTP_HOST"),
+				Port:        getInt("SMTP_PORT", 587),
+				Username:    os.Getenv("SMTP_USERNAME"),
+				Password:    os.Getenv("SMTP_PASSWORD"),
+				From:        getEnv("SMTP_FROM", "Office Reservations <noreply@localhost>"),
+				ImplicitTLS: getBool("SMTP_IMPLICIT_TLS", false),
+				Timeout:     getDuration("SMTP_TIMEOUT", 30*time.Second),
+			},
+			Interval:         getDuration("NOTIFICATION_INTERVAL", time.Minute),
+			DayReminderLead:  getDuration("NOTIFICATION_DAY_REMINDER", 24*time.Hour),
+			HourReminderLead: getDuration("NOTIFICATION_HOUR_REMINDER", time.Hour),
+			MaxAttempts:      getInt("NOTIFICATION_MAX_ATTEMPTS", 5),
+			OfficeOpening:    officeOpening,
+		},
+		Realtime: RealtimeConfig{
+			Heartbeat:     getDuration("REALTIME_HEARTBEAT", 25*time.Second),
+			ReplayLimit:   getInt("REALTIME_REPLAY_LIMIT", 500),
+			Buffer:        getInt("REALTIME_BUFFER", 64),
+			RetryInterval: getDuration("REALTIME_RETRY_INTERVAL", 5*time.Second),
+		},
+		Idempotency: IdempotencyConfig{
+			TTL:             getDuration("IDEMPOTENCY_TTL", 24*time.Hour),
+			Lease:           getDuration("IDEMPOTENCY_LEASE", time.Minute),
+			CleanupInterval: getDuration("IDEMPOTENCY_CLEANUP_INTERVAL", time.Hour),
+		},
+	}
+}
+
+// getEnv gets environment variable with fallback
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// getBool gets a boolean environment variable with fallback
+func getBool(key string, fallback bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("Invalid %s %q, using %t", key, value, fallback)
+		return fallback
+	}
+	return parsed
+}
+
+// getInt gets a positive integer environment variable with fallback
+func getInt(key string, fallback int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed <= 0 {
+		log.Printf("Invalid %s %q, using %d", key, value, fallback)
+		return fallback
+	}
+	return parsed
+}
+
+// getDuration gets a positive duration environment variable with fallback
+func getDuration(key string, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil || parsed <= 0 {
+		log.Printf("Invalid %s %q, using %s", key, value, fallback)
+		return fallback
+	}
+	return parsed
+}
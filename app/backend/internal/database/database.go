@@ -1,8 +1,10 @@
 package database
 
 import (
+	"context"
 	"fmt"
-	"office-reservations/internal/models"
+	"log"
+	"office-reservations/migrations"
 	"os"
 
 	"gorm.io/driver/postgres"
@@ -33,47 +35,31 @@ func Initialize() (*gorm.DB, error) {
 	return db, nil
 }
 
-// RunMigrations runs database migrations
+// RunMigrations applies every pending schema migration
 func RunMigrations(db *gorm.DB) error {
-	// Enable UUID extension
-	if err := db.Exec("CREATE EXTENSION IF NOT EXISTS \"uuid-ossp\"").Error; err != nil {
-		return fmt.Errorf("failed to create uuid extension: %w", err)
+	migrator, err := NewSchemaMigrator(db)
+	if err != nil {
+		return err
 	}
 
-	// Auto migrate models
-	if err := db.AutoMigrate(
-		&models.OfficeMap{},
-		&models.Space{},
-		&models.Reservation{},
-	); err != nil {
+	applied, err := migrator.Up(context.Background())
+	if err != nil {
 		return fmt.Errorf("failed to run migrations: %w", err)
 	}
-
-	// Create indexes
-	if err := createIndexes(db); err != nil {
-		return fmt.Errorf("failed to create indexes: %w", err)
+	if applied > 0 {
+		log.Printf("Applied %d migrations, schema is at version %d", applied, migrator.Latest())
 	}
 
 	return nil
 }
 
-// createIndexes creates database indexes for better performance
-func createIndexes(db *gorm.DB) error {
-	indexes := []string{
-		"CREATE INDEX IF NOT EXISTS idx_spaces_map_id ON spaces(map_id)",
-		"CREATE INDEX IF NOT EXISTS idx_reservations_space_id ON reservations(space_id)",
-		"CREATE INDEX IF NOT EXISTS idx_reservations_date ON reservations(date)",
-		"CREATE INDEX IF NOT EXISTS idx_reservations_user_id ON reservations(user_id)",
-		"CREATE UNIQUE INDEX IF NOT EXISTS idx_reservations_unique ON reservations(space_id, date, start_time) WHERE status = 'active'",
-	}
-
-	for _, index := range indexes {
-		if err := db.Exec(index).Error; err != nil {
-			return fmt.Errorf("failed to create index: %s, error: %w", index, err)
-		}
+// NewSchemaMigrator returns a migrator for the migrations embedded in the binary
+func NewSchemaMigrator(db *gorm.DB) (*Migrator, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database handle: %w", err)
 	}
-
-	return nil
+	return NewMigrator(sqlDB, migrations.FS)
 }
 
 // getEnv gets environment variable with fallback
@@ -0,0 +1,383 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// migrationLockID is the PostgreSQL advisory lock key held while migrating, so
+// replicas starting at the same time apply migrations one after another
+const migrationLockID int64 = 7264101
+
+var (
+	ErrChecksumMismatch = errors.New("migration was modified after it was applied")
+	ErrNoDownMigration  = errors.New("migration has no down script")
+	ErrUnknownMigration = errors.New("database has a migration unknown to this build")
+	ErrUnknownVersion   = errors.New("unknown migration version")
+)
+
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// legacyPreludeFile is run before the first migration on databases created
+// before versioned migrations, which already have a reservations table
+const legacyPreludeFile = "legacy_prelude.sql"
+
+// Migration is a versioned schema change
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       string
+	Down     string
+	Checksum string
+}
+
+// MigrationStatus describes whether a migration has been applied
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+	// Modified is true if the up script changed after it was applied
+	Modified bool
+	// Missing is true if the database has the migration but this build does not
+	Missing bool
+}
+
+// appliedMigration is a row of the schema_migrations table
+type appliedMigration struct {
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+// Migrator applies and rolls back the SQL migrations of an fs.FS
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+	// legacyPrelude prepares databases created before versioned migrations for
+	// the first migration; empty if fsys has none
+	legacyPrelude string
+}
+
+// NewMigrator loads the migrations in fsys, and its legacy prelude if any
+func NewMigrator(db *sql.DB, fsys fs.FS) (*Migrator, error) {
+	migrations, err := LoadMigrations(fsys)
+	if err != nil {
+		return nil, err
+	}
+	prelude, err := fs.ReadFile(fsys, legacyPreludeFile)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read %s: %w", legacyPreludeFile, err)
+	}
+	return &Migrator{db: db, migrations: migrations, legacyPrelude: string(prelude)}, nil
+}
+
+// LoadMigrations reads NNNN_name.up.sql and NNNN_name.down.sql files, sorted by version
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("invalid migration version in %s", entry.Name())
+		}
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", entry.Name(), err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		} else if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has two names: %s and %s", version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = string(content)
+			sum := sha256.Sum256(content)
+			m.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up script", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Latest returns the highest known migration version
+func (m *Migrator) Latest() int64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Status lists every known migration and every applied migration unknown to this build
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var statuses []MigrationStatus
+	err := m.withLock(ctx, func(conn *sql.Conn, applied map[int64]appliedMigration) error {
+		for _, migration := range m.migrations {
+			status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+			if a, ok := applied[migration.Version]; ok {
+				appliedAt := a.AppliedAt
+				status.Applied = true
+				status.AppliedAt = &appliedAt
+				status.Modified = a.Checksum != migration.Checksum
+			}
+			statuses = append(statuses, status)
+		}
+		for version, a := range applied {
+			if m.find(version) == nil {
+				appliedAt := a.AppliedAt
+				statuses = append(statuses, MigrationStatus{Version: version, Name: a.Name, Applied: true, AppliedAt: &appliedAt, Missing: true})
+			}
+		}
+		return nil
+	})
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses, err
+}
+
+// Up applies every pending migration and returns how many were applied
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	return m.To(ctx, m.Latest())
+}
+
+// Down rolls back the given number of applied migrations, newest first
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	count := 0
+	err := m.withLock(ctx, func(conn *sql.Conn, applied map[int64]appliedMigration) error {
+		if err := m.verify(applied, true); err != nil {
+			return err
+		}
+		for i := len(m.migrations) - 1; i >= 0 && count < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+			if err := m.rollback(ctx, conn, migration); err != nil {
+				return err
+			}
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// To migrates up or down until version is the newest applied migration. Version 0
+// rolls back every migration. It returns how many migrations were applied or rolled back.
+func (m *Migrator) To(ctx context.Context, version int64) (int, error) {
+	if version != 0 && m.find(version) == nil {
+		return 0, fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+
+	count := 0
+	err := m.withLock(ctx, func(conn *sql.Conn, applied map[int64]appliedMigration) error {
+		rollingBack := false
+		for v := range applied {
+			if v > version {
+				rollingBack = true
+			}
+		}
+		if err := m.verify(applied, rollingBack); err != nil {
+			return err
+		}
+
+		// Roll back newer migrations first, then apply pending ones in order
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; !ok || migration.Version <= version {
+				continue
+			}
+			if err := m.rollback(ctx, conn, migration); err != nil {
+				return err
+			}
+			count++
+		}
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; ok || migration.Version > version {
+				continue
+			}
+			if err := m.apply(ctx, conn, migration); err != nil {
+				return err
+			}
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// verify refuses to run when an applied migration was modified. Migrations this
+// build does not know are tolerated when moving up, so an older replica can still
+// start, but block rolling back.
+func (m *Migrator) verify(applied map[int64]appliedMigration, rollingBack bool) error {
+	for version, a := range applied {
+		migration := m.find(version)
+		if migration == nil {
+			if rollingBack {
+				return fmt.Errorf("%w: %d_%s", ErrUnknownMigration, version, a.Name)
+			}
+			log.Printf("Database has migration %d_%s, which this build does not know", version, a.Name)
+			continue
+		}
+		if a.Checksum != migration.Checksum {
+			return fmt.Errorf("%w: %d_%s", ErrChecksumMismatch, version, migration.Name)
+		}
+	}
+	return nil
+}
+
+// apply runs an up script and records it in one transaction. The first
+// migration is preceded by the legacy prelude on databases that predate it.
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, migration Migration) error {
+	log.Printf("Applying migration %d_%s", migration.Version, migration.Name)
+	return inTx(ctx, conn, func(tx *sql.Tx) error {
+		if migration.Version == m.migrations[0].Version && m.legacyPrelude != "" {
+			if err := m.prepareLegacy(ctx, tx); err != nil {
+				return err
+			}
+		}
+		if _, err := tx.ExecContext(ctx, migration.Up); err != nil {
+			return fmt.Errorf("migration %d_%s failed: %w", migration.Version, migration.Name, err)
+		}
+		_, err := tx.ExecContext(ctx,
+			"INSERT INTO schema_migrations (version, name, checksum, applied_at) VALUES ($1, $2, $3, NOW())",
+			migration.Version, migration.Name, migration.Checksum)
+		return err
+	})
+}
+
+// prepareLegacy runs the legacy prelude if the database already has a
+// reservations table
+func (m *Migrator) prepareLegacy(ctx context.Context, tx *sql.Tx) error {
+	var legacy bool
+	if err := tx.QueryRowContext(ctx, "SELECT to_regclass('reservations') IS NOT NULL").Scan(&legacy); err != nil {
+		return fmt.Errorf("failed to inspect the existing schema: %w", err)
+	}
+	if !legacy {
+		return nil
+	}
+	log.Printf("Preparing a database created before versioned migrations")
+	if _, err := tx.ExecContext(ctx, m.legacyPrelude); err != nil {
+		return fmt.Errorf("%s failed: %w", legacyPreludeFile, err)
+	}
+	return nil
+}
+
+// rollback runs a down script and removes its record in one transaction
+func (m *Migrator) rollback(ctx context.Context, conn *sql.Conn, migration Migration) error {
+	if migration.Down == "" {
+		return fmt.Errorf("%w: %d_%s", ErrNoDownMigration, migration.Version, migration.Name)
+	}
+	log.Printf("Rolling back migration %d_%s", migration.Version, migration.Name)
+	return inTx(ctx, conn, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, migration.Down); err != nil {
+			return fmt.Errorf("rollback of %d_%s failed: %w", migration.Version, migration.Name, err)
+		}
+		_, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = $1", migration.Version)
+		return err
+	})
+}
+
+// withLock runs fn on a single connection holding the migration advisory lock,
+// with the schema_migrations table created and its rows loaded
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn, applied map[int64]appliedMigration) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockID); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer func() {
+		// Use a fresh context so the lock is released even if ctx was cancelled
+		if _, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockID); err != nil {
+			log.Printf("Failed to release migration lock: %v", err)
+		}
+	}()
+
+	if _, err := conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version BIGINT PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		checksum VARCHAR(64) NOT NULL,
+		applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+	)`); err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	rows, err := conn.QueryContext(ctx, "SELECT version, name, checksum, applied_at FROM schema_migrations")
+	if err != nil {
+		return fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	applied := map[int64]appliedMigration{}
+	for rows.Next() {
+		var version int64
+		var a appliedMigration
+		if err := rows.Scan(&version, &a.Name, &a.Checksum, &a.AppliedAt); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to read schema_migrations: %w", err)
+		}
+		applied[version] = a
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+
+	return fn(conn, applied)
+}
+
+func (m *Migrator) find(version int64) *Migration {
+	for i := range m.migrations {
+		if m.migrations[i].Version == version {
+			return &m.migrations[i]
+		}
+	}
+	return nil
+}
+
+func inTx(ctx context.Context, conn *sql.Conn, fn func(tx *sql.Tx) error) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,87 @@
+package database
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"office-reservations/migrations"
+)
+
+// TestReleasedMigrationsUnchanged guards the checksums recorded by databases
+// that applied the released migrations; editing one makes them refuse to start
+func TestReleasedMigrationsUnchanged(t *testing.T) {
+	released := map[int64]string{
+		1:  "93fa38187f11c319ea51d5d5560e2d66a75db5d492b1f5258c7009067cab69d8",
+		2:  "371ff58c0555242ad5269752972bfac485937e1da5c8c7df52530f7c108bcf4a",
+		3:  "b4f19f3cb8a3d4321e076b4e9e23ac0f30898c98d3b73591a9edac5f8e91c5ac",
+		4:  "efaf288098f5ce936369dde53549c64fb302601dfb2addefd7347badf4fa99ea",
+		5:  "a8c8d5835e070e7f84513171518f099aaf311e0353f48bf76cf224a8c352a497",
+		6:  "96540a8f56e9df01ea1f52cc258a82d628b74e82992829dbe2af0ec8913f5524",
+		7:  "32abd1c6402d132e20682d70d56933b3381b71272aaf4273eb58dcd38aa96403",
+		8:  "db8f3f35b8a76ed3bf64dc492af3aed3a043ec84f6da1698595e83e2e3af0c53",
+		9:  "daedcc03a1ed05341f8724e4666018cbadf9b92722b5c7327ffa411540f9b347",
+		10: "cb265e83b84d0a89d010e689ba1438d0e611783d355161b573f830ad717cad51",
+		11: "971511f8f940a575ac67f0c422b940290a75bcd89fb51b778e5155efd2969f90",
+		12: "1409abbafb3ccc8fa041ab86febf2e57808ee3f043fb900961f3174c44a77427",
+		13: "4687951053e9eb3194d35c7a498afe06a02a2b6a73ee42acfcf65b27360d0c22",
+		14: "4d69668021eef6a4a1e15dc557dbc8e1ad452568c899f57915e3d88378a39ce1",
+	}
+
+	loaded, err := LoadMigrations(migrations.FS)
+	if err != nil {
+		t.Fatalf("LoadMigrations() error = %v", err)
+	}
+	for _, m := range loaded {
+		if want, ok := released[m.Version]; ok && m.Checksum != want {
+			t.Errorf("migration %d_%s was modified after it was released", m.Version, m.Name)
+		}
+	}
+}
+
+func TestNewMigratorLegacyPrelude(t *testing.T) {
+	tests := []struct {
+		name        string
+		files       fstest.MapFS
+		wantPrelude string
+		wantVersion []int64
+	}{
+		{
+			name: "prelude is loaded apart from the migrations",
+			files: fstest.MapFS{
+				"0001_initial.up.sql":   {Data: []byte("CREATE TABLE a ();")},
+				"0001_initial.down.sql": {Data: []byte("DROP TABLE a;")},
+				"0002_more.up.sql":      {Data: []byte("CREATE TABLE b ();")},
+				legacyPreludeFile:       {Data: []byte("ALTER TABLE a ADD COLUMN IF NOT EXISTS c INT;")},
+			},
+			wantPrelude: "ALTER TABLE a ADD COLUMN IF NOT EXISTS c INT;",
+			wantVersion: []int64{1, 2},
+		},
+		{
+			name: "prelude is optional",
+			files: fstest.MapFS{
+				"0001_initial.up.sql": {Data: []byte("CREATE TABLE a ();")},
+			},
+			wantVersion: []int64{1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewMigrator(nil, tt.files)
+			if err != nil {
+				t.Fatalf("NewMigrator() error = %v", err)
+			}
+			if m.legacyPrelude != tt.wantPrelude {
+				t.Errorf("legacyPrelude = %q, want %q", m.legacyPrelude, tt.wantPrelude)
+			}
+			if len(m.migrations) != len(tt.wantVersion) {
+				t.Fatalf("loaded %d migrations, want %d", len(m.migrations), len(tt.wantVersion))
+			}
+			for i, version := range tt.wantVersion {
+				if m.migrations[i].Version != version {
+					t.Errorf("migrations[%d].Version = %d, want %d", i, m.migrations[i].Version, version)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditEntityType names the kind of resource an audit entry is about
+type AuditEntityType string
+
+const (
+	AuditEntityReservation AuditEntityType = "reservation"
+	AuditEntitySpace       AuditEntityType = "space"
+	AuditEntityMap         AuditEntityType = "map"
+)
+
+// AuditAction names the change recorded by an audit entry
+type AuditAction string
+
+const (
+	AuditActionCreate  AuditAction = "create"
+	AuditActionUpdate  AuditAction = "update"
+	AuditActionCancel  AuditAction = "cancel"
+	AuditActionCheckIn AuditAction = "check_in"
+	AuditActionDelete  AuditAction = "delete"
+)
+
+// AuditEntry records one change of a reservation, space or map. Entries are
+// appended in the same transaction as the change and never modified, so they
+// outlive the resources they describe.
+type AuditEntry struct {
+	ID         uuid.UUID
+	EntityType AuditEntityType
+	EntityID   uuid.UUID
+	// MapID is the office map the resource belongs to
+	MapID  *uuid.UUID
+	Action AuditAction
+	// ActorID and ActorName identify who made the change; both are empty for
+	// changes made by the system, such as the no-show sweeper
+	ActorID   string
+	ActorName string
+	Reason    string
+	// Before and After are JSON snapshots of the resource. Before is nil for
+	// created resources and After is nil for deleted ones.
+	Before    []byte
+	After     []byte
+	CreatedAt time.Time
+}
+
+// IsSystem returns true if the change was not made on behalf of a user
+func (e *AuditEntry) IsSystem() bool {
+	return e.ActorID == ""
+}
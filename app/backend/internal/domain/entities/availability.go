@@ -0,0 +1,88 @@
+package entities
+
+import "sort"
+
+// OccupancyState summarizes how much of a time window a space is booked
+type OccupancyState string
+
+const (
+	OccupancyFree    OccupancyState = "free"
+	OccupancyPartial OccupancyState = "partial"
+	OccupancyBooked  OccupancyState = "booked"
+	// OccupancyUnavailable marks spaces that cannot be booked at all
+	OccupancyUnavailable OccupancyState = "unavailable"
+	// OccupancyClosed marks a window that is closed throughout
+	OccupancyClosed OccupancyState = "closed"
+)
+
+// MergeRanges sorts the ranges and merges those that overlap or touch
+func MergeRanges(ranges []TimeRange) []TimeRange {
+	if len(ranges) == 0 {
+		return []TimeRange{}
+	}
+	sorted := append([]TimeRange(nil), ranges...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Start < sorted[j].Start })
+
+	merged := []TimeRange{sorted[0]}
+	for _, r := range sorted[1:] {
+		last := &merged[len(merged)-1]
+		if r.Start <= last.End {
+			if r.End > last.End {
+				last.End = r.End
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+// ClipRanges returns the parts of the ranges that lie inside window, merged
+func ClipRanges(ranges []TimeRange, window TimeRange) []TimeRange {
+	clipped := make([]TimeRange, 0, len(ranges))
+	for _, r := range ranges {
+		if !r.Overlaps(window) {
+			continue
+		}
+		if r.Start < window.Start {
+			r.Start = window.Start
+		}
+		if r.End > window.End {
+			r.End = window.End
+		}
+		clipped = append(clipped, r)
+	}
+	return MergeRanges(clipped)
+}
+
+// FreeRanges returns the parts of window not covered by the busy ranges
+func FreeRanges(window TimeRange, busy []TimeRange) []TimeRange {
+	free := []TimeRange{}
+	start := window.Start
+	for _, r := range ClipRanges(busy, window) {
+		if r.Start > start {
+			free = append(free, TimeRange{Start: start, End: r.Start})
+		}
+		start = r.End
+	}
+	if start < window.End {
+		free = append(free, TimeRange{Start: start, End: window.End})
+	}
+	return free
+}
+
+// Occupancy returns the state of a window given its busy ranges
+func Occupancy(window TimeRange, busy []TimeRange) OccupancyState {
+	booked := 0
+	for _, r := range ClipRanges(busy, window) {
+		booked += r.Duration()
+	}
+	switch {
+	case booked == 0:
+		return OccupancyFree
+	case booked >= window.Duration():
+		return OccupancyBooked
+	default:
+		return OccupancyPartial
+	}
+}
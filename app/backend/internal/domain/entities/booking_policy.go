@@ -0,0 +1,110 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PolicyRule names a rule of a booking policy
+type PolicyRule string
+
+const (
+	RuleDateInPast         PolicyRule = "date_in_past"
+	RuleMaxAdvanceDays     PolicyRule = "max_advance_days"
+	RuleMinDuration        PolicyRule = "min_duration"
+	RuleMaxDuration        PolicyRule = "max_duration"
+	RuleAllowedHours       PolicyRule = "allowed_hours"
+	RuleMaxBookingsPerWeek PolicyRule = "max_bookings_per_week"
+	RuleMaxConsecutiveDays PolicyRule = "max_consecutive_days"
+	RuleBlackoutDate       PolicyRule = "blackout_date"
+	// RuleOpeningHours is broken by bookings outside the opening hours of the map
+	RuleOpeningHours PolicyRule = "opening_hours"
+)
+
+// BookingPolicy restricts the reservations that can be made. The scope fields
+// select the bookings it applies to; a nil scope field matches every map, space
+// type or role. Each rule is optional and only enforced when set.
+type BookingPolicy struct {
+	ID        uuid.UUID
+	Name      string
+	MapID     *uuid.UUID
+	SpaceType *SpaceType
+	Role      *string
+	// MaxAdvanceDays is how many days ahead a booking can be made
+	MaxAdvanceDays *int
+	// MinDurationMinutes and MaxDurationMinutes bound the length of a booking.
+	// All-day bookings last a full day.
+	MinDurationMinutes *int
+	MaxDurationMinutes *int
+	// AllowedStart and AllowedEnd (HH:MM) bound the hours of timed bookings
+	AllowedStart *string
+	AllowedEnd   *string
+	// MaxBookingsPerWeek limits the bookings a user holds in a Monday to Sunday week
+	MaxBookingsPerWeek *int
+	// MaxConsecutiveDays limits how many days in a row a user can book
+	MaxConsecutiveDays *int
+	// BlackoutDates are days on which nothing can be booked
+	BlackoutDates []time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// Applies returns true if the policy covers a booking of a space of the given
+// map and type by a user holding any of the given roles
+func (p *BookingPolicy) Applies(mapID uuid.UUID, spaceType SpaceType, roles []string) bool {
+	if p.MapID != nil && *p.MapID != mapID {
+		return false
+	}
+	if p.SpaceType != nil && *p.SpaceType != spaceType {
+		return false
+	}
+	if p.Role == nil {
+		return true
+	}
+	for _, role := range roles {
+		if role == *p.Role {
+			return true
+		}
+	}
+	return false
+}
+
+// Specificity ranks how narrowly the policy is scoped. A role outweighs a map,
+// which outweighs a space type, so a policy for a role overrides one for a map.
+func (p *BookingPolicy) Specificity() int {
+	specificity := 0
+	if p.Role != nil {
+		specificity += 4
+	}
+	if p.MapID != nil {
+		specificity += 2
+	}
+	if p.SpaceType != nil {
+		specificity++
+	}
+	return specificity
+}
+
+// IsBlackout returns true if date is one of the policy's blackout dates
+func (p *BookingPolicy) IsBlackout(date time.Time) bool {
+	for _, d := range p.BlackoutDates {
+		if d.Year() == date.Year() && d.YearDay() == date.YearDay() {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowedHours returns the time range of the policy's allowed hours, or false
+// if the policy does not restrict them
+func (p *BookingPolicy) AllowedHours() (TimeRange, bool) {
+	if p.AllowedStart == nil && p.AllowedEnd == nil {
+		return TimeRange{}, false
+	}
+	hours, err := NewTimeRange(p.AllowedStart, p.AllowedEnd)
+	if err != nil {
+		return TimeRange{}, false
+	}
+	return hours, true
+}
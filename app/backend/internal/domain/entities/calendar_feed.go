@@ -0,0 +1,12 @@
+package entities
+
+import "time"
+
+// CalendarFeedToken lets calendar clients, which cannot send bearer tokens,
+// read the calendar feeds on behalf of a user. Only a hash of the secret is
+// stored; a user has at most one token.
+type CalendarFeedToken struct {
+	UserID    string
+	TokenHash string
+	CreatedAt time.Time
+}
@@ -0,0 +1,68 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Closure takes a whole map, a space group or a single space out of service,
+// such as a floor closed for a holiday or a meeting room under maintenance.
+// It runs from StartTime on StartDate until EndTime on EndDate; a nil StartTime
+// means midnight and a nil EndTime the end of the day.
+type Closure struct {
+	ID uuid.UUID
+	// MapID is always set; GroupID or SpaceID narrow the closure to part of the map
+	MapID     uuid.UUID
+	GroupID   *uuid.UUID
+	SpaceID   *uuid.UUID
+	StartDate time.Time
+	EndDate   time.Time
+	StartTime *string
+	EndTime   *string
+	Reason    string
+	// ExternalID is the UID of the calendar event the closure was imported from
+	ExternalID *string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// Covers returns true if the closure applies to the space
+func (c *Closure) Covers(space *Space) bool {
+	if space.MapID != c.MapID {
+		return false
+	}
+	if c.SpaceID != nil {
+		return *c.SpaceID == space.ID
+	}
+	if c.GroupID != nil {
+		return space.GroupID != nil && *space.GroupID == *c.GroupID
+	}
+	return true
+}
+
+// RangeOn returns the part of date the closure covers, or false if it does not
+// cover any of it
+func (c *Closure) RangeOn(date time.Time) (TimeRange, bool) {
+	if date.Before(c.StartDate) || date.After(c.EndDate) {
+		return TimeRange{}, false
+	}
+	r := TimeRange{Start: 0, End: MinutesPerDay}
+	if date.Equal(c.StartDate) && c.StartTime != nil {
+		if start, err := ParseClock(*c.StartTime); err == nil {
+			r.Start = start
+		}
+	}
+	if date.Equal(c.EndDate) && c.EndTime != nil {
+		if end, err := ParseClock(*c.EndTime); err == nil {
+			r.End = end
+		}
+	}
+	return r, r.IsValid()
+}
+
+// Blocks returns true if the closure covers any part of timeRange on date
+func (c *Closure) Blocks(date time.Time, timeRange TimeRange) bool {
+	closed, ok := c.RangeOn(date)
+	return ok && closed.Overlaps(timeRange)
+}
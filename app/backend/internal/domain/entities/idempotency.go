@@ -0,0 +1,33 @@
+package entities
+
+import "time"
+
+// IdempotencyRecord remembers a request made with an Idempotency-Key header and,
+// once it completed, its response, so that retries with the same key are given
+// the same response instead of repeating the request
+type IdempotencyRecord struct {
+	// Scope is the user the key belongs to; keys of different users never clash
+	Scope string
+	Key   string
+	// RequestHash identifies the method, path and body of the request
+	RequestHash string
+	// StatusCode, Headers and Body are the response; StatusCode is zero while
+	// the request is in progress
+	StatusCode int
+	Headers    map[string]string
+	Body       []byte
+	CreatedAt  time.Time
+	// ExpiresAt ends the lease of a request in progress, and the replay of
+	// its response once completed
+	ExpiresAt time.Time
+}
+
+// Completed returns true if the response of the request has been stored
+func (r *IdempotencyRecord) Completed() bool {
+	return r.StatusCode != 0
+}
+
+// Expired returns true if the key may be reused for another request at the given time
+func (r *IdempotencyRecord) Expired(at time.Time) bool {
+	return !at.Before(r.ExpiresAt)
+}
@@ -0,0 +1,38 @@
+package entities
+
+// MapGrid is the grid the spaces of a map are placed on
+type MapGrid struct {
+	Width    int
+	Height   int
+	CellSize int
+}
+
+// Contains returns true if the rectangle at (x, y) lies inside the grid
+func (g MapGrid) Contains(x, y, width, height int) bool {
+	return x >= 0 && y >= 0 && x+width <= g.Width && y+height <= g.Height
+}
+
+// LayoutSpace is a space as placed in the JSON data of a map. ID is the client's
+// identifier and becomes the ID of the stored space when it is free.
+type LayoutSpace struct {
+	ID     string
+	Name   string
+	Type   SpaceType
+	X      int
+	Y      int
+	Width  int
+	Height int
+}
+
+// Matches returns true if the stored space already has this name, type and placement
+func (l LayoutSpace) Matches(space *Space) bool {
+	return space.Name == l.Name && space.Type == l.Type &&
+		space.X == l.X && space.Y == l.Y &&
+		space.Width == l.Width && space.Height == l.Height
+}
+
+// MapLayout is the validated JSON data of an office map
+type MapLayout struct {
+	Grid   MapGrid
+	Spaces []LayoutSpace
+}
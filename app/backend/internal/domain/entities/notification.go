@@ -0,0 +1,151 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationKind names an email sent to the holder of a booking
+type NotificationKind string
+
+const (
+	NotificationConfirmation NotificationKind = "confirmation"
+	NotificationUpdate       NotificationKind = "update"
+	NotificationCancellation NotificationKind = "cancellation"
+	NotificationDayReminder  NotificationKind = "day_reminder"
+	NotificationHourReminder NotificationKind = "hour_reminder"
+)
+
+// IsReminder returns true for the kinds sent ahead of a booking
+func (k NotificationKind) IsReminder() bool {
+	return k == NotificationDayReminder || k == NotificationHourReminder
+}
+
+// NotificationStatus represents the state of a queued notification
+type NotificationStatus string
+
+const (
+	NotificationPending NotificationStatus = "pending"
+	NotificationSent    NotificationStatus = "sent"
+	// NotificationSkipped notifications were not sent because the holder opted
+	// out, has no known address or the booking changed before it was due
+	NotificationSkipped NotificationStatus = "skipped"
+	NotificationFailed  NotificationStatus = "failed"
+)
+
+// Retry delays double after each failed attempt, from notificationRetryBase up to notificationRetryMax
+const (
+	notificationRetryBase = time.Minute
+	notificationRetryMax  = time.Hour
+)
+
+// Notification is an email about a booking queued for its holder. A group
+// booking is notified once, through one of its reservations.
+type Notification struct {
+	ID            uuid.UUID
+	UserID        string
+	ReservationID uuid.UUID
+	Kind          NotificationKind
+	// Reason explains a cancellation the holder did not make themselves
+	Reason string
+	// StartsAt is the start of the booking a reminder was queued for. A
+	// booking gets one reminder of each kind per start time.
+	StartsAt *time.Time
+	Status   NotificationStatus
+	Attempts int
+	// NextAttemptAt is when a pending notification is sent next
+	NextAttemptAt *time.Time
+	LastError     string
+	SentAt        *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// RecordSent marks the notification as handed to the mail server
+func (n *Notification) RecordSent(at time.Time) {
+	n.Attempts++
+	n.Status = NotificationSent
+	n.SentAt = &at
+	n.NextAttemptAt = nil
+	n.LastError = ""
+	n.UpdatedAt = at
+}
+
+// Skip marks the notification as not to be sent, for the given reason
+func (n *Notification) Skip(reason string, at time.Time) {
+	n.Status = NotificationSkipped
+	n.NextAttemptAt = nil
+	n.LastError = reason
+	n.UpdatedAt = at
+}
+
+// RecordFailure records a failed attempt and schedules the next one, or marks
+// the notification as failed once maxAttempts have been made
+func (n *Notification) RecordFailure(message string, at time.Time, maxAttempts int) {
+	n.Attempts++
+	n.LastError = message
+	n.UpdatedAt = at
+	if n.Attempts >= maxAttempts {
+		n.Status = NotificationFailed
+		n.NextAttemptAt = nil
+		return
+	}
+	next := at.Add(NotificationRetryDelay(n.Attempts))
+	n.Status = NotificationPending
+	n.NextAttemptAt = &next
+}
+
+// NotificationRetryDelay returns how long to wait after the given number of failed attempts
+func NotificationRetryDelay(attempts int) time.Duration {
+	delay := notificationRetryBase
+	for i := 1; i < attempts && delay < notificationRetryMax; i++ {
+		delay *= 2
+	}
+	if delay > notificationRetryMax {
+		delay = notificationRetryMax
+	}
+	return delay
+}
+
+// NotificationPreferences holds where a user is notified and which
+// notifications they receive. Users without stored preferences receive all.
+type NotificationPreferences struct {
+	UserID string
+	// Email is taken from the user's bearer token; users without one are not notified
+	Email         string
+	Confirmations bool
+	Cancellations bool
+	DayReminders  bool
+	HourReminders bool
+	UpdatedAt     time.Time
+}
+
+// DefaultNotificationPreferences returns the preferences of a user who has not
+// opted out of anything
+func DefaultNotificationPreferences(userID string) *NotificationPreferences {
+	return &NotificationPreferences{
+		UserID:        userID,
+		Confirmations: true,
+		Cancellations: true,
+		DayReminders:  true,
+		HourReminders: true,
+	}
+}
+
+// Allows returns true if the user receives notifications of the kind.
+// Confirmations cover both new and changed bookings.
+func (p *NotificationPreferences) Allows(kind NotificationKind) bool {
+	switch kind {
+	case NotificationConfirmation, NotificationUpdate:
+		return p.Confirmations
+	case NotificationCancellation:
+		return p.Cancellations
+	case NotificationDayReminder:
+		return p.DayReminders
+	case NotificationHourReminder:
+		return p.HourReminders
+	default:
+		return false
+	}
+}
@@ -12,7 +12,21 @@ type OfficeMap struct {
 	Name        string
 	Description string
 	JSONData    map[string]interface{}
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	// Timezone is the IANA name of the zone the office is in. Reservation dates
+	// and times on the map are local to it.
+	Timezone string
+	// OpeningHours are the hours the office can be booked, or nil if it is always open
+	OpeningHours *OpeningHours
+	// Version is incremented by every update; an update based on an older
+	// version is rejected
+	Version   int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// Spaces are the spaces of the map, when loaded
+	Spaces []*Space
 }
 
+// Location returns the time zone of the map
+func (m *OfficeMap) Location() *time.Location {
+	return LoadLocation(m.Timezone)
+}
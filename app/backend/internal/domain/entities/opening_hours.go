@@ -0,0 +1,71 @@
+package entities
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultTimezone is the zone of maps that do not set one
+const DefaultTimezone = "UTC"
+
+// OpeningHours holds the hours an office is open on each weekday, indexed by
+// time.Weekday. A nil day means the office is closed that day.
+type OpeningHours [7]*TimeRange
+
+// On returns the opening hours on the weekday of date, or false if the office
+// is closed that day. A nil OpeningHours is open all day, every day.
+func (h *OpeningHours) On(date time.Time) (TimeRange, bool) {
+	if h == nil {
+		return TimeRange{Start: 0, End: MinutesPerDay}, true
+	}
+	day := h[date.Weekday()]
+	if day == nil {
+		return TimeRange{}, false
+	}
+	return *day, true
+}
+
+// WeekdayName returns the lowercase English name of a weekday, as used in the
+// opening hours of the API
+func WeekdayName(day time.Weekday) string {
+	return strings.ToLower(day.String())
+}
+
+// ParseWeekday parses a weekday name such as "monday", ignoring case
+func ParseWeekday(name string) (time.Weekday, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if WeekdayName(day) == name {
+			return day, true
+		}
+	}
+	return 0, false
+}
+
+var locations sync.Map // zone name -> *time.Location
+
+// LoadLocation returns the time zone with the given IANA name, or UTC if the
+// name is empty or unknown. Loaded zones are cached.
+func LoadLocation(name string) *time.Location {
+	if name == "" {
+		name = DefaultTimezone
+	}
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	locations.Store(name, loc)
+	return loc
+}
+
+// LocalDay returns the calendar date of t in loc, as midnight UTC like the
+// stored reservation dates, and the minutes elapsed since local midnight
+func LocalDay(t time.Time, loc *time.Location) (time.Time, int) {
+	local := t.In(loc)
+	date := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+	return date, local.Hour()*60 + local.Minute()
+}
@@ -0,0 +1,289 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RecurrenceFrequency represents how often a recurrence rule repeats
+type RecurrenceFrequency string
+
+const (
+	FrequencyDaily   RecurrenceFrequency = "DAILY"
+	FrequencyWeekly  RecurrenceFrequency = "WEEKLY"
+	FrequencyMonthly RecurrenceFrequency = "MONTHLY"
+)
+
+// maxRecurrenceCount bounds COUNT so a single series cannot generate unbounded occurrences
+const maxRecurrenceCount = 366
+
+var (
+	ErrInvalidRecurrenceRule = errors.New("invalid recurrence rule")
+)
+
+var weekdayCodes = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// RecurrenceRule is a subset of the iCalendar RRULE (RFC 5545) supporting
+// FREQ=DAILY|WEEKLY|MONTHLY, INTERVAL, BYDAY, COUNT and UNTIL
+type RecurrenceRule struct {
+	Frequency RecurrenceFrequency
+	Interval  int
+	ByDay     []time.Weekday
+	Count     *int
+	Until     *time.Time
+}
+
+// ParseRecurrenceRule parses an RRULE string such as
+// "FREQ=WEEKLY;INTERVAL=1;BYDAY=MO,WE;COUNT=10". A leading "RRULE:" prefix is accepted.
+func ParseRecurrenceRule(value string) (*RecurrenceRule, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	if value == "" {
+		return nil, fmt.Errorf("%w: empty rule", ErrInvalidRecurrenceRule)
+	}
+
+	rule := &RecurrenceRule{Interval: 1}
+	for _, part := range strings.Split(value, ";") {
+		if part == "" {
+			continue
+		}
+		key, val, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: malformed part %q", ErrInvalidRecurrenceRule, part)
+		}
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			rule.Frequency = RecurrenceFrequency(strings.ToUpper(val))
+		case "INTERVAL":
+			interval, err := strconv.Atoi(val)
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid INTERVAL", ErrInvalidRecurrenceRule)
+			}
+			rule.Interval = interval
+		case "BYDAY":
+			for _, code := range strings.Split(val, ",") {
+				day, ok := weekdayCodes[strings.ToUpper(strings.TrimSpace(code))]
+				if !ok {
+					return nil, fmt.Errorf("%w: invalid BYDAY value %q", ErrInvalidRecurrenceRule, code)
+				}
+				rule.ByDay = append(rule.ByDay, day)
+			}
+		case "COUNT":
+			count, err := strconv.Atoi(val)
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid COUNT", ErrInvalidRecurrenceRule)
+			}
+			rule.Count = &count
+		case "UNTIL":
+			until, err := parseRecurrenceDate(val)
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid UNTIL", ErrInvalidRecurrenceRule)
+			}
+			rule.Until = &until
+		default:
+			return nil, fmt.Errorf("%w: unsupported part %q", ErrInvalidRecurrenceRule, key)
+		}
+	}
+
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// parseRecurrenceDate accepts the iCalendar DATE/DATE-TIME forms as well as YYYY-MM-DD
+func parseRecurrenceDate(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102", "20060102T150405Z", "20060102T150405", "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), nil
+		}
+	}
+	return time.Time{}, ErrInvalidRecurrenceRule
+}
+
+// Validate checks the rule for unsupported or contradictory values
+func (r *RecurrenceRule) Validate() error {
+	switch r.Frequency {
+	case FrequencyDaily, FrequencyWeekly, FrequencyMonthly:
+	case "":
+		return fmt.Errorf("%w: FREQ is required", ErrInvalidRecurrenceRule)
+	default:
+		return fmt.Errorf("%w: unsupported FREQ %q", ErrInvalidRecurrenceRule, r.Frequency)
+	}
+	if r.Interval < 1 {
+		return fmt.Errorf("%w: INTERVAL must be positive", ErrInvalidRecurrenceRule)
+	}
+	if r.Count != nil && r.Until != nil {
+		return fmt.Errorf("%w: COUNT and UNTIL are mutually exclusive", ErrInvalidRecurrenceRule)
+	}
+	if r.Count != nil && (*r.Count < 1 || *r.Count > maxRecurrenceCount) {
+		return fmt.Errorf("%w: COUNT must be between 1 and %d", ErrInvalidRecurrenceRule, maxRecurrenceCount)
+	}
+	return nil
+}
+
+// String formats the rule in canonical RRULE form (without the "RRULE:" prefix)
+func (r *RecurrenceRule) String() string {
+	parts := []string{"FREQ=" + string(r.Frequency)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		codes := make([]string, len(r.ByDay))
+		for i, day := range r.sortedByDay() {
+			for code, d := range weekdayCodes {
+				if d == day {
+					codes[i] = code
+				}
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+	if r.Count != nil {
+		parts = append(parts, "COUNT="+strconv.Itoa(*r.Count))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.Format("20060102"))
+	}
+	return strings.Join(parts, ";")
+}
+
+// sortedByDay returns BYDAY ordered from Monday to Sunday (the RFC 5545 default week start)
+func (r *RecurrenceRule) sortedByDay() []time.Weekday {
+	days := append([]time.Weekday(nil), r.ByDay...)
+	sort.Slice(days, func(i, j int) bool {
+		return mondayOffset(days[i]) < mondayOffset(days[j])
+	})
+	return days
+}
+
+// mondayOffset returns the number of days between Monday and the given weekday
+func mondayOffset(day time.Weekday) int {
+	return (int(day) + 6) % 7
+}
+
+// Occurrences returns the occurrence dates of a series starting on start that fall
+// within [from, to]. COUNT is counted from start, so occurrences before from still
+// consume the count.
+func (r *RecurrenceRule) Occurrences(start, from, to time.Time) []time.Time {
+	start = truncateToDate(start)
+	from = truncateToDate(from)
+	to = truncateToDate(to)
+
+	var result []time.Time
+	emitted := 0
+	// done reports whether generation must stop after considering date
+	consider := func(date time.Time) (done bool) {
+		if date.Before(start) {
+			return false
+		}
+		if r.Until != nil && date.After(*r.Until) {
+			return true
+		}
+		if r.Count != nil && emitted >= *r.Count {
+			return true
+		}
+		if date.After(to) {
+			return true
+		}
+		emitted++
+		if !date.Before(from) {
+			result = append(result, date)
+		}
+		return false
+	}
+
+	switch r.Frequency {
+	case FrequencyDaily:
+		for date := start; ; date = date.AddDate(0, 0, r.Interval) {
+			if len(r.ByDay) > 0 && !r.hasDay(date.Weekday()) {
+				if date.After(to) {
+					break
+				}
+				continue
+			}
+			if consider(date) {
+				break
+			}
+		}
+	case FrequencyWeekly:
+		days := r.sortedByDay()
+		if len(days) == 0 {
+			days = []time.Weekday{start.Weekday()}
+		}
+		weekStart := start.AddDate(0, 0, -mondayOffset(start.Weekday()))
+		for ; !weekStart.After(to); weekStart = weekStart.AddDate(0, 0, 7*r.Interval) {
+			stop := false
+			for _, day := range days {
+				if consider(weekStart.AddDate(0, 0, mondayOffset(day))) {
+					stop = true
+					break
+				}
+			}
+			if stop {
+				break
+			}
+		}
+	case FrequencyMonthly:
+		for months := 0; ; months += r.Interval {
+			monthStart := time.Date(start.Year(), start.Month()+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+			if monthStart.After(to) {
+				break
+			}
+			stop := false
+			for _, date := range r.monthlyCandidates(start, monthStart) {
+				if consider(date) {
+					stop = true
+					break
+				}
+			}
+			if stop {
+				break
+			}
+		}
+	}
+
+	return result
+}
+
+// monthlyCandidates returns the candidate dates of a monthly rule within the month
+// beginning at monthStart. Without BYDAY the start's day of month is used and months
+// lacking that day are skipped, as RFC 5545 requires.
+func (r *RecurrenceRule) monthlyCandidates(start, monthStart time.Time) []time.Time {
+	var candidates []time.Time
+	for date := monthStart; date.Month() == monthStart.Month(); date = date.AddDate(0, 0, 1) {
+		if len(r.ByDay) > 0 {
+			if r.hasDay(date.Weekday()) {
+				candidates = append(candidates, date)
+			}
+		} else if date.Day() == start.Day() {
+			candidates = append(candidates, date)
+		}
+	}
+	return candidates
+}
+
+func (r *RecurrenceRule) hasDay(day time.Weekday) bool {
+	for _, d := range r.ByDay {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// truncateToDate drops the time of day, keeping the calendar date in UTC
+func truncateToDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
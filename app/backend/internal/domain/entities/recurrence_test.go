@@ -0,0 +1,183 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func mustDate(value string) time.Time {
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestParseRecurrenceRule(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{name: "daily", value: "FREQ=DAILY", want: "FREQ=DAILY"},
+		{name: "prefix and lower case", value: "RRULE:freq=weekly;byday=we,mo;count=4", want: "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=4"},
+		{name: "interval and until", value: "FREQ=MONTHLY;INTERVAL=2;UNTIL=2024-06-30", want: "FREQ=MONTHLY;INTERVAL=2;UNTIL=20240630"},
+		{name: "until date-time", value: "FREQ=DAILY;UNTIL=20240105T235959Z", want: "FREQ=DAILY;UNTIL=20240105"},
+		{name: "empty", value: " ", wantErr: true},
+		{name: "missing FREQ", value: "COUNT=3", wantErr: true},
+		{name: "unsupported FREQ", value: "FREQ=YEARLY", wantErr: true},
+		{name: "malformed part", value: "FREQ=DAILY;COUNT", wantErr: true},
+		{name: "unsupported part", value: "FREQ=DAILY;BYMONTH=1", wantErr: true},
+		{name: "invalid BYDAY", value: "FREQ=WEEKLY;BYDAY=XX", wantErr: true},
+		{name: "zero interval", value: "FREQ=DAILY;INTERVAL=0", wantErr: true},
+		{name: "COUNT with UNTIL", value: "FREQ=DAILY;COUNT=2;UNTIL=20240105", wantErr: true},
+		{name: "COUNT too large", value: "FREQ=DAILY;COUNT=367", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRecurrenceRule(tt.value)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidRecurrenceRule) {
+					t.Fatalf("ParseRecurrenceRule(%q) error = %v, want ErrInvalidRecurrenceRule", tt.value, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseRecurrenceRule(%q) error = %v", tt.value, err)
+			}
+			if got := rule.String(); got != tt.want {
+				t.Errorf("ParseRecurrenceRule(%q).String() = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReservationSeriesOccurrences(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  string
+		start string
+		from  string
+		to    string
+		want  []string
+	}{
+		{
+			name:  "daily count",
+			rule:  "FREQ=DAILY;COUNT=3",
+			start: "2024-01-01", from: "2024-01-01", to: "2024-12-31",
+			want: []string{"2024-01-01", "2024-01-02", "2024-01-03"},
+		},
+		{
+			name:  "daily until",
+			rule:  "FREQ=DAILY;INTERVAL=2;UNTIL=20240106",
+			start: "2024-01-01", from: "2024-01-01", to: "2024-12-31",
+			want: []string{"2024-01-01", "2024-01-03", "2024-01-05"},
+		},
+		{
+			name:  "daily limited to weekdays",
+			rule:  "FREQ=DAILY;BYDAY=MO,FR",
+			start: "2024-01-01", from: "2024-01-01", to: "2024-01-09",
+			want: []string{"2024-01-01", "2024-01-05", "2024-01-08"},
+		},
+		{
+			name:  "occurrences before the window consume the count",
+			rule:  "FREQ=DAILY;COUNT=5",
+			start: "2024-01-01", from: "2024-01-04", to: "2024-12-31",
+			want: []string{"2024-01-04", "2024-01-05"},
+		},
+		{
+			name:  "weekly on several days",
+			rule:  "FREQ=WEEKLY;BYDAY=WE,MO;COUNT=4",
+			start: "2024-01-01", from: "2024-01-01", to: "2024-12-31",
+			want: []string{"2024-01-01", "2024-01-03", "2024-01-08", "2024-01-10"},
+		},
+		{
+			name:  "weekly defaults to the start weekday",
+			rule:  "FREQ=WEEKLY;COUNT=3",
+			start: "2024-01-04", from: "2024-01-01", to: "2024-12-31",
+			want: []string{"2024-01-04", "2024-01-11", "2024-01-18"},
+		},
+		{
+			name:  "weekly skips days before the start",
+			rule:  "FREQ=WEEKLY;BYDAY=MO;COUNT=2",
+			start: "2024-01-03", from: "2024-01-01", to: "2024-12-31",
+			want: []string{"2024-01-08", "2024-01-15"},
+		},
+		{
+			name:  "every other week until",
+			rule:  "FREQ=WEEKLY;INTERVAL=2;BYDAY=FR;UNTIL=20240201",
+			start: "2024-01-05", from: "2024-01-01", to: "2024-12-31",
+			want: []string{"2024-01-05", "2024-01-19"},
+		},
+		{
+			name:  "weekly window ends the series",
+			rule:  "FREQ=WEEKLY",
+			start: "2024-01-01", from: "2024-01-10", to: "2024-01-31",
+			want: []string{"2024-01-15", "2024-01-22", "2024-01-29"},
+		},
+		{
+			name:  "monthly skips months without the day",
+			rule:  "FREQ=MONTHLY;COUNT=3",
+			start: "2024-01-31", from: "2024-01-01", to: "2024-12-31",
+			want: []string{"2024-01-31", "2024-03-31", "2024-05-31"},
+		},
+		{
+			name:  "monthly on weekdays",
+			rule:  "FREQ=MONTHLY;BYDAY=TU;COUNT=6",
+			start: "2024-01-20", from: "2024-01-01", to: "2024-12-31",
+			want: []string{"2024-01-23", "2024-01-30", "2024-02-06", "2024-02-13", "2024-02-20", "2024-02-27"},
+		},
+		{
+			name:  "window before the start",
+			rule:  "FREQ=DAILY",
+			start: "2024-02-01", from: "2024-01-01", to: "2024-01-31",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRecurrenceRule(tt.rule)
+			if err != nil {
+				t.Fatalf("ParseRecurrenceRule(%q) error = %v", tt.rule, err)
+			}
+			series := &ReservationSeries{StartDate: mustDate(tt.start), Rule: rule}
+
+			got := series.Occurrences(mustDate(tt.from), mustDate(tt.to))
+			if len(got) != len(tt.want) {
+				t.Fatalf("Occurrences() = %v, want %v", got, tt.want)
+			}
+			for i, want := range tt.want {
+				if !got[i].Equal(mustDate(want)) {
+					t.Errorf("Occurrences()[%d] = %s, want %s", i, got[i].Format("2006-01-02"), want)
+				}
+			}
+		})
+	}
+}
+
+func TestReservationSeriesExceptionFor(t *testing.T) {
+	exception := &SeriesException{OccurrenceDate: mustDate("2024-01-03"), Cancelled: true}
+	series := &ReservationSeries{Exceptions: []*SeriesException{exception}}
+
+	tests := []struct {
+		name string
+		date time.Time
+		want *SeriesException
+	}{
+		{name: "same date", date: mustDate("2024-01-03"), want: exception},
+		{name: "time of day is ignored", date: mustDate("2024-01-03").Add(15 * time.Hour), want: exception},
+		{name: "other date", date: mustDate("2024-01-04"), want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := series.ExceptionFor(tt.date); got != tt.want {
+				t.Errorf("ExceptionFor(%s) = %v, want %v", tt.date, got, tt.want)
+			}
+		})
+	}
+}
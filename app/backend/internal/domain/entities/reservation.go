@@ -12,8 +12,23 @@ type ReservationStatus string
 const (
 	ReservationStatusActive    ReservationStatus = "active"
 	ReservationStatusCancelled ReservationStatus = "cancelled"
+	ReservationStatusCheckedIn ReservationStatus = "checked_in"
+	ReservationStatusNoShow    ReservationStatus = "no_show"
+	ReservationStatusCompleted ReservationStatus = "completed"
 )
 
+// IsValid returns true if s is one of the known reservation statuses
+func (s ReservationStatus) IsValid() bool {
+	switch s {
+	case ReservationStatusActive, ReservationStatusCancelled, ReservationStatusCheckedIn, ReservationStatusNoShow, ReservationStatusCompleted:
+		return true
+	}
+	return false
+}
+
+// HoldingStatuses are the statuses of reservations that keep their space occupied
+var HoldingStatuses = []ReservationStatus{ReservationStatusActive, ReservationStatusCheckedIn}
+
 // Reservation represents a booking for a space in the domain
 type Reservation struct {
 	ID        uuid.UUID
@@ -25,6 +40,18 @@ type Reservation struct {
 	EndTime   *string
 	Status    ReservationStatus
 	Notes     string
+	// SeriesID and OccurrenceDate link a materialized occurrence to its series
+	SeriesID       *uuid.UUID
+	OccurrenceDate *time.Time
+	CheckedInAt    *time.Time
+	// GroupBookingID is shared by the reservations created together when a
+	// space group is booked as one logical reservation
+	GroupBookingID *uuid.UUID
+	// Timezone is the zone of the office map of the space, when loaded
+	Timezone string
+	// Version is incremented by every update; an update based on an older
+	// version is rejected
+	Version   int64
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
@@ -39,9 +66,80 @@ func (r *Reservation) IsCancelled() bool {
 	return r.Status == ReservationStatusCancelled
 }
 
+// IsCheckedIn returns true if the holder has checked in
+func (r *Reservation) IsCheckedIn() bool {
+	return r.Status == ReservationStatusCheckedIn
+}
+
+// HoldsSpace returns true if the reservation keeps its space occupied
+func (r *Reservation) HoldsSpace() bool {
+	for _, status := range HoldingStatuses {
+		if r.Status == status {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckIn marks the reservation as claimed by its holder
+func (r *Reservation) CheckIn(at time.Time) {
+	r.Status = ReservationStatusCheckedIn
+	r.CheckedInAt = &at
+	r.UpdatedAt = time.Now()
+}
+
+// CanChangeStatusTo returns true if the reservation can be moved to status by an
+// update. Holding reservations can be cancelled and checked-in ones completed;
+// check-in has its own flow and no-shows are final.
+func (r *Reservation) CanChangeStatusTo(status ReservationStatus) bool {
+	switch {
+	case status == r.Status:
+		return true
+	case status == ReservationStatusCancelled:
+		return r.HoldsSpace()
+	case status == ReservationStatusCompleted:
+		return r.IsCheckedIn()
+	default:
+		return false
+	}
+}
+
 // Cancel marks the reservation as cancelled
 func (r *Reservation) Cancel() {
 	r.Status = ReservationStatusCancelled
 	r.UpdatedAt = time.Now()
 }
 
+// TimeRange returns the interval covered by the reservation within its date.
+// All-day reservations (nil StartTime and EndTime) cover the full day.
+func (r *Reservation) TimeRange() (TimeRange, error) {
+	return NewTimeRange(r.StartTime, r.EndTime)
+}
+
+// Interval returns the instants at which the reservation starts and ends, in
+// the time zone of its office map
+func (r *Reservation) Interval() (time.Time, time.Time, error) {
+	timeRange, err := r.TimeRange()
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	start, end := timeRange.In(r.Date, LoadLocation(r.Timezone))
+	return start, end, nil
+}
+
+// Overlaps returns true if both reservations are on the same date and their
+// time ranges intersect
+func (r *Reservation) Overlaps(other *Reservation) bool {
+	if r.Date.Format("2006-01-02") != other.Date.Format("2006-01-02") {
+		return false
+	}
+	a, err := r.TimeRange()
+	if err != nil {
+		return false
+	}
+	b, err := other.TimeRange()
+	if err != nil {
+		return false
+	}
+	return a.Overlaps(b)
+}
@@ -0,0 +1,78 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SeriesStatus represents the status of a reservation series
+type SeriesStatus string
+
+const (
+	SeriesStatusActive    SeriesStatus = "active"
+	SeriesStatusCancelled SeriesStatus = "cancelled"
+)
+
+// ReservationSeries represents a recurring booking whose occurrences are
+// materialized into individual reservations as they enter the booking window
+type ReservationSeries struct {
+	ID         uuid.UUID
+	SpaceID    uuid.UUID
+	UserID     string
+	UserName   string
+	StartDate  time.Time
+	StartTime  *string
+	EndTime    *string
+	Rule       *RecurrenceRule
+	Notes      string
+	Status     SeriesStatus
+	Exceptions []*SeriesException
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	// BookedBy is the user who created the series; its occurrences are booked
+	// on their behalf. Nil for series created before it was recorded.
+	BookedBy *SeriesBooker
+}
+
+// SeriesBooker records who created a series, with the roles that select the
+// booking policies applied to its occurrences
+type SeriesBooker struct {
+	UserID   string
+	UserName string
+	Admin    bool
+	Roles    []string
+}
+
+// SeriesException overrides or cancels a single occurrence of a series,
+// identified by the date the occurrence was originally scheduled for
+type SeriesException struct {
+	SeriesID       uuid.UUID
+	OccurrenceDate time.Time
+	Cancelled      bool
+	StartTime      *string
+	EndTime        *string
+	Notes          *string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// IsActive returns true if the series is active
+func (s *ReservationSeries) IsActive() bool {
+	return s.Status == SeriesStatusActive
+}
+
+// Occurrences returns the scheduled occurrence dates within [from, to]
+func (s *ReservationSeries) Occurrences(from, to time.Time) []time.Time {
+	return s.Rule.Occurrences(s.StartDate, from, to)
+}
+
+// ExceptionFor returns the exception registered for an occurrence date, if any
+func (s *ReservationSeries) ExceptionFor(date time.Time) *SeriesException {
+	for _, e := range s.Exceptions {
+		if e.OccurrenceDate.Format("2006-01-02") == date.Format("2006-01-02") {
+			return e
+		}
+	}
+	return nil
+}
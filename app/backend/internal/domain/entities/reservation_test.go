@@ -0,0 +1,46 @@
+package entities
+
+import "testing"
+
+func TestReservationOverlaps(t *testing.T) {
+	clock := func(value string) *string { return &value }
+
+	tests := []struct {
+		name     string
+		date     string
+		start    *string
+		end      *string
+		overlaps bool
+	}{
+		{name: "same range", date: "2024-03-04", start: clock("09:00"), end: clock("10:00"), overlaps: true},
+		{name: "partial overlap", date: "2024-03-04", start: clock("09:30"), end: clock("11:00"), overlaps: true},
+		{name: "contained", date: "2024-03-04", start: clock("09:15"), end: clock("09:45"), overlaps: true},
+		{name: "ends where it starts", date: "2024-03-04", start: clock("08:00"), end: clock("09:00")},
+		{name: "starts where it ends", date: "2024-03-04", start: clock("10:00"), end: clock("11:00")},
+		{name: "all day", date: "2024-03-04", overlaps: true},
+		{name: "open start", date: "2024-03-04", end: clock("09:01"), overlaps: true},
+		{name: "open start ending before", date: "2024-03-04", end: clock("09:00")},
+		{name: "open end", date: "2024-03-04", start: clock("09:59"), overlaps: true},
+		{name: "open end starting after", date: "2024-03-04", start: clock("10:00")},
+		{name: "other date", date: "2024-03-05", start: clock("09:00"), end: clock("10:00")},
+		{name: "all day on another date", date: "2024-03-03"},
+	}
+
+	existing := &Reservation{Date: mustDate("2024-03-04"), StartTime: clock("09:00"), EndTime: clock("10:00")}
+	allDay := &Reservation{Date: mustDate("2024-03-04")}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Reservation{Date: mustDate(tt.date), StartTime: tt.start, EndTime: tt.end}
+			if got := r.Overlaps(existing); got != tt.overlaps {
+				t.Errorf("Overlaps() = %v, want %v", got, tt.overlaps)
+			}
+			if got := existing.Overlaps(r); got != tt.overlaps {
+				t.Errorf("Overlaps() reversed = %v, want %v", got, tt.overlaps)
+			}
+			// An all-day reservation overlaps everything on its date
+			if got, want := r.Overlaps(allDay), tt.date == "2024-03-04"; got != want {
+				t.Errorf("Overlaps() with an all-day reservation = %v, want %v", got, want)
+			}
+		})
+	}
+}
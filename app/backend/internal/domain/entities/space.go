@@ -1,8 +1,6 @@
 package entities
 
 import (
-	"regexp"
-	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -18,17 +16,30 @@ const (
 	SpaceTypeInvalidSpace SpaceType = "invalid_space"
 )
 
+// IsValid returns true if t is one of the known space types
+func (t SpaceType) IsValid() bool {
+	switch t {
+	case SpaceTypeWorkstation, SpaceTypeMeetingRoom, SpaceTypeCubicle, SpaceTypeInvalidSpace:
+		return true
+	}
+	return false
+}
+
 // Space represents an individual space in the office domain
 type Space struct {
-	ID        uuid.UUID
-	MapID     uuid.UUID
-	Name      string
-	Type      SpaceType
-	X         int
-	Y         int
-	Width     int
-	Height    int
-	Capacity  int
+	ID       uuid.UUID
+	MapID    uuid.UUID
+	GroupID  *uuid.UUID
+	Name     string
+	Type     SpaceType
+	X        int
+	Y        int
+	Width    int
+	Height   int
+	Capacity int
+	// Version is incremented by every update; an update based on an older
+	// version is rejected
+	Version   int64
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
@@ -38,15 +49,7 @@ func (s *Space) IsMeetingRoom() bool {
 	return s.Type == SpaceTypeMeetingRoom
 }
 
-// GetBaseName extracts the base name from a meeting room (removes trailing numbers)
-// Uses the same regex pattern as the backend: \s*\d+$
-func (s *Space) GetBaseName() string {
-	if !s.IsMeetingRoom() {
-		return s.Name
-	}
-	// Use regex to remove trailing numbers (same as backend)
-	re := regexp.MustCompile(`\s*\d+$`)
-	baseName := re.ReplaceAllString(s.Name, "")
-	return strings.TrimSpace(baseName)
+// IsGrouped returns true if the space belongs to a space group
+func (s *Space) IsGrouped() bool {
+	return s.GroupID != nil
 }
-
@@ -0,0 +1,29 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SpaceGroup represents several spaces that are booked together as one unit,
+// such as the hexagons that make up a meeting room
+type SpaceGroup struct {
+	ID        uuid.UUID
+	MapID     uuid.UUID
+	Name      string
+	Capacity  int
+	SpaceIDs  []uuid.UUID
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// HasSpace returns true if the space is a member of the group
+func (g *SpaceGroup) HasSpace(spaceID uuid.UUID) bool {
+	for _, id := range g.SpaceIDs {
+		if id == spaceID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MinutesPerDay is the length of a full day expressed in minutes
+const MinutesPerDay = 24 * 60
+
+// ErrInvalidClock is returned when a time of day cannot be parsed
+var ErrInvalidClock = errors.New("invalid time of day")
+
+// TimeRange represents a half-open interval [Start, End) within a single day,
+// expressed in minutes since midnight
+type TimeRange struct {
+	Start int
+	End   int
+}
+
+// ParseClock parses a time of day in HH:MM or HH:MM:SS format and returns
+// the number of minutes since midnight
+func ParseClock(value string) (int, error) {
+	value = strings.TrimSpace(value)
+	if len(value) > 5 {
+		value = value[:5]
+	}
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, ErrInvalidClock
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// FormatClock formats minutes since midnight as HH:MM
+func FormatClock(minutes int) string {
+	if minutes >= MinutesPerDay {
+		return "24:00"
+	}
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// NewTimeRange builds a time range from optional start and end times.
+// A nil start means the range begins at midnight and a nil end means it runs
+// until the end of the day, so two nil values describe an all-day range.
+func NewTimeRange(startTime, endTime *string) (TimeRange, error) {
+	r := TimeRange{Start: 0, End: MinutesPerDay}
+	if startTime != nil {
+		start, err := ParseClock(*startTime)
+		if err != nil {
+			return TimeRange{}, err
+		}
+		r.Start = start
+	}
+	if endTime != nil {
+		end, err := ParseClock(*endTime)
+		if err != nil {
+			return TimeRange{}, err
+		}
+		r.End = end
+	}
+	return r, nil
+}
+
+// IsAllDay returns true if the range covers the whole day
+func (r TimeRange) IsAllDay() bool {
+	return r.Start == 0 && r.End == MinutesPerDay
+}
+
+// IsValid returns true if the range starts before it ends
+func (r TimeRange) IsValid() bool {
+	return r.Start < r.End
+}
+
+// Duration returns the length of the range in minutes
+func (r TimeRange) Duration() int {
+	return r.End - r.Start
+}
+
+// Overlaps returns true if both ranges share at least one minute
+func (r TimeRange) Overlaps(other TimeRange) bool {
+	return r.Start < other.End && other.Start < r.End
+}
+
+// In returns the instants at which the range starts and ends on date in loc.
+// An all-day range runs from local midnight to the next local midnight.
+func (r TimeRange) In(date time.Time, loc *time.Location) (time.Time, time.Time) {
+	return AtClock(date, r.Start, loc), AtClock(date, r.End, loc)
+}
+
+// AtClock returns the instant at the given minutes since midnight on date in loc
+func AtClock(date time.Time, minutes int, loc *time.Location) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, minutes, 0, 0, loc)
+}
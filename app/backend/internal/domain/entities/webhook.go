@@ -0,0 +1,168 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookEventType names a lifecycle event that webhooks can subscribe to
+type WebhookEventType string
+
+const (
+	WebhookReservationCreated   WebhookEventType = "reservation.created"
+	WebhookReservationUpdated   WebhookEventType = "reservation.updated"
+	WebhookReservationCancelled WebhookEventType = "reservation.cancelled"
+	WebhookMapUpdated           WebhookEventType = "map.updated"
+	WebhookSpaceCreated         WebhookEventType = "space.created"
+	WebhookSpaceUpdated         WebhookEventType = "space.updated"
+	WebhookSpaceDeleted         WebhookEventType = "space.deleted"
+)
+
+// WebhookEventTypes lists every event type, in documentation order
+var WebhookEventTypes = []WebhookEventType{
+	WebhookReservationCreated,
+	WebhookReservationUpdated,
+	WebhookReservationCancelled,
+	WebhookMapUpdated,
+	WebhookSpaceCreated,
+	WebhookSpaceUpdated,
+	WebhookSpaceDeleted,
+}
+
+// IsKnownWebhookEvent returns true if the event type exists
+func IsKnownWebhookEvent(eventType WebhookEventType) bool {
+	for _, t := range WebhookEventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Webhook is a subscription of an external system to lifecycle events. Each
+// delivery is signed with the webhook's secret.
+type Webhook struct {
+	ID          uuid.UUID
+	URL         string
+	Secret      string
+	Description string
+	// Events are the event types delivered to the webhook; empty means all
+	Events []WebhookEventType
+	// Active webhooks receive deliveries; deliveries to inactive ones wait
+	Active    bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Subscribes returns true if the webhook receives events of the type
+func (w *Webhook) Subscribes(eventType WebhookEventType) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, t := range w.Events {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// OutboxEvent is a lifecycle event stored in the same transaction as the change
+// it describes. Once committed it is handed to the subscribed webhooks, so no
+// event is lost or sent for a change that was rolled back.
+type OutboxEvent struct {
+	ID   uuid.UUID
+	Type WebhookEventType
+	// Seq numbers events in the order they were appended. It is assigned by the
+	// database and used as the event ID of real-time streams; the events of a
+	// map are committed in Seq order.
+	Seq int64
+	// MapID is the office map the event concerns, if any
+	MapID *uuid.UUID
+	// Data is the JSON encoded subject of the event
+	Data      []byte
+	CreatedAt time.Time
+	// DispatchedAt is set once deliveries have been created for the event
+	DispatchedAt *time.Time
+}
+
+// WebhookDeliveryStatus represents the state of a webhook delivery
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+// Retry delays double after each failed attempt, from webhookRetryBase up to webhookRetryMax
+const (
+	webhookRetryBase = 30 * time.Second
+	webhookRetryMax  = 6 * time.Hour
+)
+
+// WebhookDelivery is one event sent, or to be sent, to one webhook. Failed
+// attempts are retried with exponential backoff until maxAttempts.
+type WebhookDelivery struct {
+	ID        uuid.UUID
+	WebhookID uuid.UUID
+	EventID   uuid.UUID
+	EventType WebhookEventType
+	// Payload is the request body, kept so that retries send the same bytes
+	Payload  []byte
+	Status   WebhookDeliveryStatus
+	Attempts int
+	// NextAttemptAt is when a pending delivery is sent next
+	NextAttemptAt *time.Time
+	LastAttemptAt *time.Time
+	// ResponseStatus is the HTTP status of the last attempt, if it got a response
+	ResponseStatus *int
+	LastError      string
+	// RedeliveryOf is the delivery this one repeats on request
+	RedeliveryOf *uuid.UUID
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// RecordSuccess marks the delivery as received by the webhook
+func (d *WebhookDelivery) RecordSuccess(status int, at time.Time) {
+	d.Attempts++
+	d.Status = WebhookDeliverySucceeded
+	d.ResponseStatus = &status
+	d.LastAttemptAt = &at
+	d.NextAttemptAt = nil
+	d.LastError = ""
+	d.UpdatedAt = at
+}
+
+// RecordFailure records a failed attempt and schedules the next one, or marks
+// the delivery as failed once maxAttempts have been made. status is nil when
+// no response was received.
+func (d *WebhookDelivery) RecordFailure(status *int, message string, at time.Time, maxAttempts int) {
+	d.Attempts++
+	d.ResponseStatus = status
+	d.LastAttemptAt = &at
+	d.LastError = message
+	d.UpdatedAt = at
+	if d.Attempts >= maxAttempts {
+		d.Status = WebhookDeliveryFailed
+		d.NextAttemptAt = nil
+		return
+	}
+	next := at.Add(WebhookRetryDelay(d.Attempts))
+	d.Status = WebhookDeliveryPending
+	d.NextAttemptAt = &next
+}
+
+// WebhookRetryDelay returns how long to wait after the given number of failed attempts
+func WebhookRetryDelay(attempts int) time.Duration {
+	delay := webhookRetryBase
+	for i := 1; i < attempts && delay < webhookRetryMax; i++ {
+		delay *= 2
+	}
+	if delay > webhookRetryMax {
+		delay = webhookRetryMax
+	}
+	return delay
+}
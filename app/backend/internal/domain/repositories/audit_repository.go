@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"office-reservations/internal/domain/entities"
+)
+
+// AuditRepository defines the interface for the append-only audit log
+type AuditRepository interface {
+	// Append records an entry
+	Append(entry *entities.AuditEntry) error
+
+	// FindAll retrieves entries with optional filters, newest first
+	FindAll(filters AuditFilters) ([]*entities.AuditEntry, error)
+}
+
+// AuditFilters contains optional filters for querying the audit log
+type AuditFilters struct {
+	EntityType *entities.AuditEntityType
+	EntityID   *uuid.UUID
+	MapID      *uuid.UUID
+	ActorID    *string
+	Action     *entities.AuditAction
+	// From and To bound the time of the change, inclusive
+	From *time.Time
+	To   *time.Time
+	// Limit caps the number of entries returned; zero means no limit
+	Limit int
+}
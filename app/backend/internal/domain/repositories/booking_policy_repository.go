@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"github.com/google/uuid"
+	"office-reservations/internal/domain/entities"
+)
+
+// BookingPolicyRepository defines the interface for booking policy data operations
+type BookingPolicyRepository interface {
+	// FindByID finds a policy by its ID
+	FindByID(id uuid.UUID) (*entities.BookingPolicy, error)
+
+	// FindAll retrieves all policies
+	FindAll() ([]*entities.BookingPolicy, error)
+
+	// FindForMap retrieves the policies of a map together with the policies
+	// that apply to every map
+	FindForMap(mapID uuid.UUID) ([]*entities.BookingPolicy, error)
+
+	// Create creates a new policy
+	Create(policy *entities.BookingPolicy) error
+
+	// Update updates an existing policy
+	Update(policy *entities.BookingPolicy) error
+
+	// Delete deletes a policy
+	Delete(id uuid.UUID) error
+}
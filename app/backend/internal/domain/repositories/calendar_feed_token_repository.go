@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"office-reservations/internal/domain/entities"
+)
+
+// CalendarFeedTokenRepository defines the interface for calendar feed token data operations
+type CalendarFeedTokenRepository interface {
+	// FindByHash finds the token with the given secret hash
+	FindByHash(tokenHash string) (*entities.CalendarFeedToken, error)
+
+	// Save stores the token of a user, replacing the previous one
+	Save(token *entities.CalendarFeedToken) error
+
+	// DeleteByUserID deletes the token of a user
+	DeleteByUserID(userID string) error
+}
@@ -0,0 +1,37 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"office-reservations/internal/domain/entities"
+)
+
+// ClosureRepository defines the interface for closure data operations
+type ClosureRepository interface {
+	// FindByID finds a closure by its ID
+	FindByID(id uuid.UUID) (*entities.Closure, error)
+
+	// FindAll retrieves the closures matching the filters, ordered by start
+	FindAll(filters ClosureFilters) ([]*entities.Closure, error)
+
+	// FindByExternalID finds the closures of a map imported from a calendar event
+	FindByExternalID(mapID uuid.UUID, externalID string) ([]*entities.Closure, error)
+
+	// Create creates a new closure
+	Create(closure *entities.Closure) error
+
+	// Update updates an existing closure
+	Update(closure *entities.Closure) error
+
+	// Delete deletes a closure
+	Delete(id uuid.UUID) error
+}
+
+// ClosureFilters contains optional filters for querying closures. From and To
+// select the closures that overlap the date range.
+type ClosureFilters struct {
+	MapID *uuid.UUID
+	From  *time.Time
+	To    *time.Time
+}
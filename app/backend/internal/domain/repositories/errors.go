@@ -0,0 +1,13 @@
+package repositories
+
+import "errors"
+
+var (
+	// ErrReservationOverlap is returned when a write is rejected because the
+	// reservation would overlap another active reservation on the same space
+	ErrReservationOverlap = errors.New("reservation overlaps an existing active reservation")
+
+	// ErrVersionConflict is returned when an update is rejected because the
+	// resource was changed or deleted since the version it is based on was read
+	ErrVersionConflict = errors.New("resource was modified concurrently")
+)
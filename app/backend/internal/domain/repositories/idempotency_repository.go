@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"time"
+
+	"office-reservations/internal/domain/entities"
+)
+
+// IdempotencyRepository defines the interface for idempotency key data operations
+type IdempotencyRepository interface {
+	// Create stores a record unless its scope already has one with the same key.
+	// It returns false if the key was taken.
+	Create(record *entities.IdempotencyRecord) (bool, error)
+
+	// Find finds the record of a key
+	Find(scope, key string) (*entities.IdempotencyRecord, error)
+
+	// Complete stores the response of a record and when it expires
+	Complete(record *entities.IdempotencyRecord) error
+
+	// Delete deletes the record of a key
+	Delete(scope, key string) error
+
+	// DeleteExpired deletes the records that expired before the given time and
+	// returns how many were deleted
+	DeleteExpired(before time.Time) (int64, error)
+}
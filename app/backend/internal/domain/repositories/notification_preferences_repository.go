@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"office-reservations/internal/domain/entities"
+)
+
+// NotificationPreferencesRepository defines the interface for notification preference data operations
+type NotificationPreferencesRepository interface {
+	// FindByUserID finds the preferences of a user
+	FindByUserID(userID string) (*entities.NotificationPreferences, error)
+
+	// Save stores the preferences of a user, replacing the previous ones
+	Save(preferences *entities.NotificationPreferences) error
+
+	// SaveEmail stores the email address of a user, keeping their other
+	// preferences or creating default ones
+	SaveEmail(userID, email string) error
+}
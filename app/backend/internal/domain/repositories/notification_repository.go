@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"time"
+
+	"office-reservations/internal/domain/entities"
+)
+
+// NotificationRepository defines the interface for notification queue operations
+type NotificationRepository interface {
+	// Create queues a notification. It returns false without error if a
+	// reminder of the same kind was already queued for the booking and start time.
+	Create(notification *entities.Notification) (bool, error)
+
+	// Update updates an existing notification
+	Update(notification *entities.Notification) error
+
+	// ClaimDue returns up to limit pending notifications whose next attempt is
+	// due at now, and postpones them to leaseUntil so that no other sender
+	// picks them up while they are being sent
+	ClaimDue(now, leaseUntil time.Time, limit int) ([]*entities.Notification, error)
+}
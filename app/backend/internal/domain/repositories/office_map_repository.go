@@ -1,25 +1,42 @@
 package repositories
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"office-reservations/internal/domain/entities"
 )
 
 // OfficeMapRepository defines the interface for office map data operations
 type OfficeMapRepository interface {
-	// FindByID finds a map by its ID
+	// FindByID finds a map by its ID, including its spaces
 	FindByID(id uuid.UUID) (*entities.OfficeMap, error)
-	
-	// FindAll retrieves all maps
+
+	// FindAll retrieves all maps, including their spaces
 	FindAll() ([]*entities.OfficeMap, error)
-	
+
+	// FindPage retrieves one page of the maps matching the filters, without
+	// their spaces. They can be sorted by name (default), created_at or updated_at.
+	FindPage(filters MapFilters, page PageRequest) ([]*entities.OfficeMap, PageInfo, error)
+
 	// Create creates a new map
 	Create(m *entities.OfficeMap) error
-	
-	// Update updates an existing map
+
+	// Update updates an existing map if it is still at its version, which is
+	// then incremented. It returns ErrVersionConflict otherwise.
 	Update(m *entities.OfficeMap) error
-	
-	// Delete deletes a map
+
+	// Delete marks a map as deleted and deletes its groups, closures and
+	// booking policies. Its spaces must have been removed beforehand; they are
+	// kept with their reservations.
 	Delete(id uuid.UUID) error
 }
 
+// MapFilters contains optional filters for listing office maps
+type MapFilters struct {
+	// NameSearch selects maps whose name contains it, ignoring case
+	NameSearch *string
+	// CreatedFrom and CreatedTo bound when maps were created, inclusive
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+}
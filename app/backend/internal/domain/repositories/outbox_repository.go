@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"office-reservations/internal/domain/entities"
+)
+
+// OutboxRepository defines the interface for the transactional outbox of
+// lifecycle events
+type OutboxRepository interface {
+	// Append stores an event; within a transaction it is only visible once
+	// committed. The events of a map become visible in sequence order.
+	Append(event *entities.OutboxEvent) error
+
+	// ClaimPending locks up to limit undispatched events, oldest first, skipping
+	// events locked by other dispatchers. It must run within a transaction.
+	ClaimPending(limit int) ([]*entities.OutboxEvent, error)
+
+	// MarkDispatched records that deliveries were created for the events
+	MarkDispatched(ids []uuid.UUID, at time.Time) error
+
+	// FindBySeq finds an event by its sequence number
+	FindBySeq(seq int64) (*entities.OutboxEvent, error)
+
+	// FindByMapAfter returns up to limit events of a map appended after the
+	// event numbered afterSeq, in sequence order
+	FindByMapAfter(mapID uuid.UUID, afterSeq int64, limit int) ([]*entities.OutboxEvent, error)
+
+	// LastSeqByMap returns the sequence number of the latest event of a map, or
+	// 0 if it has none
+	LastSeqByMap(mapID uuid.UUID) (int64, error)
+}
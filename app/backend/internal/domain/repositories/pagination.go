@@ -0,0 +1,36 @@
+package repositories
+
+import "errors"
+
+var (
+	// ErrInvalidCursor is returned when a page cursor is malformed or was issued
+	// for another sort order
+	ErrInvalidCursor = errors.New("invalid cursor")
+
+	// ErrInvalidSort is returned when a listing cannot be sorted by the requested field
+	ErrInvalidSort = errors.New("invalid sort field")
+)
+
+// PageRequest selects one page of a listing. Pages are keyed on the sort field
+// and the ID, so that items added or removed meanwhile never shift later pages.
+type PageRequest struct {
+	// Limit is the page size; zero means no limit
+	Limit int
+	// Cursor continues after the last item of a previous page; empty for the first page
+	Cursor string
+	// Sort is the field the listing is ordered by; empty for its default order
+	Sort string
+	// Descending reverses the order
+	Descending bool
+}
+
+// PageInfo describes where a page ends
+type PageInfo struct {
+	// NextCursor continues after the last item of the page; empty on the last page
+	NextCursor string
+}
+
+// HasMore returns true if the listing continues after the page
+func (p PageInfo) HasMore() bool {
+	return p.NextCursor != ""
+}
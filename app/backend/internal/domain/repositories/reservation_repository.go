@@ -11,41 +11,64 @@ import (
 type ReservationRepository interface {
 	// FindByID finds a reservation by its ID
 	FindByID(id uuid.UUID) (*entities.Reservation, error)
-	
+
 	// FindAll retrieves all reservations with optional filters
 	FindAll(filters ReservationFilters) ([]*entities.Reservation, error)
-	
+
+	// FindPage retrieves one page of the reservations matching the filters.
+	// They can be sorted by date (default), created_at, updated_at or user_name.
+	FindPage(filters ReservationFilters, page PageRequest) ([]*entities.Reservation, PageInfo, error)
+
 	// Create creates a new reservation
 	Create(reservation *entities.Reservation) error
-	
-	// Update updates an existing reservation
+
+	// Update updates an existing reservation if it is still at its version,
+	// which is then incremented. It returns ErrVersionConflict otherwise.
+	// Reservations are cancelled rather than deleted.
 	Update(reservation *entities.Reservation) error
-	
-	// Delete deletes a reservation (soft delete by setting status to cancelled)
-	Delete(id uuid.UUID) error
-	
-	// DeleteBySpaceAndTime deletes reservations for a specific space, date, and time
-	DeleteBySpaceAndTime(spaceID uuid.UUID, date time.Time, startTime *string) error
-	
-	// DeleteBySpaceIDsAndTime deletes reservations for multiple spaces with same date and time
-	DeleteBySpaceIDsAndTime(spaceIDs []uuid.UUID, date time.Time, startTime *string) error
-	
+
 	// FindBySpaceAndDate finds reservations for a specific space and date
 	FindBySpaceAndDate(spaceID uuid.UUID, date time.Time) ([]*entities.Reservation, error)
-	
+
 	// FindBySpaceIDsAndDate finds reservations for multiple spaces and date
 	FindBySpaceIDsAndDate(spaceIDs []uuid.UUID, date time.Time) ([]*entities.Reservation, error)
-	
-	// FindActiveBySpaceAndTime finds active reservations for a space, date, and time
-	FindActiveBySpaceAndTime(spaceID uuid.UUID, date time.Time, startTime *string) (*entities.Reservation, error)
+
+	// TransitionStatus changes the status of a reservation only if it still has the
+	// expected status. It returns false if the reservation was changed concurrently.
+	TransitionStatus(id uuid.UUID, from, to entities.ReservationStatus) (bool, error)
+
+	// FindOverlapping finds active reservations on any of the given spaces whose
+	// time range intersects [startTime, endTime) on the given date.
+	// Nil times are treated as the start and end of the day respectively.
+	FindOverlapping(spaceIDs []uuid.UUID, date time.Time, startTime, endTime *string) ([]*entities.Reservation, error)
+
+	// FindUpcomingBySpaceIDs finds reservations on any of the given spaces from the
+	// given date on that still hold their space, ordered by date and start time
+	FindUpcomingBySpaceIDs(spaceIDs []uuid.UUID, from time.Time) ([]*entities.Reservation, error)
+
+	// FindHoldingBySpaceIDsInRange finds the reservations on any of the given spaces
+	// between the two dates, inclusive, that hold their space, in a single query
+	FindHoldingBySpaceIDsInRange(spaceIDs []uuid.UUID, from, to time.Time) ([]*entities.Reservation, error)
 }
 
 // ReservationFilters contains optional filters for querying reservations
 type ReservationFilters struct {
-	From    *time.Time
-	To      *time.Time
-	UserID  *string
-	SpaceID *uuid.UUID
-	Status  *entities.ReservationStatus
+	From     *time.Time
+	To       *time.Time
+	UserID   *string
+	SpaceID  *uuid.UUID
+	MapID    *uuid.UUID
+	Status   *entities.ReservationStatus
+	SeriesID *uuid.UUID
+	// GroupBookingID selects the reservations booked together for a space group
+	GroupBookingID *uuid.UUID
+	// Statuses selects reservations having any of the statuses
+	Statuses []entities.ReservationStatus
+	// SpaceType selects reservations of spaces of a type
+	SpaceType *entities.SpaceType
+	// UserNameSearch selects holders whose name contains it, ignoring case
+	UserNameSearch *string
+	// CreatedFrom and CreatedTo bound when reservations were made, inclusive
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
 }
-
@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"github.com/google/uuid"
+	"office-reservations/internal/domain/entities"
+)
+
+// ReservationSeriesRepository defines the interface for reservation series data operations
+type ReservationSeriesRepository interface {
+	// FindByID finds a series by its ID, including its exceptions
+	FindByID(id uuid.UUID) (*entities.ReservationSeries, error)
+
+	// FindActive retrieves all active series, including their exceptions
+	FindActive() ([]*entities.ReservationSeries, error)
+
+	// Create creates a new series
+	Create(series *entities.ReservationSeries) error
+
+	// Update updates an existing series
+	Update(series *entities.ReservationSeries) error
+
+	// Delete deletes a series with its exceptions
+	Delete(id uuid.UUID) error
+
+	// SaveException creates or replaces the exception for an occurrence date
+	SaveException(exception *entities.SeriesException) error
+
+	// CancelBySpaceIDs cancels the active series on the given spaces
+	CancelBySpaceIDs(spaceIDs []uuid.UUID) error
+}
@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"github.com/google/uuid"
+	"office-reservations/internal/domain/entities"
+)
+
+// SpaceGroupRepository defines the interface for space group data operations
+type SpaceGroupRepository interface {
+	// FindByID finds a group by its ID, including its member spaces
+	FindByID(id uuid.UUID) (*entities.SpaceGroup, error)
+
+	// FindByMapID finds all groups of a map
+	FindByMapID(mapID uuid.UUID) ([]*entities.SpaceGroup, error)
+
+	// FindAll retrieves all groups
+	FindAll() ([]*entities.SpaceGroup, error)
+
+	// Create creates a new group and assigns its member spaces
+	Create(group *entities.SpaceGroup) error
+
+	// Update updates an existing group and replaces its member spaces
+	Update(group *entities.SpaceGroup) error
+
+	// Delete deletes a group, leaving its spaces ungrouped
+	Delete(id uuid.UUID) error
+}
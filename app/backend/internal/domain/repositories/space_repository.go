@@ -1,6 +1,8 @@
 package repositories
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"office-reservations/internal/domain/entities"
 )
@@ -9,23 +11,54 @@ import (
 type SpaceRepository interface {
 	// FindByID finds a space by its ID
 	FindByID(id uuid.UUID) (*entities.Space, error)
-	
+
+	// FindAll retrieves all spaces
+	FindAll() ([]*entities.Space, error)
+
+	// FindPage retrieves one page of the spaces matching the filters. They can
+	// be sorted by name (default), type, position or created_at.
+	FindPage(filters SpaceFilters, page PageRequest) ([]*entities.Space, PageInfo, error)
+
 	// FindByMapID finds all spaces for a specific map
 	FindByMapID(mapID uuid.UUID) ([]*entities.Space, error)
-	
+
 	// FindByTypeAndMapID finds spaces by type and map ID
 	FindByTypeAndMapID(spaceType entities.SpaceType, mapID uuid.UUID) ([]*entities.Space, error)
-	
-	// FindMeetingRoomsByBaseName finds meeting room spaces with the same base name
-	FindMeetingRoomsByBaseName(baseName string, mapID uuid.UUID) ([]*entities.Space, error)
-	
+
+	// FindByGroupID finds all member spaces of a space group
+	FindByGroupID(groupID uuid.UUID) ([]*entities.Space, error)
+
+	// FindByIDs finds the spaces with the given IDs
+	FindByIDs(ids []uuid.UUID) ([]*entities.Space, error)
+
+	// LockByIDs acquires row locks on the given spaces until the surrounding
+	// transaction ends, serializing bookings that touch the same spaces
+	LockByIDs(ids []uuid.UUID) error
+
 	// Create creates a new space
 	Create(space *entities.Space) error
-	
-	// Update updates an existing space
+
+	// Update updates an existing space if it is still at its version, which is
+	// then incremented. It returns ErrVersionConflict otherwise.
 	Update(space *entities.Space) error
-	
-	// Delete deletes a space
-	Delete(id uuid.UUID) error
+
+	// IDTaken reports whether a space has the ID, including soft-deleted spaces
+	IDTaken(id uuid.UUID) (bool, error)
+
+	// SoftDeleteByIDs removes the spaces with the given IDs from their groups and
+	// marks them deleted. They are no longer found, but are kept with their
+	// reservations as history.
+	SoftDeleteByIDs(ids []uuid.UUID) error
 }
 
+// SpaceFilters contains optional filters for listing spaces
+type SpaceFilters struct {
+	MapID   *uuid.UUID
+	Type    *entities.SpaceType
+	GroupID *uuid.UUID
+	// NameSearch selects spaces whose name contains it, ignoring case
+	NameSearch *string
+	// CreatedFrom and CreatedTo bound when spaces were created, inclusive
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+}
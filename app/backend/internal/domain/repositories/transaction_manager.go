@@ -0,0 +1,24 @@
+package repositories
+
+// TxRepositories groups the repositories bound to a single transaction
+type TxRepositories struct {
+	Reservations ReservationRepository
+	Series       ReservationSeriesRepository
+	Spaces       SpaceRepository
+	SpaceGroups  SpaceGroupRepository
+	Maps         OfficeMapRepository
+	Closures     ClosureRepository
+	// Outbox records lifecycle events atomically with the changes they describe
+	Outbox            OutboxRepository
+	Webhooks          WebhookRepository
+	WebhookDeliveries WebhookDeliveryRepository
+	// Audit records who changed what atomically with the change
+	Audit AuditRepository
+}
+
+// TransactionManager defines the contract for running a unit of work atomically
+type TransactionManager interface {
+	// WithinTransaction runs fn with repositories bound to one transaction.
+	// The transaction is committed when fn returns nil and rolled back otherwise.
+	WithinTransaction(fn func(repos TxRepositories) error) error
+}
@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"office-reservations/internal/domain/entities"
+)
+
+// WebhookDeliveryRepository defines the interface for webhook delivery data operations
+type WebhookDeliveryRepository interface {
+	// FindByID finds a delivery by its ID
+	FindByID(id uuid.UUID) (*entities.WebhookDelivery, error)
+
+	// FindByWebhookID retrieves the deliveries of a webhook, newest first
+	FindByWebhookID(webhookID uuid.UUID, filters WebhookDeliveryFilters) ([]*entities.WebhookDelivery, error)
+
+	// Create creates a new delivery
+	Create(delivery *entities.WebhookDelivery) error
+
+	// Update updates an existing delivery
+	Update(delivery *entities.WebhookDelivery) error
+
+	// ClaimDue returns up to limit pending deliveries of active webhooks whose
+	// next attempt is due at now, and postpones them to leaseUntil so that no
+	// other sender picks them up while they are being sent
+	ClaimDue(now, leaseUntil time.Time, limit int) ([]*entities.WebhookDelivery, error)
+}
+
+// WebhookDeliveryFilters contains optional filters for querying deliveries
+type WebhookDeliveryFilters struct {
+	Status *entities.WebhookDeliveryStatus
+	// Limit caps the number of deliveries returned; zero means no limit
+	Limit int
+}
@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"github.com/google/uuid"
+	"office-reservations/internal/domain/entities"
+)
+
+// WebhookRepository defines the interface for webhook subscription data operations
+type WebhookRepository interface {
+	// FindByID finds a webhook by its ID
+	FindByID(id uuid.UUID) (*entities.Webhook, error)
+
+	// FindAll retrieves all webhooks, oldest first
+	FindAll() ([]*entities.Webhook, error)
+
+	// FindActive retrieves the webhooks that receive deliveries
+	FindActive() ([]*entities.Webhook, error)
+
+	// Create creates a new webhook
+	Create(webhook *entities.Webhook) error
+
+	// Update updates an existing webhook
+	Update(webhook *entities.Webhook) error
+
+	// Delete deletes a webhook with its deliveries
+	Delete(id uuid.UUID) error
+}
@@ -0,0 +1,309 @@
+// Package ics writes bookings as iCalendar data, for calendar feeds and for the
+// invitations attached to notification emails
+package ics
+
+import (
+	"bytes"
+	"fmt"
+	"office-reservations/internal/application/services"
+	"office-reservations/internal/domain/entities"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// icsLineLength is the maximum length of a content line in octets, excluding the line break
+const icsLineLength = 75
+
+// icsUIDDomain makes the UIDs of published events globally unique
+const icsUIDDomain = "office-reservations"
+
+// icsWriter writes the content lines of an iCalendar file, folding long lines
+type icsWriter struct {
+	buf bytes.Buffer
+}
+
+// line writes a property, folding it at icsLineLength octets without
+// splitting UTF-8 characters
+func (w *icsWriter) line(name, value string) {
+	line := name + ":" + value
+	limit := icsLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		w.buf.WriteString(line[:cut])
+		w.buf.WriteString("\r\n ")
+		line = line[cut:]
+		// Continuation lines start with a space
+		limit = icsLineLength - 1
+	}
+	w.buf.WriteString(line)
+	w.buf.WriteString("\r\n")
+}
+
+// WriteFeed renders a calendar feed as an iCalendar file. Timed bookings are
+// written in the time zone of their map, with a VTIMEZONE for each zone;
+// all-day bookings are written as DATE events. withNotes adds the notes of each
+// booking, for the feed of its holder; feeds of spaces and maps can be read with
+// any feed token, so they name neither the holders nor their notes.
+func WriteFeed(feed *services.CalendarFeed, withNotes bool, now time.Time) []byte {
+	w := &icsWriter{}
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", "-//Office Reservations//Calendar Feed//EN")
+	w.line("CALSCALE", "GREGORIAN")
+	w.line("METHOD", "PUBLISH")
+	w.line("X-WR-CALNAME", escapeCalendarText(feed.Name))
+	w.line("REFRESH-INTERVAL;VALUE=DURATION", "PT1H")
+	w.line("X-PUBLISHED-TTL", "PT1H")
+
+	for _, zone := range feedZones(feed) {
+		writeTimezone(w, zone.loc, zone.from, zone.to)
+	}
+	for _, entry := range feed.Entries {
+		writeEvent(w, entry, withNotes, nil, now)
+	}
+
+	w.line("END", "VCALENDAR")
+	return w.buf.Bytes()
+}
+
+// Attendee is the recipient of an invitation
+type Attendee struct {
+	Name  string
+	Email string
+}
+
+// invitation holds the scheduling properties of an event sent by email
+type invitation struct {
+	organizer string
+	attendee  Attendee
+}
+
+// WriteInvite renders a booking as the invitation attached to an email to its
+// holder, sent by organizer. It also returns the iTIP method of the
+// invitation: REQUEST adds or updates the event in the holder's calendar and
+// CANCEL removes it.
+func WriteInvite(entry services.CalendarEntry, organizer string, attendee Attendee, now time.Time) ([]byte, string) {
+	method := "REQUEST"
+	if entry.Reservation.IsCancelled() {
+		method = "CANCEL"
+	}
+
+	w := &icsWriter{}
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", "-//Office Reservations//Notifications//EN")
+	w.line("CALSCALE", "GREGORIAN")
+	w.line("METHOD", method)
+	for _, zone := range feedZones(&services.CalendarFeed{Entries: []services.CalendarEntry{entry}}) {
+		writeTimezone(w, zone.loc, zone.from, zone.to)
+	}
+	writeEvent(w, entry, true, &invitation{organizer: organizer, attendee: attendee}, now)
+	w.line("END", "VCALENDAR")
+	return w.buf.Bytes(), method
+}
+
+// writeEvent writes the VEVENT of a booking, with its notes if withNotes is set.
+// inv adds the properties of an invitation and is nil for feeds.
+func writeEvent(w *icsWriter, entry services.CalendarEntry, withNotes bool, inv *invitation, now time.Time) {
+	r := entry.Reservation
+	w.line("BEGIN", "VEVENT")
+	w.line("UID", eventUID(r))
+	w.line("DTSTAMP", now.UTC().Format("20060102T150405Z"))
+
+	timeRange, err := r.TimeRange()
+	if err != nil || timeRange.IsAllDay() {
+		w.line("DTSTART;VALUE=DATE", r.Date.Format("20060102"))
+		w.line("DTEND;VALUE=DATE", r.Date.AddDate(0, 0, 1).Format("20060102"))
+	} else {
+		loc := entry.Map.Location()
+		start, end := timeRange.In(r.Date, loc)
+		writeEventTime(w, "DTSTART", start, loc)
+		writeEventTime(w, "DTEND", end, loc)
+	}
+
+	w.line("SUMMARY", escapeCalendarText(entry.Title))
+	w.line("LOCATION", escapeCalendarText(entry.Map.Name))
+	if notes := strings.TrimSpace(r.Notes); withNotes && notes != "" {
+		w.line("DESCRIPTION", escapeCalendarText(notes))
+	}
+
+	if r.IsCancelled() {
+		w.line("STATUS", "CANCELLED")
+		w.line("TRANSP", "TRANSPARENT")
+	} else {
+		w.line("STATUS", "CONFIRMED")
+		w.line("TRANSP", "OPAQUE")
+	}
+	w.line("CREATED", r.CreatedAt.UTC().Format("20060102T150405Z"))
+	w.line("LAST-MODIFIED", r.UpdatedAt.UTC().Format("20060102T150405Z"))
+	if inv != nil {
+		// Calendar clients only apply an update with a higher sequence number
+		w.line("SEQUENCE", fmt.Sprint(int(r.UpdatedAt.Sub(r.CreatedAt)/time.Second)))
+		w.line("ORGANIZER", "mailto:"+inv.organizer)
+		w.line("ATTENDEE;CN="+quoteParam(inv.attendee.Name)+";ROLE=REQ-PARTICIPANT", "mailto:"+inv.attendee.Email)
+	}
+	w.line("END", "VEVENT")
+}
+
+// writeEventTime writes a DATE-TIME property, in UTC for maps in UTC and as a
+// local time with a TZID otherwise
+func writeEventTime(w *icsWriter, name string, t time.Time, loc *time.Location) {
+	if loc == time.UTC {
+		w.line(name, t.UTC().Format("20060102T150405Z"))
+		return
+	}
+	w.line(name+";TZID="+loc.String(), t.In(loc).Format("20060102T150405"))
+}
+
+// eventUID returns the stable UID of a booking. The reservations of a group
+// booking share the UID of the booking.
+func eventUID(r *entities.Reservation) string {
+	if r.GroupBookingID != nil {
+		return fmt.Sprintf("group-%s@%s", r.GroupBookingID, icsUIDDomain)
+	}
+	return fmt.Sprintf("%s@%s", r.ID, icsUIDDomain)
+}
+
+// feedZone is a time zone used by a feed and the years its events span
+type feedZone struct {
+	loc      *time.Location
+	from, to time.Time
+}
+
+// feedZones returns the time zones of the timed events of a feed, ordered by name
+func feedZones(feed *services.CalendarFeed) []feedZone {
+	zones := make(map[string]*feedZone)
+	for _, entry := range feed.Entries {
+		timeRange, err := entry.Reservation.TimeRange()
+		if err != nil || timeRange.IsAllDay() {
+			continue
+		}
+		loc := entry.Map.Location()
+		if loc == time.UTC {
+			continue
+		}
+		year := entry.Reservation.Date.Year()
+		from := time.Date(year, time.January, 1, 0, 0, 0, 0, loc)
+		to := time.Date(year+1, time.January, 1, 0, 0, 0, 0, loc)
+
+		zone, ok := zones[loc.String()]
+		if !ok {
+			zones[loc.String()] = &feedZone{loc: loc, from: from, to: to}
+			continue
+		}
+		if from.Before(zone.from) {
+			zone.from = from
+		}
+		if to.After(zone.to) {
+			zone.to = to
+		}
+	}
+
+	result := make([]feedZone, 0, len(zones))
+	for _, zone := range zones {
+		result = append(result, *zone)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].loc.String() < result[j].loc.String() })
+	return result
+}
+
+// writeTimezone writes a VTIMEZONE for loc with an observance for the offset
+// in effect at from and one for each transition until to
+func writeTimezone(w *icsWriter, loc *time.Location, from, to time.Time) {
+	w.line("BEGIN", "VTIMEZONE")
+	w.line("TZID", loc.String())
+
+	name, offset := from.In(loc).Zone()
+	writeObservance(w, from.In(loc).IsDST(), from, offset, offset, name)
+	for _, t := range zoneTransitions(loc, from, to) {
+		_, previous := t.Add(-time.Second).In(loc).Zone()
+		name, offset := t.In(loc).Zone()
+		writeObservance(w, t.In(loc).IsDST(), t, previous, offset, name)
+	}
+
+	w.line("END", "VTIMEZONE")
+}
+
+// writeObservance writes a STANDARD or DAYLIGHT component starting at the
+// instant onset, which is given as a local time in the offset before it
+func writeObservance(w *icsWriter, daylight bool, onset time.Time, offsetFrom, offsetTo int, name string) {
+	component := "STANDARD"
+	if daylight {
+		component = "DAYLIGHT"
+	}
+	w.line("BEGIN", component)
+	w.line("DTSTART", onset.In(time.FixedZone("", offsetFrom)).Format("20060102T150405"))
+	w.line("TZOFFSETFROM", formatUTCOffset(offsetFrom))
+	w.line("TZOFFSETTO", formatUTCOffset(offsetTo))
+	if name != "" {
+		w.line("TZNAME", escapeCalendarText(name))
+	}
+	w.line("END", component)
+}
+
+// zoneTransitions returns the instants between from and to at which the UTC
+// offset of loc changes. Offsets are compared a day apart, and each change is
+// located to the second.
+func zoneTransitions(loc *time.Location, from, to time.Time) []time.Time {
+	offsetAt := func(unix int64) int {
+		_, offset := time.Unix(unix, 0).In(loc).Zone()
+		return offset
+	}
+
+	var transitions []time.Time
+	const day = 24 * 60 * 60
+	for t := from.Unix(); t < to.Unix(); t += day {
+		before, after := offsetAt(t), offsetAt(t+day)
+		if before == after {
+			continue
+		}
+		lo, hi := t, t+day
+		for hi-lo > 1 {
+			mid := lo + (hi-lo)/2
+			if offsetAt(mid) == before {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		if hi < to.Unix() {
+			transitions = append(transitions, time.Unix(hi, 0))
+		}
+	}
+	return transitions
+}
+
+// formatUTCOffset formats an offset in seconds as a UTC-OFFSET value
+func formatUTCOffset(offset int) string {
+	sign := "+"
+	if offset < 0 {
+		sign, offset = "-", -offset
+	}
+	hours, minutes, seconds := offset/3600, offset/60%60, offset%60
+	if seconds != 0 {
+		return fmt.Sprintf("%s%02d%02d%02d", sign, hours, minutes, seconds)
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, hours, minutes)
+}
+
+// quoteParam quotes a parameter value, dropping the characters it cannot contain
+func quoteParam(value string) string {
+	value = strings.Map(func(r rune) rune {
+		if r == '"' || r < ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, value)
+	return `"` + value + `"`
+}
+
+// escapeCalendarText escapes a TEXT value
+func escapeCalendarText(value string) string {
+	value = strings.ReplaceAll(value, "\r\n", "\n")
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`, "\r", `\n`).Replace(value)
+}
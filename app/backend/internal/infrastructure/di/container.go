@@ -3,8 +3,13 @@ package di
 import (
 	"gorm.io/gorm"
 	"office-reservations/internal/application/services"
+	"office-reservations/internal/auth"
+	"office-reservations/internal/config"
 	domainRepos "office-reservations/internal/domain/repositories"
+	"office-reservations/internal/infrastructure/notifications"
+	"office-reservations/internal/infrastructure/realtime"
 	infraRepos "office-reservations/internal/infrastructure/repositories"
+	"office-reservations/internal/infrastructure/webhooks"
 	"office-reservations/internal/interfaces/http"
 )
 
@@ -13,34 +18,189 @@ type Container struct {
 	// Repositories
 	ReservationRepo domainRepos.ReservationRepository
 	SpaceRepo       domainRepos.SpaceRepository
+	TxManager       domainRepos.TransactionManager
+	SeriesRepo      domainRepos.ReservationSeriesRepository
+	SpaceGroupRepo  domainRepos.SpaceGroupRepository
+	MapRepo         domainRepos.OfficeMapRepository
+	PolicyRepo      domainRepos.BookingPolicyRepository
+	ClosureRepo     domainRepos.ClosureRepository
+	FeedTokenRepo   domainRepos.CalendarFeedTokenRepository
+	WebhookRepo     domainRepos.WebhookRepository
 
 	// Services
-	ReservationService *services.ReservationService
-	SpaceService       *services.SpaceService
+	ReservationService    *services.ReservationService
+	SpaceService          *services.SpaceService
+	SeriesService         *services.ReservationSeriesService
+	CheckInService        *services.CheckInService
+	SpaceGroupService     *services.SpaceGroupService
+	MapService            *services.MapService
+	AvailabilityService   *services.AvailabilityService
+	DeskAssignmentService *services.DeskAssignmentService
+	BookingPolicyService  *services.BookingPolicyService
+	ClosureService        *services.ClosureService
+	CalendarService       *services.CalendarService
+	WebhookService        *services.WebhookService
+	NotificationService   *services.NotificationService
+	RealtimeService       *services.RealtimeService
+	AuditService          *services.AuditService
+	IdempotencyService    *services.IdempotencyService
+
+	// EventListener receives the events committed by every instance
+	EventListener services.EventListener
+
+	// Authentication and authorization
+	Verifier  *auth.Verifier
+	DevIssuer *auth.DevIssuer
+	Guard     *auth.Guard
 
 	// Handlers
-	ReservationHandler *http.ReservationHandler
+	ReservationHandler    *http.ReservationHandler
+	SeriesHandler         *http.ReservationSeriesHandler
+	SpaceGroupHandler     *http.SpaceGroupHandler
+	MapHandler            *http.MapHandler
+	MapFileHandler        *http.MapFileHandler
+	SpaceHandler          *http.SpaceHandler
+	SearchHandler         *http.SearchHandler
+	DeskAssignmentHandler *http.DeskAssignmentHandler
+	BookingPolicyHandler  *http.BookingPolicyHandler
+	ClosureHandler        *http.ClosureHandler
+	CalendarHandler       *http.CalendarHandler
+	WebhookHandler        *http.WebhookHandler
+	NotificationHandler   *http.NotificationHandler
+	MapEventsHandler      *http.MapEventsHandler
+	AuditHandler          *http.AuditHandler
+	AuthHandler           *http.AuthHandler
 }
 
 // NewContainer creates a new dependency injection container
-func NewContainer(db *gorm.DB) *Container {
+func NewContainer(db *gorm.DB, cfg *config.Config) (*Container, error) {
+	// Initialize authentication
+	verifier, err := auth.NewVerifier(&cfg.Auth)
+	if err != nil {
+		return nil, err
+	}
+	var devIssuer *auth.DevIssuer
+	if cfg.Auth.DevIssuer {
+		devIssuer = auth.NewDevIssuer(&cfg.Auth)
+	}
+
+	// Initialize email delivery; notifications are off without a mail server
+	var mailer services.NotificationMailer
+	if cfg.Notifications.SMTP.Enabled() {
+		smtpMailer, err := notifications.NewSMTPMailer(cfg.Notifications.SMTP)
+		if err != nil {
+			return nil, err
+		}
+		mailer = smtpMailer
+	}
+
+	// Initialize the listener for events committed by any instance
+	eventListener, err := realtime.NewPostgresListener(db)
+	if err != nil {
+		return nil, err
+	}
+
 	// Initialize repositories
 	reservationRepo := infraRepos.NewReservationRepository(db)
 	spaceRepo := infraRepos.NewSpaceRepository(db)
+	txManager := infraRepos.NewTransactionManager(db)
+	seriesRepo := infraRepos.NewReservationSeriesRepository(db)
+	spaceGroupRepo := infraRepos.NewSpaceGroupRepository(db)
+	mapRepo := infraRepos.NewOfficeMapRepository(db)
+	policyRepo := infraRepos.NewBookingPolicyRepository(db)
+	closureRepo := infraRepos.NewClosureRepository(db)
+	feedTokenRepo := infraRepos.NewCalendarFeedTokenRepository(db)
+	webhookRepo := infraRepos.NewWebhookRepository(db)
+	webhookDeliveryRepo := infraRepos.NewWebhookDeliveryRepository(db)
+	outboxRepo := infraRepos.NewOutboxRepository(db)
+	notificationRepo := infraRepos.NewNotificationRepository(db)
+	notificationPreferencesRepo := infraRepos.NewNotificationPreferencesRepository(db)
+	auditRepo := infraRepos.NewAuditRepository(db)
+	idempotencyRepo := infraRepos.NewIdempotencyRepository(db)
+	guard := auth.NewGuard(infraRepos.NewAccessDenialRepository(db))
 
 	// Initialize services
-	reservationService := services.NewReservationService(reservationRepo, spaceRepo)
-	spaceService := services.NewSpaceService(spaceRepo)
+	bookingPolicyService := services.NewBookingPolicyService(policyRepo, reservationRepo, spaceRepo, mapRepo)
+	notificationService := services.NewNotificationService(notificationRepo, notificationPreferencesRepo, reservationRepo, spaceRepo, spaceGroupRepo, mapRepo, mailer, cfg.Notifications)
+	reservationService := services.NewReservationService(reservationRepo, spaceRepo, mapRepo, txManager, bookingPolicyService, notificationService)
+	spaceService := services.NewSpaceService(spaceRepo, mapRepo, reservationRepo, closureRepo, txManager, notificationService)
+	seriesService := services.NewReservationSeriesService(seriesRepo, reservationRepo, spaceRepo, mapRepo, reservationService)
+	checkInService := services.NewCheckInService(reservationRepo, spaceRepo, mapRepo, txManager, cfg.CheckIn)
+	spaceGroupService := services.NewSpaceGroupService(spaceGroupRepo, spaceRepo)
+	mapService := services.NewMapService(mapRepo, txManager, notificationService)
+	availabilityService := services.NewAvailabilityService(mapRepo, spaceRepo, spaceGroupRepo, reservationRepo, closureRepo)
+	deskAssignmentService := services.NewDeskAssignmentService(reservationService, reservationRepo, spaceRepo, mapRepo, closureRepo)
+	closureService := services.NewClosureService(closureRepo, mapRepo, spaceRepo, spaceGroupRepo, txManager, notificationService)
+	webhookService := services.NewWebhookService(webhookRepo, webhookDeliveryRepo, txManager, webhooks.NewHTTPSender(cfg.Webhooks.Timeout), cfg.Webhooks)
+	calendarService := services.NewCalendarService(feedTokenRepo, reservationRepo, spaceRepo, spaceGroupRepo, mapRepo)
+	realtimeService := services.NewRealtimeService(outboxRepo, mapRepo, cfg.Realtime)
+	auditService := services.NewAuditService(auditRepo, reservationRepo)
+	idempotencyService := services.NewIdempotencyService(idempotencyRepo, cfg.Idempotency)
 
 	// Initialize handlers
-	reservationHandler := http.NewReservationHandler(reservationService)
+	reservationHandler := http.NewReservationHandler(reservationService, checkInService, spaceService, guard)
+	seriesHandler := http.NewReservationSeriesHandler(seriesService, guard)
+	spaceGroupHandler := http.NewSpaceGroupHandler(spaceGroupService)
+	mapHandler := http.NewMapHandler(mapService, availabilityService)
+	mapFileHandler := http.NewMapFileHandler(mapService)
+	spaceHandler := http.NewSpaceHandler(spaceService)
+	searchHandler := http.NewSearchHandler(availabilityService)
+	deskAssignmentHandler := http.NewDeskAssignmentHandler(deskAssignmentService)
+	bookingPolicyHandler := http.NewBookingPolicyHandler(bookingPolicyService)
+	closureHandler := http.NewClosureHandler(closureService)
+	calendarHandler := http.NewCalendarHandler(calendarService)
+	webhookHandler := http.NewWebhookHandler(webhookService)
+	notificationHandler := http.NewNotificationHandler(notificationService)
+	mapEventsHandler := http.NewMapEventsHandler(realtimeService)
+	auditHandler := http.NewAuditHandler(auditService, guard)
+	authHandler := http.NewAuthHandler(devIssuer)
 
 	return &Container{
-		ReservationRepo:   reservationRepo,
-		SpaceRepo:         spaceRepo,
-		ReservationService: reservationService,
-		SpaceService:       spaceService,
-		ReservationHandler: reservationHandler,
-	}
+		ReservationRepo:       reservationRepo,
+		SpaceRepo:             spaceRepo,
+		TxManager:             txManager,
+		SeriesRepo:            seriesRepo,
+		SpaceGroupRepo:        spaceGroupRepo,
+		MapRepo:               mapRepo,
+		PolicyRepo:            policyRepo,
+		ClosureRepo:           closureRepo,
+		FeedTokenRepo:         feedTokenRepo,
+		WebhookRepo:           webhookRepo,
+		ReservationService:    reservationService,
+		SpaceService:          spaceService,
+		SeriesService:         seriesService,
+		CheckInService:        checkInService,
+		SpaceGroupService:     spaceGroupService,
+		MapService:            mapService,
+		AvailabilityService:   availabilityService,
+		DeskAssignmentService: deskAssignmentService,
+		BookingPolicyService:  bookingPolicyService,
+		ClosureService:        closureService,
+		CalendarService:       calendarService,
+		WebhookService:        webhookService,
+		NotificationService:   notificationService,
+		RealtimeService:       realtimeService,
+		AuditService:          auditService,
+		IdempotencyService:    idempotencyService,
+		EventListener:         eventListener,
+		Verifier:              verifier,
+		DevIssuer:             devIssuer,
+		Guard:                 guard,
+		ReservationHandler:    reservationHandler,
+		SeriesHandler:         seriesHandler,
+		SpaceGroupHandler:     spaceGroupHandler,
+		MapHandler:            mapHandler,
+		MapFileHandler:        mapFileHandler,
+		SpaceHandler:          spaceHandler,
+		SearchHandler:         searchHandler,
+		DeskAssignmentHandler: deskAssignmentHandler,
+		BookingPolicyHandler:  bookingPolicyHandler,
+		ClosureHandler:        closureHandler,
+		CalendarHandler:       calendarHandler,
+		WebhookHandler:        webhookHandler,
+		NotificationHandler:   notificationHandler,
+		MapEventsHandler:      mapEventsHandler,
+		AuditHandler:          auditHandler,
+		AuthHandler:           authHandler,
+	}, nil
 }
-
@@ -0,0 +1,71 @@
+package mappers
+
+import (
+	"gorm.io/datatypes"
+	"office-reservations/internal/domain/entities"
+	"office-reservations/internal/models"
+)
+
+// ToDomainAuditEntry converts a database model to a domain entity
+func ToDomainAuditEntry(m *models.AuditEntry) *entities.AuditEntry {
+	if m == nil {
+		return nil
+	}
+	return &entities.AuditEntry{
+		ID:         m.ID,
+		EntityType: entities.AuditEntityType(m.EntityType),
+		EntityID:   m.EntityID,
+		MapID:      m.MapID,
+		Action:     entities.AuditAction(m.Action),
+		ActorID:    m.ActorID,
+		ActorName:  m.ActorName,
+		Reason:     m.Reason,
+		Before:     auditSnapshotBytes(m.Before),
+		After:      auditSnapshotBytes(m.After),
+		CreatedAt:  m.CreatedAt,
+	}
+}
+
+// ToDomainAuditEntries converts a slice of database models to domain entities
+func ToDomainAuditEntries(models []models.AuditEntry) []*entities.AuditEntry {
+	result := make([]*entities.AuditEntry, len(models))
+	for i := range models {
+		result[i] = ToDomainAuditEntry(&models[i])
+	}
+	return result
+}
+
+// ToModelAuditEntry converts a domain entity to a database model
+func ToModelAuditEntry(e *entities.AuditEntry) *models.AuditEntry {
+	if e == nil {
+		return nil
+	}
+	model := &models.AuditEntry{
+		ID:         e.ID,
+		EntityType: string(e.EntityType),
+		EntityID:   e.EntityID,
+		MapID:      e.MapID,
+		Action:     string(e.Action),
+		ActorID:    e.ActorID,
+		ActorName:  e.ActorName,
+		Reason:     e.Reason,
+		CreatedAt:  e.CreatedAt,
+	}
+	if e.Before != nil {
+		before := datatypes.JSON(e.Before)
+		model.Before = &before
+	}
+	if e.After != nil {
+		after := datatypes.JSON(e.After)
+		model.After = &after
+	}
+	return model
+}
+
+// auditSnapshotBytes returns a stored snapshot, or nil if there is none
+func auditSnapshotBytes(snapshot *datatypes.JSON) []byte {
+	if snapshot == nil {
+		return nil
+	}
+	return []byte(*snapshot)
+}
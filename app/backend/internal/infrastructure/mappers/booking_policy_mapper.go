@@ -0,0 +1,94 @@
+package mappers
+
+import (
+	"encoding/json"
+	"time"
+
+	"gorm.io/datatypes"
+	"office-reservations/internal/domain/entities"
+	"office-reservations/internal/models"
+)
+
+// ToDomainBookingPolicy converts a database model to a domain entity
+func ToDomainBookingPolicy(m *models.BookingPolicy) *entities.BookingPolicy {
+	if m == nil {
+		return nil
+	}
+	var spaceType *entities.SpaceType
+	if m.SpaceType != nil {
+		t := entities.SpaceType(*m.SpaceType)
+		spaceType = &t
+	}
+	// Blackout dates are stored as a JSON list of YYYY-MM-DD strings
+	var days []string
+	_ = json.Unmarshal(m.BlackoutDates, &days)
+	blackoutDates := make([]time.Time, 0, len(days))
+	for _, day := range days {
+		if date, err := time.Parse("2006-01-02", day); err == nil {
+			blackoutDates = append(blackoutDates, date)
+		}
+	}
+	return &entities.BookingPolicy{
+		ID:                 m.ID,
+		Name:               m.Name,
+		MapID:              m.MapID,
+		SpaceType:          spaceType,
+		Role:               m.Role,
+		MaxAdvanceDays:     m.MaxAdvanceDays,
+		MinDurationMinutes: m.MinDurationMinutes,
+		MaxDurationMinutes: m.MaxDurationMinutes,
+		AllowedStart:       m.AllowedStart,
+		AllowedEnd:         m.AllowedEnd,
+		MaxBookingsPerWeek: m.MaxBookingsPerWeek,
+		MaxConsecutiveDays: m.MaxConsecutiveDays,
+		BlackoutDates:      blackoutDates,
+		CreatedAt:          m.CreatedAt,
+		UpdatedAt:          m.UpdatedAt,
+	}
+}
+
+// ToDomainBookingPolicies converts a slice of database models to domain entities
+func ToDomainBookingPolicies(models []models.BookingPolicy) []*entities.BookingPolicy {
+	result := make([]*entities.BookingPolicy, len(models))
+	for i := range models {
+		result[i] = ToDomainBookingPolicy(&models[i])
+	}
+	return result
+}
+
+// ToModelBookingPolicy converts a domain entity to a database model
+func ToModelBookingPolicy(e *entities.BookingPolicy) (*models.BookingPolicy, error) {
+	if e == nil {
+		return nil, nil
+	}
+	var spaceType *string
+	if e.SpaceType != nil {
+		t := string(*e.SpaceType)
+		spaceType = &t
+	}
+	days := make([]string, len(e.BlackoutDates))
+	for i, date := range e.BlackoutDates {
+		days[i] = date.Format("2006-01-02")
+	}
+	blackoutDates, err := json.Marshal(days)
+	if err != nil {
+		return nil, err
+	}
+	return &models.BookingPolicy{
+		ID:                 e.ID,
+		Name:               e.Name,
+		MapID:              e.MapID,
+		SpaceType:          spaceType,
+		Role:               e.Role,
+		MaxAdvanceDays:     e.MaxAdvanceDays,
+		MinDurationMinutes: e.MinDurationMinutes,
+		MaxDurationMinutes: e.MaxDurationMinutes,
+		AllowedStart:       e.AllowedStart,
+		AllowedEnd:         e.AllowedEnd,
+		MaxBookingsPerWeek: e.MaxBookingsPerWeek,
+		MaxConsecutiveDays: e.MaxConsecutiveDays,
+		BlackoutDates:      datatypes.JSON(blackoutDates),
+		CreatedAt:          e.CreatedAt,
+		UpdatedAt:          e.UpdatedAt,
+	}, nil
+}
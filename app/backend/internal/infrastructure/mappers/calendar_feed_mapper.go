@@ -0,0 +1,30 @@
+package mappers
+
+import (
+	"office-reservations/internal/domain/entities"
+	"office-reservations/internal/models"
+)
+
+// ToDomainCalendarFeedToken converts a database model to a domain entity
+func ToDomainCalendarFeedToken(m *models.CalendarFeedToken) *entities.CalendarFeedToken {
+	if m == nil {
+		return nil
+	}
+	return &entities.CalendarFeedToken{
+		UserID:    m.UserID,
+		TokenHash: m.TokenHash,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+// ToModelCalendarFeedToken converts a domain entity to a database model
+func ToModelCalendarFeedToken(e *entities.CalendarFeedToken) *models.CalendarFeedToken {
+	if e == nil {
+		return nil
+	}
+	return &models.CalendarFeedToken{
+		UserID:    e.UserID,
+		TokenHash: e.TokenHash,
+		CreatedAt: e.CreatedAt,
+	}
+}
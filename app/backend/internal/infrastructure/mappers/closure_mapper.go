@@ -0,0 +1,57 @@
+package mappers
+
+import (
+	"office-reservations/internal/domain/entities"
+	"office-reservations/internal/models"
+)
+
+// ToDomainClosure converts a database model to a domain entity
+func ToDomainClosure(m *models.Closure) *entities.Closure {
+	if m == nil {
+		return nil
+	}
+	return &entities.Closure{
+		ID:         m.ID,
+		MapID:      m.MapID,
+		GroupID:    m.GroupID,
+		SpaceID:    m.SpaceID,
+		StartDate:  m.StartDate,
+		EndDate:    m.EndDate,
+		StartTime:  m.StartTime,
+		EndTime:    m.EndTime,
+		Reason:     m.Reason,
+		ExternalID: m.ExternalID,
+		CreatedAt:  m.CreatedAt,
+		UpdatedAt:  m.UpdatedAt,
+	}
+}
+
+// ToDomainClosures converts a slice of database models to domain entities
+func ToDomainClosures(models []models.Closure) []*entities.Closure {
+	result := make([]*entities.Closure, len(models))
+	for i := range models {
+		result[i] = ToDomainClosure(&models[i])
+	}
+	return result
+}
+
+// ToModelClosure converts a domain entity to a database model
+func ToModelClosure(e *entities.Closure) *models.Closure {
+	if e == nil {
+		return nil
+	}
+	return &models.Closure{
+		ID:         e.ID,
+		MapID:      e.MapID,
+		GroupID:    e.GroupID,
+		SpaceID:    e.SpaceID,
+		StartDate:  e.StartDate,
+		EndDate:    e.EndDate,
+		StartTime:  e.StartTime,
+		EndTime:    e.EndTime,
+		Reason:     e.Reason,
+		ExternalID: e.ExternalID,
+		CreatedAt:  e.CreatedAt,
+		UpdatedAt:  e.UpdatedAt,
+	}
+}
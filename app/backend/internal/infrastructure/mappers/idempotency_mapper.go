@@ -0,0 +1,61 @@
+package mappers
+
+import (
+	"encoding/json"
+
+	"gorm.io/datatypes"
+	"office-reservations/internal/domain/entities"
+	"office-reservations/internal/models"
+)
+
+// ToDomainIdempotencyRecord converts a database model to a domain entity
+func ToDomainIdempotencyRecord(m *models.IdempotencyKey) *entities.IdempotencyRecord {
+	if m == nil {
+		return nil
+	}
+	var headers map[string]string
+	if len(m.Headers) > 0 {
+		// Stored headers are always a JSON object; anything else is treated as empty
+		_ = json.Unmarshal(m.Headers, &headers)
+	}
+	record := &entities.IdempotencyRecord{
+		Scope:       m.Scope,
+		Key:         m.Key,
+		RequestHash: m.RequestHash,
+		Headers:     headers,
+		Body:        m.Body,
+		CreatedAt:   m.CreatedAt,
+		ExpiresAt:   m.ExpiresAt,
+	}
+	if m.StatusCode != nil {
+		record.StatusCode = *m.StatusCode
+	}
+	return record
+}
+
+// ToModelIdempotencyKey converts a domain entity to a database model
+func ToModelIdempotencyKey(e *entities.IdempotencyRecord) (*models.IdempotencyKey, error) {
+	if e == nil {
+		return nil, nil
+	}
+	model := &models.IdempotencyKey{
+		Scope:       e.Scope,
+		Key:         e.Key,
+		RequestHash: e.RequestHash,
+		Body:        e.Body,
+		CreatedAt:   e.CreatedAt,
+		ExpiresAt:   e.ExpiresAt,
+	}
+	if e.StatusCode != 0 {
+		statusCode := e.StatusCode
+		model.StatusCode = &statusCode
+	}
+	if e.Headers != nil {
+		headers, err := json.Marshal(e.Headers)
+		if err != nil {
+			return nil, err
+		}
+		model.Headers = datatypes.JSON(headers)
+	}
+	return model, nil
+}
@@ -0,0 +1,91 @@
+package mappers
+
+import (
+	"office-reservations/internal/domain/entities"
+	"office-reservations/internal/models"
+)
+
+// ToDomainNotification converts a database model to a domain entity
+func ToDomainNotification(m *models.Notification) *entities.Notification {
+	if m == nil {
+		return nil
+	}
+	return &entities.Notification{
+		ID:            m.ID,
+		UserID:        m.UserID,
+		ReservationID: m.ReservationID,
+		Kind:          entities.NotificationKind(m.Kind),
+		Reason:        m.Reason,
+		StartsAt:      m.StartsAt,
+		Status:        entities.NotificationStatus(m.Status),
+		Attempts:      m.Attempts,
+		NextAttemptAt: m.NextAttemptAt,
+		LastError:     m.LastError,
+		SentAt:        m.SentAt,
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+	}
+}
+
+// ToDomainNotifications converts a slice of database models to domain entities
+func ToDomainNotifications(models []models.Notification) []*entities.Notification {
+	result := make([]*entities.Notification, len(models))
+	for i := range models {
+		result[i] = ToDomainNotification(&models[i])
+	}
+	return result
+}
+
+// ToModelNotification converts a domain entity to a database model
+func ToModelNotification(e *entities.Notification) *models.Notification {
+	if e == nil {
+		return nil
+	}
+	return &models.Notification{
+		ID:            e.ID,
+		UserID:        e.UserID,
+		ReservationID: e.ReservationID,
+		Kind:          string(e.Kind),
+		Reason:        e.Reason,
+		StartsAt:      e.StartsAt,
+		Status:        string(e.Status),
+		Attempts:      e.Attempts,
+		NextAttemptAt: e.NextAttemptAt,
+		LastError:     e.LastError,
+		SentAt:        e.SentAt,
+		CreatedAt:     e.CreatedAt,
+		UpdatedAt:     e.UpdatedAt,
+	}
+}
+
+// ToDomainNotificationPreferences converts a database model to a domain entity
+func ToDomainNotificationPreferences(m *models.NotificationPreference) *entities.NotificationPreferences {
+	if m == nil {
+		return nil
+	}
+	return &entities.NotificationPreferences{
+		UserID:        m.UserID,
+		Email:         m.Email,
+		Confirmations: m.Confirmations,
+		Cancellations: m.Cancellations,
+		DayReminders:  m.DayReminders,
+		HourReminders: m.HourReminders,
+		UpdatedAt:     m.UpdatedAt,
+	}
+}
+
+// ToModelNotificationPreferences converts a domain entity to a database model
+func ToModelNotificationPreferences(e *entities.NotificationPreferences) *models.NotificationPreference {
+	if e == nil {
+		return nil
+	}
+	return &models.NotificationPreference{
+		UserID:        e.UserID,
+		Email:         e.Email,
+		Confirmations: e.Confirmations,
+		Cancellations: e.Cancellations,
+		DayReminders:  e.DayReminders,
+		HourReminders: e.HourReminders,
+		UpdatedAt:     e.UpdatedAt,
+	}
+}
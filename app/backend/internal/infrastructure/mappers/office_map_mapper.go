@@ -0,0 +1,119 @@
+package mappers
+
+import (
+	"encoding/json"
+	"time"
+
+	"gorm.io/datatypes"
+	"office-reservations/internal/domain/entities"
+	"office-reservations/internal/models"
+)
+
+// ToDomainOfficeMap converts a database model with its preloaded spaces to a domain entity
+func ToDomainOfficeMap(m *models.OfficeMap) *entities.OfficeMap {
+	if m == nil {
+		return nil
+	}
+	var jsonData map[string]interface{}
+	if len(m.JSONData) > 0 {
+		// Stored data is always a JSON object; anything else is treated as empty
+		_ = json.Unmarshal(m.JSONData, &jsonData)
+	}
+	return &entities.OfficeMap{
+		ID:           m.ID,
+		Name:         m.Name,
+		Description:  m.Description,
+		JSONData:     jsonData,
+		Timezone:     m.Timezone,
+		OpeningHours: toDomainOpeningHours(m.OpeningHours),
+		Version:      m.Version,
+		CreatedAt:    m.CreatedAt,
+		UpdatedAt:    m.UpdatedAt,
+		Spaces:       ToDomainSpaces(m.Spaces),
+	}
+}
+
+// ToDomainOfficeMaps converts a slice of database models to domain entities
+func ToDomainOfficeMaps(models []models.OfficeMap) []*entities.OfficeMap {
+	result := make([]*entities.OfficeMap, len(models))
+	for i := range models {
+		result[i] = ToDomainOfficeMap(&models[i])
+	}
+	return result
+}
+
+// ToModelOfficeMap converts a domain entity to a database model, without its spaces
+func ToModelOfficeMap(e *entities.OfficeMap) (*models.OfficeMap, error) {
+	if e == nil {
+		return nil, nil
+	}
+	jsonData, err := json.Marshal(e.JSONData)
+	if err != nil {
+		return nil, err
+	}
+	openingHours, err := toModelOpeningHours(e.OpeningHours)
+	if err != nil {
+		return nil, err
+	}
+	return &models.OfficeMap{
+		ID:           e.ID,
+		Name:         e.Name,
+		Description:  e.Description,
+		JSONData:     datatypes.JSON(jsonData),
+		Timezone:     e.Timezone,
+		OpeningHours: openingHours,
+		Version:      e.Version,
+		CreatedAt:    e.CreatedAt,
+		UpdatedAt:    e.UpdatedAt,
+	}, nil
+}
+
+// storedHours is the stored form of the opening hours of one weekday
+type storedHours struct {
+	Open  string `json:"open"`
+	Close string `json:"close"`
+}
+
+// toDomainOpeningHours converts stored opening hours, keyed by lowercase
+// weekday name, to the domain form. Unreadable days are treated as closed.
+func toDomainOpeningHours(data datatypes.JSON) *entities.OpeningHours {
+	if len(data) == 0 || string(data) == "null" {
+		return nil
+	}
+	var days map[string]storedHours
+	if err := json.Unmarshal(data, &days); err != nil {
+		return nil
+	}
+	hours := &entities.OpeningHours{}
+	for name, stored := range days {
+		day, ok := entities.ParseWeekday(name)
+		if !ok {
+			continue
+		}
+		if r, err := entities.NewTimeRange(&stored.Open, &stored.Close); err == nil {
+			hours[day] = &r
+		}
+	}
+	return hours
+}
+
+// toModelOpeningHours converts opening hours to their stored form
+func toModelOpeningHours(hours *entities.OpeningHours) (datatypes.JSON, error) {
+	if hours == nil {
+		return nil, nil
+	}
+	days := make(map[string]storedHours)
+	for day, r := range hours {
+		if r != nil {
+			days[entities.WeekdayName(time.Weekday(day))] = storedHours{
+				Open:  entities.FormatClock(r.Start),
+				Close: entities.FormatClock(r.End),
+			}
+		}
+	}
+	data, err := json.Marshal(days)
+	if err != nil {
+		return nil, err
+	}
+	return datatypes.JSON(data), nil
+}
@@ -0,0 +1,47 @@
+package mappers
+
+import (
+	"gorm.io/datatypes"
+	"office-reservations/internal/domain/entities"
+	"office-reservations/internal/models"
+)
+
+// ToDomainOutboxEvent converts a database model to a domain entity
+func ToDomainOutboxEvent(m *models.OutboxEvent) *entities.OutboxEvent {
+	if m == nil {
+		return nil
+	}
+	return &entities.OutboxEvent{
+		ID:           m.ID,
+		Type:         entities.WebhookEventType(m.Type),
+		Seq:          m.Seq,
+		MapID:        m.MapID,
+		Data:         []byte(m.Data),
+		CreatedAt:    m.CreatedAt,
+		DispatchedAt: m.DispatchedAt,
+	}
+}
+
+// ToDomainOutboxEvents converts a slice of database models to domain entities
+func ToDomainOutboxEvents(models []models.OutboxEvent) []*entities.OutboxEvent {
+	result := make([]*entities.OutboxEvent, len(models))
+	for i := range models {
+		result[i] = ToDomainOutboxEvent(&models[i])
+	}
+	return result
+}
+
+// ToModelOutboxEvent converts a domain entity to a database model
+func ToModelOutboxEvent(e *entities.OutboxEvent) *models.OutboxEvent {
+	if e == nil {
+		return nil
+	}
+	return &models.OutboxEvent{
+		ID:           e.ID,
+		Type:         string(e.Type),
+		MapID:        e.MapID,
+		Data:         datatypes.JSON(e.Data),
+		CreatedAt:    e.CreatedAt,
+		DispatchedAt: e.DispatchedAt,
+	}
+}
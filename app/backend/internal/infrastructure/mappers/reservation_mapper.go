@@ -11,15 +11,22 @@ func ToDomainReservation(m *models.Reservation) *entities.Reservation {
 		return nil
 	}
 	return &entities.Reservation{
-		ID:        m.ID,
-		SpaceID:   m.SpaceID,
-		UserID:    m.UserID,
-		UserName:  m.UserName,
-		Date:      m.Date,
-		StartTime: m.StartTime,
-		EndTime:   m.EndTime,
-		Status:    entities.ReservationStatus(m.Status),
-		Notes:     m.Notes,
+		ID:             m.ID,
+		SpaceID:        m.SpaceID,
+		UserID:         m.UserID,
+		UserName:       m.UserName,
+		Date:           m.Date,
+		StartTime:      m.StartTime,
+		EndTime:        m.EndTime,
+		Status:         entities.ReservationStatus(m.Status),
+		Notes:          m.Notes,
+		SeriesID:       m.SeriesID,
+		OccurrenceDate: m.OccurrenceDate,
+		CheckedInAt:    m.CheckedInAt,
+		GroupBookingID: m.GroupBookingID,
+		// Set when the space and its map were preloaded
+		Timezone:  m.Space.Map.Timezone,
+		Version:   m.Version,
 		CreatedAt: m.CreatedAt,
 		UpdatedAt: m.UpdatedAt,
 	}
@@ -40,17 +47,21 @@ func ToModelReservation(e *entities.Reservation) *models.Reservation {
 		return nil
 	}
 	return &models.Reservation{
-		ID:        e.ID,
-		SpaceID:   e.SpaceID,
-		UserID:    e.UserID,
-		UserName:  e.UserName,
-		Date:      e.Date,
-		StartTime: e.StartTime,
-		EndTime:   e.EndTime,
-		Status:    string(e.Status),
-		Notes:     e.Notes,
-		CreatedAt: e.CreatedAt,
-		UpdatedAt: e.UpdatedAt,
+		ID:             e.ID,
+		SpaceID:        e.SpaceID,
+		UserID:         e.UserID,
+		UserName:       e.UserName,
+		Date:           e.Date,
+		StartTime:      e.StartTime,
+		EndTime:        e.EndTime,
+		Status:         string(e.Status),
+		Notes:          e.Notes,
+		SeriesID:       e.SeriesID,
+		OccurrenceDate: e.OccurrenceDate,
+		CheckedInAt:    e.CheckedInAt,
+		GroupBookingID: e.GroupBookingID,
+		Version:        e.Version,
+		CreatedAt:      e.CreatedAt,
+		UpdatedAt:      e.UpdatedAt,
 	}
 }
-
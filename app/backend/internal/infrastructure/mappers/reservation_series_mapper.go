@@ -0,0 +1,148 @@
+package mappers
+
+import (
+	"encoding/json"
+
+	"gorm.io/datatypes"
+	"office-reservations/internal/domain/entities"
+	"office-reservations/internal/models"
+)
+
+// ToDomainReservationSeries converts a database model to a domain entity.
+// An unparsable stored rule yields a nil Rule so callers can skip the series.
+func ToDomainReservationSeries(m *models.ReservationSeries) *entities.ReservationSeries {
+	if m == nil {
+		return nil
+	}
+	rule, _ := entities.ParseRecurrenceRule(m.RRule)
+	exceptions := make([]*entities.SeriesException, len(m.Exceptions))
+	for i := range m.Exceptions {
+		exceptions[i] = ToDomainSeriesException(&m.Exceptions[i])
+	}
+	return &entities.ReservationSeries{
+		ID:         m.ID,
+		SpaceID:    m.SpaceID,
+		UserID:     m.UserID,
+		UserName:   m.UserName,
+		StartDate:  m.StartDate,
+		StartTime:  m.StartTime,
+		EndTime:    m.EndTime,
+		Rule:       rule,
+		Notes:      m.Notes,
+		Status:     entities.SeriesStatus(m.Status),
+		BookedBy:   toDomainSeriesBooker(m.BookedBy),
+		Exceptions: exceptions,
+		CreatedAt:  m.CreatedAt,
+		UpdatedAt:  m.UpdatedAt,
+	}
+}
+
+// ToDomainReservationSeriesList converts a slice of database models to domain entities
+func ToDomainReservationSeriesList(models []models.ReservationSeries) []*entities.ReservationSeries {
+	result := make([]*entities.ReservationSeries, len(models))
+	for i := range models {
+		result[i] = ToDomainReservationSeries(&models[i])
+	}
+	return result
+}
+
+// ToModelReservationSeries converts a domain entity to a database model.
+// Exceptions are persisted separately and are not included.
+func ToModelReservationSeries(e *entities.ReservationSeries) *models.ReservationSeries {
+	if e == nil {
+		return nil
+	}
+	rrule := ""
+	if e.Rule != nil {
+		rrule = e.Rule.String()
+	}
+	return &models.ReservationSeries{
+		ID:        e.ID,
+		SpaceID:   e.SpaceID,
+		UserID:    e.UserID,
+		UserName:  e.UserName,
+		StartDate: e.StartDate,
+		StartTime: e.StartTime,
+		EndTime:   e.EndTime,
+		RRule:     rrule,
+		Notes:     e.Notes,
+		Status:    string(e.Status),
+		BookedBy:  toModelSeriesBooker(e.BookedBy),
+		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
+	}
+}
+
+// storedBooker is the stored form of the user who created a series
+type storedBooker struct {
+	UserID   string   `json:"user_id"`
+	UserName string   `json:"user_name"`
+	Admin    bool     `json:"admin,omitempty"`
+	Roles    []string `json:"roles,omitempty"`
+}
+
+// toDomainSeriesBooker converts a stored series booker; missing or unreadable
+// data yields nil
+func toDomainSeriesBooker(data datatypes.JSON) *entities.SeriesBooker {
+	if len(data) == 0 || string(data) == "null" {
+		return nil
+	}
+	var stored storedBooker
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil
+	}
+	return &entities.SeriesBooker{
+		UserID:   stored.UserID,
+		UserName: stored.UserName,
+		Admin:    stored.Admin,
+		Roles:    stored.Roles,
+	}
+}
+
+// toModelSeriesBooker converts a series booker to its stored form
+func toModelSeriesBooker(b *entities.SeriesBooker) datatypes.JSON {
+	if b == nil {
+		return nil
+	}
+	data, _ := json.Marshal(storedBooker{
+		UserID:   b.UserID,
+		UserName: b.UserName,
+		Admin:    b.Admin,
+		Roles:    b.Roles,
+	})
+	return datatypes.JSON(data)
+}
+
+// ToDomainSeriesException converts a database model to a domain entity
+func ToDomainSeriesException(m *models.ReservationSeriesException) *entities.SeriesException {
+	if m == nil {
+		return nil
+	}
+	return &entities.SeriesException{
+		SeriesID:       m.SeriesID,
+		OccurrenceDate: m.OccurrenceDate,
+		Cancelled:      m.Cancelled,
+		StartTime:      m.StartTime,
+		EndTime:        m.EndTime,
+		Notes:          m.Notes,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}
+}
+
+// ToModelSeriesException converts a domain entity to a database model
+func ToModelSeriesException(e *entities.SeriesException) *models.ReservationSeriesException {
+	if e == nil {
+		return nil
+	}
+	return &models.ReservationSeriesException{
+		SeriesID:       e.SeriesID,
+		OccurrenceDate: e.OccurrenceDate,
+		Cancelled:      e.Cancelled,
+		StartTime:      e.StartTime,
+		EndTime:        e.EndTime,
+		Notes:          e.Notes,
+		CreatedAt:      e.CreatedAt,
+		UpdatedAt:      e.UpdatedAt,
+	}
+}
@@ -0,0 +1,52 @@
+package mappers
+
+import (
+	"github.com/google/uuid"
+	"office-reservations/internal/domain/entities"
+	"office-reservations/internal/models"
+)
+
+// ToDomainSpaceGroup converts a database model with its preloaded spaces to a domain entity
+func ToDomainSpaceGroup(m *models.SpaceGroup) *entities.SpaceGroup {
+	if m == nil {
+		return nil
+	}
+	spaceIDs := make([]uuid.UUID, len(m.Spaces))
+	for i, space := range m.Spaces {
+		spaceIDs[i] = space.ID
+	}
+	return &entities.SpaceGroup{
+		ID:        m.ID,
+		MapID:     m.MapID,
+		Name:      m.Name,
+		Capacity:  m.Capacity,
+		SpaceIDs:  spaceIDs,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+// ToDomainSpaceGroups converts a slice of database models to domain entities
+func ToDomainSpaceGroups(models []models.SpaceGroup) []*entities.SpaceGroup {
+	result := make([]*entities.SpaceGroup, len(models))
+	for i := range models {
+		result[i] = ToDomainSpaceGroup(&models[i])
+	}
+	return result
+}
+
+// ToModelSpaceGroup converts a domain entity to a database model.
+// Member spaces are persisted separately and are not included.
+func ToModelSpaceGroup(e *entities.SpaceGroup) *models.SpaceGroup {
+	if e == nil {
+		return nil
+	}
+	return &models.SpaceGroup{
+		ID:        e.ID,
+		MapID:     e.MapID,
+		Name:      e.Name,
+		Capacity:  e.Capacity,
+		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
+	}
+}
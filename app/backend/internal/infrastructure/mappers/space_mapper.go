@@ -13,6 +13,7 @@ func ToDomainSpace(m *models.Space) *entities.Space {
 	return &entities.Space{
 		ID:        m.ID,
 		MapID:     m.MapID,
+		GroupID:   m.GroupID,
 		Name:      m.Name,
 		Type:      entities.SpaceType(m.Type),
 		X:         m.X,
@@ -20,6 +21,7 @@ func ToDomainSpace(m *models.Space) *entities.Space {
 		Width:     m.Width,
 		Height:    m.Height,
 		Capacity:  m.Capacity,
+		Version:   m.Version,
 		CreatedAt: m.CreatedAt,
 		UpdatedAt: m.UpdatedAt,
 	}
@@ -42,6 +44,7 @@ func ToModelSpace(e *entities.Space) *models.Space {
 	return &models.Space{
 		ID:        e.ID,
 		MapID:     e.MapID,
+		GroupID:   e.GroupID,
 		Name:      e.Name,
 		Type:      string(e.Type),
 		X:         e.X,
@@ -49,8 +52,8 @@ func ToModelSpace(e *entities.Space) *models.Space {
 		Width:     e.Width,
 		Height:    e.Height,
 		Capacity:  e.Capacity,
+		Version:   e.Version,
 		CreatedAt: e.CreatedAt,
 		UpdatedAt: e.UpdatedAt,
 	}
 }
-
@@ -0,0 +1,61 @@
+package mappers
+
+import (
+	"office-reservations/internal/domain/entities"
+	"office-reservations/internal/models"
+)
+
+// ToDomainWebhookDelivery converts a database model to a domain entity
+func ToDomainWebhookDelivery(m *models.WebhookDelivery) *entities.WebhookDelivery {
+	if m == nil {
+		return nil
+	}
+	return &entities.WebhookDelivery{
+		ID:             m.ID,
+		WebhookID:      m.WebhookID,
+		EventID:        m.EventID,
+		EventType:      entities.WebhookEventType(m.EventType),
+		Payload:        []byte(m.Payload),
+		Status:         entities.WebhookDeliveryStatus(m.Status),
+		Attempts:       m.Attempts,
+		NextAttemptAt:  m.NextAttemptAt,
+		LastAttemptAt:  m.LastAttemptAt,
+		ResponseStatus: m.ResponseStatus,
+		LastError:      m.LastError,
+		RedeliveryOf:   m.RedeliveryOf,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}
+}
+
+// ToDomainWebhookDeliveries converts a slice of database models to domain entities
+func ToDomainWebhookDeliveries(models []models.WebhookDelivery) []*entities.WebhookDelivery {
+	result := make([]*entities.WebhookDelivery, len(models))
+	for i := range models {
+		result[i] = ToDomainWebhookDelivery(&models[i])
+	}
+	return result
+}
+
+// ToModelWebhookDelivery converts a domain entity to a database model
+func ToModelWebhookDelivery(e *entities.WebhookDelivery) *models.WebhookDelivery {
+	if e == nil {
+		return nil
+	}
+	return &models.WebhookDelivery{
+		ID:             e.ID,
+		WebhookID:      e.WebhookID,
+		EventID:        e.EventID,
+		EventType:      string(e.EventType),
+		Payload:        string(e.Payload),
+		Status:         string(e.Status),
+		Attempts:       e.Attempts,
+		NextAttemptAt:  e.NextAttemptAt,
+		LastAttemptAt:  e.LastAttemptAt,
+		ResponseStatus: e.ResponseStatus,
+		LastError:      e.LastError,
+		RedeliveryOf:   e.RedeliveryOf,
+		CreatedAt:      e.CreatedAt,
+		UpdatedAt:      e.UpdatedAt,
+	}
+}
@@ -0,0 +1,63 @@
+package mappers
+
+import (
+	"encoding/json"
+
+	"gorm.io/datatypes"
+	"office-reservations/internal/domain/entities"
+	"office-reservations/internal/models"
+)
+
+// ToDomainWebhook converts a database model to a domain entity
+func ToDomainWebhook(m *models.Webhook) *entities.Webhook {
+	if m == nil {
+		return nil
+	}
+	// Events are stored as a JSON list of event type names
+	var events []entities.WebhookEventType
+	_ = json.Unmarshal(m.Events, &events)
+	return &entities.Webhook{
+		ID:          m.ID,
+		URL:         m.URL,
+		Secret:      m.Secret,
+		Description: m.Description,
+		Events:      events,
+		Active:      m.Active,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
+
+// ToDomainWebhooks converts a slice of database models to domain entities
+func ToDomainWebhooks(models []models.Webhook) []*entities.Webhook {
+	result := make([]*entities.Webhook, len(models))
+	for i := range models {
+		result[i] = ToDomainWebhook(&models[i])
+	}
+	return result
+}
+
+// ToModelWebhook converts a domain entity to a database model
+func ToModelWebhook(e *entities.Webhook) (*models.Webhook, error) {
+	if e == nil {
+		return nil, nil
+	}
+	events := e.Events
+	if events == nil {
+		events = []entities.WebhookEventType{}
+	}
+	data, err := json.Marshal(events)
+	if err != nil {
+		return nil, err
+	}
+	return &models.Webhook{
+		ID:          e.ID,
+		URL:         e.URL,
+		Secret:      e.Secret,
+		Description: e.Description,
+		Events:      datatypes.JSON(data),
+		Active:      e.Active,
+		CreatedAt:   e.CreatedAt,
+		UpdatedAt:   e.UpdatedAt,
+	}, nil
+}
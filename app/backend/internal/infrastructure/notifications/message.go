@@ -0,0 +1,226 @@
+package notifications
+
+import (
+	"bytes"
+	"embed"
+	"encoding/base64"
+	"fmt"
+	htmltemplate "html/template"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/google/uuid"
+	"office-reservations/internal/application/services"
+	"office-reservations/internal/domain/entities"
+	"office-reservations/internal/ics"
+)
+
+//go:embed templates
+var templateFS embed.FS
+
+var (
+	htmlTemplate = htmltemplate.Must(htmltemplate.ParseFS(templateFS, "templates/notification.html"))
+	textTemplate = texttemplate.Must(texttemplate.ParseFS(templateFS, "templates/notification.txt"))
+)
+
+// base64LineLength is the length of the lines of base64 encoded attachments
+const base64LineLength = 76
+
+// emailContent is the data the email templates are rendered with
+type emailContent struct {
+	Subject string
+	Heading string
+	Intro   string
+	Reason  string
+	Name    string
+	Space   string
+	Office  string
+	Date    string
+	Time    string
+	Notes   string
+	// Cancelled bookings are shown struck through
+	Cancelled bool
+	// Invite is set when a calendar file is attached
+	Invite bool
+}
+
+// contentOf writes the wording of a notification
+func contentOf(message *services.NotificationMessage) emailContent {
+	entry := message.Booking
+	r := entry.Reservation
+	loc := entry.Map.Location()
+	start := message.Start.In(loc)
+
+	content := emailContent{
+		Reason:    message.Reason,
+		Name:      message.ToName,
+		Space:     entry.Title,
+		Office:    entry.Map.Name,
+		Date:      r.Date.Format("Monday, 2 January 2006"),
+		Time:      "All day",
+		Notes:     strings.TrimSpace(r.Notes),
+		Cancelled: r.IsCancelled(),
+		Invite:    hasInvite(message.Kind),
+	}
+	if content.Name == "" {
+		content.Name = r.UserID
+	}
+	timeRange, err := r.TimeRange()
+	if err == nil && !timeRange.IsAllDay() {
+		content.Time = fmt.Sprintf("%s–%s (%s)", entities.FormatClock(timeRange.Start), entities.FormatClock(timeRange.End), loc)
+	}
+
+	day := r.Date.Format("Mon 2 Jan")
+	switch message.Kind {
+	case entities.NotificationConfirmation:
+		content.Heading = "Booking confirmed"
+		content.Intro = "Your booking is confirmed."
+		content.Subject = fmt.Sprintf("Booking confirmed: %s, %s", entry.Title, day)
+	case entities.NotificationUpdate:
+		content.Heading = "Booking changed"
+		content.Intro = "Your booking has been changed. These are its new details."
+		content.Subject = fmt.Sprintf("Booking changed: %s, %s", entry.Title, day)
+	case entities.NotificationCancellation:
+		content.Heading = "Booking cancelled"
+		content.Intro = "Your booking has been cancelled."
+		content.Subject = fmt.Sprintf("Booking cancelled: %s, %s", entry.Title, day)
+	case entities.NotificationDayReminder:
+		content.Heading = "Upcoming booking"
+		content.Intro = "This is a reminder of your upcoming booking."
+		content.Subject = fmt.Sprintf("Reminder: %s on %s at %s", entry.Title, day, start.Format("15:04"))
+	case entities.NotificationHourReminder:
+		content.Heading = "Your booking starts soon"
+		content.Intro = fmt.Sprintf("Your booking starts at %s.", start.Format("15:04"))
+		content.Subject = fmt.Sprintf("Starting soon: %s at %s", entry.Title, start.Format("15:04"))
+	}
+	return content
+}
+
+// hasInvite returns true if emails of the kind carry a calendar file. Reminders
+// do not, as the booking is already in the holder's calendar.
+func hasInvite(kind entities.NotificationKind) bool {
+	return !kind.IsReminder()
+}
+
+// composeMessage writes the MIME message of a notification: a text and an HTML
+// version, with the booking attached as a calendar file for kinds that carry one
+func composeMessage(from *mail.Address, message *services.NotificationMessage, now time.Time) ([]byte, error) {
+	content := contentOf(message)
+	var text, html bytes.Buffer
+	if err := textTemplate.Execute(&text, content); err != nil {
+		return nil, err
+	}
+	if err := htmlTemplate.Execute(&html, content); err != nil {
+		return nil, err
+	}
+
+	var alternativeBody bytes.Buffer
+	alternative := multipart.NewWriter(&alternativeBody)
+	if err := writeQuotedPrintable(alternative, "text/plain; charset=utf-8", text.Bytes()); err != nil {
+		return nil, err
+	}
+	if err := writeQuotedPrintable(alternative, "text/html; charset=utf-8", html.Bytes()); err != nil {
+		return nil, err
+	}
+	if err := alternative.Close(); err != nil {
+		return nil, err
+	}
+	contentType := "multipart/alternative; boundary=" + alternative.Boundary()
+	body := alternativeBody.Bytes()
+
+	if content.Invite {
+		var mixedBody bytes.Buffer
+		mixed := multipart.NewWriter(&mixedBody)
+		part, err := mixed.CreatePart(textproto.MIMEHeader{"Content-Type": {contentType}})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := part.Write(body); err != nil {
+			return nil, err
+		}
+		attendee := ics.Attendee{Name: content.Name, Email: message.To}
+		invite, method := ics.WriteInvite(message.Booking, from.Address, attendee, now)
+		if err := writeAttachment(mixed, "text/calendar; charset=utf-8; method="+method, "invite.ics", invite); err != nil {
+			return nil, err
+		}
+		if err := mixed.Close(); err != nil {
+			return nil, err
+		}
+		contentType = "multipart/mixed; boundary=" + mixed.Boundary()
+		body = mixedBody.Bytes()
+	}
+
+	var msg bytes.Buffer
+	to := &mail.Address{Name: content.Name, Address: message.To}
+	writeHeader(&msg, "From", from.String())
+	writeHeader(&msg, "To", to.String())
+	writeHeader(&msg, "Subject", mime.QEncoding.Encode("utf-8", singleLine(content.Subject)))
+	writeHeader(&msg, "Date", now.Format(time.RFC1123Z))
+	writeHeader(&msg, "Message-ID", fmt.Sprintf("<%s@%s>", uuid.New(), messageIDDomain(from.Address)))
+	writeHeader(&msg, "MIME-Version", "1.0")
+	writeHeader(&msg, "Content-Type", contentType)
+	msg.WriteString("\r\n")
+	msg.Write(body)
+	return msg.Bytes(), nil
+}
+
+// writeQuotedPrintable writes a quoted-printable text part
+func writeQuotedPrintable(w *multipart.Writer, contentType string, content []byte) error {
+	part, err := w.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {contentType},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return err
+	}
+	qp := quotedprintable.NewWriter(part)
+	if _, err := qp.Write(content); err != nil {
+		return err
+	}
+	return qp.Close()
+}
+
+// writeAttachment writes a base64 encoded attachment
+func writeAttachment(w *multipart.Writer, contentType, filename string, content []byte) error {
+	part, err := w.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {contentType},
+		"Content-Transfer-Encoding": {"base64"},
+		"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": filename})},
+	})
+	if err != nil {
+		return err
+	}
+	encoded := base64.StdEncoding.EncodeToString(content)
+	for len(encoded) > base64LineLength {
+		if _, err := part.Write([]byte(encoded[:base64LineLength] + "\r\n")); err != nil {
+			return err
+		}
+		encoded = encoded[base64LineLength:]
+	}
+	_, err = part.Write([]byte(encoded + "\r\n"))
+	return err
+}
+
+// writeHeader writes a header field
+func writeHeader(buf *bytes.Buffer, name, value string) {
+	buf.WriteString(name + ": " + value + "\r\n")
+}
+
+// singleLine replaces line breaks, which would end a header field
+func singleLine(value string) string {
+	return strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ").Replace(value)
+}
+
+// messageIDDomain returns the domain of the sender address, for Message-IDs
+func messageIDDomain(address string) string {
+	if at := strings.LastIndex(address, "@"); at >= 0 && at < len(address)-1 {
+		return address[at+1:]
+	}
+	return "localhost"
+}
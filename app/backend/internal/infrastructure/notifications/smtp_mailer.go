@@ -0,0 +1,102 @@
+// Package notifications sends the emails that inform users about their bookings
+package notifications
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"office-reservations/internal/application/services"
+	"office-reservations/internal/config"
+)
+
+// SMTPMailer sends notification emails through an SMTP server
+type SMTPMailer struct {
+	config config.SMTPConfig
+	from   *mail.Address
+}
+
+// NewSMTPMailer creates a new SMTP mailer. It fails if the sender address is invalid.
+func NewSMTPMailer(cfg config.SMTPConfig) (*SMTPMailer, error) {
+	from, err := mail.ParseAddress(cfg.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SMTP_FROM %q: %w", cfg.From, err)
+	}
+	return &SMTPMailer{config: cfg, from: from}, nil
+}
+
+// Send writes the email of a notification and hands it to the server
+func (m *SMTPMailer) Send(message *services.NotificationMessage) error {
+	body, err := composeMessage(m.from, message, time.Now())
+	if err != nil {
+		return err
+	}
+	return m.deliver(message.To, body)
+}
+
+// deliver sends a message to one recipient. Connections use TLS from the start
+// with ImplicitTLS, and are upgraded with STARTTLS when the server offers it otherwise.
+func (m *SMTPMailer) deliver(to string, body []byte) error {
+	addr := net.JoinHostPort(m.config.Host, strconv.Itoa(m.config.Port))
+	dialer := &net.Dialer{Timeout: m.config.Timeout}
+	tlsConfig := &tls.Config{ServerName: m.config.Host}
+
+	var conn net.Conn
+	var err error
+	if m.config.ImplicitTLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+	if err != nil {
+		return err
+	}
+	if err := conn.SetDeadline(time.Now().Add(m.config.Timeout)); err != nil {
+		conn.Close()
+		return err
+	}
+
+	client, err := smtp.NewClient(conn, m.config.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if !m.config.ImplicitTLS {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(tlsConfig); err != nil {
+				return err
+			}
+		}
+	}
+	if m.config.Username != "" {
+		auth := smtp.PlainAuth("", m.config.Username, m.config.Password, m.config.Host)
+		if err := client.Auth(auth); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(m.from.Address); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(body); err != nil {
+		w.Close()
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
@@ -0,0 +1,61 @@
+// Package realtime receives the announcements of committed events from PostgreSQL
+package realtime
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/stdlib"
+	"gorm.io/gorm"
+)
+
+// outboxChannel is the channel committed outbox events are announced on
+const outboxChannel = "outbox_events"
+
+// PostgresListener listens for outbox event announcements on a dedicated
+// connection taken from the application's pool
+type PostgresListener struct {
+	db *sql.DB
+}
+
+// NewPostgresListener creates a new listener on the database of db
+func NewPostgresListener(db *gorm.DB) (*PostgresListener, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database handle: %w", err)
+	}
+	return &PostgresListener{db: sqlDB}, nil
+}
+
+// Listen runs LISTEN on a connection of its own and reports every announcement
+// until ctx is done or the connection fails. The connection is then discarded,
+// as it may still be listening or be broken.
+func (l *PostgresListener) Listen(ctx context.Context, listening func(), notify func(payload string)) error {
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return conn.Raw(func(driverConn any) error {
+		stdConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return fmt.Errorf("unsupported database driver %T", driverConn)
+		}
+		pgConn := stdConn.Conn()
+		if _, err := pgConn.Exec(ctx, "LISTEN "+outboxChannel); err != nil {
+			return fmt.Errorf("%w: %w", driver.ErrBadConn, err)
+		}
+		listening()
+
+		for {
+			notification, err := pgConn.WaitForNotification(ctx)
+			if err != nil {
+				return fmt.Errorf("%w: %w", driver.ErrBadConn, err)
+			}
+			notify(notification.Payload)
+		}
+	})
+}
@@ -0,0 +1,36 @@
+package repositories
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"office-reservations/internal/auth"
+	"office-reservations/internal/models"
+)
+
+// accessDenialRepository implements auth.DenialRecorder
+type accessDenialRepository struct {
+	db *gorm.DB
+}
+
+// NewAccessDenialRepository creates a recorder that stores denials in the database
+func NewAccessDenialRepository(db *gorm.DB) auth.DenialRecorder {
+	return &accessDenialRepository{db: db}
+}
+
+func (r *accessDenialRepository) RecordDenial(denial *auth.Denial) error {
+	model := &models.AccessDenial{
+		UserID:     denial.UserID,
+		Roles:      strings.Join(denial.Roles, ","),
+		Method:     denial.Method,
+		Path:       denial.Path,
+		Permission: string(denial.Permission),
+		Reason:     denial.Reason,
+		CreatedAt:  denial.CreatedAt,
+	}
+	if mapID, err := uuid.Parse(denial.MapID); err == nil {
+		model.MapID = &mapID
+	}
+	return r.db.Create(model).Error
+}
@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"gorm.io/gorm"
+	"office-reservations/internal/domain/entities"
+	domainRepos "office-reservations/internal/domain/repositories"
+	"office-reservations/internal/infrastructure/mappers"
+	"office-reservations/internal/models"
+)
+
+// auditRepository implements AuditRepository interface
+type auditRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditRepository creates a new audit repository
+func NewAuditRepository(db *gorm.DB) domainRepos.AuditRepository {
+	return &auditRepository{db: db}
+}
+
+func (r *auditRepository) Append(entry *entities.AuditEntry) error {
+	return r.db.Create(mappers.ToModelAuditEntry(entry)).Error
+}
+
+func (r *auditRepository) FindAll(filters domainRepos.AuditFilters) ([]*entities.AuditEntry, error) {
+	query := r.db.Model(&models.AuditEntry{})
+	if filters.EntityType != nil {
+		query = query.Where("entity_type = ?", string(*filters.EntityType))
+	}
+	if filters.EntityID != nil {
+		query = query.Where("entity_id = ?", *filters.EntityID)
+	}
+	if filters.MapID != nil {
+		query = query.Where("map_id = ?", *filters.MapID)
+	}
+	if filters.ActorID != nil {
+		query = query.Where("actor_id = ?", *filters.ActorID)
+	}
+	if filters.Action != nil {
+		query = query.Where("action = ?", string(*filters.Action))
+	}
+	if filters.From != nil {
+		query = query.Where("created_at >= ?", *filters.From)
+	}
+	if filters.To != nil {
+		query = query.Where("created_at <= ?", *filters.To)
+	}
+	if filters.Limit > 0 {
+		query = query.Limit(filters.Limit)
+	}
+
+	var models []models.AuditEntry
+	if err := query.Order("created_at DESC, id").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return mappers.ToDomainAuditEntries(models), nil
+}
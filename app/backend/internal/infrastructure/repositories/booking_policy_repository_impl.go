@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"office-reservations/internal/domain/entities"
+	domainRepos "office-reservations/internal/domain/repositories"
+	"office-reservations/internal/infrastructure/mappers"
+	"office-reservations/internal/models"
+)
+
+// bookingPolicyRepository implements BookingPolicyRepository interface
+type bookingPolicyRepository struct {
+	db *gorm.DB
+}
+
+// NewBookingPolicyRepository creates a new booking policy repository
+func NewBookingPolicyRepository(db *gorm.DB) domainRepos.BookingPolicyRepository {
+	return &bookingPolicyRepository{db: db}
+}
+
+func (r *bookingPolicyRepository) FindByID(id uuid.UUID) (*entities.BookingPolicy, error) {
+	var model models.BookingPolicy
+	if err := r.db.First(&model, id).Error; err != nil {
+		return nil, err
+	}
+	return mappers.ToDomainBookingPolicy(&model), nil
+}
+
+func (r *bookingPolicyRepository) FindAll() ([]*entities.BookingPolicy, error) {
+	var models []models.BookingPolicy
+	if err := r.db.Order("name ASC").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return mappers.ToDomainBookingPolicies(models), nil
+}
+
+func (r *bookingPolicyRepository) FindForMap(mapID uuid.UUID) ([]*entities.BookingPolicy, error) {
+	var models []models.BookingPolicy
+	if err := r.db.Where("map_id = ? OR map_id IS NULL", mapID).
+		Order("name ASC").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return mappers.ToDomainBookingPolicies(models), nil
+}
+
+func (r *bookingPolicyRepository) Create(policy *entities.BookingPolicy) error {
+	model, err := mappers.ToModelBookingPolicy(policy)
+	if err != nil {
+		return err
+	}
+	return r.db.Omit(clause.Associations).Create(model).Error
+}
+
+func (r *bookingPolicyRepository) Update(policy *entities.BookingPolicy) error {
+	model, err := mappers.ToModelBookingPolicy(policy)
+	if err != nil {
+		return err
+	}
+	return r.db.Omit(clause.Associations).Save(model).Error
+}
+
+func (r *bookingPolicyRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&models.BookingPolicy{}, id).Error
+}
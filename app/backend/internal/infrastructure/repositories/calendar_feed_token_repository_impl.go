@@ -0,0 +1,40 @@
+package repositories
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"office-reservations/internal/domain/entities"
+	domainRepos "office-reservations/internal/domain/repositories"
+	"office-reservations/internal/infrastructure/mappers"
+	"office-reservations/internal/models"
+)
+
+// calendarFeedTokenRepository implements CalendarFeedTokenRepository interface
+type calendarFeedTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewCalendarFeedTokenRepository creates a new calendar feed token repository
+func NewCalendarFeedTokenRepository(db *gorm.DB) domainRepos.CalendarFeedTokenRepository {
+	return &calendarFeedTokenRepository{db: db}
+}
+
+func (r *calendarFeedTokenRepository) FindByHash(tokenHash string) (*entities.CalendarFeedToken, error) {
+	var model models.CalendarFeedToken
+	if err := r.db.Where("token_hash = ?", tokenHash).First(&model).Error; err != nil {
+		return nil, err
+	}
+	return mappers.ToDomainCalendarFeedToken(&model), nil
+}
+
+func (r *calendarFeedTokenRepository) Save(token *entities.CalendarFeedToken) error {
+	model := mappers.ToModelCalendarFeedToken(token)
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"token_hash", "created_at"}),
+	}).Create(model).Error
+}
+
+func (r *calendarFeedTokenRepository) DeleteByUserID(userID string) error {
+	return r.db.Where("user_id = ?", userID).Delete(&models.CalendarFeedToken{}).Error
+}
@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"office-reservations/internal/domain/entities"
+	domainRepos "office-reservations/internal/domain/repositories"
+	"office-reservations/internal/infrastructure/mappers"
+	"office-reservations/internal/models"
+)
+
+// closureRepository implements ClosureRepository interface
+type closureRepository struct {
+	db *gorm.DB
+}
+
+// NewClosureRepository creates a new closure repository
+func NewClosureRepository(db *gorm.DB) domainRepos.ClosureRepository {
+	return &closureRepository{db: db}
+}
+
+func (r *closureRepository) FindByID(id uuid.UUID) (*entities.Closure, error) {
+	var model models.Closure
+	if err := r.db.First(&model, id).Error; err != nil {
+		return nil, err
+	}
+	return mappers.ToDomainClosure(&model), nil
+}
+
+func (r *closureRepository) FindAll(filters domainRepos.ClosureFilters) ([]*entities.Closure, error) {
+	var models []models.Closure
+	query := r.db
+
+	if filters.MapID != nil {
+		query = query.Where("map_id = ?", *filters.MapID)
+	}
+	if filters.From != nil {
+		query = query.Where("end_date >= ?", *filters.From)
+	}
+	if filters.To != nil {
+		query = query.Where("start_date <= ?", *filters.To)
+	}
+
+	if err := query.Order("start_date ASC, start_time ASC NULLS FIRST").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return mappers.ToDomainClosures(models), nil
+}
+
+func (r *closureRepository) FindByExternalID(mapID uuid.UUID, externalID string) ([]*entities.Closure, error) {
+	var models []models.Closure
+	if err := r.db.Where("map_id = ? AND external_id = ?", mapID, externalID).
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return mappers.ToDomainClosures(models), nil
+}
+
+func (r *closureRepository) Create(closure *entities.Closure) error {
+	model := mappers.ToModelClosure(closure)
+	return r.db.Omit(clause.Associations).Create(model).Error
+}
+
+func (r *closureRepository) Update(closure *entities.Closure) error {
+	model := mappers.ToModelClosure(closure)
+	return r.db.Omit(clause.Associations).Save(model).Error
+}
+
+func (r *closureRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&models.Closure{}, id).Error
+}
@@ -0,0 +1,40 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	domainRepos "office-reservations/internal/domain/repositories"
+)
+
+// PostgreSQL error codes used to translate constraint violations
+const (
+	pgExclusionViolation = "23P01"
+)
+
+// translateReservationError maps database constraint violations to domain errors
+func translateReservationError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgExclusionViolation {
+		return domainRepos.ErrReservationOverlap
+	}
+	return err
+}
+
+// updateVersioned writes every column of model, which holds the next version,
+// if its row is still at version. It returns ErrVersionConflict when the row was
+// changed or deleted since that version was read.
+func updateVersioned(db *gorm.DB, model interface{}, version int64) error {
+	result := db.Model(model).Omit(clause.Associations).Select("*").
+		Where("version = ?", version).
+		Updates(model)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domainRepos.ErrVersionConflict
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"office-reservations/internal/domain/entities"
+	domainRepos "office-reservations/internal/domain/repositories"
+	"office-reservations/internal/infrastructure/mappers"
+	"office-reservations/internal/models"
+)
+
+// idempotencyRepository implements IdempotencyRepository interface
+type idempotencyRepository struct {
+	db *gorm.DB
+}
+
+// NewIdempotencyRepository creates a new idempotency key repository
+func NewIdempotencyRepository(db *gorm.DB) domainRepos.IdempotencyRepository {
+	return &idempotencyRepository{db: db}
+}
+
+func (r *idempotencyRepository) Create(record *entities.IdempotencyRecord) (bool, error) {
+	model, err := mappers.ToModelIdempotencyKey(record)
+	if err != nil {
+		return false, err
+	}
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(model)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *idempotencyRepository) Find(scope, key string) (*entities.IdempotencyRecord, error) {
+	var model models.IdempotencyKey
+	if err := r.db.Where("scope = ? AND key = ?", scope, key).First(&model).Error; err != nil {
+		return nil, err
+	}
+	return mappers.ToDomainIdempotencyRecord(&model), nil
+}
+
+func (r *idempotencyRepository) Complete(record *entities.IdempotencyRecord) error {
+	model, err := mappers.ToModelIdempotencyKey(record)
+	if err != nil {
+		return err
+	}
+	return r.db.Model(&models.IdempotencyKey{}).
+		Where("scope = ? AND key = ?", record.Scope, record.Key).
+		Updates(map[string]interface{}{
+			"status_code": model.StatusCode,
+			"headers":     model.Headers,
+			"body":        model.Body,
+			"expires_at":  model.ExpiresAt,
+		}).Error
+}
+
+func (r *idempotencyRepository) Delete(scope, key string) error {
+	return r.db.Where("scope = ? AND key = ?", scope, key).Delete(&models.IdempotencyKey{}).Error
+}
+
+func (r *idempotencyRepository) DeleteExpired(before time.Time) (int64, error) {
+	result := r.db.Where("expires_at <= ?", before).Delete(&models.IdempotencyKey{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,48 @@
+package repositories
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"office-reservations/internal/domain/entities"
+	domainRepos "office-reservations/internal/domain/repositories"
+	"office-reservations/internal/infrastructure/mappers"
+	"office-reservations/internal/models"
+)
+
+// notificationPreferencesRepository implements NotificationPreferencesRepository interface
+type notificationPreferencesRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationPreferencesRepository creates a new notification preferences repository
+func NewNotificationPreferencesRepository(db *gorm.DB) domainRepos.NotificationPreferencesRepository {
+	return &notificationPreferencesRepository{db: db}
+}
+
+func (r *notificationPreferencesRepository) FindByUserID(userID string) (*entities.NotificationPreferences, error) {
+	var model models.NotificationPreference
+	if err := r.db.Where("user_id = ?", userID).First(&model).Error; err != nil {
+		return nil, err
+	}
+	return mappers.ToDomainNotificationPreferences(&model), nil
+}
+
+func (r *notificationPreferencesRepository) Save(preferences *entities.NotificationPreferences) error {
+	model := mappers.ToModelNotificationPreferences(preferences)
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"email", "confirmations", "cancellations", "day_reminders", "hour_reminders", "updated_at"}),
+	}).Create(model).Error
+}
+
+func (r *notificationPreferencesRepository) SaveEmail(userID, email string) error {
+	model := mappers.ToModelNotificationPreferences(entities.DefaultNotificationPreferences(userID))
+	model.Email = email
+	model.UpdatedAt = time.Now()
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"email", "updated_at"}),
+	}).Create(model).Error
+}
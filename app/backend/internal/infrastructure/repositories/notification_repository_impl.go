@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"office-reservations/internal/domain/entities"
+	domainRepos "office-reservations/internal/domain/repositories"
+	"office-reservations/internal/infrastructure/mappers"
+	"office-reservations/internal/models"
+)
+
+// notificationRepository implements NotificationRepository interface
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationRepository creates a new notification repository
+func NewNotificationRepository(db *gorm.DB) domainRepos.NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+func (r *notificationRepository) Create(notification *entities.Notification) (bool, error) {
+	model := mappers.ToModelNotification(notification)
+	// Reminders already queued for the same start hit the unique index and are skipped
+	result := r.db.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).Create(model)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *notificationRepository) Update(notification *entities.Notification) error {
+	model := mappers.ToModelNotification(notification)
+	return r.db.Omit(clause.Associations).Save(model).Error
+}
+
+func (r *notificationRepository) ClaimDue(now, leaseUntil time.Time, limit int) ([]*entities.Notification, error) {
+	var models []models.Notification
+	err := r.db.Raw(`UPDATE notifications SET next_attempt_at = ?
+		WHERE id IN (
+			SELECT id FROM notifications
+			WHERE status = ? AND next_attempt_at <= ?
+			ORDER BY next_attempt_at LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`, leaseUntil, string(entities.NotificationPending), now, limit).
+		Scan(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	return mappers.ToDomainNotifications(models), nil
+}
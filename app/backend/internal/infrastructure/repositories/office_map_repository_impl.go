@@ -0,0 +1,130 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"office-reservations/internal/domain/entities"
+	domainRepos "office-reservations/internal/domain/repositories"
+	"office-reservations/internal/infrastructure/mappers"
+	"office-reservations/internal/models"
+)
+
+// officeMapRepository implements OfficeMapRepository interface
+type officeMapRepository struct {
+	db *gorm.DB
+}
+
+// NewOfficeMapRepository creates a new office map repository
+func NewOfficeMapRepository(db *gorm.DB) domainRepos.OfficeMapRepository {
+	return &officeMapRepository{db: db}
+}
+
+func (r *officeMapRepository) FindByID(id uuid.UUID) (*entities.OfficeMap, error) {
+	var model models.OfficeMap
+	if err := r.db.Preload("Spaces", orderSpaces).First(&model, id).Error; err != nil {
+		return nil, err
+	}
+	return mappers.ToDomainOfficeMap(&model), nil
+}
+
+func (r *officeMapRepository) FindAll() ([]*entities.OfficeMap, error) {
+	var models []models.OfficeMap
+	if err := r.db.Preload("Spaces", orderSpaces).Order("name ASC").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return mappers.ToDomainOfficeMaps(models), nil
+}
+
+// mapSorts are the orders maps can be listed in
+var mapSorts = map[string]sortOrder{
+	"name":       {columns: []sortColumn{{expr: "name", sqlType: "text"}, {expr: "id", sqlType: "uuid"}}},
+	"created_at": {columns: []sortColumn{{expr: "created_at", sqlType: "timestamptz"}, {expr: "id", sqlType: "uuid"}}},
+	"updated_at": {columns: []sortColumn{{expr: "updated_at", sqlType: "timestamptz"}, {expr: "id", sqlType: "uuid"}}},
+}
+
+// mapCursorValues returns the position of a map in a sort order
+func mapCursorValues(sort string, m *models.OfficeMap) []string {
+	switch sort {
+	case "created_at":
+		return []string{m.CreatedAt.Format(time.RFC3339Nano), m.ID.String()}
+	case "updated_at":
+		return []string{m.UpdatedAt.Format(time.RFC3339Nano), m.ID.String()}
+	default:
+		return []string{m.Name, m.ID.String()}
+	}
+}
+
+func (r *officeMapRepository) FindPage(filters domainRepos.MapFilters, page domainRepos.PageRequest) ([]*entities.OfficeMap, domainRepos.PageInfo, error) {
+	sort, order, err := resolveSort(mapSorts, "name", page)
+	if err != nil {
+		return nil, domainRepos.PageInfo{}, err
+	}
+
+	query := r.db.Model(&models.OfficeMap{})
+	if filters.NameSearch != nil {
+		query = query.Where("name ILIKE ?", containsPattern(*filters.NameSearch))
+	}
+	if filters.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *filters.CreatedFrom)
+	}
+	if filters.CreatedTo != nil {
+		query = query.Where("created_at <= ?", *filters.CreatedTo)
+	}
+	query, err = paginate(query, sort, order, page)
+	if err != nil {
+		return nil, domainRepos.PageInfo{}, err
+	}
+
+	var models []models.OfficeMap
+	if err := query.Find(&models).Error; err != nil {
+		return nil, domainRepos.PageInfo{}, err
+	}
+	n, info := pageEnd(len(models), sort, page, func(i int) []string {
+		return mapCursorValues(sort, &models[i])
+	})
+	return mappers.ToDomainOfficeMaps(models[:n]), info, nil
+}
+
+func (r *officeMapRepository) Create(m *entities.OfficeMap) error {
+	m.Version = 1
+	model, err := mappers.ToModelOfficeMap(m)
+	if err != nil {
+		return err
+	}
+	return r.db.Omit(clause.Associations).Create(model).Error
+}
+
+func (r *officeMapRepository) Update(m *entities.OfficeMap) error {
+	model, err := mappers.ToModelOfficeMap(m)
+	if err != nil {
+		return err
+	}
+	model.Version++
+	if err := updateVersioned(r.db, model, m.Version); err != nil {
+		return err
+	}
+	m.Version = model.Version
+	m.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+func (r *officeMapRepository) Delete(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// The map is only marked as deleted, so the cascade does not reach
+		// what is scoped to it
+		for _, model := range []interface{}{&models.Closure{}, &models.BookingPolicy{}, &models.SpaceGroup{}} {
+			if err := tx.Where("map_id = ?", id).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Delete(&models.OfficeMap{}, id).Error
+	})
+}
+
+// orderSpaces lists a map's spaces by position, as the map is drawn
+func orderSpaces(db *gorm.DB) *gorm.DB {
+	return db.Order("y ASC, x ASC, name ASC")
+}
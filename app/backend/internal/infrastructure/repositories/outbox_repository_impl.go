@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"office-reservations/internal/domain/entities"
+	domainRepos "office-reservations/internal/domain/repositories"
+	"office-reservations/internal/infrastructure/mappers"
+	"office-reservations/internal/models"
+)
+
+// outboxRepository implements OutboxRepository interface
+type outboxRepository struct {
+	db *gorm.DB
+}
+
+// NewOutboxRepository creates a new outbox repository
+func NewOutboxRepository(db *gorm.DB) domainRepos.OutboxRepository {
+	return &outboxRepository{db: db}
+}
+
+// outboxMapLockClass namespaces the advisory locks that serialize the events of
+// each map; the second key is a hash of the map ID
+const outboxMapLockClass = 9
+
+// Append takes a transaction-level lock on the map of the event before the
+// database numbers it. Transactions appending events of the same map then
+// commit in the order of their sequence numbers, so a stream that resumes
+// after an event never skips an earlier numbered one committed later.
+func (r *outboxRepository) Append(event *entities.OutboxEvent) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if event.MapID != nil {
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(?, hashtext(?))", outboxMapLockClass, event.MapID.String()).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Create(mappers.ToModelOutboxEvent(event)).Error
+	})
+}
+
+func (r *outboxRepository) ClaimPending(limit int) ([]*entities.OutboxEvent, error) {
+	var models []models.OutboxEvent
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("dispatched_at IS NULL").
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	return mappers.ToDomainOutboxEvents(models), nil
+}
+
+func (r *outboxRepository) MarkDispatched(ids []uuid.UUID, at time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.Model(&models.OutboxEvent{}).Where("id IN ?", ids).Update("dispatched_at", at).Error
+}
+
+func (r *outboxRepository) FindBySeq(seq int64) (*entities.OutboxEvent, error) {
+	var model models.OutboxEvent
+	if err := r.db.Where("seq = ?", seq).First(&model).Error; err != nil {
+		return nil, err
+	}
+	return mappers.ToDomainOutboxEvent(&model), nil
+}
+
+func (r *outboxRepository) FindByMapAfter(mapID uuid.UUID, afterSeq int64, limit int) ([]*entities.OutboxEvent, error) {
+	var models []models.OutboxEvent
+	err := r.db.Where("map_id = ? AND seq > ?", mapID, afterSeq).
+		Order("seq ASC").
+		Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	return mappers.ToDomainOutboxEvents(models), nil
+}
+
+func (r *outboxRepository) LastSeqByMap(mapID uuid.UUID) (int64, error) {
+	var seq int64
+	err := r.db.Model(&models.OutboxEvent{}).
+		Where("map_id = ?", mapID).
+		Select("COALESCE(MAX(seq), 0)").
+		Scan(&seq).Error
+	return seq, err
+}
@@ -0,0 +1,110 @@
+package repositories
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	domainRepos "office-reservations/internal/domain/repositories"
+)
+
+// sortColumn is a column, or expression, a listing is ordered by. Cursor values
+// are compared to it as its SQL type.
+type sortColumn struct {
+	expr    string
+	sqlType string
+}
+
+// sortOrder is an order a listing can be sorted in. The last column must be
+// unique, such as the ID, so that every item has a distinct position.
+type sortOrder struct {
+	columns []sortColumn
+}
+
+// pageCursor is the position of the last item of a page, for the sort order it
+// was issued for
+type pageCursor struct {
+	Sort       string   `json:"s"`
+	Descending bool     `json:"d,omitempty"`
+	Values     []string `json:"v"`
+}
+
+// resolveSort returns the name and columns of the requested sort order, or of
+// the default one when none is requested
+func resolveSort(orders map[string]sortOrder, defaultSort string, page domainRepos.PageRequest) (string, sortOrder, error) {
+	name := page.Sort
+	if name == "" {
+		name = defaultSort
+	}
+	order, ok := orders[name]
+	if !ok {
+		return "", sortOrder{}, domainRepos.ErrInvalidSort
+	}
+	return name, order, nil
+}
+
+// paginate orders the query and restricts it to the page after the cursor. One
+// item more than the limit is fetched to tell whether another page follows.
+func paginate(query *gorm.DB, name string, order sortOrder, page domainRepos.PageRequest) (*gorm.DB, error) {
+	direction, comparison := "ASC", ">"
+	if page.Descending {
+		direction, comparison = "DESC", "<"
+	}
+
+	if page.Cursor != "" {
+		cursor, err := decodeCursor(page.Cursor)
+		if err != nil || cursor.Sort != name || cursor.Descending != page.Descending || len(cursor.Values) != len(order.columns) {
+			return nil, domainRepos.ErrInvalidCursor
+		}
+		exprs := make([]string, len(order.columns))
+		params := make([]string, len(order.columns))
+		values := make([]interface{}, len(order.columns))
+		for i, column := range order.columns {
+			exprs[i] = column.expr
+			params[i] = fmt.Sprintf("CAST(? AS %s)", column.sqlType)
+			values[i] = cursor.Values[i]
+		}
+		query = query.Where(fmt.Sprintf("(%s) %s (%s)", strings.Join(exprs, ", "), comparison, strings.Join(params, ", ")), values...)
+	}
+
+	for _, column := range order.columns {
+		query = query.Order(column.expr + " " + direction)
+	}
+	if page.Limit > 0 {
+		query = query.Limit(page.Limit + 1)
+	}
+	return query, nil
+}
+
+// pageEnd trims the extra item fetched by paginate and returns the length of
+// the page and where it ends. last returns the cursor values of the item at an index.
+func pageEnd(found int, name string, page domainRepos.PageRequest, last func(i int) []string) (int, domainRepos.PageInfo) {
+	if page.Limit <= 0 || found <= page.Limit {
+		return found, domainRepos.PageInfo{}
+	}
+	cursor := encodeCursor(pageCursor{Sort: name, Descending: page.Descending, Values: last(page.Limit - 1)})
+	return page.Limit, domainRepos.PageInfo{NextCursor: cursor}
+}
+
+func encodeCursor(cursor pageCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(value string) (pageCursor, error) {
+	var cursor pageCursor
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return cursor, err
+	}
+	err = json.Unmarshal(data, &cursor)
+	return cursor, err
+}
+
+// containsPattern returns a LIKE pattern matching values that contain s
+func containsPattern(s string) string {
+	s = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+	return "%" + s + "%"
+}
@@ -0,0 +1,169 @@
+package repositories
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	domainRepos "office-reservations/internal/domain/repositories"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor pageCursor
+	}{
+		{name: "ascending", cursor: pageCursor{Sort: "date", Values: []string{"2024-01-05", "8c5b2f1e-3f5a-4c1e-9a57-0c3a1b2d4e5f"}}},
+		{name: "descending", cursor: pageCursor{Sort: "name", Descending: true, Values: []string{"Desk 4", "1"}}},
+		{name: "values needing escapes", cursor: pageCursor{Sort: "name", Values: []string{`a "quoted", /slashed/ + name`, ""}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded := encodeCursor(tt.cursor)
+			if strings.ContainsAny(encoded, "+/=") {
+				t.Errorf("encodeCursor() = %q, want a URL-safe value without padding", encoded)
+			}
+			decoded, err := decodeCursor(encoded)
+			if err != nil {
+				t.Fatalf("decodeCursor(%q) error = %v", encoded, err)
+			}
+			if !reflect.DeepEqual(decoded, tt.cursor) {
+				t.Errorf("decodeCursor(encodeCursor(%+v)) = %+v", tt.cursor, decoded)
+			}
+		})
+	}
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{name: "not base64", value: "not a cursor!"},
+		{name: "padded base64", value: "eyJzIjoiZGF0ZSJ9=="},
+		{name: "not JSON", value: "bm90IGpzb24"},
+		{name: "wrong JSON type", value: "WyJkYXRlIl0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeCursor(tt.value); err == nil {
+				t.Errorf("decodeCursor(%q) error = nil, want an error", tt.value)
+			}
+		})
+	}
+}
+
+func TestPaginateRejectsMismatchedCursors(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatalf("gorm.Open() error = %v", err)
+	}
+	order := sortOrder{columns: []sortColumn{{expr: "date", sqlType: "date"}, {expr: "id", sqlType: "uuid"}}}
+
+	tests := []struct {
+		name    string
+		page    domainRepos.PageRequest
+		wantErr error
+	}{
+		{
+			name: "no cursor",
+			page: domainRepos.PageRequest{Limit: 10},
+		},
+		{
+			name: "matching cursor",
+			page: domainRepos.PageRequest{Limit: 10, Cursor: encodeCursor(pageCursor{Sort: "date", Values: []string{"2024-01-05", "id"}})},
+		},
+		{
+			name:    "malformed cursor",
+			page:    domainRepos.PageRequest{Limit: 10, Cursor: "???"},
+			wantErr: domainRepos.ErrInvalidCursor,
+		},
+		{
+			name:    "cursor of another sort order",
+			page:    domainRepos.PageRequest{Limit: 10, Cursor: encodeCursor(pageCursor{Sort: "name", Values: []string{"a", "id"}})},
+			wantErr: domainRepos.ErrInvalidCursor,
+		},
+		{
+			name:    "cursor of another direction",
+			page:    domainRepos.PageRequest{Limit: 10, Descending: true, Cursor: encodeCursor(pageCursor{Sort: "date", Values: []string{"2024-01-05", "id"}})},
+			wantErr: domainRepos.ErrInvalidCursor,
+		},
+		{
+			name:    "cursor with missing values",
+			page:    domainRepos.PageRequest{Limit: 10, Cursor: encodeCursor(pageCursor{Sort: "date", Values: []string{"2024-01-05"}})},
+			wantErr: domainRepos.ErrInvalidCursor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := paginate(db, "date", order, tt.page)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("paginate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPageEnd(t *testing.T) {
+	ids := []string{"a", "b", "c", "d"}
+	last := func(i int) []string { return []string{ids[i]} }
+
+	tests := []struct {
+		name       string
+		found      int
+		page       domainRepos.PageRequest
+		wantLength int
+		wantCursor *pageCursor
+	}{
+		{name: "unlimited", found: 4, page: domainRepos.PageRequest{}, wantLength: 4},
+		{name: "last page", found: 3, page: domainRepos.PageRequest{Limit: 3}, wantLength: 3},
+		{name: "more pages", found: 4, page: domainRepos.PageRequest{Limit: 3}, wantLength: 3, wantCursor: &pageCursor{Sort: "id", Values: []string{"c"}}},
+		{name: "descending", found: 3, page: domainRepos.PageRequest{Limit: 2, Descending: true}, wantLength: 2, wantCursor: &pageCursor{Sort: "id", Descending: true, Values: []string{"b"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			length, info := pageEnd(tt.found, "id", tt.page, last)
+			if length != tt.wantLength {
+				t.Errorf("pageEnd() length = %d, want %d", length, tt.wantLength)
+			}
+			if tt.wantCursor == nil {
+				if info.NextCursor != "" {
+					t.Errorf("pageEnd() NextCursor = %q, want none", info.NextCursor)
+				}
+				return
+			}
+			cursor, err := decodeCursor(info.NextCursor)
+			if err != nil {
+				t.Fatalf("decodeCursor(%q) error = %v", info.NextCursor, err)
+			}
+			if !reflect.DeepEqual(cursor, *tt.wantCursor) {
+				t.Errorf("pageEnd() cursor = %+v, want %+v", cursor, *tt.wantCursor)
+			}
+		})
+	}
+}
+
+func TestContainsPattern(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "desk", want: "%desk%"},
+		{value: "50%", want: `%50\%%`},
+		{value: "desk_1", want: `%desk\_1%`},
+		{value: `a\b`, want: `%a\\b%`},
+	}
+
+	for _, tt := range tests {
+		if got := containsPattern(tt.value); got != tt.want {
+			t.Errorf("containsPattern(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
@@ -23,14 +23,76 @@ func NewReservationRepository(db *gorm.DB) domainRepos.ReservationRepository {
 
 func (r *reservationRepository) FindByID(id uuid.UUID) (*entities.Reservation, error) {
 	var model models.Reservation
-	if err := r.db.Preload("Space").First(&model, id).Error; err != nil {
+	if err := r.db.Preload("Space", withDeletedSpaces).Preload("Space.Map", selectMapTimezone).First(&model, id).Error; err != nil {
 		return nil, err
 	}
 	return mappers.ToDomainReservation(&model), nil
 }
 
 func (r *reservationRepository) FindAll(filters domainRepos.ReservationFilters) ([]*entities.Reservation, error) {
-	query := r.db.Model(&models.Reservation{}).Preload("Space")
+	var models []models.Reservation
+	if err := r.filter(filters).Order("date ASC, start_time ASC").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	return mappers.ToDomainReservations(models), nil
+}
+
+// reservationSorts are the orders reservations can be listed in
+var reservationSorts = map[string]sortOrder{
+	"date": {columns: []sortColumn{
+		{expr: "date", sqlType: "date"},
+		// All-day reservations come first in their day
+		{expr: "COALESCE(start_time, '00:00')", sqlType: "time"},
+		{expr: "id", sqlType: "uuid"},
+	}},
+	"created_at": {columns: []sortColumn{{expr: "created_at", sqlType: "timestamptz"}, {expr: "id", sqlType: "uuid"}}},
+	"updated_at": {columns: []sortColumn{{expr: "updated_at", sqlType: "timestamptz"}, {expr: "id", sqlType: "uuid"}}},
+	"user_name":  {columns: []sortColumn{{expr: "user_name", sqlType: "text"}, {expr: "id", sqlType: "uuid"}}},
+}
+
+// reservationCursorValues returns the position of a reservation in a sort order
+func reservationCursorValues(sort string, m *models.Reservation) []string {
+	switch sort {
+	case "created_at":
+		return []string{m.CreatedAt.Format(time.RFC3339Nano), m.ID.String()}
+	case "updated_at":
+		return []string{m.UpdatedAt.Format(time.RFC3339Nano), m.ID.String()}
+	case "user_name":
+		return []string{m.UserName, m.ID.String()}
+	default:
+		startTime := "00:00"
+		if m.StartTime != nil {
+			startTime = *m.StartTime
+		}
+		return []string{m.Date.Format("2006-01-02"), startTime, m.ID.String()}
+	}
+}
+
+func (r *reservationRepository) FindPage(filters domainRepos.ReservationFilters, page domainRepos.PageRequest) ([]*entities.Reservation, domainRepos.PageInfo, error) {
+	sort, order, err := resolveSort(reservationSorts, "date", page)
+	if err != nil {
+		return nil, domainRepos.PageInfo{}, err
+	}
+	query, err := paginate(r.filter(filters), sort, order, page)
+	if err != nil {
+		return nil, domainRepos.PageInfo{}, err
+	}
+
+	var models []models.Reservation
+	if err := query.Find(&models).Error; err != nil {
+		return nil, domainRepos.PageInfo{}, err
+	}
+	n, info := pageEnd(len(models), sort, page, func(i int) []string {
+		return reservationCursorValues(sort, &models[i])
+	})
+	return mappers.ToDomainReservations(models[:n]), info, nil
+}
+
+// filter selects the reservations matching the filters, with their space and
+// the time zone of its map
+func (r *reservationRepository) filter(filters domainRepos.ReservationFilters) *gorm.DB {
+	query := r.db.Model(&models.Reservation{}).Preload("Space", withDeletedSpaces).Preload("Space.Map", selectMapTimezone)
 
 	if filters.From != nil {
 		query = query.Where("date >= ?", *filters.From)
@@ -44,68 +106,65 @@ func (r *reservationRepository) FindAll(filters domainRepos.ReservationFilters)
 	if filters.SpaceID != nil {
 		query = query.Where("space_id = ?", *filters.SpaceID)
 	}
+	if filters.MapID != nil {
+		query = query.Where("space_id IN (?)", r.db.Unscoped().Model(&models.Space{}).Select("id").Where("map_id = ?", *filters.MapID))
+	}
 	if filters.Status != nil {
 		query = query.Where("status = ?", string(*filters.Status))
 	}
-
-	var models []models.Reservation
-	if err := query.Order("date ASC, start_time ASC").Find(&models).Error; err != nil {
-		return nil, err
+	if filters.SeriesID != nil {
+		query = query.Where("series_id = ?", *filters.SeriesID)
 	}
-
-	return mappers.ToDomainReservations(models), nil
+	if filters.GroupBookingID != nil {
+		query = query.Where("group_booking_id = ?", *filters.GroupBookingID)
+	}
+	if len(filters.Statuses) > 0 {
+		statuses := make([]string, len(filters.Statuses))
+		for i, status := range filters.Statuses {
+			statuses[i] = string(status)
+		}
+		query = query.Where("status IN ?", statuses)
+	}
+	if filters.SpaceType != nil {
+		query = query.Where("space_id IN (?)", r.db.Model(&models.Space{}).Select("id").Where("type = ?", string(*filters.SpaceType)))
+	}
+	if filters.UserNameSearch != nil {
+		query = query.Where("user_name ILIKE ?", containsPattern(*filters.UserNameSearch))
+	}
+	if filters.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *filters.CreatedFrom)
+	}
+	if filters.CreatedTo != nil {
+		query = query.Where("created_at <= ?", *filters.CreatedTo)
+	}
+	return query
 }
 
 func (r *reservationRepository) Create(reservation *entities.Reservation) error {
+	reservation.Version = 1
 	model := mappers.ToModelReservation(reservation)
-	return r.db.Create(model).Error
+	return translateReservationError(r.db.Create(model).Error)
 }
 
 func (r *reservationRepository) Update(reservation *entities.Reservation) error {
 	model := mappers.ToModelReservation(reservation)
-	return r.db.Save(model).Error
-}
-
-func (r *reservationRepository) Delete(id uuid.UUID) error {
-	return r.db.Model(&models.Reservation{}).
-		Where("id = ?", id).
-		Update("status", string(entities.ReservationStatusCancelled)).Error
-}
-
-func (r *reservationRepository) DeleteBySpaceAndTime(spaceID uuid.UUID, date time.Time, startTime *string) error {
-	query := r.db.Model(&models.Reservation{}).
-		Where("space_id = ? AND date = ?", spaceID, date)
-
-	if startTime != nil {
-		normalizedTime := *startTime
-		if len(normalizedTime) > 5 {
-			normalizedTime = normalizedTime[:5]
-		}
-		query = query.Where("(start_time = ? OR start_time = ? OR start_time::text LIKE ?)",
-			startTime, normalizedTime, normalizedTime+":%")
-	} else {
-		query = query.Where("start_time IS NULL")
+	model.Version++
+	if err := updateVersioned(r.db, model, reservation.Version); err != nil {
+		return translateReservationError(err)
 	}
-
-	return query.Delete(&models.Reservation{}).Error
+	reservation.Version = model.Version
+	reservation.UpdatedAt = model.UpdatedAt
+	return nil
 }
 
-func (r *reservationRepository) DeleteBySpaceIDsAndTime(spaceIDs []uuid.UUID, date time.Time, startTime *string) error {
-	query := r.db.Model(&models.Reservation{}).
-		Where("space_id IN ? AND date = ?", spaceIDs, date)
-
-	if startTime != nil {
-		normalizedTime := *startTime
-		if len(normalizedTime) > 5 {
-			normalizedTime = normalizedTime[:5]
-		}
-		query = query.Where("(start_time = ? OR start_time = ? OR start_time::text LIKE ?)",
-			startTime, normalizedTime, normalizedTime+":%")
-	} else {
-		query = query.Where("start_time IS NULL")
+func (r *reservationRepository) TransitionStatus(id uuid.UUID, from, to entities.ReservationStatus) (bool, error) {
+	result := r.db.Model(&models.Reservation{}).
+		Where("id = ? AND status = ?", id, string(from)).
+		Updates(map[string]interface{}{"status": string(to), "version": gorm.Expr("version + 1"), "updated_at": time.Now()})
+	if result.Error != nil {
+		return false, translateReservationError(result.Error)
 	}
-
-	return query.Delete(&models.Reservation{}).Error
+	return result.RowsAffected > 0, nil
 }
 
 func (r *reservationRepository) FindBySpaceAndDate(spaceID uuid.UUID, date time.Time) ([]*entities.Reservation, error) {
@@ -126,29 +185,70 @@ func (r *reservationRepository) FindBySpaceIDsAndDate(spaceIDs []uuid.UUID, date
 	return mappers.ToDomainReservations(models), nil
 }
 
-func (r *reservationRepository) FindActiveBySpaceAndTime(spaceID uuid.UUID, date time.Time, startTime *string) (*entities.Reservation, error) {
-	query := r.db.Model(&models.Reservation{}).
-		Where("space_id = ? AND date = ? AND status = ?", spaceID, date, string(entities.ReservationStatusActive))
+func (r *reservationRepository) FindOverlapping(spaceIDs []uuid.UUID, date time.Time, startTime, endTime *string) ([]*entities.Reservation, error) {
+	if len(spaceIDs) == 0 {
+		return []*entities.Reservation{}, nil
+	}
 
-	if startTime != nil {
-		normalizedTime := *startTime
-		if len(normalizedTime) > 5 {
-			normalizedTime = normalizedTime[:5]
-		}
-		query = query.Where("(start_time = ? OR start_time = ? OR start_time::text LIKE ?)",
-			startTime, normalizedTime, normalizedTime+":%")
-	} else {
-		query = query.Where("start_time IS NULL")
+	// reservation_range is defined by the migrations and is the same expression
+	// backing the reservations_no_overlap exclusion constraint
+	var models []models.Reservation
+	if err := r.db.
+		Where("space_id IN ? AND status IN ?", spaceIDs, holdingStatuses()).
+		Where("reservation_range(date, start_time, end_time) && reservation_range(?::date, ?::time, ?::time)",
+			date.Format("2006-01-02"), startTime, endTime).
+		Order("date ASC, start_time ASC").
+		Find(&models).Error; err != nil {
+		return nil, err
 	}
+	return mappers.ToDomainReservations(models), nil
+}
 
-	var model models.Reservation
-	if err := query.First(&model).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return nil, nil
-		}
+func (r *reservationRepository) FindUpcomingBySpaceIDs(spaceIDs []uuid.UUID, from time.Time) ([]*entities.Reservation, error) {
+	if len(spaceIDs) == 0 {
+		return []*entities.Reservation{}, nil
+	}
+	var models []models.Reservation
+	if err := r.db.Where("space_id IN ? AND date >= ? AND status IN ?",
+		spaceIDs, from.Format("2006-01-02"), holdingStatuses()).
+		Order("date ASC, start_time ASC").
+		Find(&models).Error; err != nil {
 		return nil, err
 	}
+	return mappers.ToDomainReservations(models), nil
+}
 
-	return mappers.ToDomainReservation(&model), nil
+func (r *reservationRepository) FindHoldingBySpaceIDsInRange(spaceIDs []uuid.UUID, from, to time.Time) ([]*entities.Reservation, error) {
+	if len(spaceIDs) == 0 {
+		return []*entities.Reservation{}, nil
+	}
+	var models []models.Reservation
+	if err := r.db.Where("space_id IN ? AND date BETWEEN ? AND ? AND status IN ?",
+		spaceIDs, from.Format("2006-01-02"), to.Format("2006-01-02"), holdingStatuses()).
+		Order("date ASC, start_time ASC").
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return mappers.ToDomainReservations(models), nil
+}
+
+// withDeletedSpaces also preloads spaces removed from their map, which past
+// reservations keep referring to
+func withDeletedSpaces(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
 }
 
+// selectMapTimezone loads only the time zone of the maps of preloaded spaces,
+// including deleted maps
+func selectMapTimezone(db *gorm.DB) *gorm.DB {
+	return db.Unscoped().Select("id", "timezone")
+}
+
+// holdingStatuses returns the statuses that keep a space occupied as strings
+func holdingStatuses() []string {
+	statuses := make([]string, len(entities.HoldingStatuses))
+	for i, status := range entities.HoldingStatuses {
+		statuses[i] = string(status)
+	}
+	return statuses
+}
@@ -0,0 +1,119 @@
+package repositories
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"office-reservations/internal/database"
+	"office-reservations/internal/domain/entities"
+	domainRepos "office-reservations/internal/domain/repositories"
+	"office-reservations/internal/models"
+)
+
+// testDB migrates the database named by TEST_DATABASE_URL and returns a
+// transaction on it that is rolled back when the test ends. The test is skipped
+// when the variable is not set.
+func testDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("gorm.Open() error = %v", err)
+	}
+	if err := database.RunMigrations(db); err != nil {
+		t.Fatalf("RunMigrations() error = %v", err)
+	}
+	tx := db.Begin()
+	t.Cleanup(func() { tx.Rollback() })
+	return tx
+}
+
+// testSpace stores a map with one workstation and returns the workstation's ID
+func testSpace(t *testing.T, db *gorm.DB) uuid.UUID {
+	t.Helper()
+	officeMap := models.OfficeMap{ID: uuid.New(), Name: "Office", JSONData: datatypes.JSON(`{}`), Timezone: "UTC"}
+	if err := db.Create(&officeMap).Error; err != nil {
+		t.Fatalf("creating a map: %v", err)
+	}
+	space := models.Space{ID: uuid.New(), MapID: officeMap.ID, Name: "Desk 1", Type: string(entities.SpaceTypeWorkstation)}
+	if err := db.Create(&space).Error; err != nil {
+		t.Fatalf("creating a space: %v", err)
+	}
+	return space.ID
+}
+
+func TestReservationOverlapConstraint(t *testing.T) {
+	db := testDB(t)
+	clock := func(value string) *string { return &value }
+	date := time.Date(2030, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		date    time.Time
+		start   *string
+		end     *string
+		wantErr error
+	}{
+		{name: "partial overlap", date: date, start: clock("09:30"), end: clock("10:30"), wantErr: domainRepos.ErrReservationOverlap},
+		{name: "contained", date: date, start: clock("09:15"), end: clock("09:45"), wantErr: domainRepos.ErrReservationOverlap},
+		{name: "ends where it starts", date: date, start: clock("08:00"), end: clock("09:00")},
+		{name: "starts where it ends", date: date, start: clock("10:00"), end: clock("11:00")},
+		{name: "all day", date: date, wantErr: domainRepos.ErrReservationOverlap},
+		{name: "all day on the next date", date: date.AddDate(0, 0, 1)},
+		{name: "slot of a cancelled reservation", date: date, start: clock("12:00"), end: clock("13:00")},
+		{name: "slot of a checked-in reservation", date: date, start: clock("14:30"), end: clock("15:30"), wantErr: domainRepos.ErrReservationOverlap},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spaceID := testSpace(t, db)
+			repo := NewReservationRepository(db)
+			existing := []*entities.Reservation{
+				{ID: uuid.New(), SpaceID: spaceID, UserID: "alice", Date: date, StartTime: clock("09:00"), EndTime: clock("10:00"), Status: entities.ReservationStatusActive},
+				{ID: uuid.New(), SpaceID: spaceID, UserID: "alice", Date: date, StartTime: clock("12:00"), EndTime: clock("13:00"), Status: entities.ReservationStatusCancelled},
+				{ID: uuid.New(), SpaceID: spaceID, UserID: "alice", Date: date, StartTime: clock("14:00"), EndTime: clock("15:00"), Status: entities.ReservationStatusCheckedIn},
+			}
+			for _, r := range existing {
+				if err := repo.Create(r); err != nil {
+					t.Fatalf("Create() error = %v", err)
+				}
+			}
+
+			// A savepoint keeps the test transaction usable after the violation
+			r := &entities.Reservation{ID: uuid.New(), SpaceID: spaceID, UserID: "bob", Date: tt.date, StartTime: tt.start, EndTime: tt.end, Status: entities.ReservationStatusActive}
+			err := db.Transaction(func(tx *gorm.DB) error {
+				return NewReservationRepository(tx).Create(r)
+			})
+			if err != tt.wantErr {
+				t.Fatalf("Create() error = %v, want %v", err, tt.wantErr)
+			}
+
+			// FindOverlapping reports exactly the reservations the constraint refuses
+			overlapping, err := repo.FindOverlapping([]uuid.UUID{spaceID}, tt.date, tt.start, tt.end)
+			if err != nil {
+				t.Fatalf("FindOverlapping() error = %v", err)
+			}
+			if tt.wantErr == nil {
+				if len(overlapping) != 1 || overlapping[0].ID != r.ID {
+					t.Errorf("FindOverlapping() = %d reservations, want only the new one", len(overlapping))
+				}
+			} else if len(overlapping) == 0 {
+				t.Errorf("FindOverlapping() found nothing, want the conflicting reservations")
+			}
+			for _, found := range overlapping {
+				if !found.HoldsSpace() {
+					t.Errorf("FindOverlapping() returned a %s reservation", found.Status)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"office-reservations/internal/domain/entities"
+	domainRepos "office-reservations/internal/domain/repositories"
+	"office-reservations/internal/infrastructure/mappers"
+	"office-reservations/internal/models"
+)
+
+// reservationSeriesRepository implements ReservationSeriesRepository interface
+type reservationSeriesRepository struct {
+	db *gorm.DB
+}
+
+// NewReservationSeriesRepository creates a new reservation series repository
+func NewReservationSeriesRepository(db *gorm.DB) domainRepos.ReservationSeriesRepository {
+	return &reservationSeriesRepository{db: db}
+}
+
+func (r *reservationSeriesRepository) FindByID(id uuid.UUID) (*entities.ReservationSeries, error) {
+	var model models.ReservationSeries
+	if err := r.db.Preload("Exceptions").First(&model, id).Error; err != nil {
+		return nil, err
+	}
+	return mappers.ToDomainReservationSeries(&model), nil
+}
+
+func (r *reservationSeriesRepository) FindActive() ([]*entities.ReservationSeries, error) {
+	var models []models.ReservationSeries
+	if err := r.db.Preload("Exceptions").
+		Where("status = ?", string(entities.SeriesStatusActive)).
+		Order("created_at ASC").
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return mappers.ToDomainReservationSeriesList(models), nil
+}
+
+func (r *reservationSeriesRepository) Create(series *entities.ReservationSeries) error {
+	model := mappers.ToModelReservationSeries(series)
+	return r.db.Omit(clause.Associations).Create(model).Error
+}
+
+func (r *reservationSeriesRepository) Update(series *entities.ReservationSeries) error {
+	model := mappers.ToModelReservationSeries(series)
+	return r.db.Omit(clause.Associations).Save(model).Error
+}
+
+func (r *reservationSeriesRepository) Delete(id uuid.UUID) error {
+	// Exceptions are deleted by ON DELETE CASCADE
+	return r.db.Delete(&models.ReservationSeries{}, id).Error
+}
+
+func (r *reservationSeriesRepository) SaveException(exception *entities.SeriesException) error {
+	model := mappers.ToModelSeriesException(exception)
+	return r.db.Omit(clause.Associations).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "series_id"}, {Name: "occurrence_date"}},
+		DoUpdates: clause.AssignmentColumns([]string{"cancelled", "start_time", "end_time", "notes", "updated_at"}),
+	}).Create(model).Error
+}
+
+func (r *reservationSeriesRepository) CancelBySpaceIDs(spaceIDs []uuid.UUID) error {
+	if len(spaceIDs) == 0 {
+		return nil
+	}
+	return r.db.Model(&models.ReservationSeries{}).
+		Where("space_id IN ? AND status = ?", spaceIDs, string(entities.SeriesStatusActive)).
+		Updates(map[string]interface{}{"status": string(entities.SeriesStatusCancelled), "updated_at": time.Now()}).Error
+}
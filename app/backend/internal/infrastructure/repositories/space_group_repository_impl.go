@@ -0,0 +1,93 @@
+package repositories
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"office-reservations/internal/domain/entities"
+	domainRepos "office-reservations/internal/domain/repositories"
+	"office-reservations/internal/infrastructure/mappers"
+	"office-reservations/internal/models"
+)
+
+// spaceGroupRepository implements SpaceGroupRepository interface
+type spaceGroupRepository struct {
+	db *gorm.DB
+}
+
+// NewSpaceGroupRepository creates a new space group repository
+func NewSpaceGroupRepository(db *gorm.DB) domainRepos.SpaceGroupRepository {
+	return &spaceGroupRepository{db: db}
+}
+
+func (r *spaceGroupRepository) FindByID(id uuid.UUID) (*entities.SpaceGroup, error) {
+	var model models.SpaceGroup
+	if err := r.db.Preload("Spaces").First(&model, id).Error; err != nil {
+		return nil, err
+	}
+	return mappers.ToDomainSpaceGroup(&model), nil
+}
+
+func (r *spaceGroupRepository) FindByMapID(mapID uuid.UUID) ([]*entities.SpaceGroup, error) {
+	var models []models.SpaceGroup
+	if err := r.db.Preload("Spaces").Where("map_id = ?", mapID).
+		Order("name ASC").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return mappers.ToDomainSpaceGroups(models), nil
+}
+
+func (r *spaceGroupRepository) FindAll() ([]*entities.SpaceGroup, error) {
+	var models []models.SpaceGroup
+	if err := r.db.Preload("Spaces").Order("name ASC").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return mappers.ToDomainSpaceGroups(models), nil
+}
+
+func (r *spaceGroupRepository) Create(group *entities.SpaceGroup) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		model := mappers.ToModelSpaceGroup(group)
+		if err := tx.Omit(clause.Associations).Create(model).Error; err != nil {
+			return err
+		}
+		return assignGroupMembers(tx, group.ID, group.SpaceIDs)
+	})
+}
+
+func (r *spaceGroupRepository) Update(group *entities.SpaceGroup) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		model := mappers.ToModelSpaceGroup(group)
+		if err := tx.Omit(clause.Associations).Save(model).Error; err != nil {
+			return err
+		}
+		return assignGroupMembers(tx, group.ID, group.SpaceIDs)
+	})
+}
+
+func (r *spaceGroupRepository) Delete(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Space{}).Where("group_id = ?", id).
+			Updates(map[string]interface{}{"group_id": nil, "version": gorm.Expr("version + 1")}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.SpaceGroup{}, id).Error
+	})
+}
+
+// assignGroupMembers makes spaceIDs the exact member set of the group
+func assignGroupMembers(tx *gorm.DB, groupID uuid.UUID, spaceIDs []uuid.UUID) error {
+	release := tx.Model(&models.Space{}).Where("group_id = ?", groupID)
+	if len(spaceIDs) > 0 {
+		release = release.Where("id NOT IN ?", spaceIDs)
+	}
+	// Joining or leaving a group is a change of the space
+	if err := release.Updates(map[string]interface{}{"group_id": nil, "version": gorm.Expr("version + 1")}).Error; err != nil {
+		return err
+	}
+	if len(spaceIDs) == 0 {
+		return nil
+	}
+	return tx.Model(&models.Space{}).Where("id IN ? AND group_id IS DISTINCT FROM ?", spaceIDs, groupID).
+		Updates(map[string]interface{}{"group_id": groupID, "version": gorm.Expr("version + 1")}).Error
+}
@@ -1,10 +1,12 @@
 package repositories
 
 import (
-	"strings"
+	"strconv"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"office-reservations/internal/domain/entities"
 	domainRepos "office-reservations/internal/domain/repositories"
 	"office-reservations/internal/infrastructure/mappers"
@@ -29,6 +31,76 @@ func (r *spaceRepository) FindByID(id uuid.UUID) (*entities.Space, error) {
 	return mappers.ToDomainSpace(&model), nil
 }
 
+func (r *spaceRepository) FindAll() ([]*entities.Space, error) {
+	var models []models.Space
+	if err := r.db.Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return mappers.ToDomainSpaces(models), nil
+}
+
+// spaceSorts are the orders spaces can be listed in
+var spaceSorts = map[string]sortOrder{
+	"name":       {columns: []sortColumn{{expr: "name", sqlType: "text"}, {expr: "id", sqlType: "uuid"}}},
+	"type":       {columns: []sortColumn{{expr: "type", sqlType: "text"}, {expr: "name", sqlType: "text"}, {expr: "id", sqlType: "uuid"}}},
+	"position":   {columns: []sortColumn{{expr: "y", sqlType: "integer"}, {expr: "x", sqlType: "integer"}, {expr: "id", sqlType: "uuid"}}},
+	"created_at": {columns: []sortColumn{{expr: "created_at", sqlType: "timestamptz"}, {expr: "id", sqlType: "uuid"}}},
+}
+
+// spaceCursorValues returns the position of a space in a sort order
+func spaceCursorValues(sort string, m *models.Space) []string {
+	switch sort {
+	case "type":
+		return []string{m.Type, m.Name, m.ID.String()}
+	case "position":
+		return []string{strconv.Itoa(m.Y), strconv.Itoa(m.X), m.ID.String()}
+	case "created_at":
+		return []string{m.CreatedAt.Format(time.RFC3339Nano), m.ID.String()}
+	default:
+		return []string{m.Name, m.ID.String()}
+	}
+}
+
+func (r *spaceRepository) FindPage(filters domainRepos.SpaceFilters, page domainRepos.PageRequest) ([]*entities.Space, domainRepos.PageInfo, error) {
+	sort, order, err := resolveSort(spaceSorts, "name", page)
+	if err != nil {
+		return nil, domainRepos.PageInfo{}, err
+	}
+
+	query := r.db.Model(&models.Space{})
+	if filters.MapID != nil {
+		query = query.Where("map_id = ?", *filters.MapID)
+	}
+	if filters.Type != nil {
+		query = query.Where("type = ?", string(*filters.Type))
+	}
+	if filters.GroupID != nil {
+		query = query.Where("group_id = ?", *filters.GroupID)
+	}
+	if filters.NameSearch != nil {
+		query = query.Where("name ILIKE ?", containsPattern(*filters.NameSearch))
+	}
+	if filters.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *filters.CreatedFrom)
+	}
+	if filters.CreatedTo != nil {
+		query = query.Where("created_at <= ?", *filters.CreatedTo)
+	}
+	query, err = paginate(query, sort, order, page)
+	if err != nil {
+		return nil, domainRepos.PageInfo{}, err
+	}
+
+	var models []models.Space
+	if err := query.Find(&models).Error; err != nil {
+		return nil, domainRepos.PageInfo{}, err
+	}
+	n, info := pageEnd(len(models), sort, page, func(i int) []string {
+		return spaceCursorValues(sort, &models[i])
+	})
+	return mappers.ToDomainSpaces(models[:n]), info, nil
+}
+
 func (r *spaceRepository) FindByMapID(mapID uuid.UUID) ([]*entities.Space, error) {
 	var models []models.Space
 	if err := r.db.Where("map_id = ?", mapID).Find(&models).Error; err != nil {
@@ -46,36 +118,65 @@ func (r *spaceRepository) FindByTypeAndMapID(spaceType entities.SpaceType, mapID
 	return mappers.ToDomainSpaces(models), nil
 }
 
-func (r *spaceRepository) FindMeetingRoomsByBaseName(baseName string, mapID uuid.UUID) ([]*entities.Space, error) {
-	// Find all meeting rooms for this map
-	allMeetingRooms, err := r.FindByTypeAndMapID(entities.SpaceTypeMeetingRoom, mapID)
-	if err != nil {
+func (r *spaceRepository) FindByGroupID(groupID uuid.UUID) ([]*entities.Space, error) {
+	var models []models.Space
+	if err := r.db.Where("group_id = ?", groupID).Order("name ASC").
+		Find(&models).Error; err != nil {
 		return nil, err
 	}
+	return mappers.ToDomainSpaces(models), nil
+}
 
-	// Filter by base name
-	var matching []*entities.Space
-	baseNameLower := strings.ToLower(strings.TrimSpace(baseName))
-	for _, space := range allMeetingRooms {
-		if strings.ToLower(strings.TrimSpace(space.GetBaseName())) == baseNameLower {
-			matching = append(matching, space)
-		}
+func (r *spaceRepository) FindByIDs(ids []uuid.UUID) ([]*entities.Space, error) {
+	if len(ids) == 0 {
+		return []*entities.Space{}, nil
 	}
+	var models []models.Space
+	if err := r.db.Where("id IN ?", ids).Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return mappers.ToDomainSpaces(models), nil
+}
 
-	return matching, nil
+func (r *spaceRepository) LockByIDs(ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	var locked []uuid.UUID
+	return r.db.Raw("SELECT id FROM spaces WHERE id IN ? ORDER BY id FOR UPDATE", ids).
+		Scan(&locked).Error
 }
 
 func (r *spaceRepository) Create(space *entities.Space) error {
+	space.Version = 1
 	model := mappers.ToModelSpace(space)
-	return r.db.Create(model).Error
+	return r.db.Omit(clause.Associations).Create(model).Error
 }
 
 func (r *spaceRepository) Update(space *entities.Space) error {
 	model := mappers.ToModelSpace(space)
-	return r.db.Save(model).Error
+	model.Version++
+	if err := updateVersioned(r.db, model, space.Version); err != nil {
+		return err
+	}
+	space.Version = model.Version
+	space.UpdatedAt = model.UpdatedAt
+	return nil
 }
 
-func (r *spaceRepository) Delete(id uuid.UUID) error {
-	return r.db.Delete(&models.Space{}, id).Error
+func (r *spaceRepository) IDTaken(id uuid.UUID) (bool, error) {
+	var count int64
+	if err := r.db.Unscoped().Model(&models.Space{}).Where("id = ?", id).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *spaceRepository) SoftDeleteByIDs(ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.Model(&models.Space{}).Where("id IN ?", ids).
+		Updates(map[string]interface{}{"deleted_at": time.Now(), "group_id": nil}).Error
 }
 
@@ -0,0 +1,32 @@
+package repositories
+
+import (
+	"gorm.io/gorm"
+	domainRepos "office-reservations/internal/domain/repositories"
+)
+
+// transactionManager implements TransactionManager using GORM transactions
+type transactionManager struct {
+	db *gorm.DB
+}
+
+// NewTransactionManager creates a new transaction manager
+func NewTransactionManager(db *gorm.DB) domainRepos.TransactionManager {
+	return &transactionManager{db: db}
+}
+
+func (m *transactionManager) WithinTransaction(fn func(repos domainRepos.TxRepositories) error) error {
+	return m.db.Transaction(func(tx *gorm.DB) error {
+		return fn(domainRepos.TxRepositories{
+			Reservations:      NewReservationRepository(tx),
+			Spaces:            NewSpaceRepository(tx),
+			SpaceGroups:       NewSpaceGroupRepository(tx),
+			Maps:              NewOfficeMapRepository(tx),
+			Closures:          NewClosureRepository(tx),
+			Outbox:            NewOutboxRepository(tx),
+			Webhooks:          NewWebhookRepository(tx),
+			WebhookDeliveries: NewWebhookDeliveryRepository(tx),
+			Audit:             NewAuditRepository(tx),
+		})
+	})
+}
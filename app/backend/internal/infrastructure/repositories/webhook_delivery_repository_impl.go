@@ -0,0 +1,74 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"office-reservations/internal/domain/entities"
+	domainRepos "office-reservations/internal/domain/repositories"
+	"office-reservations/internal/infrastructure/mappers"
+	"office-reservations/internal/models"
+)
+
+// webhookDeliveryRepository implements WebhookDeliveryRepository interface
+type webhookDeliveryRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookDeliveryRepository creates a new webhook delivery repository
+func NewWebhookDeliveryRepository(db *gorm.DB) domainRepos.WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{db: db}
+}
+
+func (r *webhookDeliveryRepository) FindByID(id uuid.UUID) (*entities.WebhookDelivery, error) {
+	var model models.WebhookDelivery
+	if err := r.db.First(&model, id).Error; err != nil {
+		return nil, err
+	}
+	return mappers.ToDomainWebhookDelivery(&model), nil
+}
+
+func (r *webhookDeliveryRepository) FindByWebhookID(webhookID uuid.UUID, filters domainRepos.WebhookDeliveryFilters) ([]*entities.WebhookDelivery, error) {
+	query := r.db.Where("webhook_id = ?", webhookID)
+	if filters.Status != nil {
+		query = query.Where("status = ?", string(*filters.Status))
+	}
+	if filters.Limit > 0 {
+		query = query.Limit(filters.Limit)
+	}
+
+	var models []models.WebhookDelivery
+	if err := query.Order("created_at DESC").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return mappers.ToDomainWebhookDeliveries(models), nil
+}
+
+func (r *webhookDeliveryRepository) Create(delivery *entities.WebhookDelivery) error {
+	model := mappers.ToModelWebhookDelivery(delivery)
+	return r.db.Omit(clause.Associations).Create(model).Error
+}
+
+func (r *webhookDeliveryRepository) Update(delivery *entities.WebhookDelivery) error {
+	model := mappers.ToModelWebhookDelivery(delivery)
+	return r.db.Omit(clause.Associations).Save(model).Error
+}
+
+func (r *webhookDeliveryRepository) ClaimDue(now, leaseUntil time.Time, limit int) ([]*entities.WebhookDelivery, error) {
+	var models []models.WebhookDelivery
+	err := r.db.Raw(`UPDATE webhook_deliveries SET next_attempt_at = ?
+		WHERE id IN (
+			SELECT d.id FROM webhook_deliveries d JOIN webhooks w ON w.id = d.webhook_id
+			WHERE d.status = ? AND d.next_attempt_at <= ? AND w.active
+			ORDER BY d.next_attempt_at LIMIT ?
+			FOR UPDATE OF d SKIP LOCKED
+		)
+		RETURNING *`, leaseUntil, string(entities.WebhookDeliveryPending), now, limit).
+		Scan(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	return mappers.ToDomainWebhookDeliveries(models), nil
+}
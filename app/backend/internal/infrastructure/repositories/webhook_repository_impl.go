@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"office-reservations/internal/domain/entities"
+	domainRepos "office-reservations/internal/domain/repositories"
+	"office-reservations/internal/infrastructure/mappers"
+	"office-reservations/internal/models"
+)
+
+// webhookRepository implements WebhookRepository interface
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookRepository creates a new webhook repository
+func NewWebhookRepository(db *gorm.DB) domainRepos.WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+func (r *webhookRepository) FindByID(id uuid.UUID) (*entities.Webhook, error) {
+	var model models.Webhook
+	if err := r.db.First(&model, id).Error; err != nil {
+		return nil, err
+	}
+	return mappers.ToDomainWebhook(&model), nil
+}
+
+func (r *webhookRepository) FindAll() ([]*entities.Webhook, error) {
+	var models []models.Webhook
+	if err := r.db.Order("created_at ASC").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return mappers.ToDomainWebhooks(models), nil
+}
+
+func (r *webhookRepository) FindActive() ([]*entities.Webhook, error) {
+	var models []models.Webhook
+	if err := r.db.Where("active").Order("created_at ASC").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return mappers.ToDomainWebhooks(models), nil
+}
+
+func (r *webhookRepository) Create(webhook *entities.Webhook) error {
+	model, err := mappers.ToModelWebhook(webhook)
+	if err != nil {
+		return err
+	}
+	return r.db.Create(model).Error
+}
+
+func (r *webhookRepository) Update(webhook *entities.Webhook) error {
+	model, err := mappers.ToModelWebhook(webhook)
+	if err != nil {
+		return err
+	}
+	return r.db.Save(model).Error
+}
+
+func (r *webhookRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&models.Webhook{}, id).Error
+}
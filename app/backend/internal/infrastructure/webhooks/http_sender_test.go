@@ -0,0 +1,125 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"office-reservations/internal/domain/entities"
+)
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"event":"reservation.created"}`)
+
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp string
+		body      []byte
+		want      string
+	}{
+		{name: "delivery", secret: "secret", timestamp: "1700000000", body: body, want: "ec88519bb577565ad786e655004cfb09b3996b09f55f322bfeb7be353a674702"},
+		{name: "other secret", secret: "another-secret", timestamp: "1700000000", body: body, want: "afbf1f57526f9c4c5b98ced1dcc3b1b2e3e7e96f40ae3822b65141798780bf4a"},
+		{name: "other timestamp", secret: "secret", timestamp: "1700000001", body: body, want: "3192bff58300e65adcd65e9699fb60925bb44e2b967a105029a7a61a9290fcb1"},
+		{name: "empty body", secret: "secret", timestamp: "1700000000", body: nil, want: "4bc5f74d868b97888288889c5d9d65df02526f94c1592a79fdf4fe8b26e311e5"},
+		{name: "empty secret", secret: "", timestamp: "1700000000", body: []byte(`{}`), want: "a9dc44c8eda3de70e9cbf3e488895f1abc26acb1461d3124a3cb886af35251cf"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sign(tt.secret, tt.timestamp, tt.body); got != tt.want {
+				t.Errorf("Sign(%q, %q, %q) = %s, want %s", tt.secret, tt.timestamp, tt.body, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHTTPSenderSend(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		redirect   bool
+		wantStatus int
+	}{
+		{name: "accepted", status: http.StatusNoContent, wantStatus: http.StatusNoContent},
+		{name: "rejected", status: http.StatusInternalServerError, wantStatus: http.StatusInternalServerError},
+		{name: "redirects are not followed", redirect: true, wantStatus: http.StatusFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhook := &entities.Webhook{ID: uuid.New(), Secret: "secret"}
+			delivery := &entities.WebhookDelivery{
+				ID:        uuid.New(),
+				WebhookID: webhook.ID,
+				EventID:   uuid.New(),
+				EventType: entities.WebhookReservationCreated,
+				Payload:   []byte(`{"event":"reservation.created"}`),
+			}
+
+			var received *http.Request
+			var body []byte
+			redirected := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/elsewhere" {
+					redirected = true
+					w.WriteHeader(http.StatusOK)
+					return
+				}
+				received = r
+				body, _ = io.ReadAll(r.Body)
+				if tt.redirect {
+					http.Redirect(w, r, "/elsewhere", http.StatusFound)
+					return
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+			webhook.URL = server.URL + "/hook"
+
+			status, err := NewHTTPSender(5*time.Second).Send(webhook, delivery)
+			if err != nil {
+				t.Fatalf("Send() error = %v", err)
+			}
+			if status != tt.wantStatus {
+				t.Errorf("Send() status = %d, want %d", status, tt.wantStatus)
+			}
+			if redirected {
+				t.Errorf("Send() followed a redirect")
+			}
+			if received == nil {
+				t.Fatal("Send() did not reach the webhook")
+			}
+
+			if string(body) != string(delivery.Payload) {
+				t.Errorf("body = %s, want %s", body, delivery.Payload)
+			}
+			headers := map[string]string{
+				HeaderEvent:    string(delivery.EventType),
+				HeaderEventID:  delivery.EventID.String(),
+				HeaderDelivery: delivery.ID.String(),
+				"Content-Type": "application/json",
+			}
+			for name, want := range headers {
+				if got := received.Header.Get(name); got != want {
+					t.Errorf("%s = %q, want %q", name, got, want)
+				}
+			}
+
+			// A receiver verifies the signature from the timestamp and body it got
+			timestamp := received.Header.Get(HeaderTimestamp)
+			want := "sha256=" + Sign(webhook.Secret, timestamp, body)
+			if got := received.Header.Get(HeaderSignature); !hmac.Equal([]byte(got), []byte(want)) {
+				t.Errorf("%s = %q, want %q", HeaderSignature, got, want)
+			}
+			if sent, err := strconv.ParseInt(timestamp, 10, 64); err != nil || time.Since(time.Unix(sent, 0)) > time.Minute {
+				t.Errorf("%s = %q, want the current Unix time", HeaderTimestamp, timestamp)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package dto
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
+
+// AuditEntryResponseDTO represents one change recorded in the audit log
+type AuditEntryResponseDTO struct {
+	ID         uuid.UUID  `json:"id"`
+	EntityType string     `json:"entity_type"`
+	EntityID   uuid.UUID  `json:"entity_id"`
+	MapID      *uuid.UUID `json:"map_id"`
+	Action     string     `json:"action"`
+	// ActorID and ActorName are empty for changes made by the system
+	ActorID   string `json:"actor_id"`
+	ActorName string `json:"actor_name"`
+	Reason    string `json:"reason"`
+	// Before is null for created resources and After for deleted ones
+	Before    json.RawMessage `json:"before"`
+	After     json.RawMessage `json:"after"`
+	CreatedAt string          `json:"created_at"`
+}
@@ -0,0 +1,17 @@
+package dto
+
+// DevTokenRequestDTO represents the HTTP request for a development token
+type DevTokenRequestDTO struct {
+	UserID   string   `json:"user_id" binding:"required"`
+	UserName string   `json:"user_name"`
+	Email    string   `json:"email"`
+	Roles    []string `json:"roles"`
+	MapIDs   []string `json:"map_ids"` // Limits facilities_admin to these office maps
+}
+
+// TokenResponseDTO represents an issued bearer token
+type TokenResponseDTO struct {
+	Token     string `json:"token"`
+	TokenType string `json:"token_type"`
+	ExpiresAt string `json:"expires_at"`
+}
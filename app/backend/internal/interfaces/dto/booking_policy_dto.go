@@ -0,0 +1,57 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+)
+
+// BookingPolicyRequestDTO represents the HTTP request for creating or replacing a
+// booking policy. Omitted scope fields match everything and omitted rules are not enforced.
+type BookingPolicyRequestDTO struct {
+	Name               string     `json:"name" binding:"required"`
+	MapID              *uuid.UUID `json:"map_id"`
+	SpaceType          *string    `json:"space_type"`
+	Role               *string    `json:"role"`
+	MaxAdvanceDays     *int       `json:"max_advance_days"`
+	MinDurationMinutes *int       `json:"min_duration_minutes"`
+	MaxDurationMinutes *int       `json:"max_duration_minutes"`
+	AllowedStart       *string    `json:"allowed_start"` // Format: HH:MM
+	AllowedEnd         *string    `json:"allowed_end"`   // Format: HH:MM
+	MaxBookingsPerWeek *int       `json:"max_bookings_per_week"`
+	MaxConsecutiveDays *int       `json:"max_consecutive_days"`
+	BlackoutDates      []string   `json:"blackout_dates"` // Format: YYYY-MM-DD
+}
+
+// BookingPolicyResponseDTO represents the HTTP response for a booking policy
+type BookingPolicyResponseDTO struct {
+	ID                 uuid.UUID  `json:"id"`
+	Name               string     `json:"name"`
+	MapID              *uuid.UUID `json:"map_id"`
+	SpaceType          *string    `json:"space_type"`
+	Role               *string    `json:"role"`
+	MaxAdvanceDays     *int       `json:"max_advance_days"`
+	MinDurationMinutes *int       `json:"min_duration_minutes"`
+	MaxDurationMinutes *int       `json:"max_duration_minutes"`
+	AllowedStart       *string    `json:"allowed_start"`
+	AllowedEnd         *string    `json:"allowed_end"`
+	MaxBookingsPerWeek *int       `json:"max_bookings_per_week"`
+	MaxConsecutiveDays *int       `json:"max_consecutive_days"`
+	BlackoutDates      []string   `json:"blackout_dates"`
+	CreatedAt          string     `json:"created_at"`
+	UpdatedAt          string     `json:"updated_at"`
+}
+
+// PolicyViolationDTO represents a booking policy rule that a reservation breaks
+type PolicyViolationDTO struct {
+	Rule       string     `json:"rule"`
+	PolicyID   *uuid.UUID `json:"policy_id,omitempty"` // Omitted for built-in rules
+	PolicyName string     `json:"policy_name,omitempty"`
+	Message    string     `json:"message"`
+}
+
+// PolicyViolationResponseDTO represents the HTTP response when a reservation
+// breaks booking policy rules
+type PolicyViolationResponseDTO struct {
+	Error      string               `json:"error"`
+	Code       string               `json:"code"`
+	Violations []PolicyViolationDTO `json:"violations"`
+}
@@ -0,0 +1,13 @@
+package dto
+
+// CalendarFeedTokenResponseDTO represents a newly issued calendar feed token.
+// The token is only shown once; issuing a new one revokes it.
+type CalendarFeedTokenResponseDTO struct {
+	Token string `json:"token"`
+	// UserFeedURL is the path of the caller's own feed, including the token
+	UserFeedURL string `json:"user_feed_url"`
+	// SpaceFeedURL and MapFeedURL are path templates with an {id} placeholder
+	SpaceFeedURL string `json:"space_feed_url"`
+	MapFeedURL   string `json:"map_feed_url"`
+	CreatedAt    string `json:"created_at"`
+}
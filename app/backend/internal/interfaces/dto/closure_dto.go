@@ -0,0 +1,61 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+)
+
+// ClosureRequestDTO represents the HTTP request for creating or replacing a
+// closure. The closure applies to the whole map unless group_id or space_id is set.
+type ClosureRequestDTO struct {
+	MapID     uuid.UUID  `json:"map_id" binding:"required"`
+	GroupID   *uuid.UUID `json:"group_id"`
+	SpaceID   *uuid.UUID `json:"space_id"`
+	StartDate string     `json:"start_date" binding:"required"` // Format: YYYY-MM-DD
+	EndDate   string     `json:"end_date"`                      // Format: YYYY-MM-DD, defaults to start_date
+	StartTime *string    `json:"start_time"`                    // Format: HH:MM, on start_date; omitted means midnight
+	EndTime   *string    `json:"end_time"`                      // Format: HH:MM, on end_date; omitted means end of day
+	Reason    string     `json:"reason" binding:"required"`
+}
+
+// ClosureResponseDTO represents the HTTP response for a closure
+type ClosureResponseDTO struct {
+	ID         uuid.UUID  `json:"id"`
+	MapID      uuid.UUID  `json:"map_id"`
+	GroupID    *uuid.UUID `json:"group_id"`
+	SpaceID    *uuid.UUID `json:"space_id"`
+	StartDate  string     `json:"start_date"`
+	EndDate    string     `json:"end_date"`
+	StartTime  *string    `json:"start_time"`
+	EndTime    *string    `json:"end_time"`
+	Reason     string     `json:"reason"`
+	ExternalID *string    `json:"external_id"` // UID of the imported calendar event
+	CreatedAt  string     `json:"created_at"`
+	UpdatedAt  string     `json:"updated_at"`
+	// CancelledReservations is set when creating or updating the closure
+	// cancelled reservations that fell into it
+	CancelledReservations []ReservationResponseDTO `json:"cancelled_reservations,omitempty"`
+}
+
+// ClosureImportResponseDTO represents the HTTP response for a calendar import
+type ClosureImportResponseDTO struct {
+	Created               []ClosureResponseDTO     `json:"created"`
+	Updated               []ClosureResponseDTO     `json:"updated"`
+	Skipped               []CalendarFileErrorDTO   `json:"skipped"`
+	CancelledReservations []ReservationResponseDTO `json:"cancelled_reservations"`
+}
+
+// CalendarFileErrorDTO describes an event of an imported calendar file that was
+// rejected or skipped. Line is where the event starts.
+type CalendarFileErrorDTO struct {
+	Line    int    `json:"line"`
+	UID     string `json:"uid,omitempty"`
+	Message string `json:"message"`
+}
+
+// ClosedResponseDTO represents the HTTP response when a reservation falls into
+// one or more closures
+type ClosedResponseDTO struct {
+	Error    string               `json:"error"`
+	Code     string               `json:"code"`
+	Closures []ClosureResponseDTO `json:"closures"`
+}
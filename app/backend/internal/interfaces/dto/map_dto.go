@@ -0,0 +1,100 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+)
+
+// CreateMapRequestDTO represents the HTTP request for creating a map
+type CreateMapRequestDTO struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	JSONData    map[string]interface{} `json:"json_data"`
+	Timezone    string                 `json:"timezone"` // IANA name, defaults to UTC
+	// OpeningHours are keyed by weekday name; missing days are closed and no
+	// opening hours at all mean the office is always open
+	OpeningHours map[string]*OpeningHoursDTO `json:"opening_hours"`
+}
+
+// UpdateMapRequestDTO represents the HTTP request for updating a map
+type UpdateMapRequestDTO struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	JSONData    map[string]interface{} `json:"json_data"` // Syncs the spaces of the map when provided
+	Timezone    string                 `json:"timezone"`
+	// OpeningHours replace the current ones when provided; {} makes the office always open
+	OpeningHours map[string]*OpeningHoursDTO `json:"opening_hours"`
+	// CancelReservations allows removing spaces that still have upcoming reservations
+	CancelReservations bool `json:"cancel_reservations"`
+}
+
+// MapResponseDTO represents the HTTP response for a map
+type MapResponseDTO struct {
+	ID          uuid.UUID              `json:"id"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	JSONData    map[string]interface{} `json:"json_data"`
+	Timezone    string                 `json:"timezone"`
+	// OpeningHours is null when the office is always open
+	OpeningHours map[string]OpeningHoursDTO `json:"opening_hours"`
+	Spaces       []SpaceResponseDTO         `json:"spaces,omitempty"` // Left out of map listings
+	// Version is also sent as the ETag of the map
+	Version   int64  `json:"version"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+	// Sync is set when the request changed the spaces of the map
+	Sync *SpaceSyncSummaryDTO `json:"sync,omitempty"`
+}
+
+// OpeningHoursDTO represents the opening hours of one weekday
+type OpeningHoursDTO struct {
+	Open  string `json:"open"`  // Format: HH:MM
+	Close string `json:"close"` // Format: HH:MM
+}
+
+// SpaceSyncSummaryDTO reports the changes applied when syncing a map's spaces
+type SpaceSyncSummaryDTO struct {
+	Created              int                      `json:"created"`
+	Updated              int                      `json:"updated"`
+	Unchanged            int                      `json:"unchanged"`
+	Removed              int                      `json:"removed"`
+	AffectedReservations []AffectedReservationDTO `json:"affected_reservations"`
+}
+
+// AffectedReservationDTO is an upcoming reservation on a space removed from a map
+type AffectedReservationDTO struct {
+	ID        uuid.UUID `json:"id"`
+	SpaceID   uuid.UUID `json:"space_id"`
+	SpaceName string    `json:"space_name"`
+	UserID    string    `json:"user_id"`
+	UserName  string    `json:"user_name"`
+	Date      string    `json:"date"` // Format: YYYY-MM-DD
+	StartTime *string   `json:"start_time"`
+	EndTime   *string   `json:"end_time"`
+}
+
+// MapSyncConflictResponseDTO represents the HTTP response when a map update would
+// remove spaces that have upcoming reservations
+type MapSyncConflictResponseDTO struct {
+	Error string               `json:"error"`
+	Sync  *SpaceSyncSummaryDTO `json:"sync"`
+}
+
+// FieldErrorDTO describes one invalid field of a map or space
+type FieldErrorDTO struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationErrorResponseDTO represents the HTTP response for a rejected map or space
+type ValidationErrorResponseDTO struct {
+	Error  string          `json:"error"`
+	Errors []FieldErrorDTO `json:"errors"`
+}
+
+// MapFileErrorDTO describes an invalid row of an imported map file. Line is the
+// line number for CSV files and the 1-based position in "spaces" for JSON files.
+type MapFileErrorDTO struct {
+	Line    int    `json:"line"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,23 @@
+package dto
+
+// NotificationPreferencesRequestDTO represents the HTTP request for changing
+// which notifications the caller receives. Omitted fields are kept.
+type NotificationPreferencesRequestDTO struct {
+	Confirmations *bool `json:"confirmations"`
+	Cancellations *bool `json:"cancellations"`
+	DayReminders  *bool `json:"day_reminders"`
+	HourReminders *bool `json:"hour_reminders"`
+}
+
+// NotificationPreferencesResponseDTO represents the HTTP response for the
+// caller's notification preferences
+type NotificationPreferencesResponseDTO struct {
+	// Email is the address from the caller's token; empty means no emails are sent
+	Email string `json:"email"`
+	// Enabled is false when the server is not configured to send emails
+	Enabled       bool `json:"enabled"`
+	Confirmations bool `json:"confirmations"`
+	Cancellations bool `json:"cancellations"`
+	DayReminders  bool `json:"day_reminders"`
+	HourReminders bool `json:"hour_reminders"`
+}
@@ -0,0 +1,17 @@
+package dto
+
+// PageResponseDTO represents one page of a listing
+type PageResponseDTO struct {
+	Data       interface{}   `json:"data"`
+	Pagination PaginationDTO `json:"pagination"`
+}
+
+// PaginationDTO describes a page and how to fetch the next one
+type PaginationDTO struct {
+	Limit int    `json:"limit"`
+	Sort  string `json:"sort"`
+	Order string `json:"order"`
+	// NextCursor is passed as cursor to fetch the next page; null on the last page
+	NextCursor *string `json:"next_cursor"`
+	HasMore    bool    `json:"has_more"`
+}
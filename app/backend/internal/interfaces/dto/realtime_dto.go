@@ -0,0 +1,20 @@
+package dto
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
+
+// MapEventDTO represents a change of an office map streamed to clients. Over
+// Server-Sent Events the ID and type are sent as the SSE id and event fields
+// and the DTO as the data.
+type MapEventDTO struct {
+	// ID is the event ID clients resume from; absent on heartbeats
+	ID        *int64          `json:"id,omitempty"`
+	Type      string          `json:"type"`
+	MapID     uuid.UUID       `json:"map_id"`
+	EventID   *uuid.UUID      `json:"event_id,omitempty"` // Same as the webhook event ID
+	Data      json.RawMessage `json:"data,omitempty"`
+	CreatedAt string          `json:"created_at,omitempty"`
+}
@@ -7,36 +7,147 @@ import (
 // CreateReservationRequestDTO represents the HTTP request for creating a reservation
 type CreateReservationRequestDTO struct {
 	SpaceID   uuid.UUID `json:"space_id" binding:"required"`
-	UserID    string    `json:"user_id" binding:"required"`
-	UserName  string    `json:"user_name"`
-	Date      string    `json:"date" binding:"required"`      // Format: YYYY-MM-DD
-	StartTime string    `json:"start_time,omitempty"`          // Format: HH:MM
-	EndTime   string    `json:"end_time,omitempty"`           // Format: HH:MM
+	UserID    string    `json:"user_id"`                 // Admin only: book on behalf of another user
+	UserName  string    `json:"user_name"`               // Admin only, with user_id
+	Date      string    `json:"date" binding:"required"` // Format: YYYY-MM-DD
+	StartTime string    `json:"start_time,omitempty"`    // Format: HH:MM
+	EndTime   string    `json:"end_time,omitempty"`      // Format: HH:MM
 	Notes     string    `json:"notes"`
+	Force     bool      `json:"force"` // Admin only: cancel overlapping reservations instead of failing
 }
 
 // UpdateReservationRequestDTO represents the HTTP request for updating a reservation
 type UpdateReservationRequestDTO struct {
 	UserName  string `json:"user_name"`
-	Date      string `json:"date"`      // Format: YYYY-MM-DD
+	Date      string `json:"date"`       // Format: YYYY-MM-DD
 	StartTime string `json:"start_time"` // Format: HH:MM
 	EndTime   string `json:"end_time"`   // Format: HH:MM
-	Status    string `json:"status"`
+	Status    string `json:"status" binding:"omitempty,oneof=active cancelled completed"`
 	Notes     string `json:"notes"`
 }
 
 // ReservationResponseDTO represents the HTTP response for a reservation
 type ReservationResponseDTO struct {
-	ID        uuid.UUID `json:"id"`
-	SpaceID   uuid.UUID `json:"space_id"`
-	UserID    string    `json:"user_id"`
-	UserName  string    `json:"user_name"`
-	Date      string    `json:"date"` // Format: YYYY-MM-DD
-	StartTime *string   `json:"start_time,omitempty"`
-	EndTime   *string   `json:"end_time,omitempty"`
-	Status    string    `json:"status"`
+	ID          uuid.UUID  `json:"id"`
+	SpaceID     uuid.UUID  `json:"space_id"`
+	UserID      string     `json:"user_id"`
+	UserName    string     `json:"user_name"`
+	Date        string     `json:"date"` // Format: YYYY-MM-DD
+	StartTime   *string    `json:"start_time,omitempty"`
+	EndTime     *string    `json:"end_time,omitempty"`
+	Status      string     `json:"status"`
+	Notes       string     `json:"notes"`
+	SeriesID    *uuid.UUID `json:"series_id,omitempty"`
+	CheckedInAt *string    `json:"checked_in_at,omitempty"`
+	// GroupBookingID is shared by the reservations of a space group booking
+	GroupBookingID *uuid.UUID `json:"group_booking_id,omitempty"`
+	// Timezone is the zone of the office map; StartsAt and EndsAt are the
+	// reservation's interval in it (RFC 3339). All three are omitted when unknown.
+	Timezone string  `json:"timezone,omitempty"`
+	StartsAt *string `json:"starts_at,omitempty"`
+	EndsAt   *string `json:"ends_at,omitempty"`
+	// Version is also sent as the ETag of the reservation
+	Version   int64  `json:"version"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// ReservationConflictResponseDTO represents the HTTP response when a reservation
+// overlaps existing active reservations
+type ReservationConflictResponseDTO struct {
+	Error     string                   `json:"error"`
+	Conflicts []ReservationResponseDTO `json:"conflicts"`
+}
+
+// AssignDeskRequestDTO represents the HTTP request for booking any free desk of a map
+type AssignDeskRequestDTO struct {
+	MapID     uuid.UUID `json:"map_id" binding:"required"`
+	Date      string    `json:"date" binding:"required"` // Format: YYYY-MM-DD
+	StartTime string    `json:"start_time,omitempty"`    // Format: HH:MM
+	EndTime   string    `json:"end_time,omitempty"`      // Format: HH:MM
 	Notes     string    `json:"notes"`
-	CreatedAt string    `json:"created_at"`
-	UpdatedAt string    `json:"updated_at"`
+	UserID    string    `json:"user_id"`   // Admin only: assign a desk to another user
+	UserName  string    `json:"user_name"` // Admin only, with user_id
+	// FavoriteSpaceIDs are preferred in the given order
+	FavoriteSpaceIDs []uuid.UUID `json:"favorite_space_ids"`
+	// Teammates are user IDs to sit close to
+	Teammates  []string `json:"teammates"`
+	SpaceTypes []string `json:"space_types"` // workstation and/or cubicle
+}
+
+// DeskAssignmentResponseDTO represents the HTTP response for an assigned desk
+type DeskAssignmentResponseDTO struct {
+	Reservation ReservationResponseDTO `json:"reservation"`
+	Space       SpaceResponseDTO       `json:"space"`
+	Reasons     []string               `json:"reasons"`
 }
 
+// DeskAlreadyBookedResponseDTO represents the HTTP response when the user already
+// has a desk for the requested time slot
+type DeskAlreadyBookedResponseDTO struct {
+	Error       string                 `json:"error"`
+	Reservation ReservationResponseDTO `json:"reservation"`
+}
+
+// BatchCreateReservationsRequestDTO represents the HTTP request for booking several reservations
+type BatchCreateReservationsRequestDTO struct {
+	// Mode is all_or_nothing (default) or best_effort
+	Mode  string                        `json:"mode"`
+	Items []CreateReservationRequestDTO `json:"items"`
+}
+
+// BatchCancelReservationsRequestDTO represents the HTTP request for cancelling
+// several reservations, either by ID or every reservation matching a filter
+type BatchCancelReservationsRequestDTO struct {
+	// Mode is all_or_nothing (default) or best_effort; it only applies to items
+	Mode   string                       `json:"mode"`
+	Items  []CancelReservationItemDTO   `json:"items"`
+	Filter *CancelReservationsFilterDTO `json:"filter"`
+}
+
+// CancelReservationItemDTO names a reservation to cancel
+type CancelReservationItemDTO struct {
+	ID uuid.UUID `json:"id" binding:"required"`
+	// Version is optional; when set, the reservation is only cancelled if it
+	// has not been changed since
+	Version *int64 `json:"version"`
+}
+
+// CancelReservationsFilterDTO selects the reservations of a space or map, between
+// two dates, inclusive, to cancel
+type CancelReservationsFilterDTO struct {
+	SpaceID *uuid.UUID `json:"space_id"`
+	MapID   *uuid.UUID `json:"map_id"`
+	From    string     `json:"from"` // Format: YYYY-MM-DD
+	To      string     `json:"to"`   // Format: YYYY-MM-DD
+}
+
+// BatchReservationsResponseDTO represents the HTTP response for a batch, with
+// the result of each item in the order of the request
+type BatchReservationsResponseDTO struct {
+	Mode      string                    `json:"mode"`
+	Succeeded int                       `json:"succeeded"`
+	Failed    int                       `json:"failed"`
+	Items     []BatchReservationItemDTO `json:"items"`
+}
+
+// BatchReservationItemDTO represents the result of one item of a batch. Status
+// is the HTTP status the item would have had as a request of its own; failed
+// items have an error and, depending on it, a code and details.
+type BatchReservationItemDTO struct {
+	Index       int                      `json:"index"`
+	Status      int                      `json:"status"`
+	Reservation *ReservationResponseDTO  `json:"reservation,omitempty"`
+	Error       string                   `json:"error,omitempty"`
+	Code        string                   `json:"code,omitempty"`
+	Conflicts   []ReservationResponseDTO `json:"conflicts,omitempty"`
+	Closures    []ClosureResponseDTO     `json:"closures,omitempty"`
+	Violations  []PolicyViolationDTO     `json:"violations,omitempty"`
+}
+
+// CancelReservationsMatchingResponseDTO represents the HTTP response for a
+// cancellation by filter
+type CancelReservationsMatchingResponseDTO struct {
+	Cancelled    int                      `json:"cancelled"`
+	Reservations []ReservationResponseDTO `json:"reservations"`
+}
@@ -0,0 +1,54 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+)
+
+// CreateReservationSeriesRequestDTO represents the HTTP request for creating a recurring reservation
+type CreateReservationSeriesRequestDTO struct {
+	SpaceID   uuid.UUID `json:"space_id" binding:"required"`
+	UserID    string    `json:"user_id"`                       // Admin only: book on behalf of another user
+	UserName  string    `json:"user_name"`                     // Admin only, with user_id
+	StartDate string    `json:"start_date" binding:"required"` // Format: YYYY-MM-DD
+	StartTime string    `json:"start_time,omitempty"`          // Format: HH:MM
+	EndTime   string    `json:"end_time,omitempty"`            // Format: HH:MM
+	RRule     string    `json:"rrule" binding:"required"`      // e.g. FREQ=WEEKLY;BYDAY=MO,WE;COUNT=10
+	Notes     string    `json:"notes"`
+}
+
+// UpdateReservationSeriesRequestDTO represents the HTTP request for editing a recurring reservation
+type UpdateReservationSeriesRequestDTO struct {
+	Scope          string  `json:"scope" binding:"required,oneof=occurrence following series"`
+	OccurrenceDate string  `json:"occurrence_date"` // Format: YYYY-MM-DD, required unless scope is series
+	StartTime      *string `json:"start_time"`      // Format: HH:MM
+	EndTime        *string `json:"end_time"`        // Format: HH:MM
+	Notes          *string `json:"notes"`
+}
+
+// OccurrenceResultDTO represents the outcome for a single occurrence of a series
+type OccurrenceResultDTO struct {
+	Date        string                   `json:"date"` // Format: YYYY-MM-DD
+	Status      string                   `json:"status"`
+	Reservation *ReservationResponseDTO  `json:"reservation,omitempty"`
+	Conflicts   []ReservationResponseDTO `json:"conflicts,omitempty"`
+	Violations  []PolicyViolationDTO     `json:"violations,omitempty"`
+	Error       string                   `json:"error,omitempty"`
+}
+
+// ReservationSeriesResponseDTO represents the HTTP response for a reservation series
+type ReservationSeriesResponseDTO struct {
+	ID           uuid.UUID                `json:"id"`
+	SpaceID      uuid.UUID                `json:"space_id"`
+	UserID       string                   `json:"user_id"`
+	UserName     string                   `json:"user_name"`
+	StartDate    string                   `json:"start_date"` // Format: YYYY-MM-DD
+	StartTime    *string                  `json:"start_time,omitempty"`
+	EndTime      *string                  `json:"end_time,omitempty"`
+	RRule        string                   `json:"rrule"`
+	Notes        string                   `json:"notes"`
+	Status       string                   `json:"status"`
+	CreatedAt    string                   `json:"created_at"`
+	UpdatedAt    string                   `json:"updated_at"`
+	Occurrences  []OccurrenceResultDTO    `json:"occurrences,omitempty"`
+	Reservations []ReservationResponseDTO `json:"reservations,omitempty"`
+}
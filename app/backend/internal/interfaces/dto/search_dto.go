@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+)
+
+// MeetingRoomSearchRequestDTO represents the HTTP request for finding free meeting room slots
+type MeetingRoomSearchRequestDTO struct {
+	DurationMinutes int        `json:"duration_minutes" binding:"required"`
+	Attendees       int        `json:"attendees" binding:"required"`
+	Date            string     `json:"date"`          // Format: YYYY-MM-DD
+	From            string     `json:"from"`          // Format: YYYY-MM-DD, with to instead of date
+	To              string     `json:"to"`            // Format: YYYY-MM-DD
+	EarliestTime    string     `json:"earliest_time"` // Format: HH:MM
+	LatestTime      string     `json:"latest_time"`   // Format: HH:MM
+	MapID           *uuid.UUID `json:"map_id"`        // Searches all maps when omitted
+	Limit           int        `json:"limit"`
+}
+
+// MeetingRoomSlotDTO represents a suggested meeting room slot
+type MeetingRoomSlotDTO struct {
+	SpaceID   *uuid.UUID  `json:"space_id,omitempty"`
+	GroupID   *uuid.UUID  `json:"group_id,omitempty"`
+	SpaceIDs  []uuid.UUID `json:"space_ids"`
+	MapID     uuid.UUID   `json:"map_id"`
+	Name      string      `json:"name"`
+	Capacity  int         `json:"capacity"`
+	Date      string      `json:"date"`       // Format: YYYY-MM-DD
+	StartTime string      `json:"start_time"` // Format: HH:MM
+	EndTime   string      `json:"end_time"`   // Format: HH:MM
+}
+
+// MeetingRoomSearchResponseDTO represents the HTTP response for a meeting room search
+type MeetingRoomSearchResponseDTO struct {
+	Slots []MeetingRoomSlotDTO `json:"slots"`
+}
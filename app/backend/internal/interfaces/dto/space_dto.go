@@ -0,0 +1,109 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+)
+
+// CreateSpaceRequestDTO represents the HTTP request for creating a space
+type CreateSpaceRequestDTO struct {
+	MapID    uuid.UUID `json:"map_id" binding:"required"`
+	Name     string    `json:"name"`
+	Type     string    `json:"type"`
+	X        int       `json:"x"`
+	Y        int       `json:"y"`
+	Width    int       `json:"width"`    // Defaults to 1
+	Height   int       `json:"height"`   // Defaults to 1
+	Capacity int       `json:"capacity"` // Defaults to 1
+}
+
+// UpdateSpaceRequestDTO represents the HTTP request for updating a space
+type UpdateSpaceRequestDTO struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	X        *int   `json:"x"`
+	Y        *int   `json:"y"`
+	Width    *int   `json:"width"`
+	Height   *int   `json:"height"`
+	Capacity *int   `json:"capacity"`
+}
+
+// SpaceResponseDTO represents the HTTP response for a space
+type SpaceResponseDTO struct {
+	ID       uuid.UUID  `json:"id"`
+	MapID    uuid.UUID  `json:"map_id"`
+	GroupID  *uuid.UUID `json:"group_id,omitempty"`
+	Name     string     `json:"name"`
+	Type     string     `json:"type"`
+	X        int        `json:"x"`
+	Y        int        `json:"y"`
+	Width    int        `json:"width"`
+	Height   int        `json:"height"`
+	Capacity int        `json:"capacity"`
+	// Version is also sent as the ETag of the space
+	Version   int64  `json:"version"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// AvailabilityResponseDTO represents the HTTP response for the availability of a
+// space on a date
+type AvailabilityResponseDTO struct {
+	SpaceID      uuid.UUID                `json:"space_id"`
+	Date         string                   `json:"date"` // Format: YYYY-MM-DD
+	IsAvailable  bool                     `json:"is_available"`
+	Reservations []ReservationResponseDTO `json:"reservations"`
+	Closures     []ClosureResponseDTO     `json:"closures"`
+}
+
+// MapAvailabilityResponseDTO represents the HTTP response for the availability of
+// every space of a map
+type MapAvailabilityResponseDTO struct {
+	MapID     uuid.UUID              `json:"map_id"`
+	From      string                 `json:"from"`       // Format: YYYY-MM-DD
+	To        string                 `json:"to"`         // Format: YYYY-MM-DD
+	StartTime string                 `json:"start_time"` // Format: HH:MM
+	EndTime   string                 `json:"end_time"`   // Format: HH:MM
+	Spaces    []SpaceAvailabilityDTO `json:"spaces"`
+}
+
+// SpaceAvailabilityDTO is the availability of a space, or of a space group whose
+// members are booked together
+type SpaceAvailabilityDTO struct {
+	SpaceID  *uuid.UUID           `json:"space_id,omitempty"`
+	GroupID  *uuid.UUID           `json:"group_id,omitempty"`
+	SpaceIDs []uuid.UUID          `json:"space_ids"`
+	Name     string               `json:"name"`
+	Type     string               `json:"type"`
+	State    string               `json:"state"` // free, partial, booked, closed or unavailable
+	Days     []DayAvailabilityDTO `json:"days"`
+}
+
+// DayAvailabilityDTO is the availability of a space within the time window on one date
+type DayAvailabilityDTO struct {
+	Date   string              `json:"date"` // Format: YYYY-MM-DD
+	State  string              `json:"state"`
+	Busy   []BusyIntervalDTO   `json:"busy"`
+	Closed []ClosedIntervalDTO `json:"closed"`
+	Free   []TimeIntervalDTO   `json:"free"`
+}
+
+// TimeIntervalDTO is a time range within a day. An end of 24:00 means midnight.
+type TimeIntervalDTO struct {
+	Start string `json:"start"` // Format: HH:MM
+	End   string `json:"end"`   // Format: HH:MM
+}
+
+// BusyIntervalDTO is a booked time range and the reservations covering it
+type BusyIntervalDTO struct {
+	Start          string      `json:"start"` // Format: HH:MM
+	End            string      `json:"end"`   // Format: HH:MM
+	ReservationIDs []uuid.UUID `json:"reservation_ids"`
+}
+
+// ClosedIntervalDTO is a time range covered by a closure
+type ClosedIntervalDTO struct {
+	Start     string    `json:"start"` // Format: HH:MM
+	End       string    `json:"end"`   // Format: HH:MM
+	ClosureID uuid.UUID `json:"closure_id"`
+	Reason    string    `json:"reason"`
+}
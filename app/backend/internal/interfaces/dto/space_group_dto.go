@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+)
+
+// CreateSpaceGroupRequestDTO represents the HTTP request for creating a space group
+type CreateSpaceGroupRequestDTO struct {
+	MapID    uuid.UUID   `json:"map_id" binding:"required"`
+	Name     string      `json:"name" binding:"required"`
+	Capacity int         `json:"capacity"`
+	SpaceIDs []uuid.UUID `json:"space_ids" binding:"required,min=1"`
+}
+
+// UpdateSpaceGroupRequestDTO represents the HTTP request for updating a space group
+type UpdateSpaceGroupRequestDTO struct {
+	Name     string      `json:"name"`
+	Capacity int         `json:"capacity"`
+	SpaceIDs []uuid.UUID `json:"space_ids"` // Replaces the members when provided
+}
+
+// SpaceGroupResponseDTO represents the HTTP response for a space group
+type SpaceGroupResponseDTO struct {
+	ID        uuid.UUID   `json:"id"`
+	MapID     uuid.UUID   `json:"map_id"`
+	Name      string      `json:"name"`
+	Capacity  int         `json:"capacity"`
+	SpaceIDs  []uuid.UUID `json:"space_ids"`
+	CreatedAt string      `json:"created_at"`
+	UpdatedAt string      `json:"updated_at"`
+}
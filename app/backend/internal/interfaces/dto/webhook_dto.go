@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
+
+// WebhookRequestDTO represents the HTTP request for creating or replacing a webhook
+type WebhookRequestDTO struct {
+	URL         string   `json:"url" binding:"required"`
+	Description string   `json:"description"`
+	Events      []string `json:"events"` // Event types; empty subscribes to all
+	Secret      *string  `json:"secret"` // Omitted generates one on create and keeps the current one on update
+	Active      *bool    `json:"active"` // Defaults to true
+}
+
+// WebhookResponseDTO represents the HTTP response for a webhook
+type WebhookResponseDTO struct {
+	ID          uuid.UUID `json:"id"`
+	URL         string    `json:"url"`
+	Description string    `json:"description"`
+	Events      []string  `json:"events"`
+	Active      bool      `json:"active"`
+	// Secret is only returned when the webhook is created
+	Secret    string `json:"secret,omitempty"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// WebhookDeliveryResponseDTO represents one entry of a webhook's delivery log
+type WebhookDeliveryResponseDTO struct {
+	ID             uuid.UUID       `json:"id"`
+	WebhookID      uuid.UUID       `json:"webhook_id"`
+	EventID        uuid.UUID       `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  *string         `json:"next_attempt_at"`
+	LastAttemptAt  *string         `json:"last_attempt_at"`
+	ResponseStatus *int            `json:"response_status"`
+	LastError      string          `json:"last_error,omitempty"`
+	RedeliveryOf   *uuid.UUID      `json:"redelivery_of,omitempty"`
+	Payload        json.RawMessage `json:"payload"`
+	CreatedAt      string          `json:"created_at"`
+	UpdatedAt      string          `json:"updated_at"`
+}
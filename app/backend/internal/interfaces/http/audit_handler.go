@@ -0,0 +1,168 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"office-reservations/internal/application/services"
+	"office-reservations/internal/auth"
+	"office-reservations/internal/domain/entities"
+	"office-reservations/internal/domain/repositories"
+	"office-reservations/internal/interfaces/dto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Bounds of the audit log page size
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 500
+)
+
+// AuditHandler handles HTTP requests for the audit log
+type AuditHandler struct {
+	auditService *services.AuditService
+	guard        *auth.Guard
+}
+
+// NewAuditHandler creates a new audit handler
+func NewAuditHandler(auditService *services.AuditService, guard *auth.Guard) *AuditHandler {
+	return &AuditHandler{
+		auditService: auditService,
+		guard:        guard,
+	}
+}
+
+// GetReservationHistory handles GET /api/reservations/:id/history
+func (h *AuditHandler) GetReservationHistory(c *gin.Context) {
+	reservationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reservation ID"})
+		return
+	}
+
+	entries, err := h.auditService.GetReservationHistory(reservationID, actorFromContext(c))
+	if err != nil {
+		switch err {
+		case services.ErrReservationNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Reservation not found"})
+		case services.ErrNotReservationOwner:
+			h.guard.Deny(c, auth.PermManageReservations, "", "Only the owner or an admin can read the history of this reservation")
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch reservation history"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, toAuditEntryResponseDTOs(entries))
+}
+
+// GetAuditLog handles GET /api/audit?entity_type=&entity_id=&map_id=&actor_id=&action=&from=&to=&limit=.
+// from and to are RFC 3339 times or dates; a date in to includes the whole day.
+func (h *AuditHandler) GetAuditLog(c *gin.Context) {
+	filters := repositories.AuditFilters{Limit: defaultAuditLimit}
+	if value := c.Query("entity_type"); value != "" {
+		entityType := entities.AuditEntityType(value)
+		switch entityType {
+		case entities.AuditEntityReservation, entities.AuditEntitySpace, entities.AuditEntityMap:
+			filters.EntityType = &entityType
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid entity type (use reservation, space or map)"})
+			return
+		}
+	}
+	if value := c.Query("entity_id"); value != "" {
+		entityID, err := uuid.Parse(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid entity ID"})
+			return
+		}
+		filters.EntityID = &entityID
+	}
+	if value := c.Query("map_id"); value != "" {
+		mapID, err := uuid.Parse(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid map ID"})
+			return
+		}
+		filters.MapID = &mapID
+	}
+	if value := strings.TrimSpace(c.Query("actor_id")); value != "" {
+		filters.ActorID = &value
+	}
+	if value := c.Query("action"); value != "" {
+		action := entities.AuditAction(value)
+		switch action {
+		case entities.AuditActionCreate, entities.AuditActionUpdate, entities.AuditActionCancel, entities.AuditActionCheckIn, entities.AuditActionDelete:
+			filters.Action = &action
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid action (use create, update, cancel, check_in or delete)"})
+			return
+		}
+	}
+	if value := c.Query("from"); value != "" {
+		from, ok := parseTimeBound(value, false)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'from' time (use RFC 3339 or YYYY-MM-DD)"})
+			return
+		}
+		filters.From = &from
+	}
+	if value := c.Query("to"); value != "" {
+		to, ok := parseTimeBound(value, true)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'to' time (use RFC 3339 or YYYY-MM-DD)"})
+			return
+		}
+		filters.To = &to
+	}
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > maxAuditLimit {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit (use 1 to " + strconv.Itoa(maxAuditLimit) + ")"})
+			return
+		}
+		filters.Limit = limit
+	}
+
+	entries, err := h.auditService.GetAuditLog(filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch audit log"})
+		return
+	}
+
+	c.JSON(http.StatusOK, toAuditEntryResponseDTOs(entries))
+}
+
+// toAuditEntryResponseDTO converts an audit entry to its DTO
+func toAuditEntryResponseDTO(e *entities.AuditEntry) dto.AuditEntryResponseDTO {
+	response := dto.AuditEntryResponseDTO{
+		ID:         e.ID,
+		EntityType: string(e.EntityType),
+		EntityID:   e.EntityID,
+		MapID:      e.MapID,
+		Action:     string(e.Action),
+		ActorID:    e.ActorID,
+		ActorName:  e.ActorName,
+		Reason:     e.Reason,
+		CreatedAt:  e.CreatedAt.Format(time.RFC3339),
+	}
+	if e.Before != nil {
+		response.Before = json.RawMessage(e.Before)
+	}
+	if e.After != nil {
+		response.After = json.RawMessage(e.After)
+	}
+	return response
+}
+
+func toAuditEntryResponseDTOs(entries []*entities.AuditEntry) []dto.AuditEntryResponseDTO {
+	response := make([]dto.AuditEntryResponseDTO, len(entries))
+	for i, e := range entries {
+		response[i] = toAuditEntryResponseDTO(e)
+	}
+	return response
+}
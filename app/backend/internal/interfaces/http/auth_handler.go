@@ -0,0 +1,128 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"office-reservations/internal/application/services"
+	"office-reservations/internal/auth"
+	"office-reservations/internal/interfaces/dto"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuthHandler handles HTTP requests about the caller's identity
+type AuthHandler struct {
+	devIssuer *auth.DevIssuer
+}
+
+// NewAuthHandler creates a new auth handler. devIssuer is nil unless development
+// tokens are enabled.
+func NewAuthHandler(devIssuer *auth.DevIssuer) *AuthHandler {
+	return &AuthHandler{
+		devIssuer: devIssuer,
+	}
+}
+
+// Me handles GET /api/auth/me
+func (h *AuthHandler) Me(c *gin.Context) {
+	identity, ok := auth.IdentityFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+	c.JSON(http.StatusOK, identity)
+}
+
+// IssueDevToken handles POST /api/auth/dev-token. It is only routed when the
+// development issuer is enabled.
+func (h *AuthHandler) IssueDevToken(c *gin.Context) {
+	if h.devIssuer == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Development tokens are disabled"})
+		return
+	}
+
+	var req dto.DevTokenRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	for _, role := range req.Roles {
+		if !auth.KnownRole(role) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown role: " + role})
+			return
+		}
+	}
+
+	identity := auth.Identity{
+		UserID:   req.UserID,
+		UserName: req.UserName,
+		Email:    req.Email,
+		Roles:    req.Roles,
+		MapIDs:   req.MapIDs,
+	}
+	token, expiresAt, err := h.devIssuer.Issue(identity)
+	if errors.Is(err, auth.ErrRoleNotIssuable) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Development tokens cannot have the super_admin role unless AUTH_DEV_ISSUER_SUPER_ADMIN=true"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to issue token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.TokenResponseDTO{
+		Token:     token,
+		TokenType: "Bearer",
+		ExpiresAt: expiresAt.Format(time.RFC3339),
+	})
+}
+
+// actorFromContext returns the authenticated caller as a service actor. Requests
+// without an identity get an actor that owns nothing, never the unrestricted nil actor.
+func actorFromContext(c *gin.Context) *services.Actor {
+	identity, ok := auth.IdentityFromContext(c)
+	if !ok {
+		return &services.Actor{}
+	}
+	roles := identity.Roles
+	if len(roles) == 0 {
+		roles = []string{auth.RoleEmployee}
+	}
+	return &services.Actor{
+		UserID:   identity.UserID,
+		UserName: identity.UserName,
+		Admin:    auth.Allowed(identity, auth.PermManageReservations, ""),
+		Roles:    roles,
+	}
+}
+
+// auditReason returns why the caller makes a change, as given in the
+// X-Audit-Reason header, for the audit log
+func auditReason(c *gin.Context) string {
+	return strings.TrimSpace(c.GetHeader("X-Audit-Reason"))
+}
+
+// bookingOwner returns the user a new booking is made for. Bookings belong to
+// the caller; only reservation admins may name another user.
+func bookingOwner(actor *services.Actor, userID, userName string) (string, string) {
+	if actor.Admin && userID != "" {
+		if userName == "" {
+			userName = userID
+		}
+		return userID, userName
+	}
+	return actor.UserID, actor.UserName
+}
+
+// batchBookingOwner returns the user an item of a batch is booked for, like
+// bookingOwner, but fails with ErrBookForOthers instead of booking for the
+// caller when someone other than a reservation admin names another user
+func batchBookingOwner(actor *services.Actor, userID, userName string) (string, string, error) {
+	if !actor.Admin && userID != "" && userID != actor.UserID {
+		return "", "", services.ErrBookForOthers
+	}
+	ownerID, ownerName := bookingOwner(actor, userID, userName)
+	return ownerID, ownerName, nil
+}
@@ -0,0 +1,215 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"office-reservations/internal/application/services"
+	"office-reservations/internal/auth"
+	"office-reservations/internal/domain/entities"
+	"office-reservations/internal/interfaces/dto"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// BookingPolicyHandler handles HTTP requests for booking policies
+type BookingPolicyHandler struct {
+	policyService *services.BookingPolicyService
+}
+
+// NewBookingPolicyHandler creates a new booking policy handler
+func NewBookingPolicyHandler(policyService *services.BookingPolicyService) *BookingPolicyHandler {
+	return &BookingPolicyHandler{
+		policyService: policyService,
+	}
+}
+
+// GetPolicies handles GET /api/booking-policies
+func (h *BookingPolicyHandler) GetPolicies(c *gin.Context) {
+	var mapID *uuid.UUID
+	if value := c.Query("map_id"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid map ID"})
+			return
+		}
+		mapID = &id
+	}
+
+	policies, err := h.policyService.GetPolicies(mapID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch booking policies"})
+		return
+	}
+
+	response := make([]dto.BookingPolicyResponseDTO, len(policies))
+	for i, p := range policies {
+		response[i] = toBookingPolicyResponseDTO(p)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetPolicy handles GET /api/booking-policies/:id
+func (h *BookingPolicyHandler) GetPolicy(c *gin.Context) {
+	policyID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid booking policy ID"})
+		return
+	}
+
+	policy, err := h.policyService.GetPolicy(policyID)
+	if err != nil {
+		respondBookingPolicyError(c, err, "Failed to fetch booking policy")
+		return
+	}
+
+	c.JSON(http.StatusOK, toBookingPolicyResponseDTO(policy))
+}
+
+// CreatePolicy handles POST /api/booking-policies
+func (h *BookingPolicyHandler) CreatePolicy(c *gin.Context) {
+	req, ok := bindBookingPolicyRequest(c)
+	if !ok {
+		return
+	}
+
+	policy, err := h.policyService.CreatePolicy(req)
+	if err != nil {
+		respondBookingPolicyError(c, err, "Failed to create booking policy")
+		return
+	}
+
+	c.JSON(http.StatusCreated, toBookingPolicyResponseDTO(policy))
+}
+
+// UpdatePolicy handles PUT /api/booking-policies/:id. The request replaces the
+// whole policy, so omitted rules are removed.
+func (h *BookingPolicyHandler) UpdatePolicy(c *gin.Context) {
+	policyID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid booking policy ID"})
+		return
+	}
+
+	req, ok := bindBookingPolicyRequest(c)
+	if !ok {
+		return
+	}
+
+	policy, err := h.policyService.UpdatePolicy(policyID, req)
+	if err != nil {
+		respondBookingPolicyError(c, err, "Failed to update booking policy")
+		return
+	}
+
+	c.JSON(http.StatusOK, toBookingPolicyResponseDTO(policy))
+}
+
+// DeletePolicy handles DELETE /api/booking-policies/:id
+func (h *BookingPolicyHandler) DeletePolicy(c *gin.Context) {
+	policyID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid booking policy ID"})
+		return
+	}
+
+	if err := h.policyService.DeletePolicy(policyID); err != nil {
+		respondBookingPolicyError(c, err, "Failed to delete booking policy")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Booking policy deleted successfully"})
+}
+
+// bindBookingPolicyRequest binds the request body and checks the role against
+// the authorization policy, writing the error response when it fails
+func bindBookingPolicyRequest(c *gin.Context) (services.BookingPolicyRequest, bool) {
+	var req dto.BookingPolicyRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return services.BookingPolicyRequest{}, false
+	}
+	if req.Role != nil && !auth.KnownRole(*req.Role) {
+		c.JSON(http.StatusBadRequest, dto.ValidationErrorResponseDTO{
+			Error:  "Invalid booking policy",
+			Errors: []dto.FieldErrorDTO{{Field: "role", Message: "unknown role " + *req.Role}},
+		})
+		return services.BookingPolicyRequest{}, false
+	}
+
+	serviceReq := services.BookingPolicyRequest{
+		Name:               req.Name,
+		MapID:              req.MapID,
+		Role:               req.Role,
+		MaxAdvanceDays:     req.MaxAdvanceDays,
+		MinDurationMinutes: req.MinDurationMinutes,
+		MaxDurationMinutes: req.MaxDurationMinutes,
+		AllowedStart:       req.AllowedStart,
+		AllowedEnd:         req.AllowedEnd,
+		MaxBookingsPerWeek: req.MaxBookingsPerWeek,
+		MaxConsecutiveDays: req.MaxConsecutiveDays,
+		BlackoutDates:      req.BlackoutDates,
+	}
+	if req.SpaceType != nil {
+		spaceType := entities.SpaceType(*req.SpaceType)
+		serviceReq.SpaceType = &spaceType
+	}
+	return serviceReq, true
+}
+
+// respondBookingPolicyError maps booking policy service errors to HTTP responses
+func respondBookingPolicyError(c *gin.Context, err error, fallback string) {
+	if respondValidationError(c, err, "Invalid booking policy") {
+		return
+	}
+
+	switch {
+	case errors.Is(err, services.ErrBookingPolicyNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Booking policy not found"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
+
+// toBookingPolicyResponseDTO converts a domain entity to a response DTO
+func toBookingPolicyResponseDTO(p *entities.BookingPolicy) dto.BookingPolicyResponseDTO {
+	response := dto.BookingPolicyResponseDTO{
+		ID:                 p.ID,
+		Name:               p.Name,
+		MapID:              p.MapID,
+		Role:               p.Role,
+		MaxAdvanceDays:     p.MaxAdvanceDays,
+		MinDurationMinutes: p.MinDurationMinutes,
+		MaxDurationMinutes: p.MaxDurationMinutes,
+		AllowedStart:       formatClock(p.AllowedStart),
+		AllowedEnd:         formatClock(p.AllowedEnd),
+		MaxBookingsPerWeek: p.MaxBookingsPerWeek,
+		MaxConsecutiveDays: p.MaxConsecutiveDays,
+		BlackoutDates:      make([]string, len(p.BlackoutDates)),
+		CreatedAt:          p.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:          p.UpdatedAt.Format(time.RFC3339),
+	}
+	if p.SpaceType != nil {
+		spaceType := string(*p.SpaceType)
+		response.SpaceType = &spaceType
+	}
+	for i, date := range p.BlackoutDates {
+		response.BlackoutDates[i] = date.Format("2006-01-02")
+	}
+	return response
+}
+
+// formatClock normalizes an optional time of day to HH:MM
+func formatClock(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	minutes, err := entities.ParseClock(*value)
+	if err != nil {
+		return value
+	}
+	formatted := entities.FormatClock(minutes)
+	return &formatted
+}
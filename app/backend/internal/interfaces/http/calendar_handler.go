@@ -0,0 +1,186 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"office-reservations/internal/application/services"
+	"office-reservations/internal/auth"
+	"office-reservations/internal/ics"
+	"office-reservations/internal/interfaces/dto"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// calendarFeedSuffix is the file extension the feed routes require
+const calendarFeedSuffix = ".ics"
+
+// CalendarHandler serves read-only iCalendar feeds of reservations. Calendar
+// clients cannot send bearer tokens, so the feeds are authenticated with the
+// secret feed token of a user in the "token" query parameter.
+type CalendarHandler struct {
+	calendarService *services.CalendarService
+}
+
+// NewCalendarHandler creates a new calendar handler
+func NewCalendarHandler(calendarService *services.CalendarService) *CalendarHandler {
+	return &CalendarHandler{
+		calendarService: calendarService,
+	}
+}
+
+// IssueFeedToken handles POST /api/calendar/token. It creates a feed token for
+// the caller, revoking the previous one, and returns it with the feed URLs.
+func (h *CalendarHandler) IssueFeedToken(c *gin.Context) {
+	identity, ok := auth.IdentityFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	token, err := h.calendarService.IssueFeedToken(identity.UserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to issue calendar feed token"})
+		return
+	}
+
+	query := "?token=" + url.QueryEscape(token)
+	c.JSON(http.StatusCreated, dto.CalendarFeedTokenResponseDTO{
+		Token:        token,
+		UserFeedURL:  "/api/calendar/users/" + url.PathEscape(identity.UserID) + calendarFeedSuffix + query,
+		SpaceFeedURL: "/api/calendar/spaces/{id}" + calendarFeedSuffix + query,
+		MapFeedURL:   "/api/calendar/maps/{id}" + calendarFeedSuffix + query,
+		CreatedAt:    time.Now().Format(time.RFC3339),
+	})
+}
+
+// RevokeFeedToken handles DELETE /api/calendar/token
+func (h *CalendarHandler) RevokeFeedToken(c *gin.Context) {
+	identity, ok := auth.IdentityFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	if err := h.calendarService.RevokeFeedToken(identity.UserID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke calendar feed token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Calendar feed token revoked"})
+}
+
+// GetUserFeed handles GET /api/calendar/users/:user_id.ics?token=. Only the
+// owner of the token can read their own feed.
+func (h *CalendarHandler) GetUserFeed(c *gin.Context) {
+	userID, ok := feedParam(c, "user_id")
+	if !ok {
+		return
+	}
+	tokenUser, ok := h.feedTokenUser(c)
+	if !ok {
+		return
+	}
+	if tokenUser != userID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "The feed token does not belong to this user"})
+		return
+	}
+
+	feed, err := h.calendarService.UserFeed(userID)
+	if err != nil {
+		respondCalendarError(c, err)
+		return
+	}
+	respondCalendar(c, feed, true)
+}
+
+// GetSpaceFeed handles GET /api/calendar/spaces/:id.ics?token=. Any valid feed
+// token is accepted, so the feed names neither the holders nor their notes.
+func (h *CalendarHandler) GetSpaceFeed(c *gin.Context) {
+	value, ok := feedParam(c, "id")
+	if !ok {
+		return
+	}
+	spaceID, err := uuid.Parse(value)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid space ID"})
+		return
+	}
+	if _, ok := h.feedTokenUser(c); !ok {
+		return
+	}
+
+	feed, err := h.calendarService.SpaceFeed(spaceID)
+	if err != nil {
+		respondCalendarError(c, err)
+		return
+	}
+	respondCalendar(c, feed, false)
+}
+
+// GetMapFeed handles GET /api/calendar/maps/:id.ics?token=. Any valid feed
+// token is accepted, so the feed names neither the holders nor their notes.
+func (h *CalendarHandler) GetMapFeed(c *gin.Context) {
+	value, ok := feedParam(c, "id")
+	if !ok {
+		return
+	}
+	mapID, err := uuid.Parse(value)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid map ID"})
+		return
+	}
+	if _, ok := h.feedTokenUser(c); !ok {
+		return
+	}
+
+	feed, err := h.calendarService.MapFeed(mapID)
+	if err != nil {
+		respondCalendarError(c, err)
+		return
+	}
+	respondCalendar(c, feed, false)
+}
+
+// feedParam returns a path parameter without the .ics suffix the feed routes
+// require, responding with 404 if it is missing
+func feedParam(c *gin.Context, name string) (string, bool) {
+	value, ok := strings.CutSuffix(c.Param(name), calendarFeedSuffix)
+	if !ok || value == "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Calendar feed not found"})
+		return "", false
+	}
+	return value, true
+}
+
+// feedTokenUser returns the user of the feed token in the query, responding
+// with 401 if it is missing or unknown
+func (h *CalendarHandler) feedTokenUser(c *gin.Context) (string, bool) {
+	userID, err := h.calendarService.FeedTokenUser(c.Query("token"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "A valid calendar feed token is required"})
+		return "", false
+	}
+	return userID, true
+}
+
+// respondCalendar writes a feed as an iCalendar file
+func respondCalendar(c *gin.Context, feed *services.CalendarFeed, withNotes bool) {
+	c.Header("Cache-Control", "private, max-age=300")
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", ics.WriteFeed(feed, withNotes, time.Now()))
+}
+
+// respondCalendarError maps calendar service errors to HTTP responses
+func respondCalendarError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrSpaceNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Space not found"})
+	case errors.Is(err, services.ErrMapNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Map not found"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to build calendar feed"})
+	}
+}
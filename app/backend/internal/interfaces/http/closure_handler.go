@@ -0,0 +1,287 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"office-reservations/internal/application/services"
+	"office-reservations/internal/domain/entities"
+	"office-reservations/internal/domain/repositories"
+	"office-reservations/internal/interfaces/dto"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// maxCalendarFileSize bounds the size of an uploaded calendar file
+const maxCalendarFileSize = 5 << 20
+
+// ClosureHandler handles HTTP requests for closures of maps, space groups and spaces
+type ClosureHandler struct {
+	closureService *services.ClosureService
+}
+
+// NewClosureHandler creates a new closure handler
+func NewClosureHandler(closureService *services.ClosureService) *ClosureHandler {
+	return &ClosureHandler{
+		closureService: closureService,
+	}
+}
+
+// GetClosures handles GET /api/closures?map_id=&from=&to=
+func (h *ClosureHandler) GetClosures(c *gin.Context) {
+	var filters repositories.ClosureFilters
+	if value := c.Query("map_id"); value != "" {
+		mapID, err := uuid.Parse(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid map ID"})
+			return
+		}
+		filters.MapID = &mapID
+	}
+	if from := c.Query("from"); from != "" {
+		fromDate, err := time.Parse("2006-01-02", from)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'from' date format (use YYYY-MM-DD)"})
+			return
+		}
+		filters.From = &fromDate
+	}
+	if to := c.Query("to"); to != "" {
+		toDate, err := time.Parse("2006-01-02", to)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'to' date format (use YYYY-MM-DD)"})
+			return
+		}
+		filters.To = &toDate
+	}
+
+	closures, err := h.closureService.GetClosures(filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch closures"})
+		return
+	}
+
+	c.JSON(http.StatusOK, toClosureResponseDTOs(closures))
+}
+
+// GetClosure handles GET /api/closures/:id
+func (h *ClosureHandler) GetClosure(c *gin.Context) {
+	closureID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid closure ID"})
+		return
+	}
+
+	closure, err := h.closureService.GetClosure(closureID)
+	if err != nil {
+		respondClosureError(c, err, "Failed to fetch closure")
+		return
+	}
+
+	c.JSON(http.StatusOK, toClosureResponseDTO(closure))
+}
+
+// CreateClosure handles POST /api/closures. Upcoming reservations that fall
+// into the closure are cancelled and returned.
+func (h *ClosureHandler) CreateClosure(c *gin.Context) {
+	var req dto.ClosureRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	closure, cancelled, err := h.closureService.CreateClosure(toClosureRequest(req, actorFromContext(c)))
+	if err != nil {
+		respondClosureError(c, err, "Failed to create closure")
+		return
+	}
+
+	response := toClosureResponseDTO(closure)
+	response.CancelledReservations = toReservationResponseDTOs(cancelled)
+	c.JSON(http.StatusCreated, response)
+}
+
+// UpdateClosure handles PUT /api/closures/:id. The request replaces the whole
+// closure; reservations cancelled earlier are not restored.
+func (h *ClosureHandler) UpdateClosure(c *gin.Context) {
+	closureID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid closure ID"})
+		return
+	}
+
+	var req dto.ClosureRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	closure, cancelled, err := h.closureService.UpdateClosure(closureID, toClosureRequest(req, actorFromContext(c)))
+	if err != nil {
+		respondClosureError(c, err, "Failed to update closure")
+		return
+	}
+
+	response := toClosureResponseDTO(closure)
+	response.CancelledReservations = toReservationResponseDTOs(cancelled)
+	c.JSON(http.StatusOK, response)
+}
+
+// DeleteClosure handles DELETE /api/closures/:id
+func (h *ClosureHandler) DeleteClosure(c *gin.Context) {
+	closureID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid closure ID"})
+		return
+	}
+
+	if err := h.closureService.DeleteClosure(closureID); err != nil {
+		respondClosureError(c, err, "Failed to delete closure")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Closure deleted successfully"})
+}
+
+// ImportClosures handles POST /api/closures/import. It accepts a multipart "file"
+// in iCalendar format and closes the map given by "map_id", or only the space
+// group or space given by "group_id" or "space_id", for each event. Events
+// imported before are updated in place, matched by their UID.
+func (h *ClosureHandler) ImportClosures(c *gin.Context) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A calendar file is required in the 'file' field"})
+		return
+	}
+	if fileHeader.Size > maxCalendarFileSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Calendar file is too large"})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read calendar file"})
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read calendar file"})
+		return
+	}
+
+	mapID, err := uuid.Parse(c.PostForm("map_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid map ID"})
+		return
+	}
+	req := services.ClosureImportRequest{MapID: mapID, Actor: actorFromContext(c)}
+	if value := c.PostForm("group_id"); value != "" {
+		groupID, err := uuid.Parse(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid space group ID"})
+			return
+		}
+		req.GroupID = &groupID
+	}
+	if value := c.PostForm("space_id"); value != "" {
+		spaceID, err := uuid.Parse(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid space ID"})
+			return
+		}
+		req.SpaceID = &spaceID
+	}
+
+	events, skipped, err := parseCalendar(content)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(events) == 0 && len(skipped) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Calendar file contains no events"})
+		return
+	}
+	req.Events = make([]services.CalendarEvent, len(events))
+	for i, event := range events {
+		req.Events[i] = event.CalendarEvent
+	}
+
+	result, err := h.closureService.ImportClosures(req)
+	if err != nil {
+		respondClosureError(c, err, "Failed to import closures")
+		return
+	}
+
+	response := dto.ClosureImportResponseDTO{
+		Created:               toClosureResponseDTOs(result.Created),
+		Updated:               toClosureResponseDTOs(result.Updated),
+		Skipped:               skipped,
+		CancelledReservations: toReservationResponseDTOs(result.Cancelled),
+	}
+	if response.Skipped == nil {
+		response.Skipped = []dto.CalendarFileErrorDTO{}
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// toClosureRequest converts a request DTO to a service request made by actor
+func toClosureRequest(req dto.ClosureRequestDTO, actor *services.Actor) services.ClosureRequest {
+	return services.ClosureRequest{
+		MapID:     req.MapID,
+		GroupID:   req.GroupID,
+		SpaceID:   req.SpaceID,
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+		Reason:    req.Reason,
+		Actor:     actor,
+	}
+}
+
+// respondClosureError maps closure service errors to HTTP responses
+func respondClosureError(c *gin.Context, err error, fallback string) {
+	if respondValidationError(c, err, "Invalid closure") {
+		return
+	}
+
+	switch {
+	case errors.Is(err, services.ErrClosureNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Closure not found"})
+	case errors.Is(err, services.ErrMapNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Map not found"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
+
+// toClosureResponseDTO converts a domain entity to a response DTO
+func toClosureResponseDTO(closure *entities.Closure) dto.ClosureResponseDTO {
+	return dto.ClosureResponseDTO{
+		ID:         closure.ID,
+		MapID:      closure.MapID,
+		GroupID:    closure.GroupID,
+		SpaceID:    closure.SpaceID,
+		StartDate:  closure.StartDate.Format("2006-01-02"),
+		EndDate:    closure.EndDate.Format("2006-01-02"),
+		StartTime:  formatClock(closure.StartTime),
+		EndTime:    formatClock(closure.EndTime),
+		Reason:     closure.Reason,
+		ExternalID: closure.ExternalID,
+		CreatedAt:  closure.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:  closure.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// toClosureResponseDTOs converts closures to response DTOs
+func toClosureResponseDTOs(closures []*entities.Closure) []dto.ClosureResponseDTO {
+	response := make([]dto.ClosureResponseDTO, len(closures))
+	for i, closure := range closures {
+		response[i] = toClosureResponseDTO(closure)
+	}
+	return response
+}
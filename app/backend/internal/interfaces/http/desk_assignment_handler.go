@@ -0,0 +1,94 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"office-reservations/internal/application/services"
+	"office-reservations/internal/domain/entities"
+	"office-reservations/internal/interfaces/dto"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DeskAssignmentHandler handles HTTP requests for automatic desk assignment
+type DeskAssignmentHandler struct {
+	assignmentService *services.DeskAssignmentService
+}
+
+// NewDeskAssignmentHandler creates a new desk assignment handler
+func NewDeskAssignmentHandler(assignmentService *services.DeskAssignmentService) *DeskAssignmentHandler {
+	return &DeskAssignmentHandler{
+		assignmentService: assignmentService,
+	}
+}
+
+// AssignDesk handles POST /api/reservations/assign-desk
+func (h *DeskAssignmentHandler) AssignDesk(c *gin.Context) {
+	var req dto.AssignDeskRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date format (use YYYY-MM-DD)"})
+		return
+	}
+
+	actor := actorFromContext(c)
+	userID, userName := bookingOwner(actor, req.UserID, req.UserName)
+	serviceReq := services.AssignDeskRequest{
+		MapID:            req.MapID,
+		UserID:           userID,
+		UserName:         userName,
+		Date:             date,
+		Notes:            req.Notes,
+		FavoriteSpaceIDs: req.FavoriteSpaceIDs,
+		Teammates:        req.Teammates,
+		Actor:            actor,
+	}
+	if req.StartTime != "" {
+		serviceReq.StartTime = &req.StartTime
+	}
+	if req.EndTime != "" {
+		serviceReq.EndTime = &req.EndTime
+	}
+	for _, t := range req.SpaceTypes {
+		serviceReq.SpaceTypes = append(serviceReq.SpaceTypes, entities.SpaceType(t))
+	}
+
+	assignment, err := h.assignmentService.AssignDesk(serviceReq)
+	if err != nil {
+		if respondPolicyViolation(c, err) {
+			return
+		}
+		switch {
+		case errors.Is(err, services.ErrDeskAlreadyBooked):
+			c.JSON(http.StatusConflict, dto.DeskAlreadyBookedResponseDTO{
+				Error:       "You already have a desk booked for this time slot",
+				Reservation: toReservationResponseDTO(assignment.Reservation),
+			})
+		case errors.Is(err, services.ErrNoDeskAvailable):
+			c.JSON(http.StatusConflict, gin.H{"error": "No desk is available for this time slot"})
+		case errors.Is(err, services.ErrMapNotFound):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Map not found"})
+		case errors.Is(err, services.ErrInvalidDeskType):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Desk types must be workstation or cubicle"})
+		case errors.Is(err, services.ErrInvalidTime):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid time format (use HH:MM)"})
+		case errors.Is(err, services.ErrStartTimeAfterEndTime):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Start time must be before end time"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to assign desk"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.DeskAssignmentResponseDTO{
+		Reservation: toReservationResponseDTO(assignment.Reservation),
+		Space:       toSpaceResponseDTO(assignment.Space),
+		Reasons:     assignment.Reasons,
+	})
+}
@@ -0,0 +1,50 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"office-reservations/internal/application/services"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Reservations, spaces and maps carry a version that is incremented by every
+// change. It is sent as the ETag of the resource, and changes may name the
+// version they are based on with If-Match, so that concurrent editors cannot
+// overwrite each other.
+
+// setETag sets the ETag header to the version of a resource
+func setETag(c *gin.Context, version int64) {
+	c.Header("ETag", strconv.Quote(strconv.FormatInt(version, 10)))
+}
+
+// ifMatch returns the version named by the If-Match header, or nil if the header
+// is absent or "*". A header that names no single version the resource could be
+// at is answered with 412 and false is returned.
+func ifMatch(c *gin.Context) (*int64, bool) {
+	value := strings.TrimSpace(c.GetHeader("If-Match"))
+	if value == "" || value == "*" {
+		return nil, true
+	}
+	// Weak tags never match, as If-Match uses the strong comparison
+	unquoted, err := strconv.Unquote(value)
+	if err == nil && strings.HasPrefix(value, `"`) {
+		if version, err := strconv.ParseInt(unquoted, 10, 64); err == nil {
+			return &version, true
+		}
+	}
+	c.JSON(http.StatusPreconditionFailed, gin.H{"error": "If-Match must be the ETag of the current version"})
+	return nil, false
+}
+
+// respondVersionMismatch writes a 412 response when err is ErrVersionMismatch.
+// It returns false if err is of any other kind.
+func respondVersionMismatch(c *gin.Context, err error, resource string) bool {
+	if !errors.Is(err, services.ErrVersionMismatch) {
+		return false
+	}
+	c.JSON(http.StatusPreconditionFailed, gin.H{"error": "The " + resource + " has been modified since it was read; reload it and try again"})
+	return true
+}
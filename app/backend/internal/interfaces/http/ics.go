@@ -0,0 +1,241 @@
+package http
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"office-reservations/internal/application/services"
+	"office-reservations/internal/interfaces/dto"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// icsDuration matches the day, week and time durations of RFC 5545
+var icsDuration = regexp.MustCompile(`^P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// icsProperty is one unfolded content line of an iCalendar file
+type icsProperty struct {
+	Name   string
+	Params map[string]string
+	Value  string
+	Line   int
+}
+
+// calendarEvent is a parsed VEVENT and the line it starts on
+type calendarEvent struct {
+	services.CalendarEvent
+	Line int
+}
+
+// parseCalendar reads the events of an iCalendar file. Events that cannot be
+// imported, such as recurring or cancelled events, are returned as skipped.
+func parseCalendar(content []byte) ([]calendarEvent, []dto.CalendarFileErrorDTO, error) {
+	properties := unfoldCalendar(content)
+	if len(properties) == 0 || properties[0].Name != "BEGIN" || !strings.EqualFold(properties[0].Value, "VCALENDAR") {
+		return nil, nil, errors.New("file is not an iCalendar file (expected BEGIN:VCALENDAR)")
+	}
+
+	var events []calendarEvent
+	var skipped []dto.CalendarFileErrorDTO
+	var current []icsProperty
+	var start int
+	depth := 0
+	for _, p := range properties {
+		switch {
+		case p.Name == "BEGIN":
+			depth++
+			if depth == 2 && strings.EqualFold(p.Value, "VEVENT") {
+				current, start = []icsProperty{}, p.Line
+			}
+		case p.Name == "END":
+			if depth == 2 && current != nil && strings.EqualFold(p.Value, "VEVENT") {
+				event, err := parseCalendarEvent(current)
+				if err != nil {
+					skipped = append(skipped, dto.CalendarFileErrorDTO{Line: start, UID: event.UID, Message: err.Error()})
+				} else {
+					events = append(events, calendarEvent{CalendarEvent: event, Line: start})
+				}
+				current = nil
+			}
+			depth--
+		case depth == 2 && current != nil:
+			// Properties of nested components such as VALARM are ignored
+			current = append(current, p)
+		}
+	}
+	return events, skipped, nil
+}
+
+// unfoldCalendar splits an iCalendar file into content lines, joining folded lines
+func unfoldCalendar(content []byte) []icsProperty {
+	content = bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))
+	lines := strings.Split(strings.ReplaceAll(string(content), "\r\n", "\n"), "\n")
+
+	var properties []icsProperty
+	var raw strings.Builder
+	startLine := 0
+	flush := func() {
+		if raw.Len() > 0 {
+			if p, ok := parseCalendarLine(raw.String()); ok {
+				p.Line = startLine
+				properties = append(properties, p)
+			}
+			raw.Reset()
+		}
+	}
+	for i, line := range lines {
+		line = strings.TrimSuffix(line, "\r")
+		if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+			raw.WriteString(line[1:])
+			continue
+		}
+		flush()
+		raw.WriteString(line)
+		startLine = i + 1
+	}
+	flush()
+	return properties
+}
+
+// parseCalendarLine splits a content line into its name, parameters and value
+func parseCalendarLine(line string) (icsProperty, bool) {
+	// The value starts at the first colon outside a quoted parameter value
+	quoted := false
+	colon := -1
+	for i, r := range line {
+		if r == '"' {
+			quoted = !quoted
+		} else if r == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return icsProperty{}, false
+	}
+
+	parts := strings.Split(line[:colon], ";")
+	p := icsProperty{
+		Name:   strings.ToUpper(strings.TrimSpace(parts[0])),
+		Params: make(map[string]string, len(parts)-1),
+		Value:  line[colon+1:],
+	}
+	for _, param := range parts[1:] {
+		if key, value, ok := strings.Cut(param, "="); ok {
+			p.Params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+		}
+	}
+	return p, true
+}
+
+// parseCalendarEvent converts the properties of a VEVENT to an event
+func parseCalendarEvent(properties []icsProperty) (services.CalendarEvent, error) {
+	var event services.CalendarEvent
+	var dtstart, dtend, duration *icsProperty
+	recurring, cancelled := false, false
+	for i := range properties {
+		p := &properties[i]
+		switch p.Name {
+		case "UID":
+			event.UID = strings.TrimSpace(p.Value)
+		case "SUMMARY":
+			event.Summary = unescapeCalendarText(p.Value)
+		case "DTSTART":
+			dtstart = p
+		case "DTEND":
+			dtend = p
+		case "DURATION":
+			duration = p
+		case "RRULE", "RDATE", "RECURRENCE-ID":
+			recurring = true
+		case "STATUS":
+			cancelled = strings.EqualFold(strings.TrimSpace(p.Value), "CANCELLED")
+		}
+	}
+
+	switch {
+	case event.UID == "":
+		return event, errors.New("event has no UID")
+	case recurring:
+		return event, errors.New("recurring events are not supported")
+	case cancelled:
+		return event, errors.New("event is cancelled")
+	case dtstart == nil:
+		return event, errors.New("event has no DTSTART")
+	}
+
+	var err error
+	event.Start, event.AllDay, event.Floating, err = parseCalendarTime(dtstart)
+	if err != nil {
+		return event, fmt.Errorf("invalid DTSTART: %v", err)
+	}
+
+	switch {
+	case dtend != nil:
+		event.End, _, _, err = parseCalendarTime(dtend)
+		if err != nil {
+			return event, fmt.Errorf("invalid DTEND: %v", err)
+		}
+	case duration != nil:
+		length, err := parseCalendarDuration(duration.Value)
+		if err != nil {
+			return event, fmt.Errorf("invalid DURATION: %v", err)
+		}
+		event.End = event.Start.Add(length)
+	case event.AllDay:
+		// An all-day event without an end lasts one day
+		event.End = event.Start.AddDate(0, 0, 1)
+	}
+	if !event.End.IsZero() && event.End.Before(event.Start) {
+		return event, errors.New("event ends before it starts")
+	}
+	return event, nil
+}
+
+// parseCalendarTime parses a DATE or DATE-TIME value. Times in UTC or with a
+// TZID are returned as instants; floating times keep their wall clock in UTC.
+func parseCalendarTime(p *icsProperty) (t time.Time, allDay bool, floating bool, err error) {
+	value := strings.TrimSpace(p.Value)
+	if strings.EqualFold(p.Params["VALUE"], "DATE") || len(value) == len("20060102") {
+		t, err = time.Parse("20060102", value)
+		return t, true, false, err
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err = time.Parse("20060102T150405Z", value)
+		return t, false, false, err
+	}
+	if tzid := p.Params["TZID"]; tzid != "" {
+		loc, err := time.LoadLocation(tzid)
+		if err != nil {
+			return time.Time{}, false, false, fmt.Errorf("unknown time zone %q", tzid)
+		}
+		t, err = time.ParseInLocation("20060102T150405", value, loc)
+		return t, false, false, err
+	}
+	t, err = time.Parse("20060102T150405", value)
+	return t, false, true, err
+}
+
+// parseCalendarDuration parses a duration such as P1D or PT2H30M
+func parseCalendarDuration(value string) (time.Duration, error) {
+	match := icsDuration.FindStringSubmatch(strings.TrimPrefix(strings.TrimSpace(value), "+"))
+	if match == nil || value == "P" || value == "PT" {
+		return 0, fmt.Errorf("%q is not a duration", value)
+	}
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var length time.Duration
+	for i, unit := range units {
+		if match[i+1] != "" {
+			n, _ := strconv.Atoi(match[i+1])
+			length += time.Duration(n) * unit
+		}
+	}
+	return length, nil
+}
+
+// unescapeCalendarText decodes the escaped characters of a TEXT value
+func unescapeCalendarText(value string) string {
+	return strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(strings.TrimSpace(value))
+}
@@ -0,0 +1,120 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"office-reservations/internal/domain/repositories"
+	"office-reservations/internal/interfaces/dto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultPageLimit = 100
+	maxPageLimit     = 500
+)
+
+// pageRequest parses the limit, cursor, sort and order query parameters of a
+// listing sorted by defaultSort unless requested otherwise, responding with an
+// error if any is invalid
+func pageRequest(c *gin.Context, defaultSort string) (repositories.PageRequest, bool) {
+	page := repositories.PageRequest{
+		Limit:  defaultPageLimit,
+		Cursor: strings.TrimSpace(c.Query("cursor")),
+		Sort:   strings.TrimSpace(c.DefaultQuery("sort", defaultSort)),
+	}
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > maxPageLimit {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit (use 1 to " + strconv.Itoa(maxPageLimit) + ")"})
+			return page, false
+		}
+		page.Limit = limit
+	}
+	switch c.DefaultQuery("order", "asc") {
+	case "asc":
+	case "desc":
+		page.Descending = true
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order (use asc or desc)"})
+		return page, false
+	}
+	return page, true
+}
+
+// respondPageError writes a 400 response for an invalid cursor or sort field and
+// returns true, or returns false if err is of any other kind
+func respondPageError(c *gin.Context, err error, sorts string) bool {
+	switch {
+	case errors.Is(err, repositories.ErrInvalidCursor):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cursor; it must come from a response with the same sort and order"})
+	case errors.Is(err, repositories.ErrInvalidSort):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid sort (use " + sorts + ")"})
+	default:
+		return false
+	}
+	return true
+}
+
+// toPageResponseDTO wraps the items of a page with its pagination metadata
+func toPageResponseDTO(data interface{}, page repositories.PageRequest, info repositories.PageInfo) dto.PageResponseDTO {
+	response := dto.PageResponseDTO{
+		Data: data,
+		Pagination: dto.PaginationDTO{
+			Limit:   page.Limit,
+			Sort:    page.Sort,
+			Order:   "asc",
+			HasMore: info.HasMore(),
+		},
+	}
+	if page.Descending {
+		response.Pagination.Order = "desc"
+	}
+	if info.HasMore() {
+		cursor := info.NextCursor
+		response.Pagination.NextCursor = &cursor
+	}
+	return response
+}
+
+// parseTimeBound parses an RFC 3339 time or a UTC date. A date that ends a range
+// stands for the last moment of that day.
+func parseTimeBound(value string, endOfDay bool) (time.Time, bool) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, true
+	}
+	day, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	if endOfDay {
+		return day.AddDate(0, 0, 1).Add(-time.Nanosecond), true
+	}
+	return day, true
+}
+
+// createdRange parses the created_from and created_to query parameters,
+// responding with an error if either is invalid
+func createdRange(c *gin.Context) (*time.Time, *time.Time, bool) {
+	var from, to *time.Time
+	if value := c.Query("created_from"); value != "" {
+		t, ok := parseTimeBound(value, false)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'created_from' time (use RFC 3339 or YYYY-MM-DD)"})
+			return nil, nil, false
+		}
+		from = &t
+	}
+	if value := c.Query("created_to"); value != "" {
+		t, ok := parseTimeBound(value, true)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'created_to' time (use RFC 3339 or YYYY-MM-DD)"})
+			return nil, nil, false
+		}
+		to = &t
+	}
+	return from, to, true
+}
@@ -0,0 +1,229 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"office-reservations/internal/application/services"
+	"office-reservations/internal/interfaces/dto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"golang.org/x/net/websocket"
+)
+
+// Types of the stream control events sent besides the lifecycle events of a map
+const (
+	// mapEventReady follows the replayed events; its ID is where the stream
+	// resumes from
+	mapEventReady = "ready"
+	// mapEventReset tells a resuming client that it missed too many events and
+	// should reload the map; the stream then continues from its ID
+	mapEventReset = "reset"
+	// mapEventHeartbeat keeps idle WebSocket connections open. Server-Sent
+	// Events streams are sent comments instead.
+	mapEventHeartbeat = "heartbeat"
+)
+
+// MapEventsHandler streams the changes of an office map, such as reservations
+// being made or cancelled and spaces being moved, over Server-Sent Events and
+// WebSocket. Clients resume after the last event they received with the
+// Last-Event-ID header or the last_event_id query parameter.
+type MapEventsHandler struct {
+	realtimeService *services.RealtimeService
+}
+
+// NewMapEventsHandler creates a new map events handler
+func NewMapEventsHandler(realtimeService *services.RealtimeService) *MapEventsHandler {
+	return &MapEventsHandler{
+		realtimeService: realtimeService,
+	}
+}
+
+// StreamEvents handles GET /api/maps/:id/events as a Server-Sent Events stream.
+// The stream ends when the client falls behind; EventSource then reconnects
+// and resumes on its own.
+func (h *MapEventsHandler) StreamEvents(c *gin.Context) {
+	sub, ok := h.subscribe(c)
+	if !ok {
+		return
+	}
+	defer sub.Close()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	// Stops nginx from buffering the stream
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	w := c.Writer
+	for _, event := range openingEvents(sub) {
+		if err := writeServerSentEvent(w, event); err != nil {
+			return
+		}
+	}
+	w.Flush()
+
+	ctx := c.Request.Context()
+	for {
+		event, err := sub.Next(ctx, h.realtimeService.Heartbeat())
+		if err != nil {
+			return
+		}
+		if event == nil {
+			_, err = io.WriteString(w, ": heartbeat\n\n")
+		} else {
+			err = writeServerSentEvent(w, toMapEventDTO(event))
+		}
+		if err != nil {
+			return
+		}
+		w.Flush()
+	}
+}
+
+// StreamEventsWebSocket handles GET /api/maps/:id/events/ws. Every event is
+// sent as a JSON text message; messages from the client are ignored. The
+// connection is closed when the client falls behind, and the client should
+// reconnect with the ID of the last event it received.
+func (h *MapEventsHandler) StreamEventsWebSocket(c *gin.Context) {
+	sub, ok := h.subscribe(c)
+	if !ok {
+		return
+	}
+	defer sub.Close()
+
+	server := websocket.Server{
+		// Connections are authenticated with a bearer token rather than
+		// cookies, so pages of other origins cannot open one for a user
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(conn *websocket.Conn) {
+			h.streamWebSocket(conn, sub)
+		},
+	}
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+// streamWebSocket sends the events of a subscription until the client goes away
+// or falls behind
+func (h *MapEventsHandler) streamWebSocket(conn *websocket.Conn, sub *services.RealtimeSubscription) {
+	ctx, cancel := context.WithCancel(conn.Request().Context())
+	defer cancel()
+
+	// Reading is the only way to notice that the client closed the connection
+	go func() {
+		defer cancel()
+		var message []byte
+		for {
+			if err := websocket.Message.Receive(conn, &message); err != nil {
+				return
+			}
+		}
+	}()
+
+	for _, event := range openingEvents(sub) {
+		if err := websocket.JSON.Send(conn, event); err != nil {
+			return
+		}
+	}
+
+	for {
+		event, err := sub.Next(ctx, h.realtimeService.Heartbeat())
+		if err != nil {
+			return
+		}
+		message := dto.MapEventDTO{Type: mapEventHeartbeat, MapID: sub.MapID()}
+		if event != nil {
+			message = toMapEventDTO(event)
+		}
+		if err := websocket.JSON.Send(conn, message); err != nil {
+			return
+		}
+	}
+}
+
+// subscribe subscribes to the map in the path from the event the client resumes
+// from, responding with an error if it cannot
+func (h *MapEventsHandler) subscribe(c *gin.Context) (*services.RealtimeSubscription, bool) {
+	mapID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid map ID"})
+		return nil, false
+	}
+
+	var lastEventID *int64
+	value := c.GetHeader("Last-Event-ID")
+	if value == "" {
+		value = c.Query("last_event_id")
+	}
+	if value = strings.TrimSpace(value); value != "" {
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || id < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid last event ID"})
+			return nil, false
+		}
+		lastEventID = &id
+	}
+
+	sub, err := h.realtimeService.Subscribe(mapID, lastEventID)
+	if err != nil {
+		if errors.Is(err, services.ErrMapNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Map not found"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to subscribe to map events"})
+		return nil, false
+	}
+	return sub, true
+}
+
+// openingEvents returns what is sent when a stream starts: the replayed events
+// followed by a ready event, or only a reset event if too many were missed
+func openingEvents(sub *services.RealtimeSubscription) []dto.MapEventDTO {
+	cursor := sub.Cursor
+	if sub.Reset {
+		return []dto.MapEventDTO{{ID: &cursor, Type: mapEventReset, MapID: sub.MapID()}}
+	}
+
+	events := make([]dto.MapEventDTO, 0, len(sub.Replay)+1)
+	for _, event := range sub.Replay {
+		events = append(events, toMapEventDTO(event))
+	}
+	return append(events, dto.MapEventDTO{ID: &cursor, Type: mapEventReady, MapID: sub.MapID()})
+}
+
+// writeServerSentEvent writes an event in the text/event-stream format
+func writeServerSentEvent(w io.Writer, event dto.MapEventDTO) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if event.ID != nil {
+		if _, err := fmt.Fprintf(w, "id: %d\n", *event.ID); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+	return err
+}
+
+// toMapEventDTO converts a real-time event to its DTO
+func toMapEventDTO(event *services.RealtimeEvent) dto.MapEventDTO {
+	id := event.Seq
+	eventID := event.ID
+	return dto.MapEventDTO{
+		ID:        &id,
+		Type:      string(event.Type),
+		MapID:     event.MapID,
+		EventID:   &eventID,
+		Data:      event.Data,
+		CreatedAt: event.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,507 @@
+package http
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"office-reservations/internal/application/services"
+	"office-reservations/internal/domain/entities"
+	"office-reservations/internal/interfaces/dto"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// mapFileHeaders are the CSV columns, in the order used by example_map.csv
+var mapFileHeaders = []string{"id", "name", "type", "x", "y", "width", "height"}
+
+// requiredMapFileHeaders must be present in an imported CSV file
+var requiredMapFileHeaders = []string{"name", "type", "x", "y"}
+
+// defaultGrid is used for imported maps that do not define a grid
+var defaultGrid = map[string]interface{}{"width": 20, "height": 15, "cellSize": 40}
+
+// maxMapFileSize bounds the size of an uploaded map file
+const maxMapFileSize = 5 << 20
+
+// MapFileHandler handles importing and exporting office maps as CSV or JSON files
+type MapFileHandler struct {
+	mapService *services.MapService
+}
+
+// NewMapFileHandler creates a new map file handler
+func NewMapFileHandler(mapService *services.MapService) *MapFileHandler {
+	return &MapFileHandler{
+		mapService: mapService,
+	}
+}
+
+// mapDocument is the JSON map file format. The json_data wrapper returned by
+// GET /maps/:id is accepted as well as top-level grid and spaces.
+type mapDocument struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Grid        interface{}       `json:"grid,omitempty"`
+	Spaces      []json.RawMessage `json:"spaces"`
+	JSONData    *struct {
+		Grid   interface{}       `json:"grid"`
+		Spaces []json.RawMessage `json:"spaces"`
+	} `json:"json_data,omitempty"`
+}
+
+// mapFileSpace is a space row of an imported map file before validation
+type mapFileSpace struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	X      *int   `json:"x"`
+	Y      *int   `json:"y"`
+	Width  *int   `json:"width"`
+	Height *int   `json:"height"`
+}
+
+// jsonSpace is a space as stored in a map's JSON data
+type jsonSpace struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	X      int    `json:"x"`
+	Y      int    `json:"y"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+// importedMap is the result of parsing a map file
+type importedMap struct {
+	Name        string
+	Description string
+	Grid        interface{}
+	Spaces      []jsonSpace
+}
+
+// ImportMap handles POST /api/maps/import. It accepts a multipart "file" in CSV or
+// JSON format and creates a new map, or replaces the spaces of the map given by
+// "map_id". The map and its spaces are saved in one transaction.
+func (h *MapFileHandler) ImportMap(c *gin.Context) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A map file is required in the 'file' field"})
+		return
+	}
+	if fileHeader.Size > maxMapFileSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Map file is too large"})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read map file"})
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read map file"})
+		return
+	}
+
+	var parsed *importedMap
+	var rowErrors []dto.MapFileErrorDTO
+	switch detectMapFileFormat(c.PostForm("format"), fileHeader.Filename, content) {
+	case "json":
+		parsed, rowErrors, err = parseMapJSON(content)
+	case "csv":
+		parsed, rowErrors, err = parseMapCSV(content)
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported format (use csv or json)"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(rowErrors) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid map file", "errors": rowErrors})
+		return
+	}
+	if len(parsed.Spaces) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Map file contains no spaces"})
+		return
+	}
+
+	if name := c.PostForm("name"); name != "" {
+		parsed.Name = name
+	}
+	if description := c.PostForm("description"); description != "" {
+		parsed.Description = description
+	}
+	cancelReservations, _ := strconv.ParseBool(c.PostForm("cancel_reservations"))
+
+	var mapID uuid.UUID
+	creating := c.PostForm("map_id") == ""
+	if creating {
+		if parsed.Name == "" {
+			parsed.Name = strings.TrimSuffix(fileHeader.Filename, filepath.Ext(fileHeader.Filename))
+		}
+	} else {
+		mapID, err = uuid.Parse(c.PostForm("map_id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid map ID"})
+			return
+		}
+		current, err := h.mapService.GetMap(mapID)
+		if err != nil {
+			respondMapError(c, err, nil, "Failed to fetch map")
+			return
+		}
+		// CSV files carry no grid, so keep the current one
+		if parsed.Grid == nil {
+			parsed.Grid = current.JSONData["grid"]
+		}
+	}
+	if parsed.Grid == nil {
+		parsed.Grid = defaultGrid
+	}
+
+	jsonData, err := toJSONObject(map[string]interface{}{"grid": parsed.Grid, "spaces": parsed.Spaces})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON data"})
+		return
+	}
+
+	var officeMap *entities.OfficeMap
+	var summary *services.SpaceSyncSummary
+	if creating {
+		officeMap, summary, err = h.mapService.CreateMap(services.CreateMapRequest{
+			Name:        parsed.Name,
+			Description: parsed.Description,
+			JSONData:    jsonData,
+			Actor:       actorFromContext(c),
+			Reason:      auditReason(c),
+		})
+	} else {
+		officeMap, summary, err = h.mapService.UpdateMap(services.UpdateMapRequest{
+			ID:                 mapID,
+			Name:               parsed.Name,
+			Description:        parsed.Description,
+			JSONData:           jsonData,
+			CancelReservations: cancelReservations,
+			Actor:              actorFromContext(c),
+			Reason:             auditReason(c),
+		})
+	}
+	if err != nil {
+		respondMapError(c, err, summary, "Failed to import map")
+		return
+	}
+
+	status := http.StatusOK
+	if creating {
+		status = http.StatusCreated
+	}
+	c.JSON(status, toMapResponseDTO(officeMap, summary))
+}
+
+// ExportMap handles GET /api/maps/:id/export?format=csv|json
+func (h *MapFileHandler) ExportMap(c *gin.Context) {
+	mapID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid map ID"})
+		return
+	}
+
+	format := strings.ToLower(c.DefaultQuery("format", "csv"))
+	if format != "csv" && format != "json" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported format (use csv or json)"})
+		return
+	}
+
+	// Spaces are ordered by position so exports diff cleanly under version control
+	officeMap, err := h.mapService.GetMap(mapID)
+	if err != nil {
+		respondMapError(c, err, nil, "Failed to fetch map")
+		return
+	}
+
+	filename := exportFilename(officeMap.Name) + "_map." + format
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	if format == "json" {
+		grid := interface{}(defaultGrid)
+		if officeMap.JSONData["grid"] != nil {
+			grid = officeMap.JSONData["grid"]
+		}
+
+		rows := make([]jsonSpace, len(officeMap.Spaces))
+		for i, s := range officeMap.Spaces {
+			rows[i] = jsonSpace{ID: s.ID.String(), Name: s.Name, Type: string(s.Type), X: s.X, Y: s.Y, Width: s.Width, Height: s.Height}
+		}
+		c.IndentedJSON(http.StatusOK, gin.H{
+			"name":        officeMap.Name,
+			"description": officeMap.Description,
+			"grid":        grid,
+			"spaces":      rows,
+		})
+		return
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(strings.Join(mapFileHeaders, ",") + "\n")
+	for _, s := range officeMap.Spaces {
+		// Names are always quoted, as in example_map.csv
+		fmt.Fprintf(&buf, "%s,\"%s\",%s,%d,%d,%d,%d\n",
+			s.ID, strings.ReplaceAll(s.Name, `"`, `""`), s.Type, s.X, s.Y, s.Width, s.Height)
+	}
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+}
+
+// extension, or the first character of the content
+func detectMapFileFormat(explicit, filename string, content []byte) string {
+	if explicit != "" {
+		return strings.ToLower(explicit)
+	}
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return "csv"
+	case ".json":
+		return "json"
+	}
+	if trimmed := bytes.TrimSpace(content); len(trimmed) > 0 && trimmed[0] == '{' {
+		return "json"
+	}
+	return "csv"
+}
+
+// parseMapCSV parses a CSV map file with the columns of example_map.csv
+func parseMapCSV(content []byte) (*importedMap, []dto.MapFileErrorDTO, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, errors.New("CSV must contain a header row")
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	var missing []string
+	for _, name := range requiredMapFileHeaders {
+		if _, ok := columns[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, nil, fmt.Errorf("missing required headers: %s", strings.Join(missing, ", "))
+	}
+
+	var rows []mapFileSpace
+	var lines []int
+	var rowErrors []dto.MapFileErrorDTO
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				rowErrors = append(rowErrors, dto.MapFileErrorDTO{Line: parseErr.Line, Message: parseErr.Err.Error()})
+				continue
+			}
+			return nil, nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+		if len(record) != len(header) {
+			rowErrors = append(rowErrors, dto.MapFileErrorDTO{
+				Line:    line,
+				Message: fmt.Sprintf("row has %d values but expected %d", len(record), len(header)),
+			})
+			continue
+		}
+
+		value := func(column string) string {
+			if i, ok := columns[column]; ok {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		row := mapFileSpace{ID: value("id"), Name: value("name"), Type: value("type")}
+		numbersValid := true
+		for _, field := range []struct {
+			column string
+			target **int
+		}{{"x", &row.X}, {"y", &row.Y}, {"width", &row.Width}, {"height", &row.Height}} {
+			raw := value(field.column)
+			if raw == "" {
+				continue
+			}
+			n, err := strconv.Atoi(raw)
+			if err != nil {
+				rowErrors = append(rowErrors, dto.MapFileErrorDTO{Line: line, Field: field.column, Message: fmt.Sprintf("%q is not an integer", raw)})
+				numbersValid = false
+				continue
+			}
+			*field.target = &n
+		}
+		if !numbersValid {
+			continue
+		}
+		rows = append(rows, row)
+		lines = append(lines, line)
+	}
+
+	spaces, validationErrors := validateMapFileSpaces(rows, lines)
+	rowErrors = append(rowErrors, validationErrors...)
+	sort.SliceStable(rowErrors, func(i, j int) bool { return rowErrors[i].Line < rowErrors[j].Line })
+	return &importedMap{Spaces: spaces}, rowErrors, nil
+}
+
+// parseMapJSON parses a JSON map file as produced by the JSON export
+func parseMapJSON(content []byte) (*importedMap, []dto.MapFileErrorDTO, error) {
+	var doc mapDocument
+	if err := json.Unmarshal(content, &doc); err != nil {
+		return nil, nil, fmt.Errorf("invalid JSON: %v", err)
+	}
+
+	grid, rawSpaces := doc.Grid, doc.Spaces
+	if doc.JSONData != nil {
+		grid, rawSpaces = doc.JSONData.Grid, doc.JSONData.Spaces
+	}
+
+	var rows []mapFileSpace
+	var lines []int
+	var rowErrors []dto.MapFileErrorDTO
+	for i, raw := range rawSpaces {
+		var row mapFileSpace
+		if err := json.Unmarshal(raw, &row); err != nil {
+			rowErrors = append(rowErrors, dto.MapFileErrorDTO{Line: i + 1, Message: err.Error()})
+			continue
+		}
+		rows = append(rows, row)
+		lines = append(lines, i+1)
+	}
+
+	spaces, validationErrors := validateMapFileSpaces(rows, lines)
+	rowErrors = append(rowErrors, validationErrors...)
+	sort.SliceStable(rowErrors, func(i, j int) bool { return rowErrors[i].Line < rowErrors[j].Line })
+	return &importedMap{Name: doc.Name, Description: doc.Description, Grid: grid, Spaces: spaces}, rowErrors, nil
+}
+
+// validateMapFileSpaces checks imported rows against the space constraints and
+// returns them as JSON spaces. Width and height default to 1.
+func validateMapFileSpaces(rows []mapFileSpace, lines []int) ([]jsonSpace, []dto.MapFileErrorDTO) {
+	var spaces []jsonSpace
+	var rowErrors []dto.MapFileErrorDTO
+	seenIDs := make(map[string]int)
+	seenPositions := make(map[[2]int]int)
+
+	for i, row := range rows {
+		line := lines[i]
+		fail := func(field, message string) {
+			rowErrors = append(rowErrors, dto.MapFileErrorDTO{Line: line, Field: field, Message: message})
+		}
+		valid := true
+
+		if row.Name == "" {
+			fail("name", "name is required")
+			valid = false
+		}
+		if !entities.SpaceType(row.Type).IsValid() {
+			fail("type", fmt.Sprintf("invalid space type %q (use workstation, meeting_room, cubicle or invalid_space)", row.Type))
+			valid = false
+		}
+		if row.X == nil || *row.X < 0 {
+			fail("x", "x is required and must not be negative")
+			valid = false
+		}
+		if row.Y == nil || *row.Y < 0 {
+			fail("y", "y is required and must not be negative")
+			valid = false
+		}
+		width, height := 1, 1
+		if row.Width != nil {
+			width = *row.Width
+		}
+		if row.Height != nil {
+			height = *row.Height
+		}
+		if width < 1 {
+			fail("width", "width must be at least 1")
+			valid = false
+		}
+		if height < 1 {
+			fail("height", "height must be at least 1")
+			valid = false
+		}
+		if row.ID != "" {
+			if first, ok := seenIDs[row.ID]; ok {
+				fail("id", fmt.Sprintf("duplicate id %q (first used on line %d)", row.ID, first))
+				valid = false
+			} else {
+				seenIDs[row.ID] = line
+			}
+		}
+		if row.X != nil && row.Y != nil {
+			position := [2]int{*row.X, *row.Y}
+			if first, ok := seenPositions[position]; ok {
+				fail("", fmt.Sprintf("position (%d, %d) is already used on line %d", position[0], position[1], first))
+				valid = false
+			} else {
+				seenPositions[position] = line
+			}
+		}
+
+		if valid {
+			spaces = append(spaces, jsonSpace{
+				ID:     row.ID,
+				Name:   row.Name,
+				Type:   row.Type,
+				X:      *row.X,
+				Y:      *row.Y,
+				Width:  width,
+				Height: height,
+			})
+		}
+	}
+
+	return spaces, rowErrors
+}
+
+var unsafeFilenameChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// exportFilename turns a map name into a safe file name, as the map builder does
+func exportFilename(name string) string {
+	slug := strings.Trim(unsafeFilenameChars.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if slug == "" {
+		return "map"
+	}
+	return slug
+}
+
+// toJSONObject converts v to the generic form decoded JSON objects have
+func toJSONObject(v interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var object map[string]interface{}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	return object, nil
+}
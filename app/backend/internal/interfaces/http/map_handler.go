@@ -0,0 +1,386 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"office-reservations/internal/application/services"
+	"office-reservations/internal/domain/entities"
+	"office-reservations/internal/domain/repositories"
+	"office-reservations/internal/interfaces/dto"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// MapHandler handles HTTP requests for office maps
+type MapHandler struct {
+	mapService          *services.MapService
+	availabilityService *services.AvailabilityService
+}
+
+// NewMapHandler creates a new map handler
+func NewMapHandler(mapService *services.MapService, availabilityService *services.AvailabilityService) *MapHandler {
+	return &MapHandler{
+		mapService:          mapService,
+		availabilityService: availabilityService,
+	}
+}
+
+// GetMaps handles GET /api/maps?name=&created_from=&created_to= with the
+// pagination parameters limit, cursor, sort and order. The spaces of the maps
+// are left out; GET /api/maps/:id includes them.
+func (h *MapHandler) GetMaps(c *gin.Context) {
+	filters := repositories.MapFilters{}
+	if name := strings.TrimSpace(c.Query("name")); name != "" {
+		filters.NameSearch = &name
+	}
+
+	var ok bool
+	if filters.CreatedFrom, filters.CreatedTo, ok = createdRange(c); !ok {
+		return
+	}
+	page, ok := pageRequest(c, "name")
+	if !ok {
+		return
+	}
+
+	maps, info, err := h.mapService.GetMaps(filters, page)
+	if err != nil {
+		if respondPageError(c, err, "name, created_at or updated_at") {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch maps"})
+		return
+	}
+
+	response := make([]dto.MapResponseDTO, len(maps))
+	for i, m := range maps {
+		response[i] = toMapResponseDTO(m, nil)
+	}
+
+	c.JSON(http.StatusOK, toPageResponseDTO(response, page, info))
+}
+
+// GetMap handles GET /api/maps/:id
+func (h *MapHandler) GetMap(c *gin.Context) {
+	mapID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid map ID"})
+		return
+	}
+
+	officeMap, err := h.mapService.GetMap(mapID)
+	if err != nil {
+		respondMapError(c, err, nil, "Failed to fetch map")
+		return
+	}
+
+	setETag(c, officeMap.Version)
+	c.JSON(http.StatusOK, toMapResponseDTO(officeMap, nil))
+}
+
+// CreateMap handles POST /api/maps
+func (h *MapHandler) CreateMap(c *gin.Context) {
+	var req dto.CreateMapRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	officeMap, summary, err := h.mapService.CreateMap(services.CreateMapRequest{
+		Name:         req.Name,
+		Description:  req.Description,
+		JSONData:     req.JSONData,
+		Timezone:     req.Timezone,
+		OpeningHours: toDayHours(req.OpeningHours),
+		Actor:        actorFromContext(c),
+		Reason:       auditReason(c),
+	})
+	if err != nil {
+		respondMapError(c, err, summary, "Failed to create map")
+		return
+	}
+
+	setETag(c, officeMap.Version)
+	c.JSON(http.StatusCreated, toMapResponseDTO(officeMap, summary))
+}
+
+// UpdateMap handles PUT /api/maps/:id
+func (h *MapHandler) UpdateMap(c *gin.Context) {
+	mapID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid map ID"})
+		return
+	}
+
+	var req dto.UpdateMapRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	version, ok := ifMatch(c)
+	if !ok {
+		return
+	}
+
+	officeMap, summary, err := h.mapService.UpdateMap(services.UpdateMapRequest{
+		ID:                 mapID,
+		Name:               req.Name,
+		Description:        req.Description,
+		JSONData:           req.JSONData,
+		Timezone:           req.Timezone,
+		OpeningHours:       toDayHours(req.OpeningHours),
+		CancelReservations: req.CancelReservations,
+		Version:            version,
+		Actor:              actorFromContext(c),
+		Reason:             auditReason(c),
+	})
+	if err != nil {
+		respondMapError(c, err, summary, "Failed to update map")
+		return
+	}
+
+	setETag(c, officeMap.Version)
+	c.JSON(http.StatusOK, toMapResponseDTO(officeMap, summary))
+}
+
+// DeleteMap handles DELETE /api/maps/:id
+func (h *MapHandler) DeleteMap(c *gin.Context) {
+	mapID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid map ID"})
+		return
+	}
+
+	version, ok := ifMatch(c)
+	if !ok {
+		return
+	}
+
+	if err := h.mapService.DeleteMap(mapID, version, actorFromContext(c), auditReason(c)); err != nil {
+		respondMapError(c, err, nil, "Failed to delete map")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Map deleted successfully"})
+}
+
+// GetMapAvailability handles GET /api/maps/:id/availability?date=&from=&to=&start_time=&end_time=
+func (h *MapHandler) GetMapAvailability(c *gin.Context) {
+	mapID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid map ID"})
+		return
+	}
+
+	from, to, err := parseDateRange(c.Query("date"), c.Query("from"), c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	serviceReq := services.FloorAvailabilityRequest{MapID: mapID, From: from, To: to}
+	if startTime := c.Query("start_time"); startTime != "" {
+		serviceReq.StartTime = &startTime
+	}
+	if endTime := c.Query("end_time"); endTime != "" {
+		serviceReq.EndTime = &endTime
+	}
+
+	availability, err := h.availabilityService.GetFloorAvailability(serviceReq)
+	if err != nil {
+		respondAvailabilityError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toMapAvailabilityResponseDTO(availability))
+}
+
+// respondMapError maps map service errors to HTTP responses
+func respondMapError(c *gin.Context, err error, summary *services.SpaceSyncSummary, fallback string) {
+	if respondValidationError(c, err, "Invalid map") || respondVersionMismatch(c, err, "map") {
+		return
+	}
+
+	switch {
+	case errors.Is(err, services.ErrMapNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Map not found"})
+	case errors.Is(err, services.ErrRemovedSpacesReserved):
+		c.JSON(http.StatusConflict, dto.MapSyncConflictResponseDTO{
+			Error: "Some removed spaces have upcoming reservations; set cancel_reservations to remove them anyway",
+			Sync:  toSpaceSyncSummaryDTO(summary),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
+
+// respondValidationError writes the field errors of a rejected map or space and
+// returns true, or returns false if err is not a validation error
+func respondValidationError(c *gin.Context, err error, message string) bool {
+	var validationErr *services.ValidationError
+	if !errors.As(err, &validationErr) {
+		return false
+	}
+
+	response := dto.ValidationErrorResponseDTO{
+		Error:  message,
+		Errors: make([]dto.FieldErrorDTO, len(validationErr.Errors)),
+	}
+	for i, fe := range validationErr.Errors {
+		response.Errors[i] = dto.FieldErrorDTO{Field: fe.Field, Message: fe.Message}
+	}
+	c.JSON(http.StatusBadRequest, response)
+	return true
+}
+
+// toMapResponseDTO converts a domain entity and its sync summary to a response DTO
+func toMapResponseDTO(m *entities.OfficeMap, summary *services.SpaceSyncSummary) dto.MapResponseDTO {
+	response := dto.MapResponseDTO{
+		ID:           m.ID,
+		Name:         m.Name,
+		Description:  m.Description,
+		JSONData:     m.JSONData,
+		Timezone:     m.Timezone,
+		OpeningHours: toOpeningHoursDTO(m.OpeningHours),
+		Spaces:       make([]dto.SpaceResponseDTO, len(m.Spaces)),
+		Version:      m.Version,
+		CreatedAt:    m.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    m.UpdatedAt.Format(time.RFC3339),
+		Sync:         toSpaceSyncSummaryDTO(summary),
+	}
+	for i, s := range m.Spaces {
+		response.Spaces[i] = toSpaceResponseDTO(s)
+	}
+	return response
+}
+
+// toDayHours converts requested opening hours, skipping days set to null, or
+// returns nil if none were given
+func toDayHours(days map[string]*dto.OpeningHoursDTO) map[string]services.DayHours {
+	if days == nil {
+		return nil
+	}
+	result := make(map[string]services.DayHours, len(days))
+	for name, day := range days {
+		if day != nil {
+			result[name] = services.DayHours{Open: day.Open, Close: day.Close}
+		}
+	}
+	return result
+}
+
+// toOpeningHoursDTO converts opening hours to a response DTO keyed by weekday
+// name, or nil if the office is always open
+func toOpeningHoursDTO(hours *entities.OpeningHours) map[string]dto.OpeningHoursDTO {
+	if hours == nil {
+		return nil
+	}
+	result := make(map[string]dto.OpeningHoursDTO)
+	for day, r := range hours {
+		if r != nil {
+			result[entities.WeekdayName(time.Weekday(day))] = dto.OpeningHoursDTO{
+				Open:  entities.FormatClock(r.Start),
+				Close: entities.FormatClock(r.End),
+			}
+		}
+	}
+	return result
+}
+
+// toMapAvailabilityResponseDTO converts a floor availability to a response DTO
+func toMapAvailabilityResponseDTO(a *services.FloorAvailability) dto.MapAvailabilityResponseDTO {
+	response := dto.MapAvailabilityResponseDTO{
+		MapID:     a.MapID,
+		From:      a.From.Format("2006-01-02"),
+		To:        a.To.Format("2006-01-02"),
+		StartTime: entities.FormatClock(a.Window.Start),
+		EndTime:   entities.FormatClock(a.Window.End),
+		Spaces:    make([]dto.SpaceAvailabilityDTO, len(a.Units)),
+	}
+
+	for i, unit := range a.Units {
+		space := dto.SpaceAvailabilityDTO{
+			SpaceIDs: make([]uuid.UUID, len(unit.Spaces)),
+			Type:     string(unit.Spaces[0].Type),
+			State:    string(unit.State),
+			Days:     make([]dto.DayAvailabilityDTO, len(unit.Days)),
+		}
+		for j, s := range unit.Spaces {
+			space.SpaceIDs[j] = s.ID
+		}
+		if unit.Group != nil {
+			space.GroupID = &unit.Group.ID
+			space.Name = unit.Group.Name
+		} else {
+			space.SpaceID = &unit.Space.ID
+			space.Name = unit.Space.Name
+		}
+
+		for j, day := range unit.Days {
+			dayDTO := dto.DayAvailabilityDTO{
+				Date:   day.Date.Format("2006-01-02"),
+				State:  string(day.State),
+				Busy:   make([]dto.BusyIntervalDTO, len(day.Busy)),
+				Closed: make([]dto.ClosedIntervalDTO, len(day.Closed)),
+				Free:   make([]dto.TimeIntervalDTO, len(day.Free)),
+			}
+			for k, busy := range day.Busy {
+				dayDTO.Busy[k] = dto.BusyIntervalDTO{
+					Start:          entities.FormatClock(busy.Start),
+					End:            entities.FormatClock(busy.End),
+					ReservationIDs: busy.ReservationIDs,
+				}
+			}
+			for k, closed := range day.Closed {
+				dayDTO.Closed[k] = dto.ClosedIntervalDTO{
+					Start:     entities.FormatClock(closed.Start),
+					End:       entities.FormatClock(closed.End),
+					ClosureID: closed.ClosureID,
+					Reason:    closed.Reason,
+				}
+			}
+			for k, free := range day.Free {
+				dayDTO.Free[k] = dto.TimeIntervalDTO{
+					Start: entities.FormatClock(free.Start),
+					End:   entities.FormatClock(free.End),
+				}
+			}
+			space.Days[j] = dayDTO
+		}
+		response.Spaces[i] = space
+	}
+
+	return response
+}
+
+// toSpaceSyncSummaryDTO converts a sync summary to a response DTO
+func toSpaceSyncSummaryDTO(summary *services.SpaceSyncSummary) *dto.SpaceSyncSummaryDTO {
+	if summary == nil {
+		return nil
+	}
+
+	response := &dto.SpaceSyncSummaryDTO{
+		Created:              summary.Created,
+		Updated:              summary.Updated,
+		Unchanged:            summary.Unchanged,
+		Removed:              summary.Removed,
+		AffectedReservations: make([]dto.AffectedReservationDTO, len(summary.AffectedReservations)),
+	}
+	for i, affected := range summary.AffectedReservations {
+		r := affected.Reservation
+		response.AffectedReservations[i] = dto.AffectedReservationDTO{
+			ID:        r.ID,
+			SpaceID:   r.SpaceID,
+			SpaceName: affected.SpaceName,
+			UserID:    r.UserID,
+			UserName:  r.UserName,
+			Date:      r.Date.Format("2006-01-02"),
+			StartTime: r.StartTime,
+			EndTime:   r.EndTime,
+		}
+	}
+	return response
+}
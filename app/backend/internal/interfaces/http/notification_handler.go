@@ -0,0 +1,81 @@
+package http
+
+import (
+	"net/http"
+	"office-reservations/internal/application/services"
+	"office-reservations/internal/auth"
+	"office-reservations/internal/domain/entities"
+	"office-reservations/internal/interfaces/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NotificationHandler handles HTTP requests about the caller's email notifications
+type NotificationHandler struct {
+	notificationService *services.NotificationService
+}
+
+// NewNotificationHandler creates a new notification handler
+func NewNotificationHandler(notificationService *services.NotificationService) *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: notificationService,
+	}
+}
+
+// GetPreferences handles GET /api/notifications/preferences
+func (h *NotificationHandler) GetPreferences(c *gin.Context) {
+	identity, ok := auth.IdentityFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	preferences, err := h.notificationService.GetPreferences(identity.UserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch notification preferences"})
+		return
+	}
+
+	c.JSON(http.StatusOK, h.toPreferencesResponseDTO(preferences))
+}
+
+// UpdatePreferences handles PUT /api/notifications/preferences. Omitted fields
+// keep their current value.
+func (h *NotificationHandler) UpdatePreferences(c *gin.Context) {
+	identity, ok := auth.IdentityFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	var req dto.NotificationPreferencesRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	preferences, err := h.notificationService.UpdatePreferences(identity.UserID, services.NotificationPreferencesRequest{
+		Confirmations: req.Confirmations,
+		Cancellations: req.Cancellations,
+		DayReminders:  req.DayReminders,
+		HourReminders: req.HourReminders,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update notification preferences"})
+		return
+	}
+
+	c.JSON(http.StatusOK, h.toPreferencesResponseDTO(preferences))
+}
+
+// toPreferencesResponseDTO converts a domain entity to a response DTO
+func (h *NotificationHandler) toPreferencesResponseDTO(preferences *entities.NotificationPreferences) dto.NotificationPreferencesResponseDTO {
+	return dto.NotificationPreferencesResponseDTO{
+		Email:         preferences.Email,
+		Enabled:       h.notificationService.Enabled(),
+		Confirmations: preferences.Confirmations,
+		Cancellations: preferences.Cancellations,
+		DayReminders:  preferences.DayReminders,
+		HourReminders: preferences.HourReminders,
+	}
+}
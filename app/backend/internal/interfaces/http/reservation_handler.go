@@ -1,11 +1,15 @@
 package http
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"office-reservations/internal/application/services"
+	"office-reservations/internal/auth"
 	"office-reservations/internal/domain/entities"
 	"office-reservations/internal/domain/repositories"
 	"office-reservations/internal/interfaces/dto"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -15,16 +19,25 @@ import (
 // ReservationHandler handles HTTP requests for reservations
 type ReservationHandler struct {
 	reservationService *services.ReservationService
+	checkInService     *services.CheckInService
+	spaceService       *services.SpaceService
+	guard              *auth.Guard
 }
 
 // NewReservationHandler creates a new reservation handler
-func NewReservationHandler(reservationService *services.ReservationService) *ReservationHandler {
+func NewReservationHandler(reservationService *services.ReservationService, checkInService *services.CheckInService, spaceService *services.SpaceService, guard *auth.Guard) *ReservationHandler {
 	return &ReservationHandler{
 		reservationService: reservationService,
+		checkInService:     checkInService,
+		spaceService:       spaceService,
+		guard:              guard,
 	}
 }
 
-// GetReservations handles GET /api/reservations
+// GetReservations handles GET /api/reservations?from=&to=&user_id=&user_name=&space_id=&map_id=&space_type=&status=&created_from=&created_to=
+// with the pagination parameters limit, cursor, sort and order. status is a
+// comma-separated list of statuses or "all"; only active reservations are
+// listed by default.
 func (h *ReservationHandler) GetReservations(c *gin.Context) {
 	filters := repositories.ReservationFilters{}
 
@@ -51,6 +64,10 @@ func (h *ReservationHandler) GetReservations(c *gin.Context) {
 		filters.UserID = &userID
 	}
 
+	if userName := strings.TrimSpace(c.Query("user_name")); userName != "" {
+		filters.UserNameSearch = &userName
+	}
+
 	if spaceID := c.Query("space_id"); spaceID != "" {
 		if id, err := uuid.Parse(spaceID); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid space ID"})
@@ -60,23 +77,55 @@ func (h *ReservationHandler) GetReservations(c *gin.Context) {
 		}
 	}
 
+	if mapID := c.Query("map_id"); mapID != "" {
+		if id, err := uuid.Parse(mapID); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid map ID"})
+			return
+		} else {
+			filters.MapID = &id
+		}
+	}
+
+	if value := c.Query("space_type"); value != "" {
+		spaceType := entities.SpaceType(value)
+		if !spaceType.IsValid() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid space type"})
+			return
+		}
+		filters.SpaceType = &spaceType
+	}
+
 	// Only active reservations by default
-	activeStatus := entities.ReservationStatusActive
-	filters.Status = &activeStatus
+	if value := c.DefaultQuery("status", string(entities.ReservationStatusActive)); value != "all" {
+		for _, name := range strings.Split(value, ",") {
+			status := entities.ReservationStatus(strings.TrimSpace(name))
+			if !status.IsValid() {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status (use all, or any of active, cancelled, checked_in, no_show and completed)"})
+				return
+			}
+			filters.Statuses = append(filters.Statuses, status)
+		}
+	}
 
-	reservations, err := h.reservationService.GetReservations(filters)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch reservations"})
+	var ok bool
+	if filters.CreatedFrom, filters.CreatedTo, ok = createdRange(c); !ok {
+		return
+	}
+	page, ok := pageRequest(c, "date")
+	if !ok {
 		return
 	}
 
-	// Convert to response DTOs
-	response := make([]dto.ReservationResponseDTO, len(reservations))
-	for i, r := range reservations {
-		response[i] = toReservationResponseDTO(r)
+	reservations, info, err := h.reservationService.GetReservations(filters, page)
+	if err != nil {
+		if respondPageError(c, err, "date, created_at, updated_at or user_name") {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch reservations"})
+		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	c.JSON(http.StatusOK, toPageResponseDTO(toReservationResponseDTOs(reservations), page, info))
 }
 
 // GetReservation handles GET /api/reservations/:id
@@ -98,6 +147,7 @@ func (h *ReservationHandler) GetReservation(c *gin.Context) {
 		return
 	}
 
+	setETag(c, reservation.Version)
 	c.JSON(http.StatusOK, toReservationResponseDTO(reservation))
 }
 
@@ -117,14 +167,19 @@ func (h *ReservationHandler) CreateReservation(c *gin.Context) {
 	}
 
 	// Build service request
+	actor := actorFromContext(c)
+	userID, userName := bookingOwner(actor, req.UserID, req.UserName)
 	serviceReq := services.CreateReservationRequest{
 		SpaceID:   req.SpaceID,
-		UserID:    req.UserID,
-		UserName:  req.UserName,
+		UserID:    userID,
+		UserName:  userName,
 		Date:      date,
 		StartTime: nil,
 		EndTime:   nil,
 		Notes:     req.Notes,
+		Force:     req.Force,
+		Actor:     actor,
+		Reason:    auditReason(c),
 	}
 
 	if req.StartTime != "" {
@@ -137,13 +192,14 @@ func (h *ReservationHandler) CreateReservation(c *gin.Context) {
 	// Create reservation
 	reservation, err := h.reservationService.CreateReservation(serviceReq)
 	if err != nil {
+		if respondConflict(c, err) || respondClosed(c, err) || respondPolicyViolation(c, err) {
+			return
+		}
 		switch err {
+		case services.ErrForceRequiresAdmin:
+			h.guard.Deny(c, auth.PermManageReservations, "", "Only admins can override existing reservations")
 		case services.ErrSpaceNotFound:
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Space not found"})
-		case services.ErrDateInPast:
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Cannot reserve dates in the past"})
-		case services.ErrDateTooFarInFuture:
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Cannot reserve more than 1 week in advance"})
 		case services.ErrInvalidTime:
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid time format (use HH:MM)"})
 		case services.ErrStartTimeAfterEndTime:
@@ -156,6 +212,7 @@ func (h *ReservationHandler) CreateReservation(c *gin.Context) {
 		return
 	}
 
+	setETag(c, reservation.Version)
 	c.JSON(http.StatusCreated, toReservationResponseDTO(reservation))
 }
 
@@ -173,19 +230,29 @@ func (h *ReservationHandler) UpdateReservation(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	version, ok := ifMatch(c)
+	if !ok {
+		return
+	}
 
 	// Build service request
 	serviceReq := services.UpdateReservationRequest{
-		ID: reservationID,
+		ID:      reservationID,
+		Version: version,
+		Actor:   actorFromContext(c),
+		Reason:  auditReason(c),
 	}
 
 	if req.UserName != "" {
 		serviceReq.UserName = &req.UserName
 	}
 	if req.Date != "" {
-		if date, err := time.Parse("2006-01-02", req.Date); err == nil {
-			serviceReq.Date = &date
+		date, err := time.Parse("2006-01-02", req.Date)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date format (use YYYY-MM-DD)"})
+			return
 		}
+		serviceReq.Date = &date
 	}
 	if req.StartTime != "" {
 		serviceReq.StartTime = &req.StartTime
@@ -204,19 +271,33 @@ func (h *ReservationHandler) UpdateReservation(c *gin.Context) {
 	// Update reservation
 	reservation, err := h.reservationService.UpdateReservation(serviceReq)
 	if err != nil {
+		if respondVersionMismatch(c, err, "reservation") || respondConflict(c, err) || respondClosed(c, err) || respondPolicyViolation(c, err) {
+			return
+		}
 		switch err {
 		case services.ErrReservationNotFound:
 			c.JSON(http.StatusNotFound, gin.H{"error": "Reservation not found"})
+		case services.ErrNotReservationOwner:
+			h.guard.Deny(c, auth.PermManageReservations, "", "Only the owner or an admin can change this reservation")
 		case services.ErrCannotUpdateCancelled:
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Cannot update cancelled reservation"})
+		case services.ErrInvalidStatusChange:
+			c.JSON(http.StatusConflict, gin.H{"error": "Only active or checked-in reservations can be cancelled, and only checked-in ones completed; use the check-in endpoint to check in"})
 		case services.ErrInvalidTime:
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid time format (use HH:MM)"})
+		case services.ErrStartTimeAfterEndTime:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Start time must be before end time"})
+		case services.ErrSpaceNotFound:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch space"})
+		case services.ErrReservationAlreadyExists:
+			c.JSON(http.StatusConflict, gin.H{"error": "Space is already reserved for this time slot"})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update reservation"})
 		}
 		return
 	}
 
+	setETag(c, reservation.Version)
 	c.JSON(http.StatusOK, toReservationResponseDTO(reservation))
 }
 
@@ -229,11 +310,21 @@ func (h *ReservationHandler) DeleteReservation(c *gin.Context) {
 		return
 	}
 
-	err = h.reservationService.DeleteReservation(reservationID)
+	version, ok := ifMatch(c)
+	if !ok {
+		return
+	}
+
+	err = h.reservationService.DeleteReservation(reservationID, version, actorFromContext(c), auditReason(c))
 	if err != nil {
+		if respondVersionMismatch(c, err, "reservation") {
+			return
+		}
 		switch err {
 		case services.ErrReservationNotFound:
 			c.JSON(http.StatusNotFound, gin.H{"error": "Reservation not found"})
+		case services.ErrNotReservationOwner:
+			h.guard.Deny(c, auth.PermManageReservations, "", "Only the owner or an admin can cancel this reservation")
 		case services.ErrSpaceNotFound:
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch space"})
 		default:
@@ -245,20 +336,433 @@ func (h *ReservationHandler) DeleteReservation(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Reservation cancelled successfully"})
 }
 
+// CreateReservations handles POST /api/reservations/batch
+func (h *ReservationHandler) CreateReservations(c *gin.Context) {
+	var req dto.BatchCreateReservationsRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	mode, ok := batchMode(c, req.Mode, len(req.Items))
+	if !ok {
+		return
+	}
+
+	actor := actorFromContext(c)
+	reason := auditReason(c)
+	results := make([]services.BatchResult, len(req.Items))
+	var serviceReqs []services.CreateReservationRequest
+	var indexes []int
+	for i, item := range req.Items {
+		date, err := time.Parse("2006-01-02", item.Date)
+		if err != nil {
+			results[i].Err = services.ErrInvalidDate
+			continue
+		}
+		userID, userName, err := batchBookingOwner(actor, item.UserID, item.UserName)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		serviceReq := services.CreateReservationRequest{
+			SpaceID:  item.SpaceID,
+			UserID:   userID,
+			UserName: userName,
+			Date:     date,
+			Notes:    item.Notes,
+			Force:    item.Force,
+			Actor:    actor,
+			Reason:   reason,
+		}
+		if item.StartTime != "" {
+			startTime := item.StartTime
+			serviceReq.StartTime = &startTime
+		}
+		if item.EndTime != "" {
+			endTime := item.EndTime
+			serviceReq.EndTime = &endTime
+		}
+		serviceReqs = append(serviceReqs, serviceReq)
+		indexes = append(indexes, i)
+	}
+
+	// An all-or-nothing batch with a malformed or forbidden item is refused as a whole
+	if len(serviceReqs) < len(req.Items) && mode == services.BatchAllOrNothing {
+		for i := range results {
+			if results[i].Err == nil {
+				results[i].Err = services.ErrBatchAborted
+			}
+		}
+		respondBatch(c, mode, results, http.StatusCreated)
+		return
+	}
+
+	if len(serviceReqs) > 0 {
+		created, err := h.reservationService.CreateReservations(serviceReqs, mode)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create reservations"})
+			return
+		}
+		for j, result := range created {
+			results[indexes[j]] = result
+		}
+	}
+
+	respondBatch(c, mode, results, http.StatusCreated)
+}
+
+// CancelReservations handles POST /api/reservations/batch-cancel. The request
+// names either the reservations to cancel or a filter selecting them.
+func (h *ReservationHandler) CancelReservations(c *gin.Context) {
+	var req dto.BatchCancelReservationsRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Filter != nil {
+		if len(req.Items) > 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Send either items or a filter, not both"})
+			return
+		}
+		h.cancelMatching(c, req.Filter)
+		return
+	}
+
+	mode, ok := batchMode(c, req.Mode, len(req.Items))
+	if !ok {
+		return
+	}
+
+	items := make([]services.CancelReservationItem, len(req.Items))
+	for i, item := range req.Items {
+		items[i] = services.CancelReservationItem{ID: item.ID, Version: item.Version}
+	}
+
+	results, err := h.reservationService.CancelReservations(items, mode, actorFromContext(c), auditReason(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to cancel reservations"})
+		return
+	}
+
+	respondBatch(c, mode, results, http.StatusOK)
+}
+
+// cancelMatching cancels every reservation matching a filter. It requires the
+// permission to manage the reservations of the map the filter names.
+func (h *ReservationHandler) cancelMatching(c *gin.Context, req *dto.CancelReservationsFilterDTO) {
+	filter := services.CancelFilter{SpaceID: req.SpaceID, MapID: req.MapID}
+	if req.From != "" {
+		from, err := time.Parse("2006-01-02", req.From)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'from' date format (use YYYY-MM-DD)"})
+			return
+		}
+		filter.From = &from
+	}
+	if req.To != "" {
+		to, err := time.Parse("2006-01-02", req.To)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'to' date format (use YYYY-MM-DD)"})
+			return
+		}
+		filter.To = &to
+	}
+	if filter.From != nil && filter.To != nil && filter.To.Before(*filter.From) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "'to' must not be before 'from'"})
+		return
+	}
+
+	var mapID string
+	if req.MapID != nil {
+		mapID = req.MapID.String()
+	} else if req.SpaceID != nil {
+		spaceMapID, err := h.spaceService.GetSpaceMapID(*req.SpaceID)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Space not found"})
+			return
+		}
+		mapID = spaceMapID.String()
+	} else {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "The filter requires a space_id or map_id"})
+		return
+	}
+	identity, _ := auth.IdentityFromContext(c)
+	if !auth.Allowed(identity, auth.PermManageReservations, mapID) {
+		h.guard.Deny(c, auth.PermManageReservations, mapID, "Your role does not allow this action on this office map")
+		return
+	}
+
+	cancelled, err := h.reservationService.CancelReservationsMatching(filter, actorFromContext(c), auditReason(c))
+	if err != nil {
+		switch err {
+		case services.ErrSpaceNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Space not found"})
+		case services.ErrMapNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Map not found"})
+		case services.ErrCancelFilterRequired:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "The filter requires a space_id or map_id"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to cancel reservations"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.CancelReservationsMatchingResponseDTO{
+		Cancelled:    len(cancelled),
+		Reservations: toReservationResponseDTOs(cancelled),
+	})
+}
+
+// batchMode parses the mode of a batch, all-or-nothing by default, and checks
+// its size, responding with an error if either is invalid
+func batchMode(c *gin.Context, value string, size int) (services.BatchMode, bool) {
+	mode := services.BatchAllOrNothing
+	if value != "" {
+		mode = services.BatchMode(value)
+	}
+	switch services.ValidateBatch(mode, size) {
+	case nil:
+		return mode, true
+	case services.ErrInvalidBatchMode:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid mode (use all_or_nothing or best_effort)"})
+	case services.ErrBatchEmpty:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "The batch has no items"})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("A batch may have at most %d items", services.MaxReservationBatchSize)})
+	}
+	return "", false
+}
+
+// respondBatch writes the result of each item of a batch. The response has the
+// given status when every item succeeded. Otherwise a best-effort batch is
+// answered with 207 Multi-Status, and an all-or-nothing batch with the status
+// of the item that failed it.
+func respondBatch(c *gin.Context, mode services.BatchMode, results []services.BatchResult, status int) {
+	response := dto.BatchReservationsResponseDTO{
+		Mode:  string(mode),
+		Items: make([]dto.BatchReservationItemDTO, len(results)),
+	}
+	failedStatus := 0
+	for i, result := range results {
+		if result.Err != nil {
+			item := batchItemError(result.Err)
+			item.Index = i
+			response.Items[i] = item
+			response.Failed++
+			if failedStatus == 0 && item.Status != http.StatusFailedDependency {
+				failedStatus = item.Status
+			}
+			continue
+		}
+		reservation := toReservationResponseDTO(result.Reservation)
+		response.Items[i] = dto.BatchReservationItemDTO{Index: i, Status: status, Reservation: &reservation}
+		response.Succeeded++
+	}
+
+	if response.Failed > 0 {
+		status = http.StatusMultiStatus
+		if mode == services.BatchAllOrNothing && failedStatus != 0 {
+			status = failedStatus
+		}
+	}
+	c.JSON(status, response)
+}
+
+// batchItemError describes why an item of a batch failed, with the status and
+// body it would have had as a request of its own
+func batchItemError(err error) dto.BatchReservationItemDTO {
+	var conflictErr *services.ConflictError
+	var closedErr *services.ClosureConflictError
+	var violationErr *services.PolicyViolationError
+	switch {
+	case errors.As(err, &conflictErr):
+		return dto.BatchReservationItemDTO{
+			Status:    http.StatusConflict,
+			Error:     "Space is already reserved for this time slot",
+			Conflicts: toReservationResponseDTOs(conflictErr.Conflicts),
+		}
+	case errors.As(err, &closedErr):
+		return dto.BatchReservationItemDTO{
+			Status:   http.StatusConflict,
+			Error:    "Space is closed at this time",
+			Code:     "space_closed",
+			Closures: toClosureResponseDTOs(closedErr.Closures),
+		}
+	case errors.As(err, &violationErr):
+		return dto.BatchReservationItemDTO{
+			Status:     http.StatusBadRequest,
+			Error:      violationErr.Error(),
+			Code:       "policy_violation",
+			Violations: toPolicyViolationDTOs(violationErr),
+		}
+	case errors.Is(err, services.ErrVersionMismatch):
+		return dto.BatchReservationItemDTO{Status: http.StatusPreconditionFailed, Error: "The reservation has been modified since it was read; reload it and try again"}
+	}
+
+	switch err {
+	case services.ErrBatchAborted:
+		return dto.BatchReservationItemDTO{Status: http.StatusFailedDependency, Error: "Not applied because another item of the batch failed"}
+	case services.ErrForceRequiresAdmin:
+		return dto.BatchReservationItemDTO{Status: http.StatusForbidden, Error: "Only admins can override existing reservations"}
+	case services.ErrBookForOthers:
+		return dto.BatchReservationItemDTO{Status: http.StatusForbidden, Error: "Only admins can book for other users"}
+	case services.ErrNotReservationOwner:
+		return dto.BatchReservationItemDTO{Status: http.StatusForbidden, Error: "Only the owner or an admin can cancel this reservation"}
+	case services.ErrReservationNotFound:
+		return dto.BatchReservationItemDTO{Status: http.StatusNotFound, Error: "Reservation not found"}
+	case services.ErrSpaceNotFound:
+		return dto.BatchReservationItemDTO{Status: http.StatusBadRequest, Error: "Space not found"}
+	case services.ErrInvalidDate:
+		return dto.BatchReservationItemDTO{Status: http.StatusBadRequest, Error: "Invalid date format (use YYYY-MM-DD)"}
+	case services.ErrInvalidTime:
+		return dto.BatchReservationItemDTO{Status: http.StatusBadRequest, Error: "Invalid time format (use HH:MM)"}
+	case services.ErrStartTimeAfterEndTime:
+		return dto.BatchReservationItemDTO{Status: http.StatusBadRequest, Error: "Start time must be before end time"}
+	case services.ErrReservationAlreadyExists:
+		return dto.BatchReservationItemDTO{Status: http.StatusConflict, Error: "Space is already reserved for this time slot"}
+	default:
+		return dto.BatchReservationItemDTO{Status: http.StatusInternalServerError, Error: "Failed to process this item"}
+	}
+}
+
+// CheckIn handles POST /api/reservations/:id/check-in
+func (h *ReservationHandler) CheckIn(c *gin.Context) {
+	id := c.Param("id")
+	reservationID, err := uuid.Parse(id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reservation ID"})
+		return
+	}
+
+	reservation, err := h.checkInService.CheckIn(reservationID, actorFromContext(c))
+	if err != nil {
+		if respondVersionMismatch(c, err, "reservation") {
+			return
+		}
+		switch err {
+		case services.ErrReservationNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Reservation not found"})
+		case services.ErrNotReservationOwner:
+			h.guard.Deny(c, auth.PermManageReservations, "", "Only the owner or an admin can check in")
+		case services.ErrCheckInNotAllowed:
+			c.JSON(http.StatusConflict, gin.H{"error": "Only active reservations can be checked in"})
+		case services.ErrCheckInTooEarly:
+			c.JSON(http.StatusConflict, gin.H{"error": "Check-in window has not opened yet"})
+		case services.ErrCheckInClosed:
+			c.JSON(http.StatusConflict, gin.H{"error": "Check-in window has closed"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check in"})
+		}
+		return
+	}
+
+	setETag(c, reservation.Version)
+	c.JSON(http.StatusOK, toReservationResponseDTO(reservation))
+}
+
+// respondConflict writes a 409 response listing the conflicting reservations when
+// err is a ConflictError. It returns false if err is of any other kind.
+func respondConflict(c *gin.Context, err error) bool {
+	var conflictErr *services.ConflictError
+	if !errors.As(err, &conflictErr) {
+		return false
+	}
+
+	conflicts := make([]dto.ReservationResponseDTO, len(conflictErr.Conflicts))
+	for i, r := range conflictErr.Conflicts {
+		conflicts[i] = toReservationResponseDTO(r)
+	}
+
+	c.JSON(http.StatusConflict, dto.ReservationConflictResponseDTO{
+		Error:     "Space is already reserved for this time slot",
+		Conflicts: conflicts,
+	})
+	return true
+}
+
+// respondClosed writes a 409 response listing the closures a reservation falls
+// into and returns true, or returns false if err is not a closure conflict
+func respondClosed(c *gin.Context, err error) bool {
+	var closedErr *services.ClosureConflictError
+	if !errors.As(err, &closedErr) {
+		return false
+	}
+
+	c.JSON(http.StatusConflict, dto.ClosedResponseDTO{
+		Error:    "Space is closed at this time",
+		Code:     "space_closed",
+		Closures: toClosureResponseDTOs(closedErr.Closures),
+	})
+	return true
+}
+
+// respondPolicyViolation writes the broken booking policy rules and returns
+// true, or returns false if err is not a policy violation
+func respondPolicyViolation(c *gin.Context, err error) bool {
+	var violationErr *services.PolicyViolationError
+	if !errors.As(err, &violationErr) {
+		return false
+	}
+
+	c.JSON(http.StatusBadRequest, dto.PolicyViolationResponseDTO{
+		Error:      violationErr.Error(),
+		Code:       "policy_violation",
+		Violations: toPolicyViolationDTOs(violationErr),
+	})
+	return true
+}
+
+// toPolicyViolationDTOs converts the broken rules of a policy violation to response DTOs
+func toPolicyViolationDTOs(err *services.PolicyViolationError) []dto.PolicyViolationDTO {
+	violations := make([]dto.PolicyViolationDTO, len(err.Violations))
+	for i, v := range err.Violations {
+		violations[i] = dto.PolicyViolationDTO{
+			Rule:       string(v.Rule),
+			PolicyID:   v.PolicyID,
+			PolicyName: v.PolicyName,
+			Message:    v.Message,
+		}
+	}
+	return violations
+}
+
 // toReservationResponseDTO converts a domain entity to a response DTO
 func toReservationResponseDTO(r *entities.Reservation) dto.ReservationResponseDTO {
-	return dto.ReservationResponseDTO{
-		ID:        r.ID,
-		SpaceID:   r.SpaceID,
-		UserID:    r.UserID,
-		UserName:  r.UserName,
-		Date:      r.Date.Format("2006-01-02"),
-		StartTime: r.StartTime,
-		EndTime:   r.EndTime,
-		Status:    string(r.Status),
-		Notes:     r.Notes,
-		CreatedAt: r.CreatedAt.Format(time.RFC3339),
-		UpdatedAt: r.UpdatedAt.Format(time.RFC3339),
+	response := dto.ReservationResponseDTO{
+		ID:             r.ID,
+		SpaceID:        r.SpaceID,
+		UserID:         r.UserID,
+		UserName:       r.UserName,
+		Date:           r.Date.Format("2006-01-02"),
+		StartTime:      r.StartTime,
+		EndTime:        r.EndTime,
+		Status:         string(r.Status),
+		Notes:          r.Notes,
+		SeriesID:       r.SeriesID,
+		GroupBookingID: r.GroupBookingID,
+		Version:        r.Version,
+		CreatedAt:      r.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      r.UpdatedAt.Format(time.RFC3339),
+	}
+	if r.CheckedInAt != nil {
+		checkedInAt := r.CheckedInAt.Format(time.RFC3339)
+		response.CheckedInAt = &checkedInAt
+	}
+	if r.Timezone != "" {
+		if start, end, err := r.Interval(); err == nil {
+			startsAt, endsAt := start.Format(time.RFC3339), end.Format(time.RFC3339)
+			response.Timezone = r.Timezone
+			response.StartsAt, response.EndsAt = &startsAt, &endsAt
+		}
 	}
+	return response
 }
 
+// toReservationResponseDTOs converts reservations to response DTOs
+func toReservationResponseDTOs(reservations []*entities.Reservation) []dto.ReservationResponseDTO {
+	response := make([]dto.ReservationResponseDTO, len(reservations))
+	for i, r := range reservations {
+		response[i] = toReservationResponseDTO(r)
+	}
+	return response
+}
@@ -0,0 +1,94 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"office-reservations/internal/application/services"
+	"office-reservations/internal/auth"
+	"office-reservations/internal/interfaces/dto"
+)
+
+func TestBatchBookingOwner(t *testing.T) {
+	employee := &services.Actor{UserID: "alice", UserName: "Alice"}
+	admin := &services.Actor{UserID: "admin", UserName: "Admin", Admin: true}
+
+	tests := []struct {
+		name      string
+		actor     *services.Actor
+		userID    string
+		userName  string
+		wantID    string
+		wantName  string
+		wantError error
+	}{
+		{name: "own booking", actor: employee, wantID: "alice", wantName: "Alice"},
+		{name: "naming oneself", actor: employee, userID: "alice", userName: "Someone", wantID: "alice", wantName: "Alice"},
+		{name: "naming another user", actor: employee, userID: "bob", userName: "Bob", wantError: services.ErrBookForOthers},
+		{name: "admin booking for another user", actor: admin, userID: "bob", userName: "Bob", wantID: "bob", wantName: "Bob"},
+		{name: "admin without a name", actor: admin, userID: "bob", wantID: "bob", wantName: "bob"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, name, err := batchBookingOwner(tt.actor, tt.userID, tt.userName)
+			if err != tt.wantError {
+				t.Fatalf("batchBookingOwner() error = %v, want %v", err, tt.wantError)
+			}
+			if id != tt.wantID || name != tt.wantName {
+				t.Errorf("batchBookingOwner() = %q, %q, want %q, %q", id, name, tt.wantID, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestCreateReservationsForbidsBookingForOthers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	// Forbidden items are refused before the service is called
+	handler := NewReservationHandler(nil, nil, nil, nil)
+	router := gin.New()
+	router.POST("/api/reservations/batch", func(c *gin.Context) {
+		auth.SetIdentity(c, &auth.Identity{UserID: "alice", UserName: "Alice", Roles: []string{auth.RoleEmployee}})
+	}, handler.CreateReservations)
+
+	tests := []struct {
+		name         string
+		mode         string
+		users        []string
+		wantStatus   int
+		wantStatuses []int
+	}{
+		{name: "all or nothing", mode: "all_or_nothing", users: []string{"", "bob"}, wantStatus: http.StatusForbidden, wantStatuses: []int{http.StatusFailedDependency, http.StatusForbidden}},
+		{name: "best effort", mode: "best_effort", users: []string{"bob", "carol"}, wantStatus: http.StatusMultiStatus, wantStatuses: []int{http.StatusForbidden, http.StatusForbidden}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := make([]string, len(tt.users))
+			for i, user := range tt.users {
+				items[i] = fmt.Sprintf(`{"space_id":"8c5b2f1e-3f5a-4c1e-9a57-0c3a1b2d4e5f","date":"2030-01-15","user_id":%q}`, user)
+			}
+			body := fmt.Sprintf(`{"mode":%q,"items":[%s]}`, tt.mode, strings.Join(items, ","))
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/reservations/batch", strings.NewReader(body)))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body)
+			}
+			var response dto.BatchReservationsResponseDTO
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("decoding the response: %v", err)
+			}
+			for i, item := range response.Items {
+				if item.Status != tt.wantStatuses[i] {
+					t.Errorf("item %d status = %d, want %d", i, item.Status, tt.wantStatuses[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"office-reservations/internal/application/services"
+	"office-reservations/internal/auth"
+	"office-reservations/internal/domain/entities"
+	"office-reservations/internal/interfaces/dto"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ReservationSeriesHandler handles HTTP requests for recurring reservations
+type ReservationSeriesHandler struct {
+	seriesService *services.ReservationSeriesService
+	guard         *auth.Guard
+}
+
+// NewReservationSeriesHandler creates a new reservation series handler
+func NewReservationSeriesHandler(seriesService *services.ReservationSeriesService, guard *auth.Guard) *ReservationSeriesHandler {
+	return &ReservationSeriesHandler{
+		seriesService: seriesService,
+		guard:         guard,
+	}
+}
+
+// GetSeries handles GET /api/reservation-series/:id
+func (h *ReservationSeriesHandler) GetSeries(c *gin.Context) {
+	seriesID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid series ID"})
+		return
+	}
+
+	series, err := h.seriesService.GetSeries(seriesID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Reservation series not found"})
+		return
+	}
+
+	reservations, err := h.seriesService.GetSeriesReservations(seriesID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch series reservations"})
+		return
+	}
+
+	response := toReservationSeriesResponseDTO(series, nil)
+	response.Reservations = make([]dto.ReservationResponseDTO, len(reservations))
+	for i, r := range reservations {
+		response.Reservations[i] = toReservationResponseDTO(r)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// CreateSeries handles POST /api/reservation-series
+func (h *ReservationSeriesHandler) CreateSeries(c *gin.Context) {
+	var req dto.CreateReservationSeriesRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start date format (use YYYY-MM-DD)"})
+		return
+	}
+
+	actor := actorFromContext(c)
+	userID, userName := bookingOwner(actor, req.UserID, req.UserName)
+	serviceReq := services.CreateSeriesRequest{
+		SpaceID:   req.SpaceID,
+		UserID:    userID,
+		UserName:  userName,
+		StartDate: startDate,
+		RRule:     req.RRule,
+		Notes:     req.Notes,
+		Actor:     actor,
+	}
+	if req.StartTime != "" {
+		serviceReq.StartTime = &req.StartTime
+	}
+	if req.EndTime != "" {
+		serviceReq.EndTime = &req.EndTime
+	}
+
+	series, results, err := h.seriesService.CreateSeries(serviceReq)
+	if err != nil {
+		h.respondSeriesError(c, err, "Failed to create reservation series")
+		return
+	}
+
+	c.JSON(http.StatusCreated, toReservationSeriesResponseDTO(series, results))
+}
+
+// UpdateSeries handles PUT /api/reservation-series/:id
+func (h *ReservationSeriesHandler) UpdateSeries(c *gin.Context) {
+	seriesID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid series ID"})
+		return
+	}
+
+	var req dto.UpdateReservationSeriesRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	serviceReq := services.UpdateSeriesRequest{
+		SeriesID:  seriesID,
+		Scope:     services.SeriesScope(req.Scope),
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+		Notes:     req.Notes,
+		Actor:     actorFromContext(c),
+	}
+	if req.OccurrenceDate != "" {
+		date, err := time.Parse("2006-01-02", req.OccurrenceDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid occurrence date format (use YYYY-MM-DD)"})
+			return
+		}
+		serviceReq.OccurrenceDate = &date
+	}
+
+	series, results, err := h.seriesService.UpdateSeries(serviceReq)
+	if err != nil {
+		h.respondSeriesError(c, err, "Failed to update reservation series")
+		return
+	}
+
+	c.JSON(http.StatusOK, toReservationSeriesResponseDTO(series, results))
+}
+
+// CancelSeries handles DELETE /api/reservation-series/:id?scope=&occurrence_date=
+func (h *ReservationSeriesHandler) CancelSeries(c *gin.Context) {
+	seriesID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid series ID"})
+		return
+	}
+
+	serviceReq := services.CancelSeriesRequest{
+		SeriesID: seriesID,
+		Scope:    services.SeriesScope(c.DefaultQuery("scope", string(services.SeriesScopeSeries))),
+		Actor:    actorFromContext(c),
+	}
+	if occurrenceDate := c.Query("occurrence_date"); occurrenceDate != "" {
+		date, err := time.Parse("2006-01-02", occurrenceDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid occurrence date format (use YYYY-MM-DD)"})
+			return
+		}
+		serviceReq.OccurrenceDate = &date
+	}
+
+	if err := h.seriesService.CancelSeries(serviceReq); err != nil {
+		h.respondSeriesError(c, err, "Failed to cancel reservation series")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Reservation series cancelled successfully"})
+}
+
+// respondSeriesError maps series service errors to HTTP responses
+func (h *ReservationSeriesHandler) respondSeriesError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, entities.ErrInvalidRecurrenceRule):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrSeriesNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Reservation series not found"})
+	case errors.Is(err, services.ErrNotReservationOwner):
+		h.guard.Deny(c, auth.PermManageReservations, "", "Only the owner or an admin can change this reservation series")
+	case errors.Is(err, services.ErrSeriesCancelled):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Cannot update cancelled reservation series"})
+	case errors.Is(err, services.ErrSpaceNotFound):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Space not found"})
+	case errors.Is(err, services.ErrDateInPast):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Cannot reserve dates in the past"})
+	case errors.Is(err, services.ErrInvalidTime):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid time format (use HH:MM)"})
+	case errors.Is(err, services.ErrStartTimeAfterEndTime):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Start time must be before end time"})
+	case errors.Is(err, services.ErrInvalidSeriesScope),
+		errors.Is(err, services.ErrOccurrenceNotFound),
+		errors.Is(err, services.ErrOccurrenceDateMissing):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
+
+// toReservationSeriesResponseDTO converts a domain entity and its occurrence results to a response DTO
+func toReservationSeriesResponseDTO(s *entities.ReservationSeries, results []services.OccurrenceResult) dto.ReservationSeriesResponseDTO {
+	response := dto.ReservationSeriesResponseDTO{
+		ID:        s.ID,
+		SpaceID:   s.SpaceID,
+		UserID:    s.UserID,
+		UserName:  s.UserName,
+		StartDate: s.StartDate.Format("2006-01-02"),
+		StartTime: s.StartTime,
+		EndTime:   s.EndTime,
+		Notes:     s.Notes,
+		Status:    string(s.Status),
+		CreatedAt: s.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: s.UpdatedAt.Format(time.RFC3339),
+	}
+	if s.Rule != nil {
+		response.RRule = s.Rule.String()
+	}
+
+	for _, r := range results {
+		occurrence := dto.OccurrenceResultDTO{
+			Date:   r.Date.Format("2006-01-02"),
+			Status: string(r.Status),
+		}
+		if r.Reservation != nil {
+			reservation := toReservationResponseDTO(r.Reservation)
+			occurrence.Reservation = &reservation
+		}
+		for _, conflict := range r.Conflicts {
+			occurrence.Conflicts = append(occurrence.Conflicts, toReservationResponseDTO(conflict))
+		}
+		var violationErr *services.PolicyViolationError
+		if errors.As(r.Err, &violationErr) {
+			occurrence.Violations = toPolicyViolationDTOs(violationErr)
+		}
+		if r.Err != nil {
+			occurrence.Error = r.Err.Error()
+		}
+		response.Occurrences = append(response.Occurrences, occurrence)
+	}
+
+	return response
+}
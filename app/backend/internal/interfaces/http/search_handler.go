@@ -0,0 +1,140 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"office-reservations/internal/application/services"
+	"office-reservations/internal/domain/entities"
+	"office-reservations/internal/interfaces/dto"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// SearchHandler handles HTTP requests for finding free spaces
+type SearchHandler struct {
+	availabilityService *services.AvailabilityService
+}
+
+// NewSearchHandler creates a new search handler
+func NewSearchHandler(availabilityService *services.AvailabilityService) *SearchHandler {
+	return &SearchHandler{
+		availabilityService: availabilityService,
+	}
+}
+
+// SearchMeetingRooms handles POST /api/search/meeting-rooms
+func (h *SearchHandler) SearchMeetingRooms(c *gin.Context) {
+	var req dto.MeetingRoomSearchRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	from, to, err := parseDateRange(req.Date, req.From, req.To)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	serviceReq := services.MeetingRoomSearchRequest{
+		MapID:     req.MapID,
+		From:      from,
+		To:        to,
+		Duration:  req.DurationMinutes,
+		Attendees: req.Attendees,
+		Limit:     req.Limit,
+	}
+	if req.EarliestTime != "" {
+		serviceReq.Earliest = &req.EarliestTime
+	}
+	if req.LatestTime != "" {
+		serviceReq.Latest = &req.LatestTime
+	}
+
+	slots, err := h.availabilityService.FindMeetingRoomSlots(serviceReq)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidDuration),
+			errors.Is(err, services.ErrInvalidAttendees):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrMapNotFound):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Map not found"})
+		default:
+			respondAvailabilityError(c, err)
+		}
+		return
+	}
+
+	response := dto.MeetingRoomSearchResponseDTO{Slots: make([]dto.MeetingRoomSlotDTO, len(slots))}
+	for i, slot := range slots {
+		response.Slots[i] = toMeetingRoomSlotDTO(slot)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// parseDateRange reads either a single date or a from and to date, all in
+// YYYY-MM-DD format. Its errors are meant for the client.
+func parseDateRange(date, from, to string) (time.Time, time.Time, error) {
+	if date != "" {
+		if from != "" || to != "" {
+			return time.Time{}, time.Time{}, errors.New("Use either date or from and to")
+		}
+		from, to = date, date
+	}
+	if from == "" || to == "" {
+		return time.Time{}, time.Time{}, errors.New("Date or from and to are required (YYYY-MM-DD)")
+	}
+	fromDate, err := time.Parse("2006-01-02", from)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.New("Invalid from date format (use YYYY-MM-DD)")
+	}
+	toDate, err := time.Parse("2006-01-02", to)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.New("Invalid to date format (use YYYY-MM-DD)")
+	}
+	return fromDate, toDate, nil
+}
+
+// respondAvailabilityError maps availability service errors to HTTP responses
+func respondAvailabilityError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrMapNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Map not found"})
+	case errors.Is(err, services.ErrInvalidDateRange):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "The to date must not be before the from date"})
+	case errors.Is(err, services.ErrDateRangeTooLong):
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Date range must not exceed %d days", services.MaxAvailabilityDays)})
+	case errors.Is(err, services.ErrInvalidTime):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid time format (use HH:MM)"})
+	case errors.Is(err, services.ErrStartTimeAfterEndTime):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Start time must be before end time"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch availability"})
+	}
+}
+
+// toMeetingRoomSlotDTO converts a meeting room slot to a response DTO
+func toMeetingRoomSlotDTO(slot services.MeetingRoomSlot) dto.MeetingRoomSlotDTO {
+	response := dto.MeetingRoomSlotDTO{
+		SpaceIDs:  make([]uuid.UUID, len(slot.Room.Spaces)),
+		MapID:     slot.MapID,
+		Name:      slot.Name,
+		Capacity:  slot.Capacity,
+		Date:      slot.Date.Format("2006-01-02"),
+		StartTime: entities.FormatClock(slot.Range.Start),
+		EndTime:   entities.FormatClock(slot.Range.End),
+	}
+	for i, space := range slot.Room.Spaces {
+		response.SpaceIDs[i] = space.ID
+	}
+	if slot.Room.Group != nil {
+		response.GroupID = &slot.Room.Group.ID
+	} else {
+		response.SpaceID = &slot.Room.Space.ID
+	}
+	return response
+}
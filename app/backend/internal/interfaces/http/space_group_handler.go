@@ -0,0 +1,164 @@
+package http
+
+import (
+	"net/http"
+	"office-reservations/internal/application/services"
+	"office-reservations/internal/domain/entities"
+	"office-reservations/internal/interfaces/dto"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// SpaceGroupHandler handles HTTP requests for space groups
+type SpaceGroupHandler struct {
+	groupService *services.SpaceGroupService
+}
+
+// NewSpaceGroupHandler creates a new space group handler
+func NewSpaceGroupHandler(groupService *services.SpaceGroupService) *SpaceGroupHandler {
+	return &SpaceGroupHandler{
+		groupService: groupService,
+	}
+}
+
+// GetGroups handles GET /api/space-groups
+func (h *SpaceGroupHandler) GetGroups(c *gin.Context) {
+	var mapID *uuid.UUID
+	if value := c.Query("map_id"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid map ID"})
+			return
+		}
+		mapID = &id
+	}
+
+	groups, err := h.groupService.GetGroups(mapID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch space groups"})
+		return
+	}
+
+	response := make([]dto.SpaceGroupResponseDTO, len(groups))
+	for i, g := range groups {
+		response[i] = toSpaceGroupResponseDTO(g)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetGroup handles GET /api/space-groups/:id
+func (h *SpaceGroupHandler) GetGroup(c *gin.Context) {
+	groupID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid space group ID"})
+		return
+	}
+
+	group, err := h.groupService.GetGroup(groupID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Space group not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, toSpaceGroupResponseDTO(group))
+}
+
+// CreateGroup handles POST /api/space-groups
+func (h *SpaceGroupHandler) CreateGroup(c *gin.Context) {
+	var req dto.CreateSpaceGroupRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	group, err := h.groupService.CreateGroup(services.SpaceGroupRequest{
+		MapID:    req.MapID,
+		Name:     req.Name,
+		Capacity: req.Capacity,
+		SpaceIDs: req.SpaceIDs,
+	})
+	if err != nil {
+		respondSpaceGroupError(c, err, "Failed to create space group")
+		return
+	}
+
+	c.JSON(http.StatusCreated, toSpaceGroupResponseDTO(group))
+}
+
+// UpdateGroup handles PUT /api/space-groups/:id
+func (h *SpaceGroupHandler) UpdateGroup(c *gin.Context) {
+	groupID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid space group ID"})
+		return
+	}
+
+	var req dto.UpdateSpaceGroupRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	group, err := h.groupService.UpdateGroup(groupID, services.SpaceGroupRequest{
+		Name:     req.Name,
+		Capacity: req.Capacity,
+		SpaceIDs: req.SpaceIDs,
+	})
+	if err != nil {
+		respondSpaceGroupError(c, err, "Failed to update space group")
+		return
+	}
+
+	c.JSON(http.StatusOK, toSpaceGroupResponseDTO(group))
+}
+
+// DeleteGroup handles DELETE /api/space-groups/:id
+func (h *SpaceGroupHandler) DeleteGroup(c *gin.Context) {
+	groupID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid space group ID"})
+		return
+	}
+
+	if err := h.groupService.DeleteGroup(groupID); err != nil {
+		respondSpaceGroupError(c, err, "Failed to delete space group")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Space group deleted successfully"})
+}
+
+// respondSpaceGroupError maps space group service errors to HTTP responses
+func respondSpaceGroupError(c *gin.Context, err error, fallback string) {
+	switch err {
+	case services.ErrSpaceGroupNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Space group not found"})
+	case services.ErrSpaceNotFound:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Space not found"})
+	case services.ErrInvalidSpaceGroup,
+		services.ErrInvalidGroupCapacity,
+		services.ErrSpaceNotInMap,
+		services.ErrSpaceNotGroupable:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case services.ErrSpaceAlreadyGrouped:
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
+
+// toSpaceGroupResponseDTO converts a domain entity to a response DTO
+func toSpaceGroupResponseDTO(g *entities.SpaceGroup) dto.SpaceGroupResponseDTO {
+	return dto.SpaceGroupResponseDTO{
+		ID:        g.ID,
+		MapID:     g.MapID,
+		Name:      g.Name,
+		Capacity:  g.Capacity,
+		SpaceIDs:  g.SpaceIDs,
+		CreatedAt: g.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: g.UpdatedAt.Format(time.RFC3339),
+	}
+}
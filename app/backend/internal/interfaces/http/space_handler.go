@@ -0,0 +1,271 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"office-reservations/internal/application/services"
+	"office-reservations/internal/domain/entities"
+	"office-reservations/internal/domain/repositories"
+	"office-reservations/internal/interfaces/dto"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// SpaceHandler handles HTTP requests for spaces
+type SpaceHandler struct {
+	spaceService *services.SpaceService
+}
+
+// NewSpaceHandler creates a new space handler
+func NewSpaceHandler(spaceService *services.SpaceService) *SpaceHandler {
+	return &SpaceHandler{
+		spaceService: spaceService,
+	}
+}
+
+// GetSpaces handles GET /api/spaces?map_id=&type=&group_id=&name=&created_from=&created_to=
+// with the pagination parameters limit, cursor, sort and order
+func (h *SpaceHandler) GetSpaces(c *gin.Context) {
+	filters := repositories.SpaceFilters{}
+	if value := c.Query("map_id"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid map ID"})
+			return
+		}
+		filters.MapID = &id
+	}
+	if value := c.Query("type"); value != "" {
+		spaceType := entities.SpaceType(value)
+		if !spaceType.IsValid() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid space type"})
+			return
+		}
+		filters.Type = &spaceType
+	}
+	if value := c.Query("group_id"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid group ID"})
+			return
+		}
+		filters.GroupID = &id
+	}
+	if name := strings.TrimSpace(c.Query("name")); name != "" {
+		filters.NameSearch = &name
+	}
+
+	var ok bool
+	if filters.CreatedFrom, filters.CreatedTo, ok = createdRange(c); !ok {
+		return
+	}
+	page, ok := pageRequest(c, "name")
+	if !ok {
+		return
+	}
+
+	spaces, info, err := h.spaceService.GetSpaces(filters, page)
+	if err != nil {
+		if respondPageError(c, err, "name, type, position or created_at") {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch spaces"})
+		return
+	}
+
+	response := make([]dto.SpaceResponseDTO, len(spaces))
+	for i, s := range spaces {
+		response[i] = toSpaceResponseDTO(s)
+	}
+
+	c.JSON(http.StatusOK, toPageResponseDTO(response, page, info))
+}
+
+// GetSpace handles GET /api/spaces/:id
+func (h *SpaceHandler) GetSpace(c *gin.Context) {
+	spaceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid space ID"})
+		return
+	}
+
+	space, err := h.spaceService.GetSpace(spaceID)
+	if err != nil {
+		respondSpaceError(c, err, "Failed to fetch space")
+		return
+	}
+
+	setETag(c, space.Version)
+	c.JSON(http.StatusOK, toSpaceResponseDTO(space))
+}
+
+// CreateSpace handles POST /api/spaces
+func (h *SpaceHandler) CreateSpace(c *gin.Context) {
+	var req dto.CreateSpaceRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	space, err := h.spaceService.CreateSpace(services.CreateSpaceRequest{
+		MapID:    req.MapID,
+		Name:     req.Name,
+		Type:     entities.SpaceType(req.Type),
+		X:        req.X,
+		Y:        req.Y,
+		Width:    req.Width,
+		Height:   req.Height,
+		Capacity: req.Capacity,
+		Actor:    actorFromContext(c),
+		Reason:   auditReason(c),
+	})
+	if err != nil {
+		if errors.Is(err, services.ErrMapNotFound) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Map not found"})
+			return
+		}
+		respondSpaceError(c, err, "Failed to create space")
+		return
+	}
+
+	setETag(c, space.Version)
+	c.JSON(http.StatusCreated, toSpaceResponseDTO(space))
+}
+
+// UpdateSpace handles PUT /api/spaces/:id
+func (h *SpaceHandler) UpdateSpace(c *gin.Context) {
+	spaceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid space ID"})
+		return
+	}
+
+	var req dto.UpdateSpaceRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	version, ok := ifMatch(c)
+	if !ok {
+		return
+	}
+
+	space, err := h.spaceService.UpdateSpace(services.UpdateSpaceRequest{
+		ID:       spaceID,
+		Name:     req.Name,
+		Type:     entities.SpaceType(req.Type),
+		X:        req.X,
+		Y:        req.Y,
+		Width:    req.Width,
+		Height:   req.Height,
+		Capacity: req.Capacity,
+		Version:  version,
+		Actor:    actorFromContext(c),
+		Reason:   auditReason(c),
+	})
+	if err != nil {
+		respondSpaceError(c, err, "Failed to update space")
+		return
+	}
+
+	setETag(c, space.Version)
+	c.JSON(http.StatusOK, toSpaceResponseDTO(space))
+}
+
+// DeleteSpace handles DELETE /api/spaces/:id
+func (h *SpaceHandler) DeleteSpace(c *gin.Context) {
+	spaceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid space ID"})
+		return
+	}
+
+	version, ok := ifMatch(c)
+	if !ok {
+		return
+	}
+
+	if err := h.spaceService.DeleteSpace(spaceID, version, actorFromContext(c), auditReason(c)); err != nil {
+		respondSpaceError(c, err, "Failed to delete space")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Space deleted successfully"})
+}
+
+// GetSpaceAvailability handles GET /api/spaces/:id/availability?date=
+func (h *SpaceHandler) GetSpaceAvailability(c *gin.Context) {
+	spaceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid space ID"})
+		return
+	}
+
+	dateStr := c.Query("date")
+	if dateStr == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Date parameter is required (YYYY-MM-DD)"})
+		return
+	}
+	date, err := time.Parse("2006-01-02", dateStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date format (use YYYY-MM-DD)"})
+		return
+	}
+
+	reservations, closures, err := h.spaceService.GetAvailability(spaceID, date)
+	if err != nil {
+		respondSpaceError(c, err, "Failed to fetch reservations")
+		return
+	}
+
+	response := dto.AvailabilityResponseDTO{
+		SpaceID:      spaceID,
+		Date:         dateStr,
+		IsAvailable:  len(reservations) == 0 && len(closures) == 0,
+		Reservations: make([]dto.ReservationResponseDTO, len(reservations)),
+		Closures:     toClosureResponseDTOs(closures),
+	}
+	for i, r := range reservations {
+		response.Reservations[i] = toReservationResponseDTO(r)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// respondSpaceError maps space service errors to HTTP responses
+func respondSpaceError(c *gin.Context, err error, fallback string) {
+	if respondValidationError(c, err, "Invalid space") || respondVersionMismatch(c, err, "space") {
+		return
+	}
+
+	switch {
+	case errors.Is(err, services.ErrSpaceNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Space not found"})
+	case errors.Is(err, services.ErrMapNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Map not found"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
+
+// toSpaceResponseDTO converts a domain entity to a response DTO
+func toSpaceResponseDTO(s *entities.Space) dto.SpaceResponseDTO {
+	return dto.SpaceResponseDTO{
+		ID:        s.ID,
+		MapID:     s.MapID,
+		GroupID:   s.GroupID,
+		Name:      s.Name,
+		Type:      string(s.Type),
+		X:         s.X,
+		Y:         s.Y,
+		Width:     s.Width,
+		Height:    s.Height,
+		Capacity:  s.Capacity,
+		Version:   s.Version,
+		CreatedAt: s.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: s.UpdatedAt.Format(time.RFC3339),
+	}
+}
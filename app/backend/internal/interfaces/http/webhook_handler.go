@@ -0,0 +1,260 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"office-reservations/internal/application/services"
+	"office-reservations/internal/domain/entities"
+	"office-reservations/internal/domain/repositories"
+	"office-reservations/internal/interfaces/dto"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Bounds of the delivery log page size
+const (
+	defaultDeliveryLimit = 50
+	maxDeliveryLimit     = 200
+)
+
+// WebhookHandler handles HTTP requests for webhook subscriptions and their delivery logs
+type WebhookHandler struct {
+	webhookService *services.WebhookService
+}
+
+// NewWebhookHandler creates a new webhook handler
+func NewWebhookHandler(webhookService *services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+// GetWebhooks handles GET /api/webhooks
+func (h *WebhookHandler) GetWebhooks(c *gin.Context) {
+	webhooks, err := h.webhookService.GetWebhooks()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch webhooks"})
+		return
+	}
+
+	response := make([]dto.WebhookResponseDTO, len(webhooks))
+	for i, webhook := range webhooks {
+		response[i] = toWebhookResponseDTO(webhook)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GetWebhook handles GET /api/webhooks/:id
+func (h *WebhookHandler) GetWebhook(c *gin.Context) {
+	webhookID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid webhook ID"})
+		return
+	}
+
+	webhook, err := h.webhookService.GetWebhook(webhookID)
+	if err != nil {
+		respondWebhookError(c, err, "Failed to fetch webhook")
+		return
+	}
+
+	c.JSON(http.StatusOK, toWebhookResponseDTO(webhook))
+}
+
+// CreateWebhook handles POST /api/webhooks. The response is the only one that
+// includes the signing secret.
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	var req dto.WebhookRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	webhook, err := h.webhookService.CreateWebhook(toWebhookRequest(req))
+	if err != nil {
+		respondWebhookError(c, err, "Failed to create webhook")
+		return
+	}
+
+	response := toWebhookResponseDTO(webhook)
+	response.Secret = webhook.Secret
+	c.JSON(http.StatusCreated, response)
+}
+
+// UpdateWebhook handles PUT /api/webhooks/:id. The request replaces the whole
+// webhook, except that an omitted secret is kept.
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	webhookID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid webhook ID"})
+		return
+	}
+
+	var req dto.WebhookRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	webhook, err := h.webhookService.UpdateWebhook(webhookID, toWebhookRequest(req))
+	if err != nil {
+		respondWebhookError(c, err, "Failed to update webhook")
+		return
+	}
+
+	c.JSON(http.StatusOK, toWebhookResponseDTO(webhook))
+}
+
+// DeleteWebhook handles DELETE /api/webhooks/:id
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	webhookID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid webhook ID"})
+		return
+	}
+
+	if err := h.webhookService.DeleteWebhook(webhookID); err != nil {
+		respondWebhookError(c, err, "Failed to delete webhook")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Webhook deleted successfully"})
+}
+
+// GetDeliveries handles GET /api/webhooks/:id/deliveries?status=&limit=
+func (h *WebhookHandler) GetDeliveries(c *gin.Context) {
+	webhookID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid webhook ID"})
+		return
+	}
+
+	filters := repositories.WebhookDeliveryFilters{Limit: defaultDeliveryLimit}
+	if value := c.Query("status"); value != "" {
+		status := entities.WebhookDeliveryStatus(value)
+		switch status {
+		case entities.WebhookDeliveryPending, entities.WebhookDeliverySucceeded, entities.WebhookDeliveryFailed:
+			filters.Status = &status
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status (use pending, succeeded or failed)"})
+			return
+		}
+	}
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > maxDeliveryLimit {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit (use 1 to " + strconv.Itoa(maxDeliveryLimit) + ")"})
+			return
+		}
+		filters.Limit = limit
+	}
+
+	deliveries, err := h.webhookService.GetDeliveries(webhookID, filters)
+	if err != nil {
+		respondWebhookError(c, err, "Failed to fetch webhook deliveries")
+		return
+	}
+
+	response := make([]dto.WebhookDeliveryResponseDTO, len(deliveries))
+	for i, delivery := range deliveries {
+		response[i] = toWebhookDeliveryResponseDTO(delivery)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// Redeliver handles POST /api/webhooks/:id/deliveries/:delivery_id/redeliver.
+// The delivery is queued again as a new entry of the log and sent by the dispatcher.
+func (h *WebhookHandler) Redeliver(c *gin.Context) {
+	webhookID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid webhook ID"})
+		return
+	}
+	deliveryID, err := uuid.Parse(c.Param("delivery_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid delivery ID"})
+		return
+	}
+
+	delivery, err := h.webhookService.Redeliver(webhookID, deliveryID)
+	if err != nil {
+		respondWebhookError(c, err, "Failed to redeliver webhook delivery")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, toWebhookDeliveryResponseDTO(delivery))
+}
+
+// toWebhookRequest converts a request DTO to a service request
+func toWebhookRequest(req dto.WebhookRequestDTO) services.WebhookRequest {
+	return services.WebhookRequest{
+		URL:         req.URL,
+		Description: req.Description,
+		Events:      req.Events,
+		Secret:      req.Secret,
+		Active:      req.Active,
+	}
+}
+
+// respondWebhookError maps webhook service errors to HTTP responses
+func respondWebhookError(c *gin.Context, err error, fallback string) {
+	if respondValidationError(c, err, "Invalid webhook") {
+		return
+	}
+
+	switch {
+	case errors.Is(err, services.ErrWebhookNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Webhook not found"})
+	case errors.Is(err, services.ErrWebhookDeliveryNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Webhook delivery not found"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
+
+// toWebhookResponseDTO converts a domain entity to a response DTO without its secret
+func toWebhookResponseDTO(webhook *entities.Webhook) dto.WebhookResponseDTO {
+	events := make([]string, len(webhook.Events))
+	for i, eventType := range webhook.Events {
+		events[i] = string(eventType)
+	}
+	return dto.WebhookResponseDTO{
+		ID:          webhook.ID,
+		URL:         webhook.URL,
+		Description: webhook.Description,
+		Events:      events,
+		Active:      webhook.Active,
+		CreatedAt:   webhook.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   webhook.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// toWebhookDeliveryResponseDTO converts a domain entity to a response DTO
+func toWebhookDeliveryResponseDTO(delivery *entities.WebhookDelivery) dto.WebhookDeliveryResponseDTO {
+	response := dto.WebhookDeliveryResponseDTO{
+		ID:             delivery.ID,
+		WebhookID:      delivery.WebhookID,
+		EventID:        delivery.EventID,
+		EventType:      string(delivery.EventType),
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus,
+		LastError:      delivery.LastError,
+		RedeliveryOf:   delivery.RedeliveryOf,
+		Payload:        delivery.Payload,
+		CreatedAt:      delivery.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      delivery.UpdatedAt.Format(time.RFC3339),
+	}
+	if delivery.NextAttemptAt != nil {
+		nextAttemptAt := delivery.NextAttemptAt.Format(time.RFC3339)
+		response.NextAttemptAt = &nextAttemptAt
+	}
+	if delivery.LastAttemptAt != nil {
+		lastAttemptAt := delivery.LastAttemptAt.Format(time.RFC3339)
+		response.LastAttemptAt = &lastAttemptAt
+	}
+	return response
+}
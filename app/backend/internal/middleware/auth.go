@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"net/http"
+	"office-reservations/internal/auth"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Authenticate requires a valid bearer token and stores the caller identity in the context
+func Authenticate(verifier *auth.Verifier) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		scheme, token, ok := strings.Cut(header, " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+			c.Header("WWW-Authenticate", `Bearer realm="api"`)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+			return
+		}
+
+		identity, err := verifier.Verify(strings.TrimSpace(token))
+		if err != nil {
+			c.Header("WWW-Authenticate", `Bearer realm="api", error="invalid_token"`)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+			return
+		}
+
+		auth.SetIdentity(c, identity)
+		c.Next()
+	}
+}
+
+// TokenFromQuery accepts the bearer token in a query parameter when the request
+// has no Authorization header, for clients that cannot set headers such as
+// EventSource and browser WebSockets. It must run before Authenticate.
+func TokenFromQuery(param string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token := c.Query(param); token != "" && c.GetHeader("Authorization") == "" {
+			c.Request.Header.Set("Authorization", "Bearer "+token)
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"office-reservations/internal/auth"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// MapScope resolves the office map a request acts on. It returns an empty
+// string when the request names no map or the map cannot be determined.
+type MapScope func(c *gin.Context) string
+
+// Authorize requires the permission on every map resolved by scopes. When no
+// scope yields a map, the permission is required across all maps. It must run
+// after Authenticate.
+func Authorize(guard *auth.Guard, permission auth.Permission, scopes ...MapScope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		identity, _ := auth.IdentityFromContext(c)
+
+		var mapIDs []string
+		for _, scope := range scopes {
+			if mapID := scope(c); mapID != "" {
+				mapIDs = append(mapIDs, mapID)
+			}
+		}
+
+		if len(mapIDs) == 0 {
+			if !auth.Allowed(identity, permission, "") {
+				guard.Deny(c, permission, "", "Your role does not allow this action")
+				return
+			}
+			c.Next()
+			return
+		}
+
+		for _, mapID := range mapIDs {
+			if !auth.Allowed(identity, permission, mapID) {
+				guard.Deny(c, permission, mapID, "Your role does not allow this action on this office map")
+				return
+			}
+		}
+		c.Next()
+	}
+}
+
+// MapParam reads the map ID from a path parameter
+func MapParam(name string) MapScope {
+	return func(c *gin.Context) string {
+		return validMapID(c.Param(name))
+	}
+}
+
+// MapQuery reads the map ID from a query parameter
+func MapQuery(name string) MapScope {
+	return func(c *gin.Context) string {
+		return validMapID(c.Query(name))
+	}
+}
+
+// MapField reads the map ID from a field of the JSON or form request body. The
+// body is restored so the handler can bind it again.
+func MapField(name string) MapScope {
+	return func(c *gin.Context) string {
+		if !strings.HasPrefix(c.ContentType(), "application/json") {
+			return validMapID(c.PostForm(name))
+		}
+		if c.Request.Body == nil {
+			return ""
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		if err != nil {
+			return ""
+		}
+		var fields map[string]interface{}
+		if err := json.Unmarshal(body, &fields); err != nil {
+			return ""
+		}
+		value, _ := fields[name].(string)
+		return validMapID(value)
+	}
+}
+
+// MapOf resolves the map of the resource named by a path parameter, such as the
+// map a space belongs to
+func MapOf(param string, lookup func(id uuid.UUID) (uuid.UUID, error)) MapScope {
+	return func(c *gin.Context) string {
+		id, err := uuid.Parse(c.Param(param))
+		if err != nil {
+			return ""
+		}
+		mapID, err := lookup(id)
+		if err != nil {
+			return ""
+		}
+		return mapID.String()
+	}
+}
+
+func validMapID(value string) string {
+	id, err := uuid.Parse(strings.TrimSpace(value))
+	if err != nil {
+		return ""
+	}
+	return id.String()
+}
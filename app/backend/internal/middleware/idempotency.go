@@ -0,0 +1,165 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"net/http"
+	"office-reservations/internal/auth"
+	"office-reservations/internal/domain/entities"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// IdempotencyKeyHeader names the key a client retries a request with
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader marks responses replayed from an earlier request
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+)
+
+// replayedHeaders are the response headers stored with the response of a request
+var replayedHeaders = []string{"Content-Type", "ETag", "Location"}
+
+// IdempotencyStore remembers the requests made with an idempotency key
+type IdempotencyStore interface {
+	// Begin claims a key, returning nil if the request should run or the
+	// record of the request that already used the key
+	Begin(scope, key, requestHash string) (*entities.IdempotencyRecord, error)
+	Complete(record *entities.IdempotencyRecord) error
+	Release(scope, key string) error
+}
+
+// Idempotency makes POST requests sent with an Idempotency-Key header safe to
+// retry. The first request with a key runs and its response is stored; retries
+// with the same method, path and body are answered with that response, while
+// reusing the key for a different request is refused with 422. Keys belong to
+// the authenticated caller, so it must run after Authenticate. Server errors
+// are not stored, so those requests can be retried.
+func Idempotency(store IdempotencyStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := strings.TrimSpace(c.GetHeader(IdempotencyKeyHeader))
+		identity, ok := auth.IdentityFromContext(c)
+		if c.Request.Method != http.MethodPost || key == "" || !ok {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Idempotency-Key must be at most 255 characters"})
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Failed to read request body"})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		hash := requestHash(c.Request, body)
+
+		existing, err := store.Begin(identity.UserID, key, hash)
+		if err != nil {
+			log.Printf("Failed to claim idempotency key of %s: %v", identity.UserID, err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to check Idempotency-Key"})
+			return
+		}
+		if existing != nil {
+			replay(c, existing, hash)
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		completed := false
+		// Releases the key when the request failed or panicked
+		defer func() {
+			if completed {
+				return
+			}
+			if err := store.Release(identity.UserID, key); err != nil {
+				log.Printf("Failed to release idempotency key of %s: %v", identity.UserID, err)
+			}
+		}()
+
+		c.Next()
+
+		status := recorder.Status()
+		if status >= http.StatusInternalServerError {
+			return
+		}
+		record := &entities.IdempotencyRecord{
+			Scope:       identity.UserID,
+			Key:         key,
+			RequestHash: hash,
+			StatusCode:  status,
+			Headers:     make(map[string]string),
+			Body:        recorder.body.Bytes(),
+		}
+		for _, name := range replayedHeaders {
+			if value := recorder.Header().Get(name); value != "" {
+				record.Headers[name] = value
+			}
+		}
+		if err := store.Complete(record); err != nil {
+			log.Printf("Failed to store response for idempotency key of %s: %v", identity.UserID, err)
+			return
+		}
+		completed = true
+	}
+}
+
+// replay answers a request with the stored response of the request that used
+// its key first
+func replay(c *gin.Context, record *entities.IdempotencyRecord, hash string) {
+	if record.RequestHash != hash {
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": "Idempotency-Key was already used for a different request"})
+		return
+	}
+	if !record.Completed() {
+		c.Header("Retry-After", "1")
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": "A request with this Idempotency-Key is still in progress"})
+		return
+	}
+
+	for name, value := range record.Headers {
+		c.Header(name, value)
+	}
+	c.Header(IdempotentReplayedHeader, "true")
+	c.Status(record.StatusCode)
+	if _, err := c.Writer.Write(record.Body); err != nil {
+		log.Printf("Failed to replay response: %v", err)
+	}
+	c.Abort()
+}
+
+// requestHash identifies a request by its method, path, query and body
+func requestHash(r *http.Request, body []byte) string {
+	h := sha256.New()
+	io.WriteString(h, r.Method)
+	h.Write([]byte{0})
+	io.WriteString(h, r.URL.RequestURI())
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// responseRecorder keeps a copy of the response body while it is written
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
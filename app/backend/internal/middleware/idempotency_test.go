@@ -0,0 +1,268 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"office-reservations/internal/auth"
+	"office-reservations/internal/domain/entities"
+)
+
+// memoryIdempotencyStore keeps idempotency records in memory
+type memoryIdempotencyStore struct {
+	records map[string]*entities.IdempotencyRecord
+}
+
+func newMemoryIdempotencyStore() *memoryIdempotencyStore {
+	return &memoryIdempotencyStore{records: make(map[string]*entities.IdempotencyRecord)}
+}
+
+func (s *memoryIdempotencyStore) Begin(scope, key, requestHash string) (*entities.IdempotencyRecord, error) {
+	if record, ok := s.records[scope+"\x00"+key]; ok {
+		return record, nil
+	}
+	s.records[scope+"\x00"+key] = &entities.IdempotencyRecord{Scope: scope, Key: key, RequestHash: requestHash}
+	return nil, nil
+}
+
+func (s *memoryIdempotencyStore) Complete(record *entities.IdempotencyRecord) error {
+	s.records[record.Scope+"\x00"+record.Key] = record
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Release(scope, key string) error {
+	delete(s.records, scope+"\x00"+key)
+	return nil
+}
+
+func TestRequestHash(t *testing.T) {
+	hash := func(method, target, body string) string {
+		return requestHash(httptest.NewRequest(method, target, nil), []byte(body))
+	}
+	base := hash(http.MethodPost, "/api/reservations", `{"space_id":"a"}`)
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		same   bool
+	}{
+		{name: "same request", method: http.MethodPost, target: "/api/reservations", body: `{"space_id":"a"}`, same: true},
+		{name: "other method", method: http.MethodPut, target: "/api/reservations", body: `{"space_id":"a"}`},
+		{name: "other path", method: http.MethodPost, target: "/api/reservations/bulk", body: `{"space_id":"a"}`},
+		{name: "query", method: http.MethodPost, target: "/api/reservations?dry_run=true", body: `{"space_id":"a"}`},
+		{name: "other body", method: http.MethodPost, target: "/api/reservations", body: `{"space_id":"b"}`},
+		{name: "path and body split differently", method: http.MethodPost, target: "/api/reservations{", body: `"space_id":"a"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := hash(tt.method, tt.target, tt.body)
+			if (got == base) != tt.same {
+				t.Errorf("requestHash(%s %s %s) == base is %v, want %v", tt.method, tt.target, tt.body, got == base, tt.same)
+			}
+		})
+	}
+}
+
+func TestIdempotency(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type request struct {
+		user   string
+		method string
+		key    string
+		body   string
+	}
+	type response struct {
+		status   int
+		replayed bool
+		// call is the handler call whose response is expected, zero for
+		// responses written by the middleware
+		call int
+	}
+
+	post := func(key, body string) request {
+		return request{user: "alice", method: http.MethodPost, key: key, body: body}
+	}
+
+	tests := []struct {
+		name          string
+		handlerStatus int
+		// inProgress claims the key of alice for the first request beforehand
+		inProgress bool
+		requests   []request
+		want       []response
+	}{
+		{
+			name:          "retry is replayed",
+			handlerStatus: http.StatusCreated,
+			requests:      []request{post("k1", `{"a":1}`), post("k1", `{"a":1}`)},
+			want:          []response{{status: http.StatusCreated, call: 1}, {status: http.StatusCreated, replayed: true, call: 1}},
+		},
+		{
+			name:          "client errors are replayed",
+			handlerStatus: http.StatusConflict,
+			requests:      []request{post("k1", `{"a":1}`), post("k1", `{"a":1}`)},
+			want:          []response{{status: http.StatusConflict, call: 1}, {status: http.StatusConflict, replayed: true, call: 1}},
+		},
+		{
+			name:          "key reused for another request",
+			handlerStatus: http.StatusCreated,
+			requests:      []request{post("k1", `{"a":1}`), post("k1", `{"a":2}`)},
+			want:          []response{{status: http.StatusCreated, call: 1}, {status: http.StatusUnprocessableEntity}},
+		},
+		{
+			name:          "request in progress",
+			handlerStatus: http.StatusCreated,
+			inProgress:    true,
+			requests:      []request{post("k1", `{"a":1}`)},
+			want:          []response{{status: http.StatusConflict}},
+		},
+		{
+			name:          "server errors release the key",
+			handlerStatus: http.StatusInternalServerError,
+			requests:      []request{post("k1", `{"a":1}`), post("k1", `{"a":1}`)},
+			want:          []response{{status: http.StatusInternalServerError, call: 1}, {status: http.StatusInternalServerError, call: 2}},
+		},
+		{
+			name:          "keys belong to their user",
+			handlerStatus: http.StatusCreated,
+			requests:      []request{post("k1", `{"a":1}`), {user: "bob", method: http.MethodPost, key: "k1", body: `{"a":1}`}},
+			want:          []response{{status: http.StatusCreated, call: 1}, {status: http.StatusCreated, call: 2}},
+		},
+		{
+			name:          "requests without a key",
+			handlerStatus: http.StatusCreated,
+			requests:      []request{post("", `{"a":1}`), post("", `{"a":1}`)},
+			want:          []response{{status: http.StatusCreated, call: 1}, {status: http.StatusCreated, call: 2}},
+		},
+		{
+			name:          "other methods",
+			handlerStatus: http.StatusOK,
+			requests:      []request{{user: "alice", method: http.MethodPut, key: "k1"}, {user: "alice", method: http.MethodPut, key: "k1"}},
+			want:          []response{{status: http.StatusOK, call: 1}, {status: http.StatusOK, call: 2}},
+		},
+		{
+			name:          "anonymous requests",
+			handlerStatus: http.StatusCreated,
+			requests:      []request{{method: http.MethodPost, key: "k1"}, {method: http.MethodPost, key: "k1"}},
+			want:          []response{{status: http.StatusCreated, call: 1}, {status: http.StatusCreated, call: 2}},
+		},
+		{
+			name:          "key too long",
+			handlerStatus: http.StatusCreated,
+			requests:      []request{post(strings.Repeat("k", maxIdempotencyKeyLength+1), `{"a":1}`)},
+			want:          []response{{status: http.StatusBadRequest}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMemoryIdempotencyStore()
+			if tt.inProgress {
+				first := tt.requests[0]
+				hash := requestHash(httptest.NewRequest(first.method, "/reservations", nil), []byte(first.body))
+				store.Begin(first.user, first.key, hash)
+			}
+
+			calls := 0
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				if user := c.GetHeader("X-Test-User"); user != "" {
+					auth.SetIdentity(c, &auth.Identity{UserID: user})
+				}
+			})
+			router.Use(Idempotency(store))
+			handler := func(c *gin.Context) {
+				calls++
+				c.Header("ETag", fmt.Sprintf(`"%d"`, calls))
+				c.Header("X-Not-Replayed", "true")
+				c.JSON(tt.handlerStatus, gin.H{"call": calls})
+			}
+			router.POST("/reservations", handler)
+			router.PUT("/reservations", handler)
+
+			for i, req := range tt.requests {
+				httpReq := httptest.NewRequest(req.method, "/reservations", strings.NewReader(req.body))
+				if req.user != "" {
+					httpReq.Header.Set("X-Test-User", req.user)
+				}
+				if req.key != "" {
+					httpReq.Header.Set(IdempotencyKeyHeader, req.key)
+				}
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, httpReq)
+
+				want := tt.want[i]
+				if w.Code != want.status {
+					t.Fatalf("request %d: status = %d, want %d (body %s)", i, w.Code, want.status, w.Body.String())
+				}
+				if replayed := w.Header().Get(IdempotentReplayedHeader) == "true"; replayed != want.replayed {
+					t.Errorf("request %d: replayed = %v, want %v", i, replayed, want.replayed)
+				}
+				if want.call == 0 {
+					continue
+				}
+				if body := fmt.Sprintf(`{"call":%d}`, want.call); w.Body.String() != body {
+					t.Errorf("request %d: body = %s, want %s", i, w.Body.String(), body)
+				}
+				if etag := fmt.Sprintf(`"%d"`, want.call); w.Header().Get("ETag") != etag {
+					t.Errorf("request %d: ETag = %s, want %s", i, w.Header().Get("ETag"), etag)
+				}
+				if want.replayed && w.Header().Get("X-Not-Replayed") != "" {
+					t.Errorf("request %d: replayed a header that is not stored", i)
+				}
+			}
+			wantCalls := 0
+			for _, want := range tt.want {
+				if want.call > wantCalls {
+					wantCalls = want.call
+				}
+			}
+			if calls != wantCalls {
+				t.Errorf("handler calls = %d, want %d", calls, wantCalls)
+			}
+		})
+	}
+}
+
+func TestIdempotencyReleasesKeyOnPanic(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := newMemoryIdempotencyStore()
+
+	calls := 0
+	router := gin.New()
+	router.Use(gin.CustomRecovery(func(c *gin.Context, _ interface{}) {
+		c.AbortWithStatus(http.StatusInternalServerError)
+	}))
+	router.Use(func(c *gin.Context) {
+		auth.SetIdentity(c, &auth.Identity{UserID: "alice"})
+	})
+	router.Use(Idempotency(store))
+	router.POST("/reservations", func(c *gin.Context) {
+		calls++
+		if calls == 1 {
+			panic("handler failed")
+		}
+		c.JSON(http.StatusCreated, gin.H{"call": calls})
+	})
+
+	for i, want := range []int{http.StatusInternalServerError, http.StatusCreated} {
+		req := httptest.NewRequest(http.MethodPost, "/reservations", strings.NewReader(`{"a":1}`))
+		req.Header.Set(IdempotencyKeyHeader, "k1")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != want {
+			t.Fatalf("request %d: status = %d, want %d (body %s)", i, w.Code, want, w.Body.String())
+		}
+	}
+	if calls != 2 {
+		t.Errorf("handler calls = %d, want 2", calls)
+	}
+}
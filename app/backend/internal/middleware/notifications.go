@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"log"
+	"office-reservations/internal/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EmailRecorder stores the email address users are notified at
+type EmailRecorder interface {
+	RememberEmail(userID, email string) error
+}
+
+// RememberEmail records the email address of the authenticated caller, so that
+// they can be notified about bookings made for them. Failures are logged and
+// never fail the request.
+func RememberEmail(recorder EmailRecorder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if identity, ok := auth.IdentityFromContext(c); ok && identity.Email != "" {
+			if err := recorder.RememberEmail(identity.UserID, identity.Email); err != nil {
+				log.Printf("Failed to record email address of %s: %v", identity.UserID, err)
+			}
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireIfMatch answers requests without an If-Match header with 428
+// Precondition Required when required is set, so that clients cannot change a
+// resource without naming the version they are based on
+func RequireIfMatch(required bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if required && c.GetHeader("If-Match") == "" {
+			c.AbortWithStatusJSON(http.StatusPreconditionRequired, gin.H{"error": "If-Match header is required"})
+			return
+		}
+		c.Next()
+	}
+}
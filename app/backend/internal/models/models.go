@@ -10,112 +10,267 @@ import (
 
 // OfficeMap represents the office layout configuration
 type OfficeMap struct {
-	ID          uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
-	Name        string         `json:"name" gorm:"not null"`
-	Description string         `json:"description"`
-	JSONData    datatypes.JSON `json:"json_data" gorm:"type:jsonb;not null"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	Spaces      []Space        `json:"spaces,omitempty" gorm:"foreignKey:MapID"`
+	ID           uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	Name         string         `json:"name" gorm:"not null"`
+	Description  string         `json:"description"`
+	JSONData     datatypes.JSON `json:"json_data" gorm:"type:jsonb;not null"`
+	Timezone     string         `json:"timezone" gorm:"not null;default:'UTC'"`
+	OpeningHours datatypes.JSON `json:"opening_hours,omitempty" gorm:"type:jsonb"`
+	Version      int64          `json:"version" gorm:"not null;default:1"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	// DeletedAt is set when the map is deleted; queries leave such maps out
+	// unless Unscoped
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+	Spaces    []Space        `json:"spaces,omitempty" gorm:"foreignKey:MapID"`
 }
 
 // Space represents an individual space in the office
 type Space struct {
-	ID           uuid.UUID     `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
-	MapID        uuid.UUID     `json:"map_id" gorm:"type:uuid;not null"`
-	Name         string        `json:"name" gorm:"not null"`
-	Type         string        `json:"type" gorm:"not null;check:type IN ('workstation', 'meeting_room', 'cubicle', 'invalid_space')"`
-	X            int           `json:"x" gorm:"not null"`
-	Y            int           `json:"y" gorm:"not null"`
-	Width        int           `json:"width" gorm:"default:1"`
-	Height       int           `json:"height" gorm:"default:1"`
-	Capacity     int           `json:"capacity" gorm:"default:1"`
-	CreatedAt    time.Time     `json:"created_at"`
-	UpdatedAt    time.Time     `json:"updated_at"`
-	Map          OfficeMap     `json:"map,omitempty" gorm:"foreignKey:MapID"`
-	Reservations []Reservation `json:"reservations,omitempty" gorm:"foreignKey:SpaceID"`
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	MapID     uuid.UUID  `json:"map_id" gorm:"type:uuid;not null"`
+	GroupID   *uuid.UUID `json:"group_id,omitempty" gorm:"type:uuid;index"`
+	Name      string     `json:"name" gorm:"not null"`
+	Type      string     `json:"type" gorm:"not null;check:type IN ('workstation', 'meeting_room', 'cubicle', 'invalid_space')"`
+	X         int        `json:"x" gorm:"not null"`
+	Y         int        `json:"y" gorm:"not null"`
+	Width     int        `json:"width" gorm:"default:1"`
+	Height    int        `json:"height" gorm:"default:1"`
+	Capacity  int        `json:"capacity" gorm:"default:1"`
+	Version   int64      `json:"version" gorm:"not null;default:1"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	// DeletedAt is set when the space is removed from its map's layout; queries
+	// leave such spaces out unless Unscoped
+	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"`
+	Map          OfficeMap      `json:"map,omitempty" gorm:"foreignKey:MapID"`
+	Group        *SpaceGroup    `json:"group,omitempty" gorm:"foreignKey:GroupID;constraint:OnDelete:SET NULL"`
+	Reservations []Reservation  `json:"reservations,omitempty" gorm:"foreignKey:SpaceID"`
+}
+
+// SpaceGroup represents several spaces booked together as one unit
+type SpaceGroup struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	MapID     uuid.UUID `json:"map_id" gorm:"type:uuid;not null;index"`
+	Name      string    `json:"name" gorm:"not null"`
+	Capacity  int       `json:"capacity" gorm:"default:1"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Map       OfficeMap `json:"-" gorm:"foreignKey:MapID;constraint:OnDelete:CASCADE"`
+	Spaces    []Space   `json:"spaces,omitempty" gorm:"foreignKey:GroupID"`
 }
 
 // Reservation represents a booking for a space
 type Reservation struct {
-	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
-	SpaceID   uuid.UUID  `json:"space_id" gorm:"type:uuid;not null"`
-	UserID    string     `json:"user_id" gorm:"not null"`
-	UserName  string     `json:"user_name"`
-	Date      time.Time  `json:"date" gorm:"type:date;not null"`
-	StartTime *string `json:"start_time,omitempty" gorm:"type:time"`
-	EndTime   *string `json:"end_time,omitempty" gorm:"type:time"`
-	Status    string     `json:"status" gorm:"default:'active';check:status IN ('active', 'cancelled')"`
-	Notes     string     `json:"notes"`
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt time.Time  `json:"updated_at"`
-	Space     Space      `json:"space,omitempty" gorm:"foreignKey:SpaceID"`
-}
-
-// CreateReservationRequest represents the request payload for creating a reservation
-type CreateReservationRequest struct {
-	SpaceID   uuid.UUID `json:"space_id" binding:"required"`
-	UserID    string    `json:"user_id" binding:"required"`
-	UserName  string    `json:"user_name"`
-	Date      string    `json:"date" binding:"required"` // Format: YYYY-MM-DD
-	StartTime string    `json:"start_time,omitempty"`    // Format: HH:MM
-	EndTime   string    `json:"end_time,omitempty"`      // Format: HH:MM
-	Notes     string    `json:"notes"`
-}
-
-// UpdateReservationRequest represents the request payload for updating a reservation
-type UpdateReservationRequest struct {
-	UserName  string `json:"user_name"`
-	Date      string `json:"date"`      // Format: YYYY-MM-DD
-	StartTime string `json:"start_time"` // Format: HH:MM
-	EndTime   string `json:"end_time"`   // Format: HH:MM
-	Status    string `json:"status"`
-	Notes     string `json:"notes"`
-}
-
-// CreateMapRequest represents the request payload for creating a map
-type CreateMapRequest struct {
-	Name        string      `json:"name" binding:"required"`
-	Description string      `json:"description"`
-	JSONData    interface{} `json:"json_data" binding:"required"`
-}
-
-// UpdateMapRequest represents the request payload for updating a map
-type UpdateMapRequest struct {
-	Name        string      `json:"name"`
-	Description string      `json:"description"`
-	JSONData    interface{} `json:"json_data"`
-}
-
-// CreateSpaceRequest represents the request payload for creating a space
-type CreateSpaceRequest struct {
-	MapID    uuid.UUID `json:"map_id" binding:"required"`
-	Name     string    `json:"name" binding:"required"`
-	Type     string    `json:"type" binding:"required,oneof=workstation meeting_room cubicle invalid_space"`
-	X        int       `json:"x" binding:"required"`
-	Y        int       `json:"y" binding:"required"`
-	Width    int       `json:"width"`
-	Height   int       `json:"height"`
-	Capacity int       `json:"capacity"`
-}
-
-// UpdateSpaceRequest represents the request payload for updating a space
-type UpdateSpaceRequest struct {
-	Name     string `json:"name"`
-	Type     string `json:"type" binding:"omitempty,oneof=workstation meeting_room cubicle invalid_space"`
-	X        *int   `json:"x"`
-	Y        *int   `json:"y"`
-	Width    *int   `json:"width"`
-	Height   *int   `json:"height"`
-	Capacity *int   `json:"capacity"`
-}
-
-// AvailabilityResponse represents space availability for a specific date
-type AvailabilityResponse struct {
-	SpaceID     uuid.UUID `json:"space_id"`
-	Date        string    `json:"date"`
-	IsAvailable bool      `json:"is_available"`
-	Reservations []Reservation `json:"reservations,omitempty"`
+	ID             uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	SpaceID        uuid.UUID  `json:"space_id" gorm:"type:uuid;not null"`
+	UserID         string     `json:"user_id" gorm:"not null"`
+	UserName       string     `json:"user_name"`
+	Date           time.Time  `json:"date" gorm:"type:date;not null"`
+	StartTime      *string    `json:"start_time,omitempty" gorm:"type:time"`
+	EndTime        *string    `json:"end_time,omitempty" gorm:"type:time"`
+	Status         string     `json:"status" gorm:"default:'active';check:status IN ('active', 'cancelled', 'checked_in', 'no_show', 'completed')"`
+	Notes          string     `json:"notes"`
+	SeriesID       *uuid.UUID `json:"series_id,omitempty" gorm:"type:uuid;index"`
+	OccurrenceDate *time.Time `json:"occurrence_date,omitempty" gorm:"type:date"`
+	CheckedInAt    *time.Time `json:"checked_in_at,omitempty"`
+	GroupBookingID *uuid.UUID `json:"group_booking_id,omitempty" gorm:"type:uuid;index"`
+	Version        int64      `json:"version" gorm:"not null;default:1"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	Space          Space      `json:"space,omitempty" gorm:"foreignKey:SpaceID"`
+}
+
+// ReservationSeries represents a recurring booking
+type ReservationSeries struct {
+	ID         uuid.UUID                    `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	SpaceID    uuid.UUID                    `json:"space_id" gorm:"type:uuid;not null;index"`
+	UserID     string                       `json:"user_id" gorm:"not null"`
+	UserName   string                       `json:"user_name"`
+	StartDate  time.Time                    `json:"start_date" gorm:"type:date;not null"`
+	StartTime  *string                      `json:"start_time,omitempty" gorm:"type:time"`
+	EndTime    *string                      `json:"end_time,omitempty" gorm:"type:time"`
+	RRule      string                       `json:"rrule" gorm:"not null"`
+	Notes      string                       `json:"notes"`
+	Status     string                       `json:"status" gorm:"default:'active';check:status IN ('active', 'cancelled')"`
+	BookedBy   datatypes.JSON               `json:"booked_by,omitempty" gorm:"type:jsonb"`
+	CreatedAt  time.Time                    `json:"created_at"`
+	UpdatedAt  time.Time                    `json:"updated_at"`
+	Space      Space                        `json:"space,omitempty" gorm:"foreignKey:SpaceID;constraint:OnDelete:CASCADE"`
+	Exceptions []ReservationSeriesException `json:"exceptions,omitempty" gorm:"foreignKey:SeriesID"`
+}
+
+// ReservationSeriesException overrides or cancels a single occurrence of a series
+type ReservationSeriesException struct {
+	SeriesID       uuid.UUID         `json:"series_id" gorm:"type:uuid;primaryKey"`
+	OccurrenceDate time.Time         `json:"occurrence_date" gorm:"type:date;primaryKey"`
+	Cancelled      bool              `json:"cancelled" gorm:"not null;default:false"`
+	StartTime      *string           `json:"start_time,omitempty" gorm:"type:time"`
+	EndTime        *string           `json:"end_time,omitempty" gorm:"type:time"`
+	Notes          *string           `json:"notes,omitempty"`
+	CreatedAt      time.Time         `json:"created_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
+	Series         ReservationSeries `json:"-" gorm:"foreignKey:SeriesID;constraint:OnDelete:CASCADE"`
+}
+
+// BookingPolicy restricts the reservations that can be made on a map, a space type or by a role
+type BookingPolicy struct {
+	ID                 uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	Name               string         `json:"name" gorm:"not null"`
+	MapID              *uuid.UUID     `json:"map_id,omitempty" gorm:"type:uuid;index"`
+	SpaceType          *string        `json:"space_type,omitempty"`
+	Role               *string        `json:"role,omitempty"`
+	MaxAdvanceDays     *int           `json:"max_advance_days,omitempty"`
+	MinDurationMinutes *int           `json:"min_duration_minutes,omitempty"`
+	MaxDurationMinutes *int           `json:"max_duration_minutes,omitempty"`
+	AllowedStart       *string        `json:"allowed_start,omitempty" gorm:"type:time"`
+	AllowedEnd         *string        `json:"allowed_end,omitempty" gorm:"type:time"`
+	MaxBookingsPerWeek *int           `json:"max_bookings_per_week,omitempty"`
+	MaxConsecutiveDays *int           `json:"max_consecutive_days,omitempty"`
+	BlackoutDates      datatypes.JSON `json:"blackout_dates" gorm:"type:jsonb;not null"`
+	CreatedAt          time.Time      `json:"created_at"`
+	UpdatedAt          time.Time      `json:"updated_at"`
+	Map                *OfficeMap     `json:"-" gorm:"foreignKey:MapID;constraint:OnDelete:CASCADE"`
+}
+
+// Closure takes a map, a space group or a space out of service for a period
+type Closure struct {
+	ID         uuid.UUID   `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	MapID      uuid.UUID   `json:"map_id" gorm:"type:uuid;not null;index"`
+	GroupID    *uuid.UUID  `json:"group_id,omitempty" gorm:"type:uuid"`
+	SpaceID    *uuid.UUID  `json:"space_id,omitempty" gorm:"type:uuid"`
+	StartDate  time.Time   `json:"start_date" gorm:"type:date;not null"`
+	EndDate    time.Time   `json:"end_date" gorm:"type:date;not null"`
+	StartTime  *string     `json:"start_time,omitempty" gorm:"type:time"`
+	EndTime    *string     `json:"end_time,omitempty" gorm:"type:time"`
+	Reason     string      `json:"reason" gorm:"not null"`
+	ExternalID *string     `json:"external_id,omitempty"`
+	CreatedAt  time.Time   `json:"created_at"`
+	UpdatedAt  time.Time   `json:"updated_at"`
+	Map        OfficeMap   `json:"-" gorm:"foreignKey:MapID;constraint:OnDelete:CASCADE"`
+	Group      *SpaceGroup `json:"-" gorm:"foreignKey:GroupID;constraint:OnDelete:CASCADE"`
+	Space      *Space      `json:"-" gorm:"foreignKey:SpaceID;constraint:OnDelete:CASCADE"`
+}
+
+// CalendarFeedToken holds the hash of a user's calendar feed secret
+type CalendarFeedToken struct {
+	UserID    string    `json:"user_id" gorm:"primaryKey"`
+	TokenHash string    `json:"-" gorm:"type:char(64);not null;uniqueIndex"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Webhook subscribes an external URL to lifecycle events
+type Webhook struct {
+	ID          uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	URL         string         `json:"url" gorm:"not null"`
+	Secret      string         `json:"-" gorm:"not null"`
+	Description string         `json:"description"`
+	Events      datatypes.JSON `json:"events" gorm:"type:jsonb;not null"`
+	Active      bool           `json:"active" gorm:"not null;default:true"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+// OutboxEvent is a lifecycle event waiting to be dispatched to the webhooks
+type OutboxEvent struct {
+	ID           uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	Type         string         `json:"type" gorm:"not null"`
+	Seq          int64          `json:"seq" gorm:"->;autoIncrement;uniqueIndex"`
+	MapID        *uuid.UUID     `json:"map_id,omitempty" gorm:"type:uuid;index"`
+	Data         datatypes.JSON `json:"data" gorm:"type:jsonb;not null"`
+	CreatedAt    time.Time      `json:"created_at"`
+	DispatchedAt *time.Time     `json:"dispatched_at,omitempty"`
+}
+
+// AuditEntry is one change of a reservation, space or map in the append-only
+// audit log. It has no foreign keys so that it outlives what it describes.
+type AuditEntry struct {
+	ID         uuid.UUID       `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	EntityType string          `json:"entity_type" gorm:"not null"`
+	EntityID   uuid.UUID       `json:"entity_id" gorm:"type:uuid;not null"`
+	MapID      *uuid.UUID      `json:"map_id,omitempty" gorm:"type:uuid"`
+	Action     string          `json:"action" gorm:"not null"`
+	ActorID    string          `json:"actor_id"`
+	ActorName  string          `json:"actor_name"`
+	Reason     string          `json:"reason"`
+	Before     *datatypes.JSON `json:"before,omitempty" gorm:"type:jsonb"`
+	After      *datatypes.JSON `json:"after,omitempty" gorm:"type:jsonb"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// IdempotencyKey stores a request made with an Idempotency-Key header and its response
+type IdempotencyKey struct {
+	Scope       string         `json:"scope" gorm:"primaryKey"`
+	Key         string         `json:"key" gorm:"primaryKey"`
+	RequestHash string         `json:"request_hash" gorm:"type:char(64);not null"`
+	StatusCode  *int           `json:"status_code"`
+	Headers     datatypes.JSON `json:"headers" gorm:"type:jsonb"`
+	Body        []byte         `json:"body"`
+	CreatedAt   time.Time      `json:"created_at"`
+	ExpiresAt   time.Time      `json:"expires_at" gorm:"not null;index"`
+}
+
+// WebhookDelivery records the attempts to send an event to a webhook. The
+// payload is plain text so that retries are signed over identical bytes.
+type WebhookDelivery struct {
+	ID             uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	WebhookID      uuid.UUID  `json:"webhook_id" gorm:"type:uuid;not null;index"`
+	EventID        uuid.UUID  `json:"event_id" gorm:"type:uuid;not null"`
+	EventType      string     `json:"event_type" gorm:"not null"`
+	Payload        string     `json:"payload" gorm:"type:text;not null"`
+	Status         string     `json:"status" gorm:"not null;default:'pending';check:status IN ('pending', 'succeeded', 'failed')"`
+	Attempts       int        `json:"attempts" gorm:"not null;default:0"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty"`
+	LastAttemptAt  *time.Time `json:"last_attempt_at,omitempty"`
+	ResponseStatus *int       `json:"response_status,omitempty"`
+	LastError      string     `json:"last_error"`
+	RedeliveryOf   *uuid.UUID `json:"redelivery_of,omitempty" gorm:"type:uuid"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	Webhook        Webhook    `json:"-" gorm:"foreignKey:WebhookID;constraint:OnDelete:CASCADE"`
+}
+
+// NotificationPreference holds the email address of a user and the
+// notifications they opted out of
+type NotificationPreference struct {
+	UserID        string    `json:"user_id" gorm:"primaryKey"`
+	Email         string    `json:"email" gorm:"not null"`
+	Confirmations bool      `json:"confirmations" gorm:"not null;default:true"`
+	Cancellations bool      `json:"cancellations" gorm:"not null;default:true"`
+	DayReminders  bool      `json:"day_reminders" gorm:"not null;default:true"`
+	HourReminders bool      `json:"hour_reminders" gorm:"not null;default:true"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// Notification is an email about a reservation queued for its holder
+type Notification struct {
+	ID            uuid.UUID   `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	UserID        string      `json:"user_id" gorm:"not null"`
+	ReservationID uuid.UUID   `json:"reservation_id" gorm:"type:uuid;not null;index"`
+	Kind          string      `json:"kind" gorm:"not null"`
+	Reason        string      `json:"reason"`
+	StartsAt      *time.Time  `json:"starts_at,omitempty"`
+	Status        string      `json:"status" gorm:"not null;default:'pending';check:status IN ('pending', 'sent', 'skipped', 'failed')"`
+	Attempts      int         `json:"attempts" gorm:"not null;default:0"`
+	NextAttemptAt *time.Time  `json:"next_attempt_at,omitempty"`
+	LastError     string      `json:"last_error"`
+	SentAt        *time.Time  `json:"sent_at,omitempty"`
+	CreatedAt     time.Time   `json:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+	Reservation   Reservation `json:"-" gorm:"foreignKey:ReservationID;constraint:OnDelete:CASCADE"`
+}
+
+// AccessDenial records a request refused by the authorization policy
+type AccessDenial struct {
+	ID         uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	UserID     string     `json:"user_id" gorm:"index"`
+	Roles      string     `json:"roles"`
+	Method     string     `json:"method" gorm:"not null"`
+	Path       string     `json:"path" gorm:"not null"`
+	Permission string     `json:"permission"`
+	MapID      *uuid.UUID `json:"map_id,omitempty" gorm:"type:uuid"`
+	Reason     string     `json:"reason"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"index"`
 }
 
 // BeforeCreate hook for generating UUIDs
@@ -133,9 +288,23 @@ func (s *Space) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+func (g *SpaceGroup) BeforeCreate(tx *gorm.DB) error {
+	if g.ID == uuid.Nil {
+		g.ID = uuid.New()
+	}
+	return nil
+}
+
 func (r *Reservation) BeforeCreate(tx *gorm.DB) error {
 	if r.ID == uuid.Nil {
 		r.ID = uuid.New()
 	}
 	return nil
-}
\ No newline at end of file
+}
+
+func (s *ReservationSeries) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
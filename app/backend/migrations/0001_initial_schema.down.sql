@@ -0,0 +1,9 @@
+-- Drops the whole schema, including all data
+DROP TABLE IF EXISTS access_denials;
+DROP TABLE IF EXISTS reservations;
+DROP TABLE IF EXISTS reservation_series_exceptions;
+DROP TABLE IF EXISTS reservation_series;
+DROP TABLE IF EXISTS spaces;
+DROP TABLE IF EXISTS space_groups;
+DROP TABLE IF EXISTS office_maps;
+DROP FUNCTION IF EXISTS reservation_range(DATE, TIME, TIME);
@@ -0,0 +1,144 @@
+-- Initial database schema (formerly init.sql)
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+-- Time range covered by a reservation; all-day reservations (NULL times) span the whole date
+CREATE OR REPLACE FUNCTION reservation_range(d DATE, s TIME, e TIME) RETURNS tsrange
+LANGUAGE sql IMMUTABLE AS $$
+    SELECT tsrange(d + COALESCE(s, TIME '00:00'), CASE WHEN e IS NULL THEN (d + 1)::timestamp ELSE d + e END, '[)')
+$$;
+
+-- Office maps table
+CREATE TABLE IF NOT EXISTS office_maps (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    json_data JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Space groups table (meeting rooms made of several spaces)
+CREATE TABLE IF NOT EXISTS space_groups (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    map_id UUID NOT NULL REFERENCES office_maps(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    capacity INTEGER DEFAULT 1,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Spaces table
+CREATE TABLE IF NOT EXISTS spaces (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    map_id UUID NOT NULL REFERENCES office_maps(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    type VARCHAR(50) NOT NULL CHECK (type IN ('workstation', 'meeting_room', 'cubicle', 'invalid_space')),
+    x INTEGER NOT NULL,
+    y INTEGER NOT NULL,
+    width INTEGER DEFAULT 1,
+    height INTEGER DEFAULT 1,
+    capacity INTEGER DEFAULT 1,
+    group_id UUID REFERENCES space_groups(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Recurring reservations
+CREATE TABLE IF NOT EXISTS reservation_series (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    space_id UUID NOT NULL REFERENCES spaces(id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL,
+    user_name VARCHAR(255),
+    start_date DATE NOT NULL,
+    start_time TIME,
+    end_time TIME,
+    rrule TEXT NOT NULL,
+    notes TEXT,
+    status VARCHAR(20) DEFAULT 'active' CHECK (status IN ('active', 'cancelled')),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Per-occurrence overrides and cancellations of a series
+CREATE TABLE IF NOT EXISTS reservation_series_exceptions (
+    series_id UUID NOT NULL REFERENCES reservation_series(id) ON DELETE CASCADE,
+    occurrence_date DATE NOT NULL,
+    cancelled BOOLEAN NOT NULL DEFAULT FALSE,
+    start_time TIME,
+    end_time TIME,
+    notes TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (series_id, occurrence_date)
+);
+
+-- Reservations table
+CREATE TABLE IF NOT EXISTS reservations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    space_id UUID NOT NULL REFERENCES spaces(id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL,
+    user_name VARCHAR(255),
+    date DATE NOT NULL,
+    start_time TIME,
+    end_time TIME,
+    status VARCHAR(20) DEFAULT 'active',
+    notes TEXT,
+    series_id UUID,
+    occurrence_date DATE,
+    checked_in_at TIMESTAMP WITH TIME ZONE,
+    group_booking_id UUID,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    CONSTRAINT chk_reservations_status CHECK (status IN ('active', 'cancelled', 'checked_in', 'no_show', 'completed')),
+
+    -- Prevent double bookings, including partially overlapping time ranges
+    CONSTRAINT reservations_no_overlap EXCLUDE USING gist (
+        space_id WITH =,
+        reservation_range(date, start_time, end_time) WITH &&
+    ) WHERE (status IN ('active', 'checked_in'))
+);
+
+-- Requests refused by the authorization policy
+CREATE TABLE IF NOT EXISTS access_denials (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id VARCHAR(255),
+    roles TEXT,
+    method VARCHAR(10) NOT NULL,
+    path TEXT NOT NULL,
+    permission VARCHAR(100),
+    map_id UUID,
+    reason TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Indexes for better performance
+CREATE INDEX IF NOT EXISTS idx_spaces_map_id ON spaces(map_id);
+CREATE INDEX IF NOT EXISTS idx_spaces_group_id ON spaces(group_id);
+CREATE INDEX IF NOT EXISTS idx_space_groups_map_id ON space_groups(map_id);
+CREATE INDEX IF NOT EXISTS idx_reservation_series_space_id ON reservation_series(space_id);
+CREATE INDEX IF NOT EXISTS idx_reservations_space_id ON reservations(space_id);
+CREATE INDEX IF NOT EXISTS idx_reservations_date ON reservations(date);
+CREATE INDEX IF NOT EXISTS idx_reservations_user_id ON reservations(user_id);
+CREATE INDEX IF NOT EXISTS idx_reservations_series_id ON reservations(series_id);
+CREATE INDEX IF NOT EXISTS idx_reservations_series_occurrence ON reservations(series_id, occurrence_date);
+CREATE INDEX IF NOT EXISTS idx_reservations_group_booking_id ON reservations(group_booking_id);
+CREATE INDEX IF NOT EXISTS idx_access_denials_user_id ON access_denials(user_id);
+CREATE INDEX IF NOT EXISTS idx_access_denials_created_at ON access_denials(created_at);
+
+-- Insert sample office map into a new database
+INSERT INTO office_maps (id, name, description, json_data)
+SELECT
+    uuid_generate_v4(),
+    'Main Office Floor',
+    'Principal floor layout with workstations and meeting rooms',
+    '{
+        "grid": {
+            "width": 20,
+            "height": 15,
+            "cellSize": 40
+        },
+        "spaces": []
+    }'
+WHERE NOT EXISTS (SELECT 1 FROM office_maps);
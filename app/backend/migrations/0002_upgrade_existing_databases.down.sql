@@ -0,0 +1,2 @@
+-- Nothing to undo: 0002 only aligns older databases with the 0001 schema
+SELECT 1;
@@ -0,0 +1,66 @@
+-- Brings databases created by GORM AutoMigrate and the old numbered scripts up to
+-- the 0001 schema. Every statement is a no-op on a database created by 0001.
+
+ALTER TABLE reservations ADD COLUMN IF NOT EXISTS series_id UUID;
+ALTER TABLE reservations ADD COLUMN IF NOT EXISTS occurrence_date DATE;
+ALTER TABLE reservations ADD COLUMN IF NOT EXISTS checked_in_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE reservations ADD COLUMN IF NOT EXISTS group_booking_id UUID;
+ALTER TABLE spaces ADD COLUMN IF NOT EXISTS group_id UUID REFERENCES space_groups(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_spaces_group_id ON spaces(group_id);
+CREATE INDEX IF NOT EXISTS idx_reservations_series_id ON reservations(series_id);
+CREATE INDEX IF NOT EXISTS idx_reservations_series_occurrence ON reservations(series_id, occurrence_date);
+CREATE INDEX IF NOT EXISTS idx_reservations_group_booking_id ON reservations(group_booking_id);
+
+-- Check-in lifecycle statuses
+ALTER TABLE reservations DROP CONSTRAINT IF EXISTS reservations_status_check;
+ALTER TABLE reservations DROP CONSTRAINT IF EXISTS chk_reservations_status;
+ALTER TABLE reservations ADD CONSTRAINT chk_reservations_status
+CHECK (status IN ('active', 'cancelled', 'checked_in', 'no_show', 'completed'));
+
+-- The old unique constraint (space_id, date, start_time) and its partial index
+-- are superseded by the exclusion constraint, which also covers partial overlaps
+DROP INDEX IF EXISTS idx_reservations_unique;
+DROP INDEX IF EXISTS reservations_space_id_date_start_time_active_key;
+ALTER TABLE reservations DROP CONSTRAINT IF EXISTS reservations_space_id_date_start_time_key;
+
+-- Cancel the newest of any already overlapping pair so the constraint can be added
+UPDATE reservations r SET status = 'cancelled', updated_at = NOW()
+WHERE r.status = 'active' AND EXISTS (
+    SELECT 1 FROM reservations o
+    WHERE o.space_id = r.space_id AND o.status = 'active' AND o.id <> r.id
+    AND (o.created_at, o.id) < (r.created_at, r.id)
+    AND reservation_range(o.date, o.start_time, o.end_time) && reservation_range(r.date, r.start_time, r.end_time)
+);
+
+-- Checked-in reservations keep holding their space
+DO $$ BEGIN
+    IF EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'reservations_no_overlap'
+        AND pg_get_constraintdef(oid) NOT LIKE '%checked_in%') THEN
+        ALTER TABLE reservations DROP CONSTRAINT reservations_no_overlap;
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'reservations_no_overlap') THEN
+        ALTER TABLE reservations ADD CONSTRAINT reservations_no_overlap
+            EXCLUDE USING gist (space_id WITH =, reservation_range(date, start_time, end_time) WITH &&)
+            WHERE (status IN ('active', 'checked_in'));
+    END IF;
+END $$;
+
+-- Seed groups from the old convention: meeting rooms on the same map whose names
+-- only differ by a trailing number ("Sala Norte 1", "Sala Norte 2") form one group.
+-- Skipped once any group exists, so groups edited since are never overwritten.
+DO $$ BEGIN
+    IF NOT EXISTS (SELECT 1 FROM space_groups) THEN
+        INSERT INTO space_groups (map_id, name, capacity)
+        SELECT map_id, MIN(TRIM(REGEXP_REPLACE(name, '\s*\d+$', ''))), SUM(capacity)
+        FROM spaces
+        WHERE type = 'meeting_room'
+        GROUP BY map_id, LOWER(TRIM(REGEXP_REPLACE(name, '\s*\d+$', '')))
+        HAVING COUNT(*) > 1;
+
+        UPDATE spaces s SET group_id = g.id
+        FROM space_groups g
+        WHERE s.type = 'meeting_room' AND s.map_id = g.map_id
+        AND LOWER(TRIM(REGEXP_REPLACE(s.name, '\s*\d+$', ''))) = LOWER(g.name);
+    END IF;
+END $$;
@@ -0,0 +1,2 @@
+-- Drops booking policies; reservations are not affected
+DROP TABLE IF EXISTS booking_policies;
@@ -0,0 +1,23 @@
+-- Booking rules defined by admins per map, space type or role. NULL scope
+-- columns match everything and NULL rule columns are not enforced.
+CREATE TABLE IF NOT EXISTS booking_policies (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL,
+    map_id UUID REFERENCES office_maps(id) ON DELETE CASCADE,
+    space_type VARCHAR(50),
+    role VARCHAR(100),
+    max_advance_days INTEGER,
+    min_duration_minutes INTEGER,
+    max_duration_minutes INTEGER,
+    allowed_start TIME,
+    allowed_end TIME,
+    max_bookings_per_week INTEGER,
+    max_consecutive_days INTEGER,
+    blackout_dates JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    CONSTRAINT chk_booking_policies_space_type CHECK (space_type IN ('workstation', 'meeting_room', 'cubicle', 'invalid_space'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_booking_policies_map_id ON booking_policies(map_id);
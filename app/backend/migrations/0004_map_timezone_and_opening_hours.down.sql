@@ -0,0 +1,3 @@
+-- Drops the time zone and opening hours of office maps
+ALTER TABLE office_maps DROP COLUMN IF EXISTS opening_hours;
+ALTER TABLE office_maps DROP COLUMN IF EXISTS timezone;
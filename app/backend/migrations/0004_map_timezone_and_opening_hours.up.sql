@@ -0,0 +1,5 @@
+-- Reservation dates and times are local to the time zone of their office map.
+-- opening_hours maps lowercase weekday names to {"open": "HH:MM", "close": "HH:MM"};
+-- missing days are closed and NULL means the office is always open.
+ALTER TABLE office_maps ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+ALTER TABLE office_maps ADD COLUMN IF NOT EXISTS opening_hours JSONB;
@@ -0,0 +1,2 @@
+-- Drops closures; reservations cancelled by them stay cancelled
+DROP TABLE IF EXISTS closures;
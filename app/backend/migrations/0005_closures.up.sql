@@ -0,0 +1,23 @@
+-- Periods when a whole map, a space group or a single space cannot be booked.
+-- map_id is always set; group_id or space_id narrow the closure. start_time
+-- applies to start_date and end_time to end_date; NULL means the whole day.
+-- external_id is the UID of the calendar event a closure was imported from.
+CREATE TABLE IF NOT EXISTS closures (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    map_id UUID NOT NULL REFERENCES office_maps(id) ON DELETE CASCADE,
+    group_id UUID REFERENCES space_groups(id) ON DELETE CASCADE,
+    space_id UUID REFERENCES spaces(id) ON DELETE CASCADE,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    start_time TIME,
+    end_time TIME,
+    reason TEXT NOT NULL,
+    external_id VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    CONSTRAINT chk_closures_dates CHECK (end_date >= start_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_closures_map_id_dates ON closures(map_id, start_date, end_date);
+CREATE INDEX IF NOT EXISTS idx_closures_external_id ON closures(map_id, external_id);
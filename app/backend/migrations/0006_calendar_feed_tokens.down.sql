@@ -0,0 +1,2 @@
+-- Drops calendar feed tokens; subscribed calendars stop updating
+DROP TABLE IF EXISTS calendar_feed_tokens;
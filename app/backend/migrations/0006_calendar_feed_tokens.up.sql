@@ -0,0 +1,7 @@
+-- Secrets that let calendar clients read the ICS feeds of a user. Only the
+-- SHA-256 hash of each secret is stored; a user has at most one.
+CREATE TABLE IF NOT EXISTS calendar_feed_tokens (
+    user_id VARCHAR(255) PRIMARY KEY,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
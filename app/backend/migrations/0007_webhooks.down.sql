@@ -0,0 +1,4 @@
+-- Drops webhooks with their deliveries and any undispatched events
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS outbox_events;
+DROP TABLE IF EXISTS webhooks;
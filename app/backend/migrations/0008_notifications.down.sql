@@ -0,0 +1,3 @@
+-- Drops queued notifications and notification preferences
+DROP TABLE IF EXISTS notifications;
+DROP TABLE IF EXISTS notification_preferences;
@@ -0,0 +1,37 @@
+-- Email notifications. notification_preferences holds the address of each user,
+-- taken from their bearer token, and the notifications they opted out of;
+-- users without a row receive everything once their address is known.
+-- notifications queues the emails about bookings, sent with retries. A
+-- booking gets at most one reminder of each kind per start time.
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id VARCHAR(255) PRIMARY KEY,
+    email VARCHAR(255) NOT NULL,
+    confirmations BOOLEAN NOT NULL DEFAULT TRUE,
+    cancellations BOOLEAN NOT NULL DEFAULT TRUE,
+    day_reminders BOOLEAN NOT NULL DEFAULT TRUE,
+    hour_reminders BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS notifications (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id VARCHAR(255) NOT NULL,
+    reservation_id UUID NOT NULL REFERENCES reservations(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    starts_at TIMESTAMP WITH TIME ZONE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE,
+    last_error TEXT NOT NULL DEFAULT '',
+    sent_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    CONSTRAINT chk_notifications_kind CHECK (kind IN ('confirmation', 'update', 'cancellation', 'day_reminder', 'hour_reminder')),
+    CONSTRAINT chk_notifications_status CHECK (status IN ('pending', 'sent', 'skipped', 'failed'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_reservation_id ON notifications(reservation_id);
+CREATE INDEX IF NOT EXISTS idx_notifications_due ON notifications(next_attempt_at) WHERE status = 'pending';
+CREATE UNIQUE INDEX IF NOT EXISTS idx_notifications_reminder ON notifications(reservation_id, kind, starts_at) WHERE starts_at IS NOT NULL;
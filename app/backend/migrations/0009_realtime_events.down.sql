@@ -0,0 +1,7 @@
+-- Stops announcing outbox events and drops their sequence numbers and maps
+DROP TRIGGER IF EXISTS trg_outbox_events_notify ON outbox_events;
+DROP FUNCTION IF EXISTS notify_outbox_event();
+DROP INDEX IF EXISTS idx_outbox_events_map_seq;
+DROP INDEX IF EXISTS idx_outbox_events_seq;
+ALTER TABLE outbox_events DROP COLUMN IF EXISTS map_id;
+ALTER TABLE outbox_events DROP COLUMN IF EXISTS seq;
@@ -0,0 +1,23 @@
+-- Real-time map events. Outbox events are numbered, so that streams can resume
+-- after the last event a client received, and tagged with the map they concern.
+-- Every insert is announced on the outbox_events channel once its transaction
+-- commits, with the payload "<seq> <map_id>", so that each backend instance can
+-- push it to its own subscribers.
+ALTER TABLE outbox_events ADD COLUMN IF NOT EXISTS seq BIGSERIAL;
+ALTER TABLE outbox_events ADD COLUMN IF NOT EXISTS map_id UUID;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_outbox_events_seq ON outbox_events(seq);
+CREATE INDEX IF NOT EXISTS idx_outbox_events_map_seq ON outbox_events(map_id, seq) WHERE map_id IS NOT NULL;
+
+CREATE OR REPLACE FUNCTION notify_outbox_event() RETURNS trigger
+LANGUAGE plpgsql AS $$
+BEGIN
+    PERFORM pg_notify('outbox_events', NEW.seq || ' ' || COALESCE(NEW.map_id::text, ''));
+    RETURN NEW;
+END;
+$$;
+
+DROP TRIGGER IF EXISTS trg_outbox_events_notify ON outbox_events;
+CREATE TRIGGER trg_outbox_events_notify
+    AFTER INSERT ON outbox_events
+    FOR EACH ROW EXECUTE FUNCTION notify_outbox_event();
@@ -0,0 +1,3 @@
+-- Drops the audit log with its entries
+DROP TABLE IF EXISTS audit_entries;
+DROP FUNCTION IF EXISTS reject_audit_entry_change();
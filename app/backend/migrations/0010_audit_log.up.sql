@@ -0,0 +1,42 @@
+-- Append-only audit log of reservation, space and map changes. Entries are
+-- written in the same transaction as the change and keep JSON snapshots of the
+-- resource before and after it. There are no foreign keys, so entries outlive
+-- deleted resources, and a trigger rejects any update or delete.
+CREATE TABLE IF NOT EXISTS audit_entries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    entity_type VARCHAR(20) NOT NULL,
+    entity_id UUID NOT NULL,
+    map_id UUID,
+    action VARCHAR(20) NOT NULL,
+    actor_id VARCHAR(255) NOT NULL DEFAULT '',
+    actor_name VARCHAR(255) NOT NULL DEFAULT '',
+    reason TEXT NOT NULL DEFAULT '',
+    before JSONB,
+    after JSONB,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    CONSTRAINT chk_audit_entries_entity_type CHECK (entity_type IN ('reservation', 'space', 'map')),
+    CONSTRAINT chk_audit_entries_action CHECK (action IN ('create', 'update', 'cancel', 'check_in', 'delete'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_entries_entity ON audit_entries(entity_type, entity_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_entries_map ON audit_entries(map_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_entries_actor ON audit_entries(actor_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_entries_created_at ON audit_entries(created_at DESC);
+
+CREATE OR REPLACE FUNCTION reject_audit_entry_change() RETURNS trigger
+LANGUAGE plpgsql AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_entries is append-only';
+END;
+$$;
+
+DROP TRIGGER IF EXISTS trg_audit_entries_append_only ON audit_entries;
+CREATE TRIGGER trg_audit_entries_append_only
+    BEFORE UPDATE OR DELETE ON audit_entries
+    FOR EACH ROW EXECUTE FUNCTION reject_audit_entry_change();
+
+DROP TRIGGER IF EXISTS trg_audit_entries_no_truncate ON audit_entries;
+CREATE TRIGGER trg_audit_entries_no_truncate
+    BEFORE TRUNCATE ON audit_entries
+    FOR EACH STATEMENT EXECUTE FUNCTION reject_audit_entry_change();
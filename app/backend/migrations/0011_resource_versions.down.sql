@@ -0,0 +1,3 @@
+ALTER TABLE office_maps DROP COLUMN IF EXISTS version;
+ALTER TABLE spaces DROP COLUMN IF EXISTS version;
+ALTER TABLE reservations DROP COLUMN IF EXISTS version;
@@ -0,0 +1,6 @@
+-- Optimistic concurrency. Every update of a reservation, space or map increments
+-- its version and is only applied if the row is still at the version it was read
+-- at, so that concurrent editors cannot silently overwrite each other.
+ALTER TABLE reservations ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE spaces ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE office_maps ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
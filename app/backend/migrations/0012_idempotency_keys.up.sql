@@ -0,0 +1,19 @@
+-- Requests made with an Idempotency-Key header, per user. A row is created when
+-- the request starts, with a hash of its method, path and body, and completed
+-- with the response, which retries with the same key are given instead of
+-- running the request again. Rows are purged once they expire.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    scope VARCHAR(255) NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    request_hash CHAR(64) NOT NULL,
+    -- NULL while the request is in progress
+    status_code INTEGER,
+    headers JSONB,
+    body BYTEA,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    PRIMARY KEY (scope, key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
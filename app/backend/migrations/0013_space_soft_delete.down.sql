@@ -0,0 +1,5 @@
+-- Removed spaces would reappear without the column; delete them, and their
+-- reservations with them, as before
+DELETE FROM spaces WHERE deleted_at IS NOT NULL;
+DROP INDEX IF EXISTS idx_spaces_deleted_at;
+ALTER TABLE spaces DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,6 @@
+-- Spaces removed from a map layout are kept, marked with deleted_at, so that the
+-- reservations made on them stay in the history instead of being deleted by
+-- ON DELETE CASCADE.
+ALTER TABLE spaces ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_spaces_deleted_at ON spaces(deleted_at);
@@ -0,0 +1 @@
+ALTER TABLE reservation_series DROP COLUMN IF EXISTS booked_by;
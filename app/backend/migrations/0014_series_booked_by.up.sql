@@ -0,0 +1,3 @@
+-- The user who created a series, with their roles, so that its occurrences are
+-- booked under the same booking policies as their other reservations
+ALTER TABLE reservation_series ADD COLUMN IF NOT EXISTS booked_by JSONB;
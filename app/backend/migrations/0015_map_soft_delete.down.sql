@@ -0,0 +1,5 @@
+-- Deleted maps would reappear without the column; delete them, and their spaces
+-- and reservations with them, as before
+DELETE FROM office_maps WHERE deleted_at IS NOT NULL;
+DROP INDEX IF EXISTS idx_office_maps_deleted_at;
+ALTER TABLE office_maps DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,6 @@
+-- Deleted maps are kept, marked with deleted_at, together with their removed
+-- spaces, so that the reservations made on them stay in the history instead of
+-- being deleted by ON DELETE CASCADE.
+ALTER TABLE office_maps ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_office_maps_deleted_at ON office_maps(deleted_at);
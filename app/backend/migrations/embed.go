@@ -0,0 +1,11 @@
+// Package migrations embeds the versioned SQL migrations. Files are named
+// NNNN_description.up.sql and NNNN_description.down.sql and are applied in
+// version order; never edit a migration after it has been released.
+package migrations
+
+import "embed"
+
+// FS holds every migration file
+//
+//go:embed *.sql
+var FS embed.FS
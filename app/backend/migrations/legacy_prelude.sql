@@ -0,0 +1,23 @@
+-- Runs before 0001 on databases created by GORM AutoMigrate and the old numbered
+-- scripts, in the same transaction. Those databases already have the spaces and
+-- reservations tables, so 0001 skips creating them and then indexes columns they
+-- lack. This adds those columns; 0002 brings the rest of the schema up to date.
+-- Unlike migrations it is not checksummed, but it must stay a no-op wherever the
+-- columns exist.
+
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+CREATE TABLE IF NOT EXISTS space_groups (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    map_id UUID NOT NULL REFERENCES office_maps(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    capacity INTEGER DEFAULT 1,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+ALTER TABLE spaces ADD COLUMN IF NOT EXISTS group_id UUID REFERENCES space_groups(id) ON DELETE SET NULL;
+ALTER TABLE reservations ADD COLUMN IF NOT EXISTS series_id UUID;
+ALTER TABLE reservations ADD COLUMN IF NOT EXISTS occurrence_date DATE;
+ALTER TABLE reservations ADD COLUMN IF NOT EXISTS checked_in_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE reservations ADD COLUMN IF NOT EXISTS group_booking_id UUID;
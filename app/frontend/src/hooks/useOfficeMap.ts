@@ -14,8 +14,9 @@ export const useOfficeMap = () => {
       setError(null);
       const data = await getMaps();
       setMaps(data);
+      // Listed maps come without their spaces
       if (data.length > 0 && !currentMap) {
-        setCurrentMap(data[0]);
+        setCurrentMap(await getMap(data[0].id));
       }
     } catch (err) {
       console.error('Error fetching maps:', err);
@@ -3,6 +3,8 @@
  * Implements the ReservationRepository interface using HTTP
  */
 import axios from 'axios';
+import { attachAuthToken, clearTokenOnUnauthorized } from './auth';
+import { fetchAllPages } from './pagination';
 import type { Reservation } from '../../types';
 import type {
   ReservationRepository,
@@ -20,6 +22,9 @@ const api = axios.create({
   },
 });
 
+api.interceptors.request.use(attachAuthToken);
+api.interceptors.response.use(undefined, clearTokenOnUnauthorized);
+
 export class ReservationApiClient implements ReservationRepository {
   async findAll(filters?: ReservationFilters): Promise<Reservation[]> {
     const params = filters ? {
@@ -29,8 +34,7 @@ export class ReservationApiClient implements ReservationRepository {
       space_id: filters.space_id,
     } : {};
     
-    const response = await api.get<Reservation[]>('/reservations', { params });
-    return response.data;
+    return fetchAllPages<Reservation>(api, '/reservations', params);
   }
 
   async findById(id: string): Promise<Reservation> {
@@ -0,0 +1,71 @@
+/**
+ * Infrastructure layer: bearer token handling for API requests
+ */
+import axios from 'axios';
+import type { InternalAxiosRequestConfig } from 'axios';
+
+const TOKEN_KEY = 'auth_token';
+const DEV_USER_KEY = 'auth_dev_user';
+
+let pendingDevToken: Promise<string | null> | null = null;
+
+/**
+ * Roles requested for the development user, from VITE_DEV_TOKEN_ROLES
+ * (comma separated). The backend only issues super_admin when allowed.
+ */
+const devTokenRoles = (): string[] => {
+  const roles = (import.meta.env.VITE_DEV_TOKEN_ROLES || 'employee')
+    .split(',')
+    .map((role) => role.trim())
+    .filter(Boolean);
+  return roles.length > 0 ? roles : ['employee'];
+};
+
+/**
+ * Request a token from the backend development issuer, if it is enabled.
+ * Only development builds do this; production builds never issue their own tokens.
+ */
+const fetchDevToken = async (): Promise<string | null> => {
+  if (!import.meta.env.DEV) {
+    return null;
+  }
+  const userId = localStorage.getItem(DEV_USER_KEY) || 'dev.user';
+  try {
+    const response = await axios.post('/api/auth/dev-token', {
+      user_id: userId,
+      user_name: userId,
+      roles: devTokenRoles(),
+    });
+    const token: string = response.data.token;
+    localStorage.setItem(TOKEN_KEY, token);
+    return token;
+  } catch {
+    return null;
+  }
+};
+
+/**
+ * Axios request interceptor that adds the Authorization header
+ */
+export const attachAuthToken = async (config: InternalAxiosRequestConfig): Promise<InternalAxiosRequestConfig> => {
+  let token = localStorage.getItem(TOKEN_KEY);
+  if (!token) {
+    pendingDevToken = pendingDevToken || fetchDevToken().finally(() => { pendingDevToken = null; });
+    token = await pendingDevToken;
+  }
+  if (token) {
+    config.headers.Authorization = `Bearer ${token}`;
+  }
+  return config;
+};
+
+/**
+ * Axios response error interceptor that drops a rejected token so the next
+ * request obtains a fresh one
+ */
+export const clearTokenOnUnauthorized = (error: any) => {
+  if (error?.response?.status === 401) {
+    localStorage.removeItem(TOKEN_KEY);
+  }
+  return Promise.reject(error);
+};
@@ -0,0 +1,27 @@
+/**
+ * Infrastructure layer: reading every page of a paginated listing
+ */
+import type { AxiosInstance } from 'axios';
+import type { Page } from '../../types';
+
+const PAGE_LIMIT = 500;
+
+/**
+ * Fetch every item of a listing by following its cursors
+ */
+export const fetchAllPages = async <T>(
+  client: AxiosInstance,
+  url: string,
+  params: Record<string, unknown> = {}
+): Promise<T[]> => {
+  const items: T[] = [];
+  let cursor: string | null = null;
+  do {
+    const response: { data: Page<T> } = await client.get<Page<T>>(url, {
+      params: { ...params, limit: PAGE_LIMIT, ...(cursor ? { cursor } : {}) },
+    });
+    items.push(...response.data.data);
+    cursor = response.data.pagination.next_cursor;
+  } while (cursor);
+  return items;
+};
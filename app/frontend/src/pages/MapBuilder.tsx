@@ -7,6 +7,13 @@ import HexagonGrid from '../components/HexagonGrid';
 import { exportMapToCSV, parseCSVToSpaces, downloadCSV, readCSVFile } from '../utils/csvUtils';
 import { useTranslation } from 'react-i18next';
 
+// Lists the field errors of a map rejected by the server, or returns null
+const describeFieldErrors = (error: any): string | null => {
+  const fieldErrors = error?.response?.data?.errors;
+  if (error?.response?.status !== 400 || !Array.isArray(fieldErrors) || fieldErrors.length === 0) return null;
+  return fieldErrors.map((e: { field: string; message: string }) => `${e.field}: ${e.message}`).join('\n');
+};
+
 const MapBuilder: React.FC = () => {
   const { t } = useTranslation();
   const { maps, currentMap, createNewMap, updateCurrentMap, deleteMapById, setCurrentMap, loading } = useOfficeMap();
@@ -75,7 +82,8 @@ const MapBuilder: React.FC = () => {
       setIsCreating(false);
       toast.success('Map created successfully!');
     } catch (error) {
-      toast.error('Failed to create map');
+      const errors = describeFieldErrors(error);
+      toast.error(errors ? t('mapBuilder.invalidMap', { errors }) : 'Failed to create map');
     }
   };
 
@@ -91,10 +99,19 @@ const MapBuilder: React.FC = () => {
         }
       };
 
-      await updateCurrentMap(currentMap.id, updatedData);
+      try {
+        await updateCurrentMap(currentMap.id, updatedData);
+      } catch (error: any) {
+        // Removed spaces still have upcoming reservations: ask before cancelling them
+        const affected = error?.response?.data?.sync?.affected_reservations;
+        if (error?.response?.status !== 409 || !affected) throw error;
+        if (!window.confirm(t('mapBuilder.confirmRemoveReserved', { count: affected.length }))) return;
+        await updateCurrentMap(currentMap.id, { ...updatedData, cancel_reservations: true });
+      }
       toast.success('Map saved successfully!');
     } catch (error) {
-      toast.error('Failed to save map');
+      const errors = describeFieldErrors(error);
+      toast.error(errors ? t('mapBuilder.invalidMap', { errors }) : 'Failed to save map');
     }
   };
 
@@ -18,7 +18,7 @@ import {
 
 const Reservations: React.FC = () => {
   const { t } = useTranslation();
-  const { maps, currentMap, setCurrentMap, fetchMap } = useOfficeMap();
+  const { maps, currentMap, fetchMap } = useOfficeMap();
   const { reservations, fetchReservations, loading: reservationsLoading } = useReservations();
   
   const [selectedDate, setSelectedDate] = useState(format(new Date(), 'yyyy-MM-dd'));
@@ -45,7 +45,8 @@ const Reservations: React.FC = () => {
   const handleMapChange = (mapId: string) => {
     const selectedMap = maps.find(map => map.id === mapId);
     if (selectedMap) {
-      setCurrentMap(selectedMap);
+      // Listed maps come without their spaces, so load the whole map
+      fetchMap(selectedMap.id);
       // Refresh reservations when changing maps
       fetchReservations();
     }
@@ -20,11 +20,19 @@ export interface OfficeMap {
     };
     spaces: MapSpace[];
   };
+  timezone: string;
+  // Keyed by weekday name (monday...sunday); missing days are closed and null means always open
+  opening_hours: Record<string, OpeningHours> | null;
   created_at: string;
   updated_at: string;
   spaces?: Space[];
 }
 
+export interface OpeningHours {
+  open: string;
+  close: string;
+}
+
 export interface Space {
   id: string;
   map_id: string;
@@ -52,6 +60,10 @@ export interface Reservation {
   end_time?: string;
   status: 'active' | 'cancelled';
   notes?: string;
+  // Zone of the office map and the reservation's interval in it
+  timezone?: string;
+  starts_at?: string;
+  ends_at?: string;
   created_at: string;
   updated_at: string;
   space?: Space;
@@ -63,4 +75,57 @@ export interface Reservation {
   // For import/export
   space_name?: string;
   space_type?: string;
-}
\ No newline at end of file
+}
+export type OccupancyState = 'free' | 'partial' | 'booked' | 'closed' | 'unavailable';
+
+export interface TimeInterval {
+  start: string;
+  end: string;
+}
+
+export interface DayAvailability {
+  date: string;
+  state: OccupancyState;
+  busy: (TimeInterval & { reservation_ids: string[] })[];
+  // Parts of the window covered by closures; never free
+  closed: (TimeInterval & { closure_id: string; reason: string })[];
+  free: TimeInterval[];
+}
+
+// A single space, or a space group whose members are booked together
+export interface SpaceAvailability {
+  space_id?: string;
+  group_id?: string;
+  space_ids: string[];
+  name: string;
+  type: Space['type'];
+  state: OccupancyState;
+  days: DayAvailability[];
+}
+
+export interface MapAvailability {
+  map_id: string;
+  from: string;
+  to: string;
+  start_time: string;
+  end_time: string;
+  spaces: SpaceAvailability[];
+}
+
+export interface DeskAssignment {
+  reservation: Reservation;
+  space: Space;
+  reasons: string[];
+}
+
+// One page of a listing; pass pagination.next_cursor as cursor to get the next one
+export interface Page<T> {
+  data: T[];
+  pagination: {
+    limit: number;
+    sort: string;
+    order: 'asc' | 'desc';
+    next_cursor: string | null;
+    has_more: boolean;
+  };
+}
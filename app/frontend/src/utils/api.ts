@@ -3,7 +3,9 @@
  * New code should use services from application/services instead
  */
 import axios from 'axios';
-import type { OfficeMap, Space, Reservation } from '../types';
+import { attachAuthToken, clearTokenOnUnauthorized } from '../infrastructure/api/auth';
+import { fetchAllPages } from '../infrastructure/api/pagination';
+import type { OfficeMap, Space, Reservation, MapAvailability, DeskAssignment } from '../types';
 import { services } from '../infrastructure/di/container';
 
 const API_BASE_URL = '/api';
@@ -15,6 +17,9 @@ const api = axios.create({
   },
 });
 
+api.interceptors.request.use(attachAuthToken);
+api.interceptors.response.use(undefined, clearTokenOnUnauthorized);
+
 // Health check
 export const healthCheck = async () => {
   const response = await api.get('/health');
@@ -22,9 +27,9 @@ export const healthCheck = async () => {
 };
 
 // Maps API
+// Maps are listed without their spaces; getMap includes them
 export const getMaps = async (): Promise<OfficeMap[]> => {
-  const response = await api.get('/maps');
-  return response.data;
+  return fetchAllPages<OfficeMap>(api, '/maps');
 };
 
 export const getMap = async (id: string): Promise<OfficeMap> => {
@@ -46,11 +51,19 @@ export const deleteMap = async (id: string): Promise<void> => {
   await api.delete(`/maps/${id}`);
 };
 
+// Availability of every space of a map, for one date or a from/to range
+export const getMapAvailability = async (
+  mapId: string,
+  params: { date?: string; from?: string; to?: string; start_time?: string; end_time?: string }
+): Promise<MapAvailability> => {
+  const response = await api.get(`/maps/${mapId}/availability`, { params });
+  return response.data;
+};
+
 // Spaces API
 export const getSpaces = async (mapId?: string): Promise<Space[]> => {
   const params = mapId ? { map_id: mapId } : {};
-  const response = await api.get('/spaces', { params });
-  return response.data;
+  return fetchAllPages<Space>(api, '/spaces', params);
 };
 
 export const createSpace = async (data: any): Promise<Space> => {
@@ -76,6 +89,21 @@ export const createReservation = async (data: any): Promise<Reservation> => {
   return services.reservation.createReservation(data);
 };
 
+// Book the best free desk of a map, ranked by favorites, teammates and history
+export const assignDesk = async (data: {
+  map_id: string;
+  date: string;
+  start_time?: string;
+  end_time?: string;
+  notes?: string;
+  favorite_space_ids?: string[];
+  teammates?: string[];
+  space_types?: Array<'workstation' | 'cubicle'>;
+}): Promise<DeskAssignment> => {
+  const response = await api.post('/reservations/assign-desk', data);
+  return response.data;
+};
+
 export const updateReservation = async (id: string, data: any): Promise<Reservation> => {
   console.log('Updating reservation:', id, 'with data:', data);
   return services.reservation.updateReservation(id, data);
@@ -86,7 +114,7 @@ export const deleteReservation = async (id: string): Promise<void> => {
 };
 
 export const cleanupMeetingRoomReservations = async (spaceId: string): Promise<any> => {
-  const response = await api.post(`/reservations/cleanup/meeting-room/${spaceId}`);
+  const response = await api.post('/reservations/batch-cancel', { filter: { space_id: spaceId } });
   return response.data;
 };
 
@@ -0,0 +1,6 @@
+/// <reference types="vite/client" />
+
+interface ImportMetaEnv {
+  /** Comma-separated roles of development tokens requested by `npm run dev` */
+  readonly VITE_DEV_TOKEN_ROLES?: string;
+}